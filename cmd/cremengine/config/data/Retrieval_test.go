@@ -62,6 +62,8 @@ func TestRetrieveConfigFromFile_RichValidConfig_NoErrors(t *testing.T) {
 		expectedAdminPort                = uint64(3031)
 		expectedCacheMaximumAgeInSeconds = uint64(5)
 		expectedJobQueueLength           = uint64(10)
+		expectedSessionIdleTimeout       = uint64(600)
+		expectedMaximumSampleSize        = uint64(500)
 	)
 
 	// when
@@ -78,6 +80,8 @@ func TestRetrieveConfigFromFile_RichValidConfig_NoErrors(t *testing.T) {
 	g.Expect(config.Engine.AdminPort).To(Equal(expectedAdminPort))
 	g.Expect(config.Engine.CacheMaximumAgeInSeconds).To(Equal(expectedCacheMaximumAgeInSeconds))
 	g.Expect(config.Engine.JobQueueLength).To(Equal(expectedJobQueueLength))
+	g.Expect(config.Engine.SessionIdleTimeoutInSeconds).To(Equal(expectedSessionIdleTimeout))
+	g.Expect(config.Engine.MaximumUncertaintySampleSize).To(Equal(expectedMaximumSampleSize))
 }
 
 func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
@@ -90,6 +94,8 @@ func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
 		expectedAdminPort                = uint64(3031)
 		expectedCacheMaximumAgeInSeconds = uint64(5)
 		expectedJobQueueLength           = uint64(10)
+		expectedSessionIdleTimeout       = uint64(600)
+		expectedMaximumSampleSize        = uint64(500)
 	)
 
 	// when
@@ -107,6 +113,8 @@ func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
 	g.Expect(config.Engine.AdminPort).To(Equal(expectedAdminPort))
 	g.Expect(config.Engine.CacheMaximumAgeInSeconds).To(Equal(expectedCacheMaximumAgeInSeconds))
 	g.Expect(config.Engine.JobQueueLength).To(Equal(expectedJobQueueLength))
+	g.Expect(config.Engine.SessionIdleTimeoutInSeconds).To(Equal(expectedSessionIdleTimeout))
+	g.Expect(config.Engine.MaximumUncertaintySampleSize).To(Equal(expectedMaximumSampleSize))
 }
 
 func TestRetrieveConfigFromString_RichInvalidSyntaxConfig_Errors(t *testing.T) {
@@ -3,10 +3,12 @@ package data
 import "github.com/LindsayBradford/crem/internal/pkg/config/data"
 
 type BasicScenarioConfig struct {
-	Name string
+	Name       string
+	RandomSeed int64
 }
 
 type ScenarioConfig struct {
 	Scenario BasicScenarioConfig
+	Annealer data.AnnealerConfig
 	Model    data.ModelConfig
 }
@@ -67,7 +67,10 @@ func (i *EngineConfigInterpreter) buildEngine(engineConfig data2.HttpServerConfi
 }
 
 func buildApiMux(serverConfig data2.HttpServerConfig) *api.Mux {
-	return new(api.Mux).Initialise()
+	return new(api.Mux).Initialise().
+		WithJobQueueLength(serverConfig.JobQueueLength).
+		WithSessionIdleTimeout(serverConfig.SessionIdleTimeoutInSeconds).
+		WithMaximumUncertaintySampleSize(serverConfig.MaximumUncertaintySampleSize)
 }
 
 func (i *EngineConfigInterpreter) Engine() engine.Engine {
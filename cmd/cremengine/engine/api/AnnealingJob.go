@@ -0,0 +1,204 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LindsayBradford/crem/cmd/cremengine/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	configData "github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	"github.com/LindsayBradford/crem/internal/pkg/server/job"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	jobScenarioNameKey      job.AttributeKey = "ScenarioName"
+	jobCurrentIterationKey  job.AttributeKey = "CurrentIteration"
+	jobMaximumIterationsKey job.AttributeKey = "MaximumIterations"
+	jobPercentCompleteKey   job.AttributeKey = "PercentComplete"
+	jobErrorKey             job.AttributeKey = "Error"
+
+	jobScenarioConfigKey job.AttributeKey = "ScenarioConfig"
+	jobScenarioTextKey   job.AttributeKey = "ScenarioText"
+	jobModelKey          job.AttributeKey = "Model"
+	jobAnnealerKey       job.AttributeKey = "Annealer"
+	jobFrontKey          job.AttributeKey = "Front"
+	jobRandomSeedKey     job.AttributeKey = "RandomSeed"
+	jobFinishedKey       job.AttributeKey = "Finished"
+)
+
+// newAnnealingJob returns a job that will anneal the scenario supplied, having first checked that the scenario's
+// model and annealer can be built.
+func newAnnealingJob(scenarioConfig *data.ScenarioConfig, scenarioText string) (*job.Job, error) {
+	jobErrors := compositeErrors.New("annealing job")
+
+	if scenarioConfig.Annealer.Type == configData.UnspecifiedAnnealerType {
+		jobErrors.AddMessage("Annealer.Type must be supplied")
+		return nil, jobErrors
+	}
+
+	modelInterpreter := interpreter.NewModelConfigInterpreter()
+	jobModel := modelInterpreter.Interpret(&scenarioConfig.Model).Model()
+	if modelInterpreter.Errors() != nil {
+		jobErrors.Add(modelInterpreter.Errors())
+	} else if _, isCatchmentModel := jobModel.(*catchment.Model); !isCatchmentModel {
+		jobErrors.AddMessage("Model.Type must be a catchment model")
+	}
+
+	annealerInterpreter := interpreter.NewAnnealerConfigInterpreter()
+	jobAnnealer := annealerInterpreter.Interpret(&scenarioConfig.Annealer).Annealer()
+	if annealerInterpreter.Errors() != nil {
+		jobErrors.Add(annealerInterpreter.Errors())
+	}
+
+	if jobErrors.Size() > 0 {
+		return nil, jobErrors
+	}
+
+	jobAnnealer.SetId(scenarioConfig.Scenario.Name)
+	jobAnnealer.SetModel(jobModel.DeepClone())
+	if seededExplorer, explorerIsSeeded := jobAnnealer.SolutionExplorer().(rand.Seeded); explorerIsSeeded && scenarioConfig.Scenario.RandomSeed != 0 {
+		seededExplorer.SetRandomSeed(scenarioConfig.Scenario.RandomSeed)
+	}
+
+	newJob := new(job.Job).Initialise()
+	newJob.SetAttribute(jobScenarioNameKey, scenarioConfig.Scenario.Name)
+	newJob.SetHiddenAttribute(jobScenarioConfigKey, scenarioConfig)
+	newJob.SetHiddenAttribute(jobScenarioTextKey, scenarioText)
+	newJob.SetHiddenAttribute(jobModelKey, jobModel)
+	newJob.SetHiddenAttribute(jobAnnealerKey, jobAnnealer)
+
+	return newJob, nil
+}
+
+func (m *Mux) enqueueJob(newJob *job.Job) job.EnqueuedStatus {
+	m.startJobQueue.Do(m.jobQueue.Start)
+	m.startEvictingPeriodically()
+
+	m.jobsMutex.Lock()
+	defer m.jobsMutex.Unlock()
+
+	enqueuedStatus := m.jobQueue.Enqueue(newJob)
+	if enqueuedStatus == job.EnqueueSucceeded {
+		m.jobs[newJob.Id] = newJob
+	}
+	return enqueuedStatus
+}
+
+func (m *Mux) job(id job.Id) (*job.Job, bool) {
+	m.jobsMutex.RLock()
+	defer m.jobsMutex.RUnlock()
+
+	foundJob, jobFound := m.jobs[id]
+	return foundJob, jobFound
+}
+
+// pruneJobsFinishedBefore forgets every job that completed or errored before the cutoff supplied.
+func (m *Mux) pruneJobsFinishedBefore(cutoff time.Time) int {
+	m.jobsMutex.Lock()
+	defer m.jobsMutex.Unlock()
+
+	prunedJobs := 0
+	for id, knownJob := range m.jobs {
+		finished, hasFinished := knownJob.HiddenAttribute(jobFinishedKey).(time.Time)
+		if hasFinished && finished.Before(cutoff) {
+			m.Logger().Info("Pruning annealing job [" + string(id) + "] after finishing at [" + finished.Format(time.RFC3339) + "]")
+			delete(m.jobs, id)
+			prunedJobs++
+		}
+	}
+	return prunedJobs
+}
+
+func (m *Mux) runAnnealingJob(annealingJob *job.Job) {
+	defer m.handleAnnealingJobPanic(annealingJob)
+
+	m.Logger().Info("Annealing job [" + string(annealingJob.Id) + "] started")
+	annealingJob.SetStatus(job.Running)
+
+	jobAnnealer := annealingJob.HiddenAttribute(jobAnnealerKey).(annealing.Annealer)
+	jobAnnealer.SetLogHandler(m.Logger())
+
+	progressObserver := &annealingJobObserver{job: annealingJob}
+	jobAnnealer.AddObserver(progressObserver)
+
+	jobAnnealer.Anneal()
+
+	finishAnnealingJob(annealingJob, job.Completed)
+	m.Logger().Info("Annealing job [" + string(annealingJob.Id) + "] completed")
+}
+
+func (m *Mux) handleAnnealingJobPanic(annealingJob *job.Job) {
+	if r := recover(); r != nil {
+		wrappingError := errors.Errorf("annealing job [%s] failed: %v", annealingJob.Id, r)
+		m.Logger().Error(wrappingError)
+
+		annealingJob.SetAttribute(jobErrorKey, fmt.Sprintf("%v", r))
+		finishAnnealingJob(annealingJob, job.Errored)
+	}
+}
+
+func finishAnnealingJob(annealingJob *job.Job, status job.Status) {
+	annealingJob.RecordCompletionTime()
+	annealingJob.SetHiddenAttribute(jobFinishedKey, time.Now())
+	annealingJob.SetStatus(status)
+}
+
+// annealingJobObserver records an annealing job's progress, and the front of solutions found once annealing finishes.
+type annealingJobObserver struct {
+	job *job.Job
+}
+
+func (o *annealingJobObserver) ObserveEvent(event observer.Event) {
+	switch event.EventType {
+	case observer.FinishedIteration:
+		o.recordProgress(event)
+	case observer.FinishedAnnealing:
+		o.recordFront(event)
+	}
+}
+
+func (o *annealingJobObserver) recordProgress(event observer.Event) {
+	currentIteration, hasCurrentIteration := event.Attribute(annealers.CurrentIteration).(uint64)
+	maximumIterations, hasMaximumIterations := event.Attribute(annealers.MaximumIterations).(uint64)
+	if !hasCurrentIteration || !hasMaximumIterations || maximumIterations == 0 {
+		return
+	}
+
+	o.job.SetAttribute(jobCurrentIterationKey, currentIteration)
+	o.job.SetAttribute(jobMaximumIterationsKey, maximumIterations)
+	o.job.SetAttribute(jobPercentCompleteKey, 100*float64(currentIteration)/float64(maximumIterations))
+}
+
+func (o *annealingJobObserver) recordFront(event observer.Event) {
+	front := make([]*archive.CompressedModelState, 0)
+
+	if event.HasAttribute(scenario.ModelArchive) {
+		modelArchive := event.Attribute(scenario.ModelArchive).(archive.NonDominanceModelArchive)
+		front = append(front, modelArchive.Archive()...)
+	} else if event.HasAttribute(scenario.CompressedModel) {
+		compressedModel := event.Attribute(scenario.CompressedModel).(archive.CompressedModelState)
+		front = append(front, &compressedModel)
+	}
+
+	o.job.SetHiddenAttribute(jobFrontKey, front)
+	if randomSeed, isSeed := event.Attribute(scenario.RandomSeed).(int64); isSeed {
+		o.job.SetHiddenAttribute(jobRandomSeedKey, randomSeed)
+	}
+}
+
+func jobModelFor(annealingJob *job.Job) *catchment.Model {
+	jobModel := annealingJob.HiddenAttribute(jobModelKey).(model.Model)
+	return toCatchmentModel(jobModel.DeepClone())
+}
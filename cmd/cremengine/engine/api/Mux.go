@@ -3,17 +3,15 @@
 package api
 
 import (
-	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/json"
-	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
-	"github.com/LindsayBradford/crem/internal/pkg/dataset"
-	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
 	serverApi "github.com/LindsayBradford/crem/internal/pkg/server/api"
+	"github.com/LindsayBradford/crem/internal/pkg/server/job"
 	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
-	"github.com/LindsayBradford/crem/pkg/attributes"
 	"github.com/LindsayBradford/crem/pkg/threading"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 )
 
 const (
@@ -23,54 +21,112 @@ const (
 	scenarioNameKey = "ScenarioName"
 
 	solutionsTextKey = "SolutionsText"
+
+	scenarioPath         = "scenario"
+	solutionsPath        = "solutions"
+	modelPath            = "model"
+	actionsPath          = "actions"
+	subcatchmentPath     = "subcatchment"
+	subcatchmentsPath    = "subcatchments"
+	contributionsPath    = "contributions"
+	identityMatchingPath = "\\d+"
+	solutionLabelPath    = "[\\w\\-]+"
+	comparePath          = "compare"
+	uncertaintyPath      = "uncertainty"
+	jobsPath             = "jobs"
+	jobIdPath            = "[0-9A-Fa-f\\-]+"
+	sessionsPath         = "sessions"
+	sessionIdPath        = "[0-9A-Fa-f\\-]+"
 )
 
 type Mux struct {
 	serverApi.Mux
 	mainThreadChannel *threading.MainThreadChannel
 
-	modelConfigInterpreter *interpreter.ModelConfigInterpreter
-	model                  *catchment.Model
-	modelSolution          *solution.Solution
-
-	solutionPool     SolutionPool
-	solutionSetTable dataset.HeadingsTable
+	defaultSession     *Session
+	sessions           map[SessionId]*Session
+	sessionsMutex      sync.RWMutex
+	sessionIdleTimeout time.Duration
+	startEvicting      sync.Once
+	stopEvicting       chan struct{}
 
 	jsonMarshaler json.Marshaler
 
-	attributes.ContainedAttributes
+	maximumUncertaintySampleSize uint
+
+	jobQueue      *job.Queue
+	jobs          map[job.Id]*job.Job
+	jobsMutex     sync.RWMutex
+	startJobQueue sync.Once
 }
 
 func (m *Mux) Initialise() *Mux {
-	const (
-		scenarioPath         = "scenario"
-		solutionsPath        = "solutions"
-		modelPath            = "model"
-		actionsPath          = "actions"
-		subcatchmentPath     = "subcatchment"
-		identityMatchingPath = "\\d+"
-		solutionLabelPath    = "[\\w\\-]+"
-	)
-
 	m.Mux.Initialise()
 
-	m.modelConfigInterpreter = interpreter.NewModelConfigInterpreter()
+	m.initialiseSessions()
+	m.maximumUncertaintySampleSize = DefaultMaximumUncertaintySampleSize
+
+	m.jobs = make(map[job.Id]*job.Job)
+	m.jobQueue = new(job.Queue).Initialise().WithJobFunction(m.runAnnealingJob)
+
+	m.AddHandler(buildV1ApiPath(sessionsPath), m.v1sessionsHandler)
+	m.AddHandler(buildV1ApiPath(sessionsPath, sessionIdPath), m.v1sessionHandler)
 
-	m.AddHandler(buildV1ApiPath(scenarioPath), m.v1scenarioHandler)
-	m.AddHandler(buildV1ApiPath(solutionsPath), m.v1solutionSetHandler)
-	m.AddHandler(buildV1ApiPath(solutionsPath, solutionLabelPath), m.v1solutionHandler)
-	m.AddHandler(buildV1ApiPath(modelPath), m.v1modelHandler)
-	m.AddHandler(buildV1ApiPath(modelPath, actionsPath), m.v1actionsHandler)
-	m.AddHandler(buildV1ApiPath(modelPath, subcatchmentPath, identityMatchingPath), m.v1subcatchmentHandler)
+	m.addSessionHandler((*Session).v1scenarioHandler, scenarioPath)
+	m.addSessionHandler((*Session).v1solutionSetHandler, solutionsPath)
+	m.addSessionHandler((*Session).v1solutionHandler, solutionsPath, solutionLabelPath)
+	m.addSessionHandler((*Session).v1solutionComparisonHandler, solutionsPath, solutionLabelPath, comparePath, solutionLabelPath)
+	m.addSessionHandler((*Session).v1solutionUncertaintyHandler, solutionsPath, solutionLabelPath, uncertaintyPath)
+	m.addSessionHandler((*Session).v1modelHandler, modelPath)
+	m.addSessionHandler((*Session).v1actionsHandler, modelPath, actionsPath)
+	m.addSessionHandler((*Session).v1subcatchmentHandler, modelPath, subcatchmentPath, identityMatchingPath)
+	m.addSessionHandler((*Session).v1subcatchmentContributionsHandler, modelPath, subcatchmentPath, identityMatchingPath, contributionsPath)
+	m.addSessionHandler((*Session).v1subcatchmentsHandler, modelPath, subcatchmentsPath)
+	m.addSessionHandler((*Session).v1jobSolutionsHandler, jobsPath, jobIdPath, solutionsPath)
+
+	m.AddHandler(buildV1ApiPath(jobsPath), m.v1jobsHandler)
+	m.AddHandler(buildV1ApiPath(jobsPath, jobIdPath), m.v1jobHandler)
 
 	return m
 }
 
+// addSessionHandler maps the handler supplied to the path elements supplied, both as nested beneath a session, and
+// (for requests naming no session) directly, acting upon the default session.
+func (m *Mux) addSessionHandler(handler SessionHandlerFunc, pathElements ...string) {
+	nestedPathElements := append([]string{sessionsPath, sessionIdPath}, pathElements...)
+	m.AddHandler(buildV1ApiPath(nestedPathElements...), m.inRequestedSession(handler))
+	m.AddHandler(buildV1ApiPath(pathElements...), m.inDefaultSession(handler))
+}
+
 func (m *Mux) WithMainThreadChannel(channel *threading.MainThreadChannel) *Mux {
 	m.mainThreadChannel = channel
 	return m
 }
 
+// WithJobQueueLength sizes both the queue of annealing jobs waiting to run, and the pool of workers running them.
+func (m *Mux) WithJobQueueLength(length uint64) *Mux {
+	m.jobQueue.WithQueueLength(length).WithWorkerNumber(length)
+	return m
+}
+
+// WithSessionIdleTimeout sets how long a session may go without requests before being evicted, and how long a finished
+// job is kept before being pruned. Zero keeps the default.
+func (m *Mux) WithSessionIdleTimeout(timeoutInSeconds uint64) *Mux {
+	if timeoutInSeconds != 0 {
+		m.sessionIdleTimeout = time.Duration(timeoutInSeconds) * time.Second
+	}
+	return m
+}
+
+// WithMaximumUncertaintySampleSize limits the sample size that uncertainty requests, analysed while their session
+// waits, may ask for. Zero keeps the default.
+func (m *Mux) WithMaximumUncertaintySampleSize(maximumSampleSize uint64) *Mux {
+	if maximumSampleSize != 0 {
+		m.maximumUncertaintySampleSize = uint(maximumSampleSize)
+	}
+	return m
+}
+
 func (m *Mux) WithCacheMaxAge(maxAgeInSeconds uint64) *Mux {
 	m.MuxImpl.WithCacheMaxAge(maxAgeInSeconds)
 	return m
@@ -96,12 +152,23 @@ func (m *Mux) AddHandler(address string, handler rest.HandlerFunc) {
 }
 
 func (m *Mux) Shutdown() {
-	if m.model != nil {
-		m.model.TearDown()
-	}
+	close(m.stopEvicting)
+	m.tearDownSessions()
 	m.MuxImpl.Shutdown()
 }
 
+func (m *Mux) SetScenario(scenarioFilePath string) {
+	m.defaultSession.SetScenario(scenarioFilePath)
+}
+
+func (m *Mux) SetSolution(solutionFilePath string) {
+	m.defaultSession.SetSolution(solutionFilePath)
+}
+
+func (m *Mux) SetSolutionSummary(solutionSummaryFilePath string) {
+	m.defaultSession.SetSolutionSummary(solutionSummaryFilePath)
+}
+
 func requestBodyToBytes(r *http.Request) []byte {
 	responseBodyBytes, _ := ioutil.ReadAll(r.Body)
 	return responseBodyBytes
@@ -28,88 +28,88 @@ const (
 	ValidationErrors     ModelAttribute = "ValidationErrors"
 )
 
-func (m *Mux) deriveExtraModelAttributes() {
-	encodingOfModel := m.deriveModelActionEncoding()
-	m.checkEncodingInSolutionSummary(encodingOfModel)
-	m.deriveModelValidityAgainstScenario()
+func (s *Session) deriveExtraModelAttributes() {
+	encodingOfModel := s.deriveModelActionEncoding()
+	s.checkEncodingInSolutionSummary(encodingOfModel)
+	s.deriveModelValidityAgainstScenario()
 }
 
-func (m *Mux) deriveModelActionEncoding() string {
-	compressedModel := modelCompressor.Compress(m.model)
+func (s *Session) deriveModelActionEncoding() string {
+	compressedModel := modelCompressor.Compress(s.model)
 	encodingOfModel := compressedModel.Encoding()
-	m.model.ReplaceAttribute(Encoding.String(), encodingOfModel)
+	s.model.ReplaceAttribute(Encoding.String(), encodingOfModel)
 	return encodingOfModel
 }
 
-func (m *Mux) deriveModelValidityAgainstScenario() bool {
-	isValid, validationErrors := m.model.StateIsValid()
-	m.model.ReplaceAttribute(ValidAgainstScenario.String(), isValid)
+func (s *Session) deriveModelValidityAgainstScenario() bool {
+	isValid, validationErrors := s.model.StateIsValid()
+	s.model.ReplaceAttribute(ValidAgainstScenario.String(), isValid)
 	if !isValid {
-		m.handleInvalidModel(validationErrors)
+		s.handleInvalidModel(validationErrors)
 	} else {
-		m.handleValidModel()
+		s.handleValidModel()
 	}
 	return isValid
 }
 
-func (m *Mux) handleValidModel() {
+func (s *Session) handleValidModel() {
 	msgText := fmt.Sprintf("New model is valid against supplied scenario")
-	m.Logger().Info(msgText)
+	s.Logger().Info(msgText)
 
-	m.model.RemoveAttribute(ValidationErrors.String())
+	s.model.RemoveAttribute(ValidationErrors.String())
 }
 
-func (m *Mux) handleInvalidModel(validationErrors *compositeErrors.CompositeError) {
+func (s *Session) handleInvalidModel(validationErrors *compositeErrors.CompositeError) {
 	msgText := fmt.Sprintf("New model is invalid against supplied scenario")
-	m.Logger().Info(msgText)
-	m.Logger().Info("Validation errors:" + validationErrors.Error())
+	s.Logger().Info(msgText)
+	s.Logger().Info("Validation errors:" + validationErrors.Error())
 
-	m.model.ReplaceAttribute(ValidationErrors.String(), validationErrors.Error())
+	s.model.ReplaceAttribute(ValidationErrors.String(), validationErrors.Error())
 }
 
-func (m *Mux) updateModelSolution() {
-	m.modelSolution = new(solution.SolutionBuilder).
-		WithId(m.model.Id()).
-		ForModel(m.model).
+func (s *Session) updateModelSolution() {
+	s.modelSolution = new(solution.SolutionBuilder).
+		WithId(s.model.Id()).
+		ForModel(s.model).
 		Build()
 }
 
-func (m *Mux) checkEncodingInSolutionSummary(encoding string) {
-	if m.solutionSetTable == nil {
+func (s *Session) checkEncodingInSolutionSummary(encoding string) {
+	if s.solutionSetTable == nil {
 		return
 	}
 
-	encodingFound := m.encodingPresentInSolutionSummaryParetoFront(encoding)
-	m.attributeModelWithParetoFrontPresence(encoding, encodingFound)
+	encodingFound := s.encodingPresentInSolutionSummaryParetoFront(encoding)
+	s.attributeModelWithParetoFrontPresence(encoding, encodingFound)
 }
 
-func (m *Mux) attributeModelWithParetoFrontPresence(encoding string, encodingFound bool) {
+func (s *Session) attributeModelWithParetoFrontPresence(encoding string, encodingFound bool) {
 	if encodingFound {
-		m.model.ReplaceAttribute(ParetoFrontMember.String(), true)
+		s.model.ReplaceAttribute(ParetoFrontMember.String(), true)
 	} else {
-		m.model.ReplaceAttribute(ParetoFrontMember.String(), false)
+		s.model.ReplaceAttribute(ParetoFrontMember.String(), false)
 		msgText := fmt.Sprintf(
 			"New model encoding [%s] matches no pareto front member", encoding)
-		m.Logger().Info(msgText)
+		s.Logger().Info(msgText)
 	}
 }
 
-func (m *Mux) encodingPresentInSolutionSummaryParetoFront(encoding string) bool {
-	colSize, rowSize := m.solutionSetTable.ColumnAndRowSize()
+func (s *Session) encodingPresentInSolutionSummaryParetoFront(encoding string) bool {
+	_, rowSize := s.solutionSetTable.ColumnAndRowSize()
+	encodingIndex, _ := columnIndexOf(s.solutionSetTable, actionsHeading)
 
 	var (
 		labelIndex    = uint(0)
-		encodingIndex = colSize - 2
 		encodingFound = false
 	)
 
 	for rowIndex := uint(1); rowIndex < rowSize; rowIndex++ {
-		if encoding == m.solutionSetTable.CellString(encodingIndex, rowIndex) {
+		if encoding == s.solutionSetTable.CellString(encodingIndex, rowIndex) {
 			encodingFound = true
-			label := m.solutionSetTable.CellString(labelIndex, rowIndex)
+			label := s.solutionSetTable.CellString(labelIndex, rowIndex)
 			msgText := fmt.Sprintf(
 				"New model's encoding [%s] matches pareto front solution set member [%s]", encoding, label)
-			m.Logger().Info(msgText)
+			s.Logger().Info(msgText)
 		}
 	}
 	return encodingFound
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/server/job/uuid"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+)
+
+type SessionId string
+
+// DefaultSessionId identifies the session that routes not nested under a session act upon. It is never evicted.
+const DefaultSessionId SessionId = "default"
+
+// Session is an isolated workspace of an engine user, holding their scenario, model, management action state and
+// solutions apart from those of every other session.
+type Session struct {
+	*Mux
+	attributes.ContainedAttributes
+
+	id           SessionId
+	mutex        sync.Mutex
+	created      time.Time
+	lastAccessed time.Time
+
+	modelConfigInterpreter *interpreter.ModelConfigInterpreter
+	model                  *catchment.Model
+	modelSolution          *solution.Solution
+
+	solutionPool     SolutionPool
+	solutionSetTable dataset.HeadingsTable
+}
+
+func newSession(id SessionId, mux *Mux) *Session {
+	newSession := &Session{
+		Mux:                    mux,
+		id:                     id,
+		created:                time.Now(),
+		modelConfigInterpreter: interpreter.NewModelConfigInterpreter(),
+	}
+	newSession.lastAccessed = newSession.created
+	return newSession
+}
+
+func newSessionId() SessionId {
+	return SessionId(uuid.New())
+}
+
+func (s *Session) Id() SessionId {
+	return s.id
+}
+
+// summary returns a snapshot of the session's timings. Callers must hold the mux's sessions lock, which guards them.
+func (s *Session) summary() SessionSummary {
+	summary := SessionSummary{
+		Id:           s.id,
+		Created:      s.created.Format(time.RFC3339Nano),
+		LastAccessed: s.lastAccessed.Format(time.RFC3339Nano),
+	}
+	return summary
+}
+
+func (s *Session) isIdleSince(cutoff time.Time) bool {
+	return s.id != DefaultSessionId && s.lastAccessed.Before(cutoff)
+}
+
+// serve handles the request with the session locked, so that requests of the same session see each other's changes
+// whole, while requests of other sessions proceed unhindered.
+func (s *Session) serve(handler SessionHandlerFunc, w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	handler(s, w, r)
+}
+
+// TearDown releases the session's model. It waits on any request of the session still being served.
+func (s *Session) TearDown() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.model != nil {
+		s.model.TearDown()
+	}
+}
+
+type SessionSummary struct {
+	Id           SessionId
+	Created      string
+	LastAccessed string
+}
+
+// SessionHandlerFunc handles requests made of a session's resources.
+type SessionHandlerFunc func(s *Session, w http.ResponseWriter, r *http.Request)
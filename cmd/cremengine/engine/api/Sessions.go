@@ -0,0 +1,181 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+)
+
+const DefaultSessionIdleTimeout = 30 * time.Minute
+
+func (m *Mux) initialiseSessions() {
+	m.sessions = make(map[SessionId]*Session)
+	m.sessionIdleTimeout = DefaultSessionIdleTimeout
+	m.stopEvicting = make(chan struct{})
+
+	m.defaultSession = newSession(DefaultSessionId, m)
+	m.sessions[DefaultSessionId] = m.defaultSession
+}
+
+func (m *Mux) createSession() *Session {
+	m.startEvictingPeriodically()
+
+	m.sessionsMutex.Lock()
+	defer m.sessionsMutex.Unlock()
+
+	createdSession := newSession(newSessionId(), m)
+	m.sessions[createdSession.id] = createdSession
+	return createdSession
+}
+
+// session returns the session with the id supplied, marking it as accessed now.
+func (m *Mux) session(id SessionId) (*Session, bool) {
+	m.sessionsMutex.Lock()
+	defer m.sessionsMutex.Unlock()
+
+	foundSession, sessionFound := m.sessions[id]
+	if sessionFound {
+		foundSession.lastAccessed = time.Now()
+	}
+	return foundSession, sessionFound
+}
+
+func (m *Mux) sessionSummary(id SessionId) (SessionSummary, bool) {
+	m.sessionsMutex.RLock()
+	defer m.sessionsMutex.RUnlock()
+
+	foundSession, sessionFound := m.sessions[id]
+	if !sessionFound {
+		return SessionSummary{}, false
+	}
+	return foundSession.summary(), true
+}
+
+func (m *Mux) endSession(id SessionId) bool {
+	m.sessionsMutex.Lock()
+	endedSession, sessionFound := m.sessions[id]
+	if sessionFound && id != DefaultSessionId {
+		delete(m.sessions, id)
+	}
+	m.sessionsMutex.Unlock()
+
+	if !sessionFound || id == DefaultSessionId {
+		return false
+	}
+
+	endedSession.TearDown()
+	return true
+}
+
+// SessionSummaries returns a summary of every live session, ordered by creation time.
+func (m *Mux) SessionSummaries() []SessionSummary {
+	m.sessionsMutex.RLock()
+	defer m.sessionsMutex.RUnlock()
+
+	liveSessions := make([]*Session, 0, len(m.sessions))
+	for _, liveSession := range m.sessions {
+		liveSessions = append(liveSessions, liveSession)
+	}
+	sort.Slice(liveSessions, func(i, j int) bool {
+		return liveSessions[i].created.Before(liveSessions[j].created)
+	})
+
+	summaries := make([]SessionSummary, len(liveSessions))
+	for index, liveSession := range liveSessions {
+		summaries[index] = liveSession.summary()
+	}
+	return summaries
+}
+
+// startEvictingPeriodically starts, if not already started, the periodic eviction of idle sessions, and of jobs that
+// finished longer ago than the session idle timeout.
+func (m *Mux) startEvictingPeriodically() {
+	m.startEvicting.Do(func() { go m.evictPeriodically() })
+}
+
+func (m *Mux) evictPeriodically() {
+	ticker := time.NewTicker(m.sessionIdleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cutoff := time.Now().Add(-m.sessionIdleTimeout)
+			m.evictSessionsIdleSince(cutoff)
+			m.pruneJobsFinishedBefore(cutoff)
+		case <-m.stopEvicting:
+			return
+		}
+	}
+}
+
+// evictSessionsIdleSince ends every session (bar the default session) not accessed since the cutoff supplied.
+func (m *Mux) evictSessionsIdleSince(cutoff time.Time) int {
+	m.sessionsMutex.Lock()
+	evictedSessions := make([]*Session, 0)
+	for id, liveSession := range m.sessions {
+		if liveSession.isIdleSince(cutoff) {
+			evictedSessions = append(evictedSessions, liveSession)
+			delete(m.sessions, id)
+		}
+	}
+	m.sessionsMutex.Unlock()
+
+	for _, evictedSession := range evictedSessions {
+		m.Logger().Info("Evicting session [" + string(evictedSession.id) + "] after being idle since [" +
+			evictedSession.lastAccessed.Format(time.RFC3339) + "]")
+		evictedSession.TearDown()
+	}
+	return len(evictedSessions)
+}
+
+func (m *Mux) tearDownSessions() {
+	m.sessionsMutex.Lock()
+	defer m.sessionsMutex.Unlock()
+
+	for _, liveSession := range m.sessions {
+		liveSession.TearDown()
+	}
+}
+
+// inDefaultSession adapts a session handler to serve requests of the default session.
+func (m *Mux) inDefaultSession(handler SessionHandlerFunc) rest.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.session(DefaultSessionId)
+		m.defaultSession.serve(handler, w, r)
+	}
+}
+
+// inRequestedSession adapts a session handler to serve requests of the session identified in the request path.
+func (m *Mux) inRequestedSession(handler SessionHandlerFunc) rest.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestedSession, sessionFound := m.session(deriveSessionIdFrom(r))
+		if !sessionFound {
+			m.Logger().Warn("Request made of unknown session [" + string(deriveSessionIdFrom(r)) + "]")
+			m.NotFoundError(w, r)
+			return
+		}
+		requestedSession.serve(handler, w, r)
+	}
+}
+
+func deriveSessionIdFrom(r *http.Request) SessionId {
+	return SessionId(pathElementFollowing(r, sessionsPath))
+}
+
+// pathElementFollowing returns the element of the request path following the first one matching that supplied, or
+// the empty string if there is none.
+func pathElementFollowing(r *http.Request, precedingElement string) string {
+	pathElements := strings.Split(r.URL.Path, rest.UrlPathSeparator)
+	for index := 0; index < len(pathElements)-1; index++ {
+		if pathElements[index] == precedingElement {
+			return pathElements[index+1]
+		}
+	}
+	return ""
+}
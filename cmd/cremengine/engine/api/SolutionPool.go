@@ -5,6 +5,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/model/uncertainty"
 	"time"
 )
 
@@ -88,6 +89,18 @@ func (sp *SolutionPool) AddSolution(label SolutionPoolLabel, modelEncoding strin
 	sp.cache[label] = NewSolutionContainer(newSolution, summary)
 }
 
+// Uncertainty analyses how the decision variables of the labelled solution spread over the sampled model inputs
+// specified.
+func (sp *SolutionPool) Uncertainty(label SolutionPoolLabel, specification uncertainty.Specification) (*uncertainty.Report, error) {
+	analysis, specificationError := specification.Analysis()
+	if specificationError != nil {
+		return nil, specificationError
+	}
+
+	poolSolution := uncertainty.Solution{Id: string(label), Encoding: sp.Solution(label).EncodedActions}
+	return analysis.WithModel(sp.referenceModel).Evaluate(poolSolution)
+}
+
 func NewSolutionContainer(solution *solution.Solution, summary string) SolutionContainer {
 	container := SolutionContainer{Solution: solution, Summary: summary, LastUpdated: time.Now()}
 	return container
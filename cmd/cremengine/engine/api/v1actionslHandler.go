@@ -14,84 +14,88 @@ import (
 
 const v1ModelActionsHandler = "v1 model actions handler"
 
-func (m *Mux) v1actionsHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1actionsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPut:
-		m.v1PutActionsHandler(w, r)
+		s.v1PutActionsHandler(w, r)
 	case http.MethodGet:
-		m.v1GetActionsHandler(w, r)
+		s.v1GetActionsHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
 type actionsWrapper struct {
 	ActiveManagementActions map[planningunit.Id]solution.ManagementActions
+	ActionIntensities       map[planningunit.Id]map[solution.ManagementActionType]float64 `json:",omitempty"`
+	ActionStartYears        map[planningunit.Id]map[solution.ManagementActionType]uint    `json:",omitempty"`
 }
 
-func (m *Mux) v1GetActionsHandler(w http.ResponseWriter, r *http.Request) {
-	if m.modelSolution == nil {
-		m.NotFoundError(w, r)
+func (s *Session) v1GetActionsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.NotFoundError(w, r)
 		return
 	}
 
-	m.writeActiveActionResponse(w)
+	s.writeActiveActionResponse(w)
 }
 
-func (m *Mux) writeActiveActionResponse(w http.ResponseWriter) {
+func (s *Session) writeActiveActionResponse(w http.ResponseWriter) {
 	activeActions := actionsWrapper{
-		ActiveManagementActions: m.modelSolution.ActiveManagementActions,
+		ActiveManagementActions: s.modelSolution.ActiveManagementActions,
+		ActionIntensities:       s.modelSolution.ActionIntensities,
+		ActionStartYears:        s.modelSolution.ActionStartYears,
 	}
 
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(activeActions)
 
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Responding with model [" + scenarioName + "] active actions state")
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with model [" + scenarioName + "] active actions state")
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) v1PutActionsHandler(w http.ResponseWriter, r *http.Request) {
-	if m.modelSolution == nil {
-		m.NotFoundError(w, r)
+func (s *Session) v1PutActionsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.NotFoundError(w, r)
 		return
 	}
 
-	if m.requestContentTypeWasNotCsv(r, w) {
+	if s.requestContentTypeWasNotCsv(r, w) {
 		return
 	}
 
-	processError := m.processRequestContentForActiveActions(r, w)
+	processError := s.processRequestContentForActiveActions(r, w)
 	if processError != nil {
 		return
 	}
 
-	restResponse := m.buildPostActionsResponse(w)
+	restResponse := s.buildPostActionsResponse(w)
 
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 
 }
 
-func (m *Mux) buildPostActionsResponse(w http.ResponseWriter) *rest.Response {
+func (s *Session) buildPostActionsResponse(w http.ResponseWriter) *rest.Response {
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(
 			rest.MessageResponse{
 				Type:    "SUCCESS",
@@ -100,36 +104,73 @@ func (m *Mux) buildPostActionsResponse(w http.ResponseWriter) *rest.Response {
 			},
 		)
 
-	m.Logger().Info("Responding with acknowledgement of management actions state change ")
+	s.Logger().Info("Responding with acknowledgement of management actions state change ")
 
 	return restResponse
 }
 
-func (m *Mux) processRequestContentForActiveActions(r *http.Request, w http.ResponseWriter) error {
-	requestTable, requestError := m.deriveRequestTable(r, w)
+func (s *Session) processRequestContentForActiveActions(r *http.Request, w http.ResponseWriter) error {
+	requestTable, requestError := s.deriveRequestTable(r, w)
 	if requestError != nil {
 		return requestError
 	}
 
-	m.processRequestTable(requestTable)
-	m.updateModelSolution()
+	if intensityError := s.validateSuppliedIntensities(requestTable); intensityError != nil {
+		s.RespondWithError(http.StatusBadRequest, intensityError.Error(), w, r)
+		return intensityError
+	}
+
+	violationsError := s.applyWithinConstraints(func() { s.processRequestTable(requestTable) })
+	if violationsError != nil {
+		s.RespondWithError(http.StatusBadRequest, violationsError.Error(), w, r)
+		return violationsError
+	}
+	s.updateModelSolution()
 
 	return nil
 }
 
-func (m *Mux) processRequestTable(headingsTable dataset.HeadingsTable) {
+// applyWithinConstraints applies the model change supplied, returning the model's management actions to their
+// earlier start years and intensities if the change leaves them breaking any of the model's action constraints, or
+// its yearly budget.
+func (s *Session) applyWithinConstraints(change func()) error {
+	modelActions := s.model.ManagementActions()
+	intensitiesBefore := make([]float64, len(modelActions))
+	startYearsBefore := make([]uint, len(modelActions))
+	for index, modelAction := range modelActions {
+		intensitiesBefore[index] = modelAction.Intensity()
+		startYearsBefore[index] = modelAction.StartYear()
+	}
+
+	change()
+
+	violations := s.model.ActionViolations()
+	if violations == nil {
+		return nil
+	}
+
+	for index := range modelActions {
+		s.model.SetManagementActionStartYear(index, startYearsBefore[index])
+		s.model.SetManagementActionIntensity(index, intensitiesBefore[index])
+	}
+	s.deriveExtraModelAttributes()
+	s.Logger().Error(violations)
+	return violations
+}
+
+func (s *Session) processRequestTable(headingsTable dataset.HeadingsTable) {
 	colSize, rowSize := headingsTable.ColumnAndRowSize()
 	for rowIndex := uint(0); rowIndex < rowSize; rowIndex++ {
 		for colIndex := uint(1); colIndex < colSize; colIndex++ {
-			m.processTableCell(headingsTable, colIndex, rowIndex)
+			s.processTableCell(headingsTable, colIndex, rowIndex)
 		}
 	}
-	m.deriveExtraModelAttributes()
+	s.deriveExtraModelAttributes()
 }
 
-func (m *Mux) processTableCell(headingsTable dataset.HeadingsTable, colIndex uint, rowIndex uint) {
-	suppliedActionState := m.deriveSuppliedActionState(headingsTable, colIndex, rowIndex)
-	modelActions := m.model.ManagementActions()
+func (s *Session) processTableCell(headingsTable dataset.HeadingsTable, colIndex uint, rowIndex uint) {
+	suppliedIntensity := headingsTable.CellFloat64(colIndex, rowIndex)
+	modelActions := s.model.ManagementActions()
 
 	for actionIndex := 0; actionIndex < len(modelActions); actionIndex++ {
 		currentAction := modelActions[actionIndex]
@@ -139,60 +180,107 @@ func (m *Mux) processTableCell(headingsTable dataset.HeadingsTable, colIndex uin
 
 		if currentAction.PlanningUnit() == planningunit.Id(rawPlanningUnit) &&
 			string(currentAction.Type()) == rawType {
-			m.model.SetManagementAction(actionIndex, suppliedActionState)
+			s.applySuppliedIntensity(actionIndex, suppliedIntensity)
 		}
 	}
 }
 
-func (m *Mux) deriveSuppliedActionState(headingsTable dataset.HeadingsTable, colIndex uint, rowIndex uint) bool {
-	var suppliedActionState bool
+// applySuppliedIntensity treats supplied values of 0 and 1 as switching the action off and on, so that a value of 1
+// applies actions whose levels stop short of 1 at their highest level.  Any other value is applied as an intensity.
+func (s *Session) applySuppliedIntensity(actionIndex int, suppliedIntensity float64) {
+	switch suppliedIntensity {
+	case 0:
+		s.model.SetManagementAction(actionIndex, false)
+	case 1:
+		s.model.SetManagementAction(actionIndex, true)
+	default:
+		s.model.SetManagementActionIntensity(actionIndex, suppliedIntensity)
+	}
+}
+
+// validateSuppliedIntensities checks that any partial intensity supplied in the table is one of the intensity levels
+// its action offers.
+func (s *Session) validateSuppliedIntensities(headingsTable dataset.HeadingsTable) error {
+	intensityErrors := compositeErrors.New("v1 PUT actions handler")
+
+	colSize, rowSize := headingsTable.ColumnAndRowSize()
+	for rowIndex := uint(0); rowIndex < rowSize; rowIndex++ {
+		planningUnit := planningunit.Id(headingsTable.CellFloat64(0, rowIndex))
+		for colIndex := uint(1); colIndex < colSize; colIndex++ {
+			suppliedIntensity := headingsTable.CellFloat64(colIndex, rowIndex)
+			if suppliedIntensity == 0 || suppliedIntensity == 1 {
+				continue
+			}
+
+			actionType := headingsTable.Header()[colIndex]
+			for _, modelAction := range s.model.ManagementActions() {
+				if modelAction.PlanningUnit() != planningUnit || string(modelAction.Type()) != actionType {
+					continue
+				}
+				if !offersIntensity(modelAction.IntensityLevels(), suppliedIntensity) {
+					msgText := fmt.Sprintf(
+						"Table management action cell [%d,%d] has intensity [%v] not among action intensity levels %v",
+						colIndex, rowIndex, suppliedIntensity, modelAction.IntensityLevels())
+					intensityErrors.AddMessage(msgText)
+					s.Logger().Error(msgText)
+				}
+			}
+		}
+	}
+
+	if intensityErrors.Size() > 0 {
+		return intensityErrors
+	}
+	return nil
+}
 
-	if headingsTable.CellFloat64(colIndex, rowIndex) == 0 {
-		suppliedActionState = false
-	} else {
-		suppliedActionState = true
+func offersIntensity(levels []float64, intensity float64) bool {
+	for _, level := range levels {
+		if level == intensity {
+			return true
+		}
 	}
-	return suppliedActionState
+	return false
 }
 
-func (m *Mux) deriveRequestTable(r *http.Request, w http.ResponseWriter) (dataset.HeadingsTable, error) {
+func (s *Session) deriveRequestTable(r *http.Request, w http.ResponseWriter) (dataset.HeadingsTable, error) {
 	rawTableContent := requestBodyToString(r)
 
-	requestTable, parseError := m.deriveSolutionTable(rawTableContent)
+	requestTable, parseError := s.deriveSolutionTable(rawTableContent)
 	if parseError != nil {
-		m.BadRequestError(w, r)
+		s.BadRequestError(w, r)
 	}
 	return requestTable, parseError
 }
 
-func (m *Mux) deriveSolutionTable(rawTableContent string) (dataset.HeadingsTable, error) {
+func (s *Session) deriveSolutionTable(rawTableContent string) (dataset.HeadingsTable, error) {
 	tmpDataSet := csv.NewDataSet("Content Dataset")
 	defer tmpDataSet.Teardown()
 
 	tmpDataSet.ParseCsvTextIntoTable("requestContent", rawTableContent)
 	if tmpDataSet.Errors() != nil {
 		wrappingError := errors.Wrap(tmpDataSet.Errors(), v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	contentTable, tableError := tmpDataSet.Table("requestContent")
 	if tableError != nil {
 		wrappingError := errors.Wrap(tmpDataSet.Errors(), v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	if contentTable == nil {
 		wrappingError := errors.Wrap(errors.New("No CSV table content found"), v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	headingsTable, hasHeadings := contentTable.(dataset.HeadingsTable)
 	if !hasHeadings {
 		wrappingError := errors.Wrap(errors.New("CSV table does not have a header row"), v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
@@ -200,7 +288,7 @@ func (m *Mux) deriveSolutionTable(rawTableContent string) (dataset.HeadingsTable
 		wrappingError := errors.Wrap(
 			errors.New("CSV table header column misses mandatory 'SubCatchment' first entry"),
 			v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
@@ -213,19 +301,19 @@ func (m *Mux) deriveSolutionTable(rawTableContent string) (dataset.HeadingsTable
 			cellValue := headingsTable.Cell(colIndex, rowIndex)
 			switch cellValue.(type) {
 			case float64:
-				if cellValue != float64(0) && cellValue != float64(1) {
+				if cellValue.(float64) < 0 || cellValue.(float64) > 1 {
 					msgText := fmt.Sprintf(
-						"Table management action cell [%d,%d] has invalid value [%v]. Must be one of [0,1]",
+						"Table management action cell [%d,%d] has invalid value [%v]. Must be within [0,1]",
 						colIndex, rowIndex, cellValue)
 					updateErrors.AddMessage(msgText)
-					m.Logger().Error(msgText)
+					s.Logger().Error(msgText)
 				}
 			default:
 				msgText := fmt.Sprintf(
-					"Table management action cell [%d,%d] has invalid value [%v]. Must be one of [0,1]",
+					"Table management action cell [%d,%d] has invalid value [%v]. Must be within [0,1]",
 					colIndex, rowIndex, cellValue)
 				updateErrors.AddMessage(msgText)
-				m.Logger().Error(msgText)
+				s.Logger().Error(msgText)
 			}
 		}
 	}
@@ -237,16 +325,16 @@ func (m *Mux) deriveSolutionTable(rawTableContent string) (dataset.HeadingsTable
 	return headingsTable, nil
 }
 
-func (m *Mux) SetSolution(solutionFilePath string) {
+func (s *Session) SetSolution(solutionFilePath string) {
 	rawTableContent := readFileAsText(solutionFilePath)
 
-	requestTable, parseError := m.deriveSolutionTable(rawTableContent)
+	requestTable, parseError := s.deriveSolutionTable(rawTableContent)
 	if parseError != nil {
 		wrappingError := errors.Wrap(parseError, v1ModelActionsHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return
 	}
 
-	m.processRequestTable(requestTable)
-	m.updateModelSolution()
+	s.processRequestTable(requestTable)
+	s.updateModelSolution()
 }
@@ -0,0 +1,212 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/LindsayBradford/crem/cmd/cremengine/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/csv"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/server/job"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const v1jobsHandler = "v1 jobs handler"
+
+func (m *Mux) v1jobsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		m.v1PostJobHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1PostJobHandler(w http.ResponseWriter, r *http.Request) {
+	if m.requestContentTypeWasNotToml(r, w) {
+		return
+	}
+
+	requestContent := requestBodyToString(r)
+	scenarioConfig, retrievalError := data.RetrieveScenarioConfigFromString(requestContent)
+	if retrievalError != nil {
+		m.handleJobRequestErrors(w, r, retrievalError)
+		return
+	}
+
+	newJob, jobError := newAnnealingJob(scenarioConfig, requestContent)
+	if jobError != nil {
+		m.handleJobRequestErrors(w, r, jobError)
+		return
+	}
+
+	if m.enqueueJob(newJob) == job.EnqueueFailed {
+		m.Logger().Warn("Annealing job queue is full. Scenario [" + scenarioConfig.Scenario.Name + "] not enqueued")
+		m.ServiceUnavailableError(w, r, errors.New("annealing job queue is full"))
+		return
+	}
+
+	m.Logger().Info("Scenario [" + scenarioConfig.Scenario.Name + "] enqueued as annealing job [" + string(newJob.Id) + "]")
+	m.writeJobResponse(w, http.StatusAccepted, newJob)
+}
+
+func (m *Mux) handleJobRequestErrors(w http.ResponseWriter, r *http.Request, requestError error) {
+	wrappingError := errors.Wrap(requestError, v1jobsHandler)
+	m.Logger().Error(wrappingError)
+	m.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
+}
+
+func (m *Mux) v1jobHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		m.v1GetJobHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	requestedJob, jobFound := m.job(deriveJobIdFrom(r))
+	if !jobFound {
+		m.NotFoundError(w, r)
+		return
+	}
+
+	m.writeJobResponse(w, http.StatusOK, requestedJob)
+}
+
+func (m *Mux) writeJobResponse(w http.ResponseWriter, responseCode int, responseJob *job.Job) {
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(responseCode).
+		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithJsonContent(responseJob.Snapshot())
+
+	writeError := restResponse.Write()
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1jobsHandler)
+		m.Logger().Error(wrappingError)
+	}
+}
+
+func (s *Session) v1jobSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.v1GetJobSolutionsHandler(w, r)
+	default:
+		s.MethodNotAllowedError(w, r)
+	}
+}
+
+func (s *Session) v1GetJobSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	requestedJob, jobFound := s.job(deriveJobIdFrom(r))
+	if !jobFound {
+		s.NotFoundError(w, r)
+		return
+	}
+
+	if requestedJob.Status() != job.Completed {
+		s.Logger().Warn("Request for solutions of annealing job [" + string(requestedJob.Id) + "] before it completed")
+		s.RespondWithError(http.StatusConflict, "Annealing job ["+string(requestedJob.Id)+"] has not completed", w, r)
+		return
+	}
+
+	solutionsText, loadError := s.loadJobSolutions(requestedJob)
+	if loadError != nil {
+		wrappingError := errors.Wrap(loadError, v1jobsHandler)
+		s.Logger().Error(wrappingError)
+		s.RespondWithError(http.StatusInternalServerError, wrappingError.Error(), w, r)
+		return
+	}
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithCsvContent(solutionsText)
+
+	s.logSolutionsGetResponse()
+	writeError := restResponse.Write()
+	s.handleSolutionsGetWriteError(writeError)
+}
+
+// loadJobSolutions adopts the completed job's scenario as the session's current scenario, and loads the front of
+// solutions the job found into the session's solution pool, returning the front's solution set summary.
+func (s *Session) loadJobSolutions(completedJob *job.Job) (string, error) {
+	scenarioConfig := completedJob.HiddenAttribute(jobScenarioConfigKey).(*data.ScenarioConfig)
+	scenarioText := completedJob.HiddenAttribute(jobScenarioTextKey).(string)
+
+	s.rememberScenarioAttributeState(scenarioConfig, scenarioText)
+	s.rememberModelState(jobModelFor(completedJob), scenarioConfig)
+	s.updateModelSolution()
+
+	summary := s.summariseJobFront(completedJob)
+	marshaledSummary, marshalError := new(csv.SummaryMarshaler).Marshal(&summary)
+	if marshalError != nil {
+		return "", marshalError
+	}
+	summaryText := string(marshaledSummary)
+
+	summaryTable, tableError := s.deriveSolutionsRequestTable(summaryText)
+	if tableError != nil {
+		return "", tableError
+	}
+	s.updateSolutionSummary(summaryTable, summaryText)
+
+	for _, entry := range summary.AsSortedArray() {
+		s.solutionPool.AddSolution(SolutionPoolLabel(entry.Id), string(entry.Actions), entry.Note)
+	}
+
+	return summaryText, nil
+}
+
+func (s *Session) summariseJobFront(completedJob *job.Job) set.Summary {
+	front, _ := completedJob.HiddenAttribute(jobFrontKey).([]*archive.CompressedModelState)
+	randomSeed, _ := completedJob.HiddenAttribute(jobRandomSeedKey).(int64)
+
+	decompressionModel := s.model.DeepClone()
+	decompressionModel.Initialise(model.AsIs)
+
+	summary := make(set.Summary, 0)
+
+	asIsSolution := buildJobSolution(decompressionModel, s.model.Id()+" As-Is", randomSeed)
+	summary[asIsSolution.Id] = *asIsSolution.Summarise().
+		WithId(string(AsIs)).
+		Noting("As-is state; zero active management actions").
+		WithSortOrder(0)
+
+	numberOfSolutions := len(front)
+	for solutionIndex, compressedModel := range front {
+		solutionNumber := solutionIndex + 1
+		new(archive.ModelCompressor).Decompress(compressedModel, decompressionModel)
+
+		solutionId := fmt.Sprintf("%s Solution (%d/%d)", s.model.Id(), solutionNumber, numberOfSolutions)
+		frontSolution := buildJobSolution(decompressionModel, solutionId, randomSeed)
+		summary[frontSolution.Id] = *frontSolution.Summarise().
+			WithId(fmt.Sprintf("%d-of-%d", solutionNumber, numberOfSolutions)).
+			Noting(fmt.Sprintf("Pareto front member %d of %d", solutionNumber, numberOfSolutions)).
+			WithSortOrder(uint64(solutionNumber))
+	}
+
+	return summary
+}
+
+func buildJobSolution(solutionModel model.Model, solutionId string, randomSeed int64) *solution.Solution {
+	return new(solution.SolutionBuilder).
+		WithId(solutionId).
+		WithRandomSeed(randomSeed).
+		ForModel(solutionModel).
+		Build()
+}
+
+func deriveJobIdFrom(r *http.Request) job.Id {
+	return job.Id(pathElementFollowing(r, jobsPath))
+}
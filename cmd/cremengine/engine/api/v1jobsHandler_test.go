@@ -0,0 +1,155 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/job"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+func TestGetUnknownJob_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "GET /jobs/{id} request for unknown job returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/jobs/0123-abcd",
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestGetJobs_NotAllowedResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "GET /jobs request returns 405 (method not allowed) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/jobs",
+		},
+		ExpectedResponseStatus: http.StatusMethodNotAllowed,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestPostJobWithInvalidModel_BadRequestResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "POST /jobs request with invalid model returns 400 (bad request) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/jobs",
+			RequestBody: invalidModelTomlText,
+			ContentType: rest.TomlMimeType,
+		},
+		ExpectedResponseStatus: http.StatusBadRequest,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestPostValidJob_SolutionsLoadedOnCompletion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	postContext := TestContext{
+		Name: "POST /jobs request returns 202 (accepted) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/jobs",
+			RequestBody: validScenarioTomlText,
+			ContentType: rest.TomlMimeType,
+		},
+		ExpectedResponseStatus: http.StatusAccepted,
+	}
+
+	// then
+	postResponse := verifyResponseStatusCode(muxUnderTest, postContext)
+	jobId := postResponse.JsonMap["Id"].(string)
+	jobUrl := baseUrl + "api/v1/jobs/" + jobId
+
+	// when
+	getJobContext := httptest.HttpTestRequestContext{Method: "GET", TargetUrl: jobUrl}
+
+	// then
+	g.Eventually(func() interface{} {
+		jobResponse := sendRequest(muxUnderTest, getJobContext)
+		return jobResponse.JsonMap["Attributes"].(map[string]interface{})["Status"]
+	}, 10*time.Second, 10*time.Millisecond).Should(Equal(string(job.Completed)))
+
+	// when
+	solutionsContext := httptest.HttpTestRequestContext{Method: "GET", TargetUrl: jobUrl + "/solutions"}
+	solutionsResponse := sendRequest(muxUnderTest, solutionsContext)
+
+	// then
+	g.Expect(solutionsResponse.StatusCode).To(BeNumerically("==", http.StatusOK))
+	g.Expect(solutionsResponse.RawResponse).To(ContainSubstring("As-Is"))
+	g.Expect(solutionsResponse.RawResponse).To(ContainSubstring("1-of-1"))
+	g.Expect(muxUnderTest.defaultSession.solutionPool.HasSolution("1-of-1")).To(BeTrue())
+
+	muxUnderTest.Shutdown()
+}
+
+func TestPruneJobsFinishedBefore_FinishedJobsPruned(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	finishedJob := new(job.Job).Initialise()
+	finishAnnealingJob(finishedJob, job.Completed)
+	cutoff := time.Now().Add(time.Millisecond)
+
+	unfinishedJob := new(job.Job).Initialise()
+
+	muxUnderTest.jobs[finishedJob.Id] = finishedJob
+	muxUnderTest.jobs[unfinishedJob.Id] = unfinishedJob
+
+	// when
+	prunedJobs := muxUnderTest.pruneJobsFinishedBefore(cutoff)
+
+	// then
+	g.Expect(prunedJobs).To(BeNumerically("==", 1))
+
+	_, finishedJobFound := muxUnderTest.job(finishedJob.Id)
+	g.Expect(finishedJobFound).To(BeFalse())
+
+	_, unfinishedJobFound := muxUnderTest.job(unfinishedJob.Id)
+	g.Expect(unfinishedJobFound).To(BeTrue())
+
+	muxUnderTest.Shutdown()
+}
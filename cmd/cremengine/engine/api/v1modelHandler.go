@@ -10,21 +10,21 @@ import (
 
 const v1modelHandler = "v1 model handler"
 
-func (m *Mux) v1modelHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1modelHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		m.v1GetModelHandler(w, r)
+		s.v1GetModelHandler(w, r)
 	case http.MethodPatch:
-		m.v1PatchModelHandler(w, r)
+		s.v1PatchModelHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
-func (m *Mux) v1GetModelHandler(w http.ResponseWriter, r *http.Request) {
-	if m.modelSolution == nil {
-		m.Logger().Warn("Attempted to get model resource with no scenario loaded")
-		m.NotFoundError(w, r)
+func (s *Session) v1GetModelHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.Logger().Warn("Attempted to get model resource with no scenario loaded")
+		s.NotFoundError(w, r)
 		return
 	}
 
@@ -32,27 +32,27 @@ func (m *Mux) v1GetModelHandler(w http.ResponseWriter, r *http.Request) {
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
-		WithJsonContent(m.modelSolution)
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithJsonContent(s.modelSolution)
 
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Responding with model [" + scenarioName + "] state")
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with model [" + scenarioName + "] state")
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1modelHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) v1PatchModelHandler(w http.ResponseWriter, r *http.Request) {
-	if m.modelSolution == nil {
-		m.Logger().Warn("Attempted to patch model resource attributes with no scenario loaded.")
-		m.NotFoundError(w, r)
+func (s *Session) v1PatchModelHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.Logger().Warn("Attempted to patch model resource attributes with no scenario loaded.")
+		s.NotFoundError(w, r)
 		return
 	}
 
-	if m.requestContentTypeWasNotJson(r, w) {
+	if s.requestContentTypeWasNotJson(r, w) {
 		return
 	}
 
@@ -62,56 +62,56 @@ func (m *Mux) v1PatchModelHandler(w http.ResponseWriter, r *http.Request) {
 
 	if parseError != nil {
 		wrappingError := errors.Wrap(parseError, v1modelHandler)
-		m.Logger().Error(wrappingError)
-		m.Logger().Error("Parsing PATCH message content for model failed")
+		s.Logger().Error(wrappingError)
+		s.Logger().Error("Parsing PATCH message content for model failed")
 
-		m.RespondWithError(http.StatusBadRequest, parseError.Error(), w, r)
+		s.RespondWithError(http.StatusBadRequest, parseError.Error(), w, r)
 		return
 	}
 
-	m.Logger().Info("Joining newly supplied attributes to current model")
-	m.model.JoiningAttributes(*requestAttributes)
+	s.Logger().Info("Joining newly supplied attributes to current model")
+	s.model.JoiningAttributes(*requestAttributes)
 
 	for _, entry := range *requestAttributes {
 		if entry.Name == "Encoding" {
 			encoding := entry.Value.(string)
-			m.Logger().Info("Re-initialising model with attribute-supp[ied alternate encoding [" + encoding + "]")
-			m.updateModelWithEncoding(encoding)
+			s.Logger().Info("Re-initialising model with attribute-supp[ied alternate encoding [" + encoding + "]")
+			s.updateModelWithEncoding(encoding)
 		}
 	}
 
-	restResponse := m.buildModelPatchResponse(w)
+	restResponse := s.buildModelPatchResponse(w)
 
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1modelHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) updateModelWithEncoding(encoding string) {
-	m.reInitialiseModelWithEncoding(encoding)
-	m.updateModelSolution()
+func (s *Session) updateModelWithEncoding(encoding string) {
+	s.reInitialiseModelWithEncoding(encoding)
+	s.updateModelSolution()
 }
 
-func (m *Mux) reInitialiseModelWithEncoding(encoding string) {
-	newModel := m.model.DeepClone()
+func (s *Session) reInitialiseModelWithEncoding(encoding string) {
+	newModel := s.model.DeepClone()
 
 	compressedModel := modelCompressor.Compress(newModel)
 	compressedModel.Decode(encoding)
 	modelCompressor.Decompress(compressedModel, newModel)
 
-	m.model = toCatchmentModel(newModel)
-	m.deriveExtraModelAttributes()
+	s.model = toCatchmentModel(newModel)
+	s.deriveExtraModelAttributes()
 }
 
-func (m *Mux) buildModelPatchResponse(w http.ResponseWriter) *rest.Response {
+func (s *Session) buildModelPatchResponse(w http.ResponseWriter) *rest.Response {
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(
 			rest.MessageResponse{
 				Type:    "SUCCESS",
@@ -120,7 +120,7 @@ func (m *Mux) buildModelPatchResponse(w http.ResponseWriter) *rest.Response {
 			},
 		)
 
-	m.Logger().Info("Responding with acknowledgement of model patch")
+	s.Logger().Info("Responding with acknowledgement of model patch")
 
 	return restResponse
 }
@@ -276,7 +276,7 @@ func TestModePatchRequest_ValidJsonBody_OkResponse(t *testing.T) {
 	// then
 	response := verifyResponseStatusCode(muxUnderTest, getContext)
 
-	modelCopy := muxUnderTest.model.DeepClone()
+	modelCopy := muxUnderTest.defaultSession.model.DeepClone()
 	referenceModel := toCatchmentModel(modelCopy)
 	referenceModel.Initialise(model.AsIs)
 	referenceModel.SetManagementAction(0, true)
@@ -12,142 +12,142 @@ import (
 
 const v1scenarioHandler = "v1 scenario handler"
 
-func (m *Mux) v1scenarioHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1scenarioHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		m.v1PostScenarioHandler(w, r)
+		s.v1PostScenarioHandler(w, r)
 	case http.MethodGet:
-		m.v1GetScenarioHandler(w, r)
+		s.v1GetScenarioHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
-func (m *Mux) v1GetScenarioHandler(w http.ResponseWriter, r *http.Request) {
-	if !m.HasAttribute(scenarioTextKey) {
-		m.NotFoundError(w, r)
+func (s *Session) v1GetScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.HasAttribute(scenarioTextKey) {
+		s.NotFoundError(w, r)
 		return
 	}
 
-	restResponse := m.buildScenarioGetResponse(w)
-	m.logScenarioGetResponse()
+	restResponse := s.buildScenarioGetResponse(w)
+	s.logScenarioGetResponse()
 	writeError := restResponse.Write()
 
-	m.handleScenarioGetWriteError(writeError)
+	s.handleScenarioGetWriteError(writeError)
 }
 
-func (m *Mux) buildScenarioGetResponse(w http.ResponseWriter) *rest.Response {
-	responseText := m.Attribute(scenarioTextKey).(string)
+func (s *Session) buildScenarioGetResponse(w http.ResponseWriter) *rest.Response {
+	responseText := s.Attribute(scenarioTextKey).(string)
 
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithTomlContent(responseText)
 	return restResponse
 }
 
-func (m *Mux) logScenarioGetResponse() {
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Responding with scenario [" + scenarioName + "] configuration")
+func (s *Session) logScenarioGetResponse() {
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with scenario [" + scenarioName + "] configuration")
 }
 
-func (m *Mux) handleScenarioGetWriteError(writeError error) {
+func (s *Session) handleScenarioGetWriteError(writeError error) {
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1scenarioHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) v1PostScenarioHandler(w http.ResponseWriter, r *http.Request) {
-	if m.requestContentTypeWasNotToml(r, w) {
+func (s *Session) v1PostScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	if s.requestContentTypeWasNotToml(r, w) {
 		return
 	}
 
-	scenarioConfig, retrievalError := m.processScenarioPostText(w, r)
+	scenarioConfig, retrievalError := s.processScenarioPostText(w, r)
 	if retrievalError != nil {
 		return
 	}
 
-	modelErrors := m.deriveDefaultModelForScenario(w, r, scenarioConfig)
+	modelErrors := s.deriveDefaultModelForScenario(w, r, scenarioConfig)
 	if modelErrors != nil {
 		return
 	}
 
-	restResponse := m.buildScenarioPostResponse(w)
+	restResponse := s.buildScenarioPostResponse(w)
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1scenarioHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) deriveDefaultModelForScenario(w http.ResponseWriter, r *http.Request, scenarioConfig *data.ScenarioConfig) error {
-	interpretedModel := m.modelConfigInterpreter.Interpret(&scenarioConfig.Model).Model()
+func (s *Session) deriveDefaultModelForScenario(w http.ResponseWriter, r *http.Request, scenarioConfig *data.ScenarioConfig) error {
+	interpretedModel := s.modelConfigInterpreter.Interpret(&scenarioConfig.Model).Model()
 	if modelAsCatchmentModel, isCatchmentModel := interpretedModel.(*catchment.Model); isCatchmentModel {
-		m.rememberModelState(modelAsCatchmentModel, scenarioConfig)
+		s.rememberModelState(modelAsCatchmentModel, scenarioConfig)
 	}
-	if m.modelConfigInterpreter.Errors() != nil {
-		m.handleModelInterpreterErrors(w, r, m.modelConfigInterpreter.Errors())
-		return m.modelConfigInterpreter.Errors()
+	if s.modelConfigInterpreter.Errors() != nil {
+		s.handleModelInterpreterErrors(w, r, s.modelConfigInterpreter.Errors())
+		return s.modelConfigInterpreter.Errors()
 	}
 	return nil
 }
 
-func (m *Mux) processScenarioPostText(w http.ResponseWriter, r *http.Request) (*data.ScenarioConfig, error) {
+func (s *Session) processScenarioPostText(w http.ResponseWriter, r *http.Request) (*data.ScenarioConfig, error) {
 	requestContent := requestBodyToString(r)
 	config, retrievalError := data.RetrieveScenarioConfigFromString(requestContent)
 
 	if retrievalError != nil {
-		m.handleScenarioRetrievalErrors(w, r, retrievalError)
+		s.handleScenarioRetrievalErrors(w, r, retrievalError)
 		return config, retrievalError
 	}
 
-	m.rememberScenarioAttributeState(config, requestContent)
+	s.rememberScenarioAttributeState(config, requestContent)
 	return config, nil
 }
 
-func (m *Mux) handleScenarioRetrievalErrors(w http.ResponseWriter, r *http.Request, retrieveError error) {
+func (s *Session) handleScenarioRetrievalErrors(w http.ResponseWriter, r *http.Request, retrieveError error) {
 	wrappingError := errors.Wrap(retrieveError, v1scenarioHandler)
-	m.Logger().Error(wrappingError)
-	m.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
+	s.Logger().Error(wrappingError)
+	s.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
 }
 
-func (m *Mux) rememberScenarioAttributeState(config *data.ScenarioConfig, requestContent string) {
-	m.ReplaceAttribute(scenarioNameKey, config.Scenario.Name)
-	m.Logger().Info("Scenario configuration [" + config.Scenario.Name + "] successfully retrieved")
+func (s *Session) rememberScenarioAttributeState(config *data.ScenarioConfig, requestContent string) {
+	s.ReplaceAttribute(scenarioNameKey, config.Scenario.Name)
+	s.Logger().Info("Scenario configuration [" + config.Scenario.Name + "] successfully retrieved")
 
-	m.ReplaceAttribute(scenarioTextKey, requestContent)
+	s.ReplaceAttribute(scenarioTextKey, requestContent)
 }
 
-func (m *Mux) rememberModelState(modelAsCatchmentModel *catchment.Model, config *data.ScenarioConfig) {
-	m.model = modelAsCatchmentModel
-	m.model.Initialise(model.AsIs)
-	m.model.SetId(config.Scenario.Name)
-	m.deriveExtraModelAttributes()
+func (s *Session) rememberModelState(modelAsCatchmentModel *catchment.Model, config *data.ScenarioConfig) {
+	s.model = modelAsCatchmentModel
+	s.model.Initialise(model.AsIs)
+	s.model.SetId(config.Scenario.Name)
+	s.deriveExtraModelAttributes()
 
-	m.solutionPool = NewSolutionPool(modelAsCatchmentModel)
+	s.solutionPool = NewSolutionPool(modelAsCatchmentModel)
 }
 
-func (m *Mux) handleModelInterpreterErrors(w http.ResponseWriter, r *http.Request, interpreterError error) {
+func (s *Session) handleModelInterpreterErrors(w http.ResponseWriter, r *http.Request, interpreterError error) {
 	wrappingError := errors.Wrap(interpreterError, v1scenarioHandler)
-	m.Logger().Error(wrappingError)
-	m.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
+	s.Logger().Error(wrappingError)
+	s.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
 }
 
-func (m *Mux) buildScenarioPostResponse(w http.ResponseWriter) *rest.Response {
-	m.modelSolution = new(solution.SolutionBuilder).
-		WithId(m.model.Id()).
-		ForModel(m.model).
+func (s *Session) buildScenarioPostResponse(w http.ResponseWriter) *rest.Response {
+	s.modelSolution = new(solution.SolutionBuilder).
+		WithId(s.model.Id()).
+		ForModel(s.model).
 		Build()
 
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(
 			rest.MessageResponse{
 				Type:    "SUCCESS",
@@ -156,34 +156,34 @@ func (m *Mux) buildScenarioPostResponse(w http.ResponseWriter) *rest.Response {
 			},
 		)
 
-	m.Logger().Info("Responding with acknowledgement of scenario configuration receipt")
+	s.Logger().Info("Responding with acknowledgement of scenario configuration receipt")
 	return restResponse
 }
 
-func (m *Mux) SetScenario(scenarioFilePath string) {
+func (s *Session) SetScenario(scenarioFilePath string) {
 	config, retrievalError := data.RetrieveScenarioConfigFromFile(scenarioFilePath)
 
 	if retrievalError != nil {
-		m.Logger().Warn(retrievalError)
+		s.Logger().Warn(retrievalError)
 		return
 	}
 
-	m.ReplaceAttribute(scenarioNameKey, config.Scenario.Name)
-	m.Logger().Info("Scenario configuration [" + config.Scenario.Name + "] successfully retrieved")
+	s.ReplaceAttribute(scenarioNameKey, config.Scenario.Name)
+	s.Logger().Info("Scenario configuration [" + config.Scenario.Name + "] successfully retrieved")
 
 	configFileContent := readFileAsText(scenarioFilePath)
-	m.ReplaceAttribute(scenarioTextKey, configFileContent)
+	s.ReplaceAttribute(scenarioTextKey, configFileContent)
 
-	interpretedModel := m.modelConfigInterpreter.Interpret(&config.Model).Model()
+	interpretedModel := s.modelConfigInterpreter.Interpret(&config.Model).Model()
 	if modelAsCatchmentModel, isCatchmentModel := interpretedModel.(*catchment.Model); isCatchmentModel {
-		m.rememberModelState(modelAsCatchmentModel, config)
+		s.rememberModelState(modelAsCatchmentModel, config)
 	}
-	if m.modelConfigInterpreter.Errors() != nil {
-		m.Logger().Warn(m.modelConfigInterpreter.Errors())
+	if s.modelConfigInterpreter.Errors() != nil {
+		s.Logger().Warn(s.modelConfigInterpreter.Errors())
 	}
 
-	m.modelSolution = new(solution.SolutionBuilder).
-		WithId(m.model.Id()).
-		ForModel(m.model).
+	s.modelSolution = new(solution.SolutionBuilder).
+		WithId(s.model.Id()).
+		ForModel(s.model).
 		Build()
 }
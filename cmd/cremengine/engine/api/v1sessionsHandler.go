@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const v1sessionsHandler = "v1 sessions handler"
+
+func (m *Mux) v1sessionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		m.v1PostSessionHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1PostSessionHandler(w http.ResponseWriter, r *http.Request) {
+	createdSession := m.createSession()
+	m.Logger().Info("Session [" + string(createdSession.Id()) + "] created")
+	createdSummary, _ := m.sessionSummary(createdSession.Id())
+	m.writeSessionResponse(w, http.StatusCreated, createdSummary)
+}
+
+func (m *Mux) v1sessionHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		m.v1GetSessionHandler(w, r)
+	case http.MethodDelete:
+		m.v1DeleteSessionHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1GetSessionHandler(w http.ResponseWriter, r *http.Request) {
+	sessionId := deriveSessionIdFrom(r)
+	if _, sessionFound := m.session(sessionId); !sessionFound {
+		m.NotFoundError(w, r)
+		return
+	}
+
+	requestedSummary, _ := m.sessionSummary(sessionId)
+	m.writeSessionResponse(w, http.StatusOK, requestedSummary)
+}
+
+func (m *Mux) v1DeleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	sessionId := deriveSessionIdFrom(r)
+	if !m.endSession(sessionId) {
+		m.NotFoundError(w, r)
+		return
+	}
+
+	m.Logger().Info("Session [" + string(sessionId) + "] ended")
+	m.writeSessionResponse(w, http.StatusOK,
+		rest.MessageResponse{
+			Type:    "SUCCESS",
+			Message: "Session [" + string(sessionId) + "] successfully ended",
+			Time:    rest.FormattedTimestamp(),
+		},
+	)
+}
+
+// SessionsHandler responds with a summary of every live session, for the admin multiplexer.
+func (m *Mux) SessionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		m.MethodNotAllowedError(w, r)
+		return
+	}
+
+	m.Logger().Info("Responding with live session summaries")
+	m.writeSessionResponse(w, http.StatusOK, m.SessionSummaries())
+}
+
+func (m *Mux) writeSessionResponse(w http.ResponseWriter, responseCode int, content interface{}) {
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(responseCode).
+		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithJsonContent(content)
+
+	writeError := restResponse.Write()
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1sessionsHandler)
+		m.Logger().Error(wrappingError)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+func TestPostSession_CreatedResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	sessionId := createTestSession(t, muxUnderTest)
+
+	// then
+	g.Expect(sessionId).To(Not(BeEmpty()))
+	g.Expect(sessionId).To(Not(Equal(DefaultSessionId)))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestGetSessions_NotAllowedResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "GET /sessions request returns 405 (method not allowed) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/sessions",
+		},
+		ExpectedResponseStatus: http.StatusMethodNotAllowed,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestGetModelOfUnknownSession_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "GET /sessions/{id}/model request for unknown session returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/sessions/0123-abcd/model",
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestPostScenarioToSession_OtherSessionsUnaffected(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	sessionId := createTestSession(t, muxUnderTest)
+	otherSessionId := createTestSession(t, muxUnderTest)
+
+	// when
+	postContext := TestContext{
+		Name: "POST /sessions/{id}/scenario request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   sessionUrl(sessionId, "scenario"),
+			RequestBody: validScenarioTomlConfig,
+			ContentType: rest.TomlMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, postContext)
+
+	verifyResponseStatusCode(muxUnderTest,
+		TestContext{
+			Name: "GET /sessions/{id}/model request of session with scenario returns 200 (ok) response",
+			T:    t,
+			Request: httptest.HttpTestRequestContext{
+				Method:    "GET",
+				TargetUrl: sessionUrl(sessionId, "model"),
+			},
+			ExpectedResponseStatus: http.StatusOK,
+		},
+	)
+
+	verifyResponseStatusCode(muxUnderTest,
+		TestContext{
+			Name: "GET /sessions/{id}/model request of other session returns 404 (not found) response",
+			T:    t,
+			Request: httptest.HttpTestRequestContext{
+				Method:    "GET",
+				TargetUrl: sessionUrl(otherSessionId, "model"),
+			},
+			ExpectedResponseStatus: http.StatusNotFound,
+		},
+	)
+
+	verifyResponseStatusCode(muxUnderTest,
+		TestContext{
+			Name: "GET /model request of default session returns 404 (not found) response",
+			T:    t,
+			Request: httptest.HttpTestRequestContext{
+				Method:    "GET",
+				TargetUrl: baseUrl + "api/v1/model",
+			},
+			ExpectedResponseStatus: http.StatusNotFound,
+		},
+	)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestDeleteSession_SessionEnded(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	sessionId := createTestSession(t, muxUnderTest)
+
+	// when
+	deleteContext := TestContext{
+		Name: "DELETE /sessions/{id} request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "DELETE",
+			TargetUrl: baseUrl + "api/v1/sessions/" + string(sessionId),
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, deleteContext)
+
+	verifyResponseStatusCode(muxUnderTest,
+		TestContext{
+			Name: "GET /sessions/{id} request of ended session returns 404 (not found) response",
+			T:    t,
+			Request: httptest.HttpTestRequestContext{
+				Method:    "GET",
+				TargetUrl: baseUrl + "api/v1/sessions/" + string(sessionId),
+			},
+			ExpectedResponseStatus: http.StatusNotFound,
+		},
+	)
+
+	muxUnderTest.Shutdown()
+}
+
+func TestEvictSessionsIdleSince_IdleSessionsEvicted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	idleSessionId := createTestSession(t, muxUnderTest)
+	cutoff := time.Now()
+	activeSessionId := createTestSession(t, muxUnderTest)
+
+	// when
+	evictedSessions := muxUnderTest.evictSessionsIdleSince(cutoff)
+
+	// then
+	g.Expect(evictedSessions).To(BeNumerically("==", 1))
+
+	_, idleSessionFound := muxUnderTest.session(idleSessionId)
+	g.Expect(idleSessionFound).To(BeFalse())
+
+	_, activeSessionFound := muxUnderTest.session(activeSessionId)
+	g.Expect(activeSessionFound).To(BeTrue())
+
+	_, defaultSessionFound := muxUnderTest.session(DefaultSessionId)
+	g.Expect(defaultSessionFound).To(BeTrue())
+
+	muxUnderTest.Shutdown()
+}
+
+func TestSessionsHandler_LiveSessionsListed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	sessionId := createTestSession(t, muxUnderTest)
+
+	// when
+	requestContext := httptest.HttpTestRequestContext{
+		Method:    "GET",
+		TargetUrl: baseUrl + "sessions",
+		Handler:   muxUnderTest.SessionsHandler,
+	}
+	responseContainer := requestContext.BuildJsonResponse()
+
+	// then
+	g.Expect(responseContainer.StatusCode).To(BeNumerically("==", http.StatusOK))
+	g.Expect(responseContainer.RawResponse).To(ContainSubstring(string(DefaultSessionId)))
+	g.Expect(responseContainer.RawResponse).To(ContainSubstring(string(sessionId)))
+
+	muxUnderTest.Shutdown()
+}
+
+func createTestSession(t *testing.T, muxUnderTest *Mux) SessionId {
+	context := TestContext{
+		Name: "POST /sessions request returns 201 (created) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "POST",
+			TargetUrl: baseUrl + "api/v1/sessions",
+		},
+		ExpectedResponseStatus: http.StatusCreated,
+	}
+
+	responseContainer := verifyResponseStatusCode(muxUnderTest, context)
+	sessionId, _ := responseContainer.JsonMap["Id"].(string)
+	return SessionId(sessionId)
+}
+
+func sessionUrl(sessionId SessionId, resource string) string {
+	return baseUrl + "api/v1/sessions/" + string(sessionId) + "/" + resource
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/csv"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const v1solutionComparisonHandler = "v1 solution comparison handler"
+
+func (s *Session) v1solutionComparisonHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.v1GetSolutionComparisonHandler(w, r)
+	default:
+		s.MethodNotAllowedError(w, r)
+	}
+}
+
+func (s *Session) v1GetSolutionComparisonHandler(w http.ResponseWriter, r *http.Request) {
+	fromLabel, fromLoaded := s.loadSolution(w, r, pathElementFollowing(r, solutionsPath))
+	if !fromLoaded {
+		return
+	}
+
+	toLabel, toLoaded := s.loadSolution(w, r, pathElementFollowing(r, comparePath))
+	if !toLoaded {
+		return
+	}
+
+	comparison := solution.Compare(s.solutionPool.Solution(fromLabel), s.solutionPool.Solution(toLabel))
+	comparison.From = string(fromLabel)
+	comparison.To = string(toLabel)
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(s.CacheMaxAge())
+
+	if rest.RequestAccepts(r, rest.CsvMimeType) {
+		comparisonAsCsv, marshalError := new(csv.ComparisonMarshaler).Marshal(comparison)
+		if marshalError != nil {
+			wrappingError := errors.Wrap(marshalError, v1solutionComparisonHandler)
+			s.Logger().Error(wrappingError)
+			s.InternalServerError(w, r, wrappingError)
+			return
+		}
+		restResponse.WithCsvContent(string(comparisonAsCsv))
+	} else {
+		restResponse.WithJsonContent(comparison)
+	}
+
+	s.Logger().Info("Responding with comparison of solution [" + comparison.From + "] to solution [" + comparison.To + "]")
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1solutionComparisonHandler)
+		s.Logger().Error(wrappingError)
+	}
+}
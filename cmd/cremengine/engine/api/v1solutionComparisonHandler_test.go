@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+func TestGetSolutionComparison_NoScenario_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "GET /api/v1/solutions/As-Is/compare/3-of-8 request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/solutions/As-Is/compare/3-of-8",
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestGetSolutionComparison_UnknownSolution_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "GET /api/v1/solutions/As-Is/compare/99-of-99 request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/solutions/As-Is/compare/99-of-99",
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestGetSolutionComparison_Json_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "GET /api/v1/solutions/As-Is/compare/3-of-8 request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/solutions/As-Is/compare/3-of-8",
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	response := verifyResponseStatusCode(muxUnderTest, context)
+
+	// then
+	g.Expect(response.JsonMap["From"]).To(Equal("As-Is"))
+	g.Expect(response.JsonMap["To"]).To(Equal("3-of-8"))
+	g.Expect(response.JsonMap["DecisionVariables"]).To(Not(BeEmpty()))
+	g.Expect(response.JsonMap["ManagementActions"]).To(Not(BeEmpty()))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestGetSolutionComparison_Csv_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "GET /api/v1/solutions/As-Is/compare/3-of-8 csv request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/solutions/As-Is/compare/3-of-8",
+			Accept:    rest.CsvMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	response := verifyResponseStatusCode(muxUnderTest, context)
+
+	// then
+	g.Expect(strings.HasPrefix(response.RawResponse, "Category, ")).To(BeTrue())
+	g.Expect(response.RawResponse).To(ContainSubstring("DecisionVariable, , "))
+	g.Expect(response.RawResponse).To(ContainSubstring(", Added\n"))
+
+	muxUnderTest.Shutdown()
+}
+
+func loadScenarioAndSolutions(t *testing.T, muxUnderTest *Mux) {
+	scenarioContext := TestContext{
+		Name: "POST /api/v1/scenario request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/scenario",
+			RequestBody: validScenarioTomlConfig,
+			ContentType: rest.TomlMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	verifyResponseStatusCode(muxUnderTest, scenarioContext)
+
+	solutionsContext := TestContext{
+		Name: "POST /api/v1/solutions request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions",
+			RequestBody: validSolutions,
+			ContentType: rest.CsvMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	verifyResponseStatusCode(muxUnderTest, solutionsContext)
+}
@@ -9,59 +9,70 @@ import (
 
 const v1solutionHandler = "v1 solution handler"
 
-func (m *Mux) v1solutionHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1solutionHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		m.v1GetSolutionHandler(w, r)
+		s.v1GetSolutionHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
-func (m *Mux) v1GetSolutionHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1GetSolutionHandler(w http.ResponseWriter, r *http.Request) {
 	requestSuppliedModelLabel := deriveModelLabelFrom(r)
 
-	if !m.HasAttribute(scenarioNameKey) {
-		m.Logger().Warn("Attempted to request model [" + requestSuppliedModelLabel + "] with no scenario loaded")
-		m.NotFoundError(w, r)
+	modelLabel, solutionLoaded := s.loadSolution(w, r, requestSuppliedModelLabel)
+	if !solutionLoaded {
 		return
 	}
 
-	if m.solutionSetTable == nil {
-		m.Logger().Warn("Attempted to request solution [" + requestSuppliedModelLabel + "] with no solution set loaded")
-		m.NotFoundError(w, r)
-		return
-	}
-
-	if !m.solutionSetTableContainsEntry(requestSuppliedModelLabel) {
-		m.Logger().Warn("Attempted to request solution [" + requestSuppliedModelLabel + "] which is not in supplied solution set")
-		m.NotFoundError(w, r)
-		return
-	}
-
-	modelLabel := SolutionPoolLabel(requestSuppliedModelLabel)
-
-	if !m.solutionPool.HasSolution(modelLabel) {
-		m.Logger().Info("Loading solution [" + requestSuppliedModelLabel + "] into solution pool")
-		detail := m.getSolutionDetail(requestSuppliedModelLabel)
-		m.solutionPool.AddSolution(modelLabel, detail.encoding, detail.summary)
-	}
-
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
-		WithJsonContent(m.solutionPool.Solution(modelLabel))
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithJsonContent(s.solutionPool.Solution(modelLabel))
 
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Responding with scenario [" + scenarioName + "] model [" + requestSuppliedModelLabel + "] state")
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with scenario [" + scenarioName + "] model [" + requestSuppliedModelLabel + "] state")
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1solutionHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
+	}
+}
+
+// loadSolution ensures the solution with the label supplied is in the session's solution pool, responding with a
+// not-found error (and returning false) if there is no such solution to load.
+func (s *Session) loadSolution(w http.ResponseWriter, r *http.Request, requestSuppliedModelLabel string) (SolutionPoolLabel, bool) {
+	if !s.HasAttribute(scenarioNameKey) {
+		s.Logger().Warn("Attempted to request model [" + requestSuppliedModelLabel + "] with no scenario loaded")
+		s.NotFoundError(w, r)
+		return "", false
+	}
+
+	if s.solutionSetTable == nil {
+		s.Logger().Warn("Attempted to request solution [" + requestSuppliedModelLabel + "] with no solution set loaded")
+		s.NotFoundError(w, r)
+		return "", false
+	}
+
+	if !s.solutionSetTableContainsEntry(requestSuppliedModelLabel) {
+		s.Logger().Warn("Attempted to request solution [" + requestSuppliedModelLabel + "] which is not in supplied solution set")
+		s.NotFoundError(w, r)
+		return "", false
+	}
+
+	modelLabel := SolutionPoolLabel(requestSuppliedModelLabel)
+
+	if !s.solutionPool.HasSolution(modelLabel) {
+		s.Logger().Info("Loading solution [" + requestSuppliedModelLabel + "] into solution pool")
+		detail := s.getSolutionDetail(requestSuppliedModelLabel)
+		s.solutionPool.AddSolution(modelLabel, detail.encoding, detail.summary)
 	}
+
+	return modelLabel, true
 }
 
 type solutionDetail struct {
@@ -70,30 +81,29 @@ type solutionDetail struct {
 	summary  string
 }
 
-func (m *Mux) solutionSetTableContainsEntry(solutionLabel string) bool {
+func (s *Session) solutionSetTableContainsEntry(solutionLabel string) bool {
 	const labelIndex = 0
-	_, rowSize := m.solutionSetTable.ColumnAndRowSize()
+	_, rowSize := s.solutionSetTable.ColumnAndRowSize()
 	for rowIndex := uint(0); rowIndex < rowSize; rowIndex++ {
-		if m.solutionSetTable.CellString(labelIndex, rowIndex) == solutionLabel {
+		if s.solutionSetTable.CellString(labelIndex, rowIndex) == solutionLabel {
 			return true
 		}
 	}
 	return false
 }
 
-func (m *Mux) getSolutionDetail(solutionLabel string) *solutionDetail {
-	const (
-		labelIndex    = 0
-		encodingIndex = 6
-		summaryIndex  = 7
-	)
-	_, rowSize := m.solutionSetTable.ColumnAndRowSize()
+func (s *Session) getSolutionDetail(solutionLabel string) *solutionDetail {
+	const labelIndex = 0
+	encodingIndex, _ := columnIndexOf(s.solutionSetTable, actionsHeading)
+	summaryIndex, _ := columnIndexOf(s.solutionSetTable, summaryHeading)
+
+	_, rowSize := s.solutionSetTable.ColumnAndRowSize()
 	for rowIndex := uint(1); rowIndex < rowSize; rowIndex++ {
-		if m.solutionSetTable.CellString(labelIndex, rowIndex) == solutionLabel {
+		if s.solutionSetTable.CellString(labelIndex, rowIndex) == solutionLabel {
 			return &solutionDetail{
 				label:    solutionLabel,
-				encoding: m.solutionSetTable.CellString(encodingIndex, rowIndex),
-				summary:  m.solutionSetTable.CellString(summaryIndex, rowIndex),
+				encoding: s.solutionSetTable.CellString(encodingIndex, rowIndex),
+				summary:  s.solutionSetTable.CellString(summaryIndex, rowIndex),
 			}
 		}
 	}
@@ -13,110 +13,116 @@ import (
 )
 
 const v1solutionSetHandler = "v1 solution set handler"
-const actionsEncodingPattern = "^[0-9A-Fa-f:]*$"
+const actionsEncodingPattern = `^[0-9A-Fa-f:]*(\|[0-9.eE+\-=,]*){0,2}$`
 
-func (m *Mux) v1solutionSetHandler(w http.ResponseWriter, r *http.Request) {
+const (
+	solutionHeading = "Solution"
+	actionsHeading  = "Actions"
+	summaryHeading  = "Summary"
+)
+
+func (s *Session) v1solutionSetHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		m.v1PostSolutionsHandler(w, r)
+		s.v1PostSolutionsHandler(w, r)
 	case http.MethodGet:
-		m.v1GetSolutionsHandler(w, r)
+		s.v1GetSolutionsHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
-func (m *Mux) v1GetSolutionsHandler(w http.ResponseWriter, r *http.Request) {
-	if !m.HasAttribute(scenarioTextKey) {
-		m.Logger().Warn("Request for solutions dataset received without pre-requisite scenario loaded.")
-		m.NotFoundError(w, r)
+func (s *Session) v1GetSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.HasAttribute(scenarioTextKey) {
+		s.Logger().Warn("Request for solutions dataset received without pre-requisite scenario loaded.")
+		s.NotFoundError(w, r)
 		return
 	}
 
-	if !m.HasAttribute(solutionsTextKey) {
-		m.Logger().Warn("Request for solutions dataset received before dataset had been posted.")
-		m.NotFoundError(w, r)
+	if !s.HasAttribute(solutionsTextKey) {
+		s.Logger().Warn("Request for solutions dataset received before dataset had been posted.")
+		s.NotFoundError(w, r)
 		return
 	}
 
-	restResponse := m.buildSolutionsGetResponse(w)
-	m.logSolutionsGetResponse()
+	restResponse := s.buildSolutionsGetResponse(w)
+	s.logSolutionsGetResponse()
 	writeError := restResponse.Write()
 
-	m.handleScenarioGetWriteError(writeError)
+	s.handleScenarioGetWriteError(writeError)
 }
 
-func (m *Mux) buildSolutionsGetResponse(w http.ResponseWriter) *rest.Response {
-	responseText := m.Attribute(solutionsTextKey).(string)
+func (s *Session) buildSolutionsGetResponse(w http.ResponseWriter) *rest.Response {
+	responseText := s.Attribute(solutionsTextKey).(string)
 
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithCsvContent(responseText)
 	return restResponse
 }
 
-func (m *Mux) logSolutionsGetResponse() {
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Responding with scenario [" + scenarioName + "] solutions table")
+func (s *Session) logSolutionsGetResponse() {
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with scenario [" + scenarioName + "] solutions table")
 }
 
-func (m *Mux) handleSolutionsGetWriteError(writeError error) {
+func (s *Session) handleSolutionsGetWriteError(writeError error) {
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) v1PostSolutionsHandler(w http.ResponseWriter, r *http.Request) {
-	if !m.HasAttribute(scenarioTextKey) {
-		m.Logger().Warn("Request to POST scenario solutions dataset without scenario loaded.")
-		m.MethodNotAllowedError(w, r)
+func (s *Session) v1PostSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.HasAttribute(scenarioTextKey) {
+		s.Logger().Warn("Request to POST scenario solutions dataset without scenario loaded.")
+		s.MethodNotAllowedError(w, r)
 		return
 	}
 
-	if m.requestContentTypeWasNotCsv(r, w) {
+	if s.requestContentTypeWasNotCsv(r, w) {
 		return
 	}
 
-	processError := m.processRequestContentForSolutions(r, w)
+	processError := s.processRequestContentForSolutions(r, w)
 	if processError != nil {
-		m.Logger().Warn("Request to POST scenario solutions dataset with invalid solution data detected.")
-		m.RespondWithError(http.StatusBadRequest, processError.Error(), w, r)
-		//m.BadRequestError(w, r)
+		s.Logger().Warn("Request to POST scenario solutions dataset with invalid solution data detected.")
+		s.RespondWithError(http.StatusBadRequest, processError.Error(), w, r)
+		//s.BadRequestError(w, r)
 		return
 	}
 
-	restResponse := m.buildSolutionsPostResponse(w)
+	restResponse := s.buildSolutionsPostResponse(w)
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) processRequestContentForSolutions(r *http.Request, w http.ResponseWriter) error {
+func (s *Session) processRequestContentForSolutions(r *http.Request, w http.ResponseWriter) error {
 	rawTableContent := requestBodyToString(r)
 
-	solutionsTable, requestError := m.deriveSolutionsRequestTable(rawTableContent)
+	solutionsTable, requestError := s.deriveSolutionsRequestTable(rawTableContent)
 	if requestError != nil {
 		return requestError
 	}
 
-	verificationError := m.verifySolutionSummaryMatchesScenario(solutionsTable)
+	verificationError := s.verifySolutionSummaryMatchesScenario(solutionsTable)
 	if verificationError != nil {
 		return verificationError
 	}
 
-	m.updateSolutionSummary(solutionsTable, rawTableContent)
+	s.updateSolutionSummary(solutionsTable, rawTableContent)
 	return nil
 }
 
-func (m *Mux) verifySolutionSummaryMatchesScenario(solutionSetTable dataset.HeadingsTable) error {
-	asIsModel := m.model.DeepClone()
+func (s *Session) verifySolutionSummaryMatchesScenario(solutionSetTable dataset.HeadingsTable) error {
+	asIsModel := s.model.DeepClone()
 	asIsModel.Initialise(model.AsIs)
 
 	numberOfDecisionVariables := len(*asIsModel.DecisionVariables())
@@ -140,57 +146,56 @@ func (m *Mux) verifySolutionSummaryMatchesScenario(solutionSetTable dataset.Head
 	return nil
 }
 
-func (m *Mux) deriveSolutionsRequestTable(rawTableContent string) (dataset.HeadingsTable, error) {
+func (s *Session) deriveSolutionsRequestTable(rawTableContent string) (dataset.HeadingsTable, error) {
 	tmpDataSet := csv.NewDataSet("Content Dataset")
 	defer tmpDataSet.Teardown()
 
 	tmpDataSet.ParseCsvTextIntoTable("requestContent", rawTableContent)
 	if tmpDataSet.Errors() != nil {
 		wrappingError := errors.Wrap(tmpDataSet.Errors(), v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	contentTable, tableError := tmpDataSet.Table("requestContent")
 	if tableError != nil {
 		wrappingError := errors.Wrap(tmpDataSet.Errors(), v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	if contentTable == nil {
 		wrappingError := errors.Wrap(errors.New("No CSV table content found"), "v1 solutions handler")
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	contentTableWithHeadings, hasHeadings := contentTable.(dataset.HeadingsTable)
 	if !hasHeadings {
 		wrappingError := errors.Wrap(errors.New("CSV table does not have a header row"), "v1 solutions handler")
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return nil, wrappingError
 	}
 
 	updateErrors := compositeErrors.New("v1 POST solutions handler")
 
-	headerLength := len(contentTableWithHeadings.Header())
-
-	if contentTableWithHeadings.Header()[0] != "Solution" {
+	if contentTableWithHeadings.Header()[0] != solutionHeading {
 		msgText := "CSV table header column misses mandatory 'Solution' entry"
 		updateErrors.AddMessage(msgText)
-		m.Logger().Error(msgText)
+		s.Logger().Error(msgText)
 	}
 
-	if contentTableWithHeadings.Header()[headerLength-2] != "Actions" {
+	if _, hasActions := columnIndexOf(contentTableWithHeadings, actionsHeading); !hasActions {
 		msgText := "CSV table header column misses mandatory 'Actions' entry"
 		updateErrors.AddMessage(msgText)
-		m.Logger().Error(msgText)
+		s.Logger().Error(msgText)
 	}
 
-	if contentTableWithHeadings.Header()[headerLength-1] != "Summary" {
+	summaryIndex, hasSummary := columnIndexOf(contentTableWithHeadings, summaryHeading)
+	if !hasSummary {
 		msgText := "CSV table header column misses mandatory 'Summary' entry"
 		updateErrors.AddMessage(msgText)
-		m.Logger().Error(msgText)
+		s.Logger().Error(msgText)
 	}
 
 	colSize, rowSize := contentTableWithHeadings.ColumnAndRowSize()
@@ -200,7 +205,7 @@ func (m *Mux) deriveSolutionsRequestTable(rawTableContent string) (dataset.Headi
 				cellValue := contentTableWithHeadings.Cell(colIndex, rowIndex)
 				heading := contentTableWithHeadings.Header()[colIndex]
 				switch heading {
-				case "Solution", "Summary":
+				case solutionHeading, summaryHeading:
 					switch cellValue.(type) {
 					case string:
 						break // deliberately do nothing
@@ -209,19 +214,22 @@ func (m *Mux) deriveSolutionsRequestTable(rawTableContent string) (dataset.Headi
 							"Table management action cell [%d,%d] with value [%v] has invalid type. Must be a string",
 							colIndex, rowIndex, cellValue)
 						updateErrors.AddMessage(msgText)
-						m.Logger().Error(msgText)
+						s.Logger().Error(msgText)
 					}
-				case "Actions":
+				case actionsHeading:
 					actionsValue := contentTableWithHeadings.CellString(colIndex, rowIndex)
 					actionsPattern := regexp.MustCompile(actionsEncodingPattern)
 					if actionsPattern.FindStringIndex(actionsValue) == nil {
 						msgText := fmt.Sprintf(
-							"Table management action cell [%d,%d] with value [%v] has invalid structure. Must be a ':' delimited Hexidecimal pattern'",
+							"Table management action cell [%d,%d] with value [%v] has invalid structure. Must be a ':' delimited Hexidecimal pattern, optionally followed by '|' delimited intensities and start years",
 							colIndex, rowIndex, cellValue)
 						updateErrors.AddMessage(msgText)
-						m.Logger().Error(msgText)
+						s.Logger().Error(msgText)
 					}
 				default:
+					if hasSummary && colIndex > summaryIndex && cellValue == "" {
+						break // details following the summary may be left blank
+					}
 					switch cellValue.(type) {
 					case float64:
 						break // deliberately does nothing
@@ -230,7 +238,7 @@ func (m *Mux) deriveSolutionsRequestTable(rawTableContent string) (dataset.Headi
 							"Table management action cell [%d,%d] with value [%v] has invalid type. Must be a 64-bit floating point decimal",
 							colIndex, rowIndex, cellValue)
 						updateErrors.AddMessage(msgText)
-						m.Logger().Error(msgText)
+						s.Logger().Error(msgText)
 					}
 				}
 			}
@@ -244,12 +252,22 @@ func (m *Mux) deriveSolutionsRequestTable(rawTableContent string) (dataset.Headi
 	return contentTableWithHeadings, nil
 }
 
-func (m *Mux) buildSolutionsPostResponse(w http.ResponseWriter) *rest.Response {
+// columnIndexOf returns the index of the solution set table column with the heading supplied, if there is one.
+func columnIndexOf(solutionSetTable dataset.HeadingsTable, heading string) (uint, bool) {
+	for index, tableHeading := range solutionSetTable.Header() {
+		if tableHeading == heading {
+			return uint(index), true
+		}
+	}
+	return 0, false
+}
+
+func (s *Session) buildSolutionsPostResponse(w http.ResponseWriter) *rest.Response {
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(
 			rest.MessageResponse{
 				Type:    "SUCCESS",
@@ -258,38 +276,38 @@ func (m *Mux) buildSolutionsPostResponse(w http.ResponseWriter) *rest.Response {
 			},
 		)
 
-	m.logSolutionsGetResponse()
+	s.logSolutionsGetResponse()
 	return restResponse
 }
 
-func (m *Mux) rememberSolutionsAttributeState(requestContent string) {
-	scenarioName := m.Attribute(scenarioNameKey).(string)
-	m.Logger().Info("Scenario [" + scenarioName + "] solutions dataset successfully cached")
-	m.ReplaceAttribute(solutionsTextKey, requestContent)
+func (s *Session) rememberSolutionsAttributeState(requestContent string) {
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Scenario [" + scenarioName + "] solutions dataset successfully cached")
+	s.ReplaceAttribute(solutionsTextKey, requestContent)
 }
 
-func (m *Mux) SetSolutionSummary(solutionSummaryFilePath string) {
-	m.Logger().Info("Retrieving Solution Summary [" + solutionSummaryFilePath + "]")
+func (s *Session) SetSolutionSummary(solutionSummaryFilePath string) {
+	s.Logger().Info("Retrieving Solution Summary [" + solutionSummaryFilePath + "]")
 	rawTableContent := readFileAsText(solutionSummaryFilePath)
 
-	requestTable, parseError := m.deriveSolutionsRequestTable(rawTableContent)
+	requestTable, parseError := s.deriveSolutionsRequestTable(rawTableContent)
 	if parseError != nil {
 		wrappingError := errors.Wrap(parseError, v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return
 	}
 
-	verificationError := m.verifySolutionSummaryMatchesScenario(requestTable)
+	verificationError := s.verifySolutionSummaryMatchesScenario(requestTable)
 	if verificationError != nil {
 		wrappingError := errors.Wrap(verificationError, v1solutionSetHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 		return
 	}
 
-	m.updateSolutionSummary(requestTable, rawTableContent)
+	s.updateSolutionSummary(requestTable, rawTableContent)
 }
 
-func (m *Mux) updateSolutionSummary(solutionSetTable dataset.HeadingsTable, rawMessageContent string) {
-	m.rememberSolutionsAttributeState(rawMessageContent)
-	m.solutionSetTable = solutionSetTable
+func (s *Session) updateSolutionSummary(solutionSetTable dataset.HeadingsTable, rawMessageContent string) {
+	s.rememberSolutionsAttributeState(rawMessageContent)
+	s.solutionSetTable = solutionSetTable
 }
@@ -2,6 +2,9 @@ package api
 
 import (
 	_ "embed"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/csv"
 	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
 	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
 	. "github.com/onsi/gomega"
@@ -310,3 +313,85 @@ func TestGetSolutionsWrongVariable_BadContentResponse(t *testing.T) {
 
 	muxUnderTest.Shutdown()
 }
+
+func TestPostSolutionsPartialIntensityCsvResource_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	verifyResponseStatusCode(muxUnderTest, TestContext{
+		Name: "POST /scenario text request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/scenario",
+			RequestBody: validScenarioTomlConfig,
+			ContentType: rest.TomlMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	})
+
+	const partialEncoding = "A1|0=0.5,5=0.25"
+	solutionSet := set.Summary{
+		"As-Is": solution.Summary{
+			SortIndex: 0,
+			Id:        "As-Is",
+			Variables: testSummaryVariables(13.682, 0, 0, 1.822, 1059.911),
+			Actions:   "0",
+			Note:      "As-is state; zero active management actions",
+		},
+		"Partial": solution.Summary{
+			SortIndex: 1,
+			Id:        "Partial",
+			Variables: testSummaryVariables(0, 0, 0, 0, 0),
+			Actions:   partialEncoding,
+			Note:      "Partial intensities, applied \"in part\"",
+		},
+	}
+	marshaledSolutionSet, marshalError := new(csv.SummaryMarshaler).Marshal(&solutionSet)
+	g.Expect(marshalError).To(BeNil())
+	g.Expect(string(marshaledSolutionSet)).To(ContainSubstring(`"` + partialEncoding + `"`))
+
+	// when
+	verifyResponseStatusCode(muxUnderTest, TestContext{
+		Name: "POST /solutions text request with partial intensity solution returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions",
+			RequestBody: string(marshaledSolutionSet),
+			ContentType: rest.CsvMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	})
+
+	response := verifyResponseStatusCode(muxUnderTest, TestContext{
+		Name: "GET /api/v1/solutions/Partial request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    "GET",
+			TargetUrl: baseUrl + "api/v1/solutions/Partial",
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	})
+
+	// then
+	g.Expect(response.JsonMap["ActionIntensities"]).To(Not(BeEmpty()))
+
+	solutionPool := muxUnderTest.defaultSession.solutionPool
+	g.Expect(solutionPool.HasSolution("Partial")).To(BeTrue())
+	g.Expect(solutionPool.Solution("Partial").EncodedActions).To(Equal(partialEncoding))
+	g.Expect(solutionPool.Summary("Partial")).To(Equal("Partial intensities, applied \"in part\""))
+
+	muxUnderTest.Shutdown()
+}
+
+func testSummaryVariables(dissolvedNitrogen, implementationCost, opportunityCost, particulateNitrogen, sedimentProduction float64) solution.VariableSetSummary {
+	return solution.VariableSetSummary{
+		{Name: "DissolvedNitrogen", Value: dissolvedNitrogen},
+		{Name: "ImplementationCost", Value: implementationCost},
+		{Name: "OpportunityCost", Value: opportunityCost},
+		{Name: "ParticulateNitrogen", Value: particulateNitrogen},
+		{Name: "SedimentProduction", Value: sedimentProduction},
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/uncertainty"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const v1solutionUncertaintyHandler = "v1 solution uncertainty handler"
+
+// DefaultMaximumUncertaintySampleSize limits the sample size of uncertainty requests, unless otherwise configured. The
+// whole analysis runs within the request, holding its session, so is kept short.
+const DefaultMaximumUncertaintySampleSize = 1000
+
+func (s *Session) v1solutionUncertaintyHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.v1PostSolutionUncertaintyHandler(w, r)
+	default:
+		s.MethodNotAllowedError(w, r)
+	}
+}
+
+func (s *Session) v1PostSolutionUncertaintyHandler(w http.ResponseWriter, r *http.Request) {
+	if s.requestContentTypeWasNotJson(r, w) {
+		return
+	}
+
+	label, solutionLoaded := s.loadSolution(w, r, pathElementFollowing(r, solutionsPath))
+	if !solutionLoaded {
+		return
+	}
+
+	specification, decodingError := decodeUncertaintySpecification(requestBodyToBytes(r))
+	if decodingError != nil {
+		s.handleUncertaintyRequestError(w, r, decodingError)
+		return
+	}
+	if sampleSizeError := s.checkSampleSizeOf(specification); sampleSizeError != nil {
+		s.handleUncertaintyRequestError(w, r, sampleSizeError)
+		return
+	}
+
+	s.Logger().Info("Analysing uncertainty of solution [" + string(label) + "]")
+	report, analysisError := s.solutionPool.Uncertainty(label, specification)
+	if analysisError != nil {
+		s.handleUncertaintyRequestError(w, r, analysisError)
+		return
+	}
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(s.CacheMaxAge())
+
+	if rest.RequestAccepts(r, rest.CsvMimeType) {
+		reportAsCsv, marshalError := new(uncertainty.CsvMarshaler).Marshal(report)
+		if marshalError != nil {
+			wrappingError := errors.Wrap(marshalError, v1solutionUncertaintyHandler)
+			s.Logger().Error(wrappingError)
+			s.InternalServerError(w, r, wrappingError)
+			return
+		}
+		restResponse.WithCsvContent(string(reportAsCsv))
+	} else {
+		restResponse.WithJsonContent(report)
+	}
+
+	s.Logger().Info("Responding with uncertainty of solution [" + string(label) + "]")
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1solutionUncertaintyHandler)
+		s.Logger().Error(wrappingError)
+	}
+}
+
+func decodeUncertaintySpecification(content []byte) (uncertainty.Specification, error) {
+	var specification uncertainty.Specification
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if decodingError := decoder.Decode(&specification); decodingError != nil {
+		return specification, errors.Wrap(decodingError, "decoding uncertainty specification")
+	}
+	return specification, nil
+}
+
+func (s *Session) checkSampleSizeOf(specification uncertainty.Specification) error {
+	sampleSize := specification.SampleSize
+	if sampleSize == 0 {
+		sampleSize = uncertainty.DefaultSampleSize
+	}
+	if sampleSize > s.maximumUncertaintySampleSize {
+		return errors.Errorf("sample size [%d] exceeds the maximum of [%d]", sampleSize, s.maximumUncertaintySampleSize)
+	}
+	return nil
+}
+
+func (s *Session) handleUncertaintyRequestError(w http.ResponseWriter, r *http.Request, requestError error) {
+	wrappingError := errors.Wrap(requestError, v1solutionUncertaintyHandler)
+	s.Logger().Error(wrappingError)
+	s.RespondWithError(http.StatusBadRequest, wrappingError.Error(), w, r)
+}
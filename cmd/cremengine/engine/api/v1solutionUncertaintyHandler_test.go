@@ -0,0 +1,179 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+const validUncertaintySpecification = `{
+  "SampleSize": 10,
+  "RandomSeed": 42,
+  "Targets": { "SedimentProduction": 10000.0 },
+  "Parameters": {
+    "BankErosionFudgeFactor": { "Distribution": "Triangular", "Spread": 0.2, "Minimum": 0.0001, "Maximum": 0.0005 }
+  },
+  "Columns": [
+    { "Table": "Actions", "Column": "DNRemovalEfficiency", "Distribution": "Uniform", "Spread": 0.2, "Maximum": 1, "PerRow": true }
+  ]
+}`
+
+const invalidUncertaintySpecification = `{
+  "Parameters": { "BankErosionFudgeFactor": { "Distribution": "Lumpy", "Spread": 0.2 } }
+}`
+
+func TestPostSolutionUncertainty_NoScenario_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/3-of-8/uncertainty request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/3-of-8/uncertainty",
+			RequestBody: validUncertaintySpecification,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestPostSolutionUncertainty_InvalidSpecification_BadRequestResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/3-of-8/uncertainty invalid request returns 400 (bad request) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/3-of-8/uncertainty",
+			RequestBody: invalidUncertaintySpecification,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusBadRequest,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestPostSolutionUncertainty_SampleSizeAboveMaximum_BadRequestResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest().WithMaximumUncertaintySampleSize(5)
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/3-of-8/uncertainty request over maximum sample size returns 400 (bad request) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/3-of-8/uncertainty",
+			RequestBody: validUncertaintySpecification,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusBadRequest,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestPostSolutionUncertainty_UnboundedParameter_BadRequestResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/3-of-8/uncertainty request with unbounded parameter returns 400 (bad request) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/3-of-8/uncertainty",
+			RequestBody: `{ "Parameters": { "HillSlopeDeliveryRatio": { "Distribution": "Normal", "Spread": 0.2 } } }`,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusBadRequest,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestPostSolutionUncertainty_Json_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/3-of-8/uncertainty request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/3-of-8/uncertainty",
+			RequestBody: validUncertaintySpecification,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	response := verifyResponseStatusCode(muxUnderTest, context)
+
+	// then
+	g.Expect(response.JsonMap["SampleSize"]).To(BeNumerically("==", 10))
+	g.Expect(response.JsonMap["Solutions"]).To(HaveLen(1))
+
+	solutionReport := response.JsonMap["Solutions"].([]interface{})[0].(map[string]interface{})
+	g.Expect(solutionReport["Id"]).To(Equal("3-of-8"))
+	g.Expect(solutionReport["Variables"]).To(Not(BeEmpty()))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestPostSolutionUncertainty_Csv_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	loadScenarioAndSolutions(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: "POST /api/v1/solutions/As-Is/uncertainty csv request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      "POST",
+			TargetUrl:   baseUrl + "api/v1/solutions/As-Is/uncertainty",
+			RequestBody: validUncertaintySpecification,
+			ContentType: rest.JsonMimeType,
+			Accept:      rest.CsvMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	response := verifyResponseStatusCode(muxUnderTest, context)
+
+	// then
+	g.Expect(strings.HasPrefix(response.RawResponse, "Solution, Variable, Baseline, ")).To(BeTrue())
+	g.Expect(response.RawResponse).To(ContainSubstring("As-Is, SedimentProduction, "))
+
+	muxUnderTest.Shutdown()
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
 	"github.com/LindsayBradford/crem/pkg/attributes"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
@@ -20,69 +22,139 @@ const (
 	v1subcatchmentHandler = "v1 subcatchment handler"
 )
 
-func (m *Mux) v1subcatchmentHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1subcatchmentHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		m.v1GetSubcatchmentHandler(w, r)
+		s.v1GetSubcatchmentHandler(w, r)
 	case http.MethodPut:
-		m.v1PutSubcatchmentHandler(w, r)
+		s.v1PutSubcatchmentHandler(w, r)
 	default:
-		m.MethodNotAllowedError(w, r)
+		s.MethodNotAllowedError(w, r)
 	}
 }
 
-func (m *Mux) v1GetSubcatchmentHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Session) v1GetSubcatchmentHandler(w http.ResponseWriter, r *http.Request) {
 	requestSuppliedSubCatchment := deriveSubCatchmentFrom(r)
 
-	if m.modelSolution == nil {
-		m.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] state with no model present")
-		m.NotFoundError(w, r)
+	if s.modelSolution == nil {
+		s.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] state with no model present")
+		s.NotFoundError(w, r)
 		return
 	}
 
 	subCatchment := toPlanningUnitId(requestSuppliedSubCatchment)
 
-	if !m.modelContains(subCatchment) {
-		m.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] state not offered by the model")
-		m.NotFoundError(w, r)
+	if !s.modelContains(subCatchment) {
+		s.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] state not offered by the model")
+		s.NotFoundError(w, r)
 		return
 	}
 
-	m.respondWithSubcatchmentState(w, subCatchment)
+	s.respondWithSubcatchmentState(w, subCatchment)
 }
 
-func (m *Mux) respondWithSubcatchmentState(w http.ResponseWriter, subCatchment planningunit.Id) {
-	m.logSubcatchmentStateMessage(subCatchment)
-	m.sendSubcatchmentStateResponse(w, subCatchment)
+func (s *Session) respondWithSubcatchmentState(w http.ResponseWriter, subCatchment planningunit.Id) {
+	s.logSubcatchmentStateMessage(subCatchment)
+	s.sendSubcatchmentStateResponse(w, subCatchment)
 }
 
-func (m *Mux) sendSubcatchmentStateResponse(w http.ResponseWriter, subCatchment planningunit.Id) {
-	responseAttributes := m.deriveResponseAttributesFor(subCatchment)
-
+func (s *Session) sendSubcatchmentStateResponse(w http.ResponseWriter, subCatchment planningunit.Id) {
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
-		WithJsonContent(responseAttributes)
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithJsonContent(s.deriveResponseAttributesFor(subCatchment))
 
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1subcatchmentHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) logSubcatchmentStateMessage(subCatchment planningunit.Id) {
-	scenarioName := m.Attribute(scenarioNameKey).(string)
+func (s *Session) logSubcatchmentStateMessage(subCatchment planningunit.Id) {
+	scenarioName := s.Attribute(scenarioNameKey).(string)
 	responseMessage := fmt.Sprintf("Responding with model [%s] subcatchment [%d] state", scenarioName, subCatchment)
-	m.Logger().Info(responseMessage)
+	s.Logger().Info(responseMessage)
+}
+
+func (s *Session) v1subcatchmentContributionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.v1GetSubcatchmentContributionsHandler(w, r)
+	default:
+		s.MethodNotAllowedError(w, r)
+	}
+}
+
+func (s *Session) v1GetSubcatchmentContributionsHandler(w http.ResponseWriter, r *http.Request) {
+	requestSuppliedSubCatchment := deriveSubCatchmentBefore(contributionsPath, r)
+
+	if s.modelSolution == nil {
+		s.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] contributions with no model present")
+		s.NotFoundError(w, r)
+		return
+	}
+
+	subCatchment := toPlanningUnitId(requestSuppliedSubCatchment)
+
+	if !s.modelContains(subCatchment) {
+		s.Logger().Warn("Attempted to request subcatchment [" + requestSuppliedSubCatchment + "] contributions not offered by the model")
+		s.NotFoundError(w, r)
+		return
+	}
+
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	responseMessage := fmt.Sprintf("Responding with model [%s] subcatchment [%d] contributions", scenarioName, subCatchment)
+	s.Logger().Info(responseMessage)
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithJsonContent(s.deriveSubcatchmentState(subCatchment))
+
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1subcatchmentHandler)
+		s.Logger().Error(wrappingError)
+	}
 }
 
-func (m *Mux) deriveResponseAttributesFor(subCatchment planningunit.Id) attributes.Attributes {
-	activeActions := m.modelSolution.ActiveManagementActions[subCatchment]
-	inactiveActions := m.modelSolution.InactiveManagementActions[subCatchment]
+// SubcatchmentState reports the state of a subcatchment's management actions, and how those active actions alter the
+// subcatchment's contribution to each decision variable. Decision variables routed down the catchment also report the
+// load accumulated by the subcatchment, under the variable's name with variable.AccumulatedSuffix appended.
+type SubcatchmentState struct {
+	SubCatchment  planningunit.Id
+	Actions       attributes.Attributes
+	Contributions []SubcatchmentContribution
+}
+
+// SubcatchmentContribution is a subcatchment's contribution to a decision variable before (as-is) and after its
+// active management actions are applied.
+type SubcatchmentContribution struct {
+	DecisionVariable string
+	Measure          variable.UnitOfMeasure `json:"UnitOfMeasure"`
+	BeforeActions    float64
+	AfterActions     float64
+	Change           float64
+}
+
+func (s *Session) deriveSubcatchmentState(subCatchment planningunit.Id) SubcatchmentState {
+	return SubcatchmentState{
+		SubCatchment:  subCatchment,
+		Actions:       s.deriveResponseAttributesFor(subCatchment),
+		Contributions: s.deriveContributionsOf(subCatchment),
+	}
+}
+
+func (s *Session) deriveResponseAttributesFor(subCatchment planningunit.Id) attributes.Attributes {
+	activeActions := s.modelSolution.ActiveManagementActions[subCatchment]
+	inactiveActions := s.modelSolution.InactiveManagementActions[subCatchment]
 
 	returnAttributes := attributes.Attributes{}
 
@@ -95,44 +167,88 @@ func (m *Mux) deriveResponseAttributesFor(subCatchment planningunit.Id) attribut
 	return returnAttributes
 }
 
-func (m *Mux) v1PutSubcatchmentHandler(w http.ResponseWriter, r *http.Request) {
-	if m.modelSolution == nil {
-		m.NotFoundError(w, r)
+func (s *Session) deriveContributionsOf(subCatchment planningunit.Id) []SubcatchmentContribution {
+	asIsSolution := s.solutionPool.Solution(AsIs)
+
+	contributions := make([]SubcatchmentContribution, 0, len(s.modelSolution.DecisionVariables))
+	for _, actionedVariable := range s.modelSolution.DecisionVariables {
+		asIsVariable, asIsHasVariable := solution.VariableNamed(asIsSolution, actionedVariable.Name)
+		if !asIsHasVariable {
+			asIsVariable = actionedVariable
+		}
+		contributions = append(contributions, contributionTo(asIsVariable, actionedVariable, subCatchment))
+
+		if actionedAccumulated, hasAccumulated := actionedVariable.Accumulated(); hasAccumulated {
+			asIsAccumulated, asIsHasAccumulated := asIsVariable.Accumulated()
+			if !asIsHasAccumulated {
+				asIsAccumulated = actionedAccumulated
+			}
+			contributions = append(contributions, contributionTo(asIsAccumulated, actionedAccumulated, subCatchment))
+		}
+	}
+	return contributions
+}
+
+func contributionTo(asIsVariable, actionedVariable variable.EncodeableDecisionVariable, subCatchment planningunit.Id) SubcatchmentContribution {
+	beforeActions := contributionOf(asIsVariable, subCatchment)
+	afterActions := contributionOf(actionedVariable, subCatchment)
+
+	return SubcatchmentContribution{
+		DecisionVariable: actionedVariable.Name,
+		Measure:          actionedVariable.Measure,
+		BeforeActions:    beforeActions,
+		AfterActions:     afterActions,
+		Change:           solution.Delta(beforeActions, afterActions),
+	}
+}
+
+func contributionOf(decisionVariable variable.EncodeableDecisionVariable, subCatchment planningunit.Id) float64 {
+	for _, planningUnitValue := range decisionVariable.ValuePerPlanningUnit {
+		if planningUnitValue.PlanningUnit == subCatchment {
+			return planningUnitValue.Value
+		}
+	}
+	return 0
+}
+
+func (s *Session) v1PutSubcatchmentHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.NotFoundError(w, r)
 		return
 	}
 
 	requestSuppliedSubCatchment := deriveSubCatchmentFrom(r)
 	subCatchment := toPlanningUnitId(requestSuppliedSubCatchment)
 
-	if !m.modelContains(subCatchment) {
-		m.NotFoundError(w, r)
+	if !s.modelContains(subCatchment) {
+		s.NotFoundError(w, r)
 		return
 	}
 
-	processingError := m.processSubcatchmentPost(w, r, subCatchment)
+	processingError := s.processSubcatchmentPost(w, r, subCatchment)
 	if processingError != nil {
-		m.reportProcessingError(w, r, processingError)
+		s.reportProcessingError(w, r, processingError)
 		return
 	}
 
-	restResponse := m.buildSubcatchmentResponse(w)
+	restResponse := s.buildSubcatchmentResponse(w)
 	writeError := restResponse.Write()
 
 	if writeError != nil {
 		wrappingError := errors.Wrap(writeError, v1subcatchmentHandler)
-		m.Logger().Error(wrappingError)
+		s.Logger().Error(wrappingError)
 	}
 }
 
-func (m *Mux) reportProcessingError(w http.ResponseWriter, r *http.Request, processingError error) {
-	m.Logger().Error(processingError)
-	m.RespondWithError(http.StatusBadRequest, processingError.Error(), w, r)
+func (s *Session) reportProcessingError(w http.ResponseWriter, r *http.Request, processingError error) {
+	s.Logger().Error(processingError)
+	s.RespondWithError(http.StatusBadRequest, processingError.Error(), w, r)
 }
 
-func (m *Mux) processSubcatchmentPost(w http.ResponseWriter, r *http.Request, subCatchment planningunit.Id) error {
-	scenarioName := m.Attribute(scenarioNameKey).(string)
+func (s *Session) processSubcatchmentPost(w http.ResponseWriter, r *http.Request, subCatchment planningunit.Id) error {
+	scenarioName := s.Attribute(scenarioNameKey).(string)
 	responseMessage := fmt.Sprintf("Processing POST of model [%s] subcatchment [%d] state", scenarioName, subCatchment)
-	m.Logger().Info(responseMessage)
+	s.Logger().Info(responseMessage)
 
 	requestContent := requestBodyToBytes(r)
 	postedAttributes := attributes.Attributes{}
@@ -142,21 +258,21 @@ func (m *Mux) processSubcatchmentPost(w http.ResponseWriter, r *http.Request, su
 		return errors.Wrap(unmnarshalError, v1subcatchmentHandler)
 	}
 
-	syntaxCheckError := m.syntaxCheckPostedAttributes(postedAttributes)
+	syntaxCheckError := s.syntaxCheckPostedAttributes(postedAttributes)
 	if syntaxCheckError != nil {
 		return syntaxCheckError
 	}
 
-	updateModelError := m.updateModel(subCatchment, postedAttributes)
+	updateModelError := s.updateModel(subCatchment, postedAttributes)
 	if updateModelError != nil {
 		return updateModelError
 	}
 
-	m.deriveExtraModelAttributes()
+	s.deriveExtraModelAttributes()
 	return nil
 }
 
-func (m *Mux) syntaxCheckPostedAttributes(postedAttributes attributes.Attributes) error {
+func (s *Session) syntaxCheckPostedAttributes(postedAttributes attributes.Attributes) error {
 	// TODO:  Hardcoding these is a bad code smell.
 	for _, entry := range postedAttributes {
 		if entry.Name != "RiverBankRestoration" && entry.Name != "HillSlopeRestoration" &&
@@ -173,11 +289,11 @@ func (m *Mux) syntaxCheckPostedAttributes(postedAttributes attributes.Attributes
 	return nil
 }
 
-func (m *Mux) updateModel(subCatchment planningunit.Id, postedAttributes attributes.Attributes) error {
+func (s *Session) updateModel(subCatchment planningunit.Id, postedAttributes attributes.Attributes) error {
 	updateErrors := compositeErrors.New("Model Update failure")
 	for _, entry := range postedAttributes {
 		postedEntryFound := false
-		for _, action := range m.model.ManagementActions() {
+		for _, action := range s.model.ManagementActions() {
 			if entry.Name == string(action.Type()) && subCatchment == action.PlanningUnit() {
 				postedEntryFound = true
 			}
@@ -192,26 +308,32 @@ func (m *Mux) updateModel(subCatchment planningunit.Id, postedAttributes attribu
 		return updateErrors
 	}
 
-	for actionIndex, action := range m.model.ManagementActions() {
-		if subCatchment != action.PlanningUnit() {
-			continue
-		}
+	constraintsError := s.applyWithinConstraints(func() {
+		for actionIndex, action := range s.model.ManagementActions() {
+			if subCatchment != action.PlanningUnit() {
+				continue
+			}
 
-		for _, entry := range postedAttributes {
-			if entry.Name == string(action.Type()) {
-				if entry.Value == InactiveAction {
-					m.model.SetManagementAction(actionIndex, false)
-				}
-				if entry.Value == ActiveAction {
-					m.model.SetManagementAction(actionIndex, true)
+			for _, entry := range postedAttributes {
+				if entry.Name == string(action.Type()) {
+					if entry.Value == InactiveAction {
+						s.model.SetManagementAction(actionIndex, false)
+					}
+					if entry.Value == ActiveAction {
+						s.model.SetManagementAction(actionIndex, true)
+					}
+					s.model.AcceptAll()
+					infoMessage := fmt.Sprintf("Model subcatchment [%d], Action [%s] set to [%s]", subCatchment, entry.Name, entry.Value)
+					s.Logger().Info(infoMessage)
 				}
-				m.model.AcceptAll()
-				infoMessage := fmt.Sprintf("Model subcatchment [%d], Action [%s] set to [%s]", subCatchment, entry.Name, entry.Value)
-				m.Logger().Info(infoMessage)
 			}
 		}
+	})
+	if constraintsError != nil {
+		return constraintsError
 	}
-	m.updateModelSolution()
+
+	s.updateModelSolution()
 	return nil
 }
 
@@ -222,6 +344,14 @@ func deriveSubCatchmentFrom(r *http.Request) string {
 	return subCatchmentAsString
 }
 
+// deriveSubCatchmentBefore returns the subcatchment named in the request's path, immediately before the path element
+// supplied.
+func deriveSubCatchmentBefore(pathElement string, r *http.Request) string {
+	trimmedPath := strings.TrimSuffix(r.URL.Path, rest.UrlPathSeparator+pathElement)
+	pathElements := strings.Split(trimmedPath, rest.UrlPathSeparator)
+	return pathElements[len(pathElements)-1]
+}
+
 func toPlanningUnitId(subCatchmentAsString string) planningunit.Id {
 	subCatchmentAsInteger, convertError := strconv.Atoi(subCatchmentAsString)
 	if convertError != nil {
@@ -232,8 +362,8 @@ func toPlanningUnitId(subCatchmentAsString string) planningunit.Id {
 	return subCatchment
 }
 
-func (m *Mux) modelContains(subCatchment planningunit.Id) bool {
-	for _, value := range m.modelSolution.PlanningUnits {
+func (s *Session) modelContains(subCatchment planningunit.Id) bool {
+	for _, value := range s.modelSolution.PlanningUnits {
 		if value == subCatchment {
 			return true
 		}
@@ -241,12 +371,12 @@ func (m *Mux) modelContains(subCatchment planningunit.Id) bool {
 	return false
 }
 
-func (m *Mux) buildSubcatchmentResponse(w http.ResponseWriter) *rest.Response {
+func (s *Session) buildSubcatchmentResponse(w http.ResponseWriter) *rest.Response {
 	restResponse := new(rest.Response).
 		Initialise().
 		WithWriter(w).
 		WithResponseCode(http.StatusOK).
-		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
 		WithJsonContent(
 			rest.MessageResponse{
 				Type:    "SUCCESS",
@@ -255,7 +385,7 @@ func (m *Mux) buildSubcatchmentResponse(w http.ResponseWriter) *rest.Response {
 			},
 		)
 
-	m.Logger().Info("Responding with acknowledgement of subcatchment state change ")
+	s.Logger().Info("Responding with acknowledgement of subcatchment state change ")
 
 	return restResponse
 }
@@ -21,6 +21,8 @@ const (
 	baseSubcatchmentUrl  = baseUrl + "api/v1/model/subcatchment"
 	validSubcatchment    = "18"
 	validSubcatchmentUrl = baseSubcatchmentUrl + rest.UrlPathSeparator + validSubcatchment
+
+	validSubcatchmentContributionsUrl = validSubcatchmentUrl + rest.UrlPathSeparator + "contributions"
 )
 
 func TestFirstSubcatchmentGetRequest_NotFoundResponse(t *testing.T) {
@@ -135,6 +137,102 @@ func TestGetValidSubcathmentResource_OkResponse(t *testing.T) {
 	muxUnderTest.Shutdown()
 }
 
+func TestGetValidSubcatchmentResource_PutBackUnchanged_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	buildValidScenario(t, muxUnderTest)
+
+	getContext := TestContext{
+		Name: http.MethodGet + " " + validSubcatchmentUrl + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: validSubcatchmentUrl,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	getResponse := verifyResponseStatusCode(muxUnderTest, getContext)
+
+	var actionAttributes attributes.Attributes
+	g.Expect(json.Unmarshal([]byte(getResponse.RawResponse), &actionAttributes)).To(Succeed())
+	g.Expect(actionAttributes).To(Not(BeEmpty()))
+
+	// when
+	putContext := TestContext{
+		Name: http.MethodPut + " " + validSubcatchmentUrl + " request of state just got returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      http.MethodPut,
+			TargetUrl:   validSubcatchmentUrl,
+			RequestBody: getResponse.RawResponse,
+			ContentType: rest.JsonMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, putContext)
+	muxUnderTest.Shutdown()
+}
+
+func TestGetValidSubcatchmentContributions_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	buildValidScenario(t, muxUnderTest)
+
+	// when
+	getContext := TestContext{
+		Name: http.MethodGet + " " + validSubcatchmentContributionsUrl + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: validSubcatchmentContributionsUrl,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	response := verifyResponseStatusCode(muxUnderTest, getContext)
+	jsonResponse := response.JsonMap
+	g.Expect(jsonResponse["SubCatchment"]).To(BeNumerically("==", 18))
+	g.Expect(jsonResponse["Actions"]).To(Not(BeEmpty()))
+
+	contributions, _ := jsonResponse["Contributions"].([]interface{})
+	g.Expect(contributions).To(Not(BeEmpty()))
+	for _, contribution := range contributions {
+		contributionMap := contribution.(map[string]interface{})
+		g.Expect(contributionMap["Change"]).To(BeNumerically("==", 0))
+	}
+
+	muxUnderTest.Shutdown()
+}
+
+func TestMissingSubcatchmentContributionsGetRequest_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	buildValidScenario(t, muxUnderTest)
+
+	// when
+	contributionsUrlUnderTest := baseSubcatchmentUrl + "/1/contributions"
+	getContext := TestContext{
+		Name: http.MethodGet + " " + contributionsUrlUnderTest + " request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: contributionsUrlUnderTest,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, getContext)
+	muxUnderTest.Shutdown()
+}
+
 func TestFirstSubcatchmentPutRequest_NotFoundResponse(t *testing.T) {
 	// given
 	muxUnderTest := buildMuxUnderTest()
@@ -233,17 +331,28 @@ func TestPostValidSubcatchmentResource_OkResponse(t *testing.T) {
 
 	// when
 	getContext := TestContext{
-		Name: http.MethodGet + " " + validSubcatchmentUrl + " request returns 200 (ok) response",
+		Name: http.MethodGet + " " + validSubcatchmentContributionsUrl + " request returns 200 (ok) response",
 		T:    t,
 		Request: httptest.HttpTestRequestContext{
 			Method:    http.MethodGet,
-			TargetUrl: validSubcatchmentUrl,
+			TargetUrl: validSubcatchmentContributionsUrl,
 		},
 		ExpectedResponseStatus: http.StatusOK,
 	}
 
 	// then
-	verifyResponseStatusCode(muxUnderTest, getContext)
+	getResponse := verifyResponseStatusCode(muxUnderTest, getContext)
+
+	costChanged := false
+	contributions, _ := getResponse.JsonMap["Contributions"].([]interface{})
+	for _, contribution := range contributions {
+		contributionMap := contribution.(map[string]interface{})
+		if contributionMap["DecisionVariable"] == "ImplementationCost" {
+			g.Expect(contributionMap["AfterActions"]).To(BeNumerically(">", contributionMap["BeforeActions"]))
+			costChanged = true
+		}
+	}
+	g.Expect(costChanged).To(BeTrue())
 
 	muxUnderTest.Shutdown()
 }
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const v1subcatchmentsHandler = "v1 subcatchments handler"
+
+func (s *Session) v1subcatchmentsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.v1GetSubcatchmentsHandler(w, r)
+	default:
+		s.MethodNotAllowedError(w, r)
+	}
+}
+
+func (s *Session) v1GetSubcatchmentsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.modelSolution == nil {
+		s.Logger().Warn("Attempted to request subcatchments state with no model present")
+		s.NotFoundError(w, r)
+		return
+	}
+
+	subcatchmentStates := make([]SubcatchmentState, 0, len(s.modelSolution.PlanningUnits))
+	for _, subCatchment := range s.modelSolution.PlanningUnits {
+		subcatchmentStates = append(subcatchmentStates, s.deriveSubcatchmentState(subCatchment))
+	}
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(s.CacheMaxAge()).
+		WithJsonContent(subcatchmentStates)
+
+	scenarioName := s.Attribute(scenarioNameKey).(string)
+	s.Logger().Info("Responding with model [" + scenarioName + "] subcatchments state")
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1subcatchmentsHandler)
+		s.Logger().Error(wrappingError)
+	}
+}
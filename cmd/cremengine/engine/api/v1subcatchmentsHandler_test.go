@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+const subcatchmentsUrl = baseUrl + "api/v1/model/subcatchments"
+
+func TestSubcatchmentsGetRequest_NoModel_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + subcatchmentsUrl + " request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: subcatchmentsUrl,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestSubcatchmentsPutRequest_NotAllowedResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	buildValidScenario(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: http.MethodPut + " " + subcatchmentsUrl + " request returns 405 (method not allowed) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodPut,
+			TargetUrl: subcatchmentsUrl,
+		},
+		ExpectedResponseStatus: http.StatusMethodNotAllowed,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestSubcatchmentsGetRequest_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	buildValidScenario(t, muxUnderTest)
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + subcatchmentsUrl + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: subcatchmentsUrl,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	response := verifyResponseStatusCode(muxUnderTest, context)
+
+	// then
+	var subcatchmentStates []SubcatchmentState
+	unmarshalError := json.Unmarshal([]byte(response.RawResponse), &subcatchmentStates)
+	g.Expect(unmarshalError).To(BeNil())
+	g.Expect(subcatchmentStates).To(HaveLen(len(muxUnderTest.defaultSession.modelSolution.PlanningUnits)))
+
+	expectedContributions := muxUnderTest.defaultSession.modelSolution.DecisionVariables.WithAccumulated()
+	g.Expect(len(expectedContributions)).To(BeNumerically(">", len(muxUnderTest.defaultSession.modelSolution.DecisionVariables)))
+
+	for _, state := range subcatchmentStates {
+		g.Expect(state.Contributions).To(HaveLen(len(expectedContributions)))
+	}
+
+	muxUnderTest.Shutdown()
+}
@@ -3,8 +3,10 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
 	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
@@ -20,12 +22,20 @@ var (
 	LogHandler    logging.Logger
 	myScenario    scenario.Scenario
 	myInterpreter interpreter2.ConfigInterpreter
+
+	resumeDirectory string
 )
 
 func init() {
 	myInterpreter = *interpreter2.NewInterpreter()
 }
 
+// ResumeFromCheckpointsIn has the next scenario run resume each of its runs from its latest checkpoint in the
+// supplied directory, overriding any ResumeFrom setting of the scenario's config file.
+func ResumeFromCheckpointsIn(directory string) {
+	resumeDirectory = directory
+}
+
 func RunExcelCompatibleScenarioFromConfigFile(configFile string) {
 	defer gracefullyHandlePanics()
 
@@ -68,6 +78,10 @@ func RunScenarioFromConfigFile(configFile string) {
 }
 
 func runScenario() {
+	cancellation, stopListening := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stopListening()
+	cancelScenarioOn(cancellation)
+
 	if runError := myScenario.Run(); runError != nil {
 		wrappingError := errors.Wrap(runError, "running scenario")
 		LogHandler.Error(wrappingError)
@@ -75,6 +89,14 @@ func runScenario() {
 	}
 }
 
+// cancelScenarioOn has the scenario stop its runs gracefully once the supplied context is done, saving whatever each
+// run has found so far.
+func cancelScenarioOn(cancellation context.Context) {
+	if cancellableScenario, scenarioIsCancellable := myScenario.(scenario.Cancellable); scenarioIsCancellable {
+		cancellableScenario.SetContext(cancellation)
+	}
+}
+
 func flushStreams() {
 	os.Stdout.Sync()
 	os.Stderr.Sync()
@@ -82,6 +104,9 @@ func flushStreams() {
 
 func deriveScenario(configFile string) {
 	myConfig := loadScenarioConfig(configFile)
+	if resumeDirectory != "" {
+		myConfig.Scenario.ResumeFrom = resumeDirectory
+	}
 	myScenario = myInterpreter.Interpret(myConfig).Scenario()
 
 	LogHandler = myScenario.LogHandler()
@@ -34,6 +34,7 @@ type Arguments struct {
 	Version      bool
 	Licence      bool
 	ScenarioFile string
+	ResumeFrom   string
 }
 
 // THe define sets up the relevant command-line
@@ -48,6 +49,13 @@ func (args *Arguments) define() {
 		"file dictating scenario run-time behaviour",
 	)
 
+	flag.StringVar(
+		&args.ResumeFrom,
+		"ResumeFrom",
+		"",
+		"directory of checkpoints from which to resume an interrupted scenario",
+	)
+
 	flag.BoolVar(
 		&args.Version,
 		"Version",
@@ -94,6 +102,10 @@ func (args *Arguments) process() {
 	if args.ScenarioFile != "" {
 		validateFilePath(args.ScenarioFile)
 	}
+
+	if args.ResumeFrom != "" {
+		validateDirectoryPath(args.ResumeFrom)
+	}
 }
 
 func validateFilePath(filePath string) {
@@ -108,6 +120,18 @@ func validateFilePath(filePath string) {
 	}
 }
 
+func validateDirectoryPath(directoryPath string) {
+	pathInfo, err := os.Stat(directoryPath)
+	if os.IsNotExist(err) {
+		exitError := errors.Errorf("directory specified [%s] does not exist", directoryPath)
+		Exit(exitError)
+	}
+	if !pathInfo.Mode().IsDir() {
+		exitError := errors.Errorf("directory specified [%s] is a file, not a directory", directoryPath)
+		Exit(exitError)
+	}
+}
+
 func Exit(exitValue interface{}) {
 	var exitCode int
 	switch exitValue.(type) {
@@ -136,9 +160,13 @@ func usageMessage() {
 	fmt.Println("  --Version                      Prints the version number of this utility.")
 	fmt.Println("  --Licence                       Prints the copyright licence of this utility.")
 	fmt.Println("  --ScenarioFile  <FilePath>     File describing a scenario to run and its  run-time behaviour.")
+	fmt.Println("  --ResumeFrom    <DirPath>      Directory of checkpoints to resume an interrupted scenario from.")
 	fmt.Println()
 	fmt.Println("Running a single scenario takes the form:")
 	fmt.Printf("  %s --ScenarioFile <FilePath>\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Resuming an interrupted scenario from its latest checkpoints takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --ResumeFrom <DirPath>\n", justExecutableName())
 
 	Exit(0)
 }
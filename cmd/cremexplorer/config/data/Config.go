@@ -10,4 +10,7 @@ type Config struct {
 	Scenario ScenarioConfig
 	Annealer data.AnnealerConfig
 	Model    data.ModelConfig
+
+	// Uncertainty, if supplied, has the scenario analyse the uncertainty of existing solutions rather than anneal.
+	Uncertainty *UncertaintyConfig
 }
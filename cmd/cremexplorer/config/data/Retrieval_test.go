@@ -87,6 +87,8 @@ func TestRetrieveConfigFromFile_RichValidConfig_NoErrors(t *testing.T) {
 	// then
 	g.Expect(retrieveError).To(BeNil())
 	g.Expect(config.Scenario.Name).To(Equal(expectedScenarioName))
+	g.Expect(config.Scenario.RandomSeed).To(BeNumerically("==", 1234567))
+	g.Expect(config.Scenario.CheckpointEveryNumberOfIterations).To(BeNumerically("==", 500))
 }
 
 func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
@@ -9,11 +9,20 @@ type ScenarioConfig struct {
 
 	RunNumber                  uint64
 	MaximumConcurrentRunNumber uint64
+	RandomSeed                 int64
+
+	CheckpointEveryNumberOfIterations uint64
+	ResumeFrom                        string
 
 	OutputPath  string
 	OutputType  ScenarioOutputType
 	OutputLevel ScenarioOutputLevel
 
+	CompareWithExactSolutions bool
+
+	BoundaryPath       string
+	BoundaryIdProperty string
+
 	CpuProfilePath string
 
 	Reporting ReportingConfig
@@ -30,16 +39,17 @@ func (sot *ScenarioOutputType) String() string {
 }
 
 var (
-	CsvOutput   = ScenarioOutputType{"CSV"}
-	JsonOutput  = ScenarioOutputType{"JSON"}
-	ExcelOutput = ScenarioOutputType{"EXCEL"}
+	CsvOutput     = ScenarioOutputType{"CSV"}
+	JsonOutput    = ScenarioOutputType{"JSON"}
+	ExcelOutput   = ScenarioOutputType{"EXCEL"}
+	GeoJsonOutput = ScenarioOutputType{"GEOJSON"}
 )
 
 func (sot *ScenarioOutputType) UnmarshalText(text []byte) error {
 	context := data.UnmarshalContext{
 		ConfigKey: "OutputType",
 		ValidValues: []string{
-			CsvOutput.value, JsonOutput.value, ExcelOutput.value,
+			CsvOutput.value, JsonOutput.value, ExcelOutput.value, GeoJsonOutput.value,
 		},
 		TextToValidate: string(text),
 		AssignmentFunction: func() {
@@ -0,0 +1,17 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import "github.com/LindsayBradford/crem/internal/pkg/model/uncertainty"
+
+// UncertaintyConfig has the explorer re-evaluate the solutions of an existing solution set over sampled model inputs,
+// instead of annealing for new solutions.
+type UncertaintyConfig struct {
+	// SolutionSetPath locates the CSV solution set summary file holding the solutions to re-evaluate.
+	SolutionSetPath string
+
+	// Solutions lists the ids of the solutions to re-evaluate. All solutions of the set are re-evaluated if empty.
+	Solutions []string
+
+	uncertainty.Specification
+}
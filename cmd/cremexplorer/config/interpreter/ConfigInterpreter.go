@@ -29,8 +29,9 @@ type ConfigInterpreter struct {
 	annealerInterpreter *interpreter.AnnealerConfigInterpreter
 	annealer            annealing.Annealer
 
-	scenarioInterpreter *ScenarioConfigInterpreter
-	scenario            scenario.Scenario
+	scenarioInterpreter    *ScenarioConfigInterpreter
+	uncertaintyInterpreter *UncertaintyConfigInterpreter
+	scenario               scenario.Scenario
 }
 
 func (i *ConfigInterpreter) initialise() *ConfigInterpreter {
@@ -44,6 +45,7 @@ func (i *ConfigInterpreter) initialise() *ConfigInterpreter {
 
 	i.scenario = scenario.NullScenario
 	i.scenarioInterpreter = NewScenarioConfigInterpreter()
+	i.uncertaintyInterpreter = NewUncertaintyConfigInterpreter()
 
 	return i
 }
@@ -57,6 +59,9 @@ func (i *ConfigInterpreter) Interpret(config *appData.Config) *ConfigInterpreter
 	i.interpretModelConfig(&config.Model)
 	i.interpretAnnealerConfig(&config.Annealer)
 	i.interpretScenarioConfig(&config.Scenario)
+	if config.Uncertainty != nil {
+		i.interpretUncertaintyConfig(config.Uncertainty, &config.Scenario)
+	}
 
 	i.annealer.SetModel(i.model)
 	i.scenario.SetAnnealer(i.annealer)
@@ -85,6 +90,17 @@ func (i *ConfigInterpreter) interpretScenarioConfig(config *appData.ScenarioConf
 	}
 }
 
+// interpretUncertaintyConfig replaces the annealing scenario with one analysing the uncertainty of existing solutions,
+// logging as the annealing scenario would have. The annealing scenario is kept if the uncertainty configuration is bad.
+func (i *ConfigInterpreter) interpretUncertaintyConfig(config *appData.UncertaintyConfig, scenarioConfig *appData.ScenarioConfig) {
+	uncertaintyScenario := i.uncertaintyInterpreter.Interpret(config, scenarioConfig, i.scenario.LogHandler()).Scenario()
+	if i.uncertaintyInterpreter.Errors() != nil {
+		i.errors.Add(i.uncertaintyInterpreter.Errors())
+		return
+	}
+	i.scenario = uncertaintyScenario
+}
+
 func (i *ConfigInterpreter) Scenario() scenario.Scenario {
 	assert.That(i.scenario != nil)
 	return i.scenario
@@ -53,3 +53,41 @@ func readTestFileAsText(filePath string) string {
 	}
 	return "error reading file"
 }
+
+func TestConfigInterpreter_UncertaintyConfig_UncertaintyScenario(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText("testdata/UncertaintyConfig.toml")
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+
+	// when
+	interpreterUnderTest := NewInterpreter().Interpret(configUnderTest)
+
+	// then
+	g.Expect(configError).To(BeNil())
+	g.Expect(configUnderTest.Uncertainty.Columns).To(HaveLen(1))
+	g.Expect(configUnderTest.Uncertainty.Columns[0].Spread).To(BeNumerically("==", 0.2))
+	g.Expect(*configUnderTest.Uncertainty.Parameters["BankErosionFudgeFactor"].Maximum).To(BeNumerically("==", 0.0005))
+
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.Scenario()).To(BeAssignableToTypeOf(&scenario.UncertaintyScenario{}))
+}
+
+func TestConfigInterpreter_BadUncertaintyConfig_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText("testdata/BadUncertaintyConfig.toml")
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+
+	// when
+	interpreterUnderTest := NewInterpreter().Interpret(configUnderTest)
+
+	// then
+	g.Expect(configError).To(BeNil())
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("SolutionSetPath"))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("[Lumpy]"))
+	g.Expect(interpreterUnderTest.Scenario()).To(BeAssignableToTypeOf(&scenario.BaseScenario{}))
+}
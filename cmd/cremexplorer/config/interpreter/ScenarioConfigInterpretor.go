@@ -5,6 +5,7 @@ package interpreter
 import (
 	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/geojson"
 	"github.com/LindsayBradford/crem/internal/pkg/scenario"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/pkg/errors"
@@ -59,12 +60,17 @@ func (i *ScenarioConfigInterpreter) interpretRunner(config *appData.ScenarioConf
 	}
 
 	logHandler := i.reportingInterpreter.LogHandler()
-	saver := buildSaver(config).WithLogHandler(logHandler)
+	saver := buildSaver(config).
+		WithBoundaries(i.interpretBoundaries(config)).
+		WithLogHandler(logHandler)
 
 	runner = scenario.NewRunner().
 		WithName(config.Name).
 		WithRunNumber(config.RunNumber).
 		WithMaximumConcurrentRuns(config.MaximumConcurrentRunNumber).
+		WithRandomSeed(config.RandomSeed).
+		WithCheckpointing(config.OutputPath, config.CheckpointEveryNumberOfIterations).
+		ResumingFrom(config.ResumeFrom).
 		WithLogHandler(logHandler).
 		WithSaver(saver)
 
@@ -83,11 +89,37 @@ func buildSaver(scenarioConfig *appData.ScenarioConfig) *scenario.Saver {
 	saver := scenario.NewSaver().
 		WithOutputType(configOutputTypeToEncodingOutputType(scenarioConfig.OutputType)).
 		WithOutputPath(scenarioConfig.OutputPath).
-		WithOutputLevel(configOutputLevelToScenarioOutputLevel(scenarioConfig.OutputLevel))
+		WithOutputLevel(configOutputLevelToScenarioOutputLevel(scenarioConfig.OutputLevel)).
+		WithExactComparison(scenarioConfig.CompareWithExactSolutions)
 
 	return saver
 }
 
+// interpretBoundaries loads the planning unit boundaries that GeoJSON output joins solutions to. Boundaries are only
+// loaded, and then must be supplied, for GeoJSON output.
+func (i *ScenarioConfigInterpreter) interpretBoundaries(config *appData.ScenarioConfig) *geojson.Boundaries {
+	if config.OutputType != appData.GeoJsonOutput {
+		return nil
+	}
+
+	if config.BoundaryPath == "" {
+		i.errors.Add(errors.New("Missing BoundaryPath field, mandatory for OutputType [" + appData.GeoJsonOutput.String() + "]"))
+		return nil
+	}
+
+	idProperty := config.BoundaryIdProperty
+	if idProperty == "" {
+		idProperty = geojson.DefaultIdProperty
+	}
+
+	boundaries, loadError := geojson.LoadBoundaries(config.BoundaryPath, idProperty)
+	if loadError != nil {
+		i.errors.Add(errors.Wrap(loadError, "interpreting BoundaryPath"))
+		return nil
+	}
+	return boundaries
+}
+
 func configOutputTypeToEncodingOutputType(outputType appData.ScenarioOutputType) encoding.OutputType {
 	return encoding.OutputType(outputType.String())
 }
@@ -56,3 +56,20 @@ func TestConfigInterpreter_ProfilingScenario_HasProfilingRunner(t *testing.T) {
 	}
 	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
 }
+
+func TestConfigInterpreter_GeoJsonOutputWithoutBoundaries_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.ScenarioConfig{
+		Name:       "GeoJSON Scenario test",
+		OutputType: data.GeoJsonOutput,
+	}
+
+	// when
+	interpreterUnderTest := NewScenarioConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("BoundaryPath"))
+}
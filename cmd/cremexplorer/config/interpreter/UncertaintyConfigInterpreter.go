@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+type UncertaintyConfigInterpreter struct {
+	errors *compositeErrors.CompositeError
+
+	scenario scenario.Scenario
+}
+
+func NewUncertaintyConfigInterpreter() *UncertaintyConfigInterpreter {
+	interpreter := new(UncertaintyConfigInterpreter).initialise()
+	return interpreter
+}
+
+func (i *UncertaintyConfigInterpreter) initialise() *UncertaintyConfigInterpreter {
+	i.errors = compositeErrors.New("Uncertainty Configuration")
+	i.scenario = scenario.NullScenario
+	return i
+}
+
+// Interpret builds a scenario analysing the uncertainty of the solutions configured, saving its report as the
+// scenario configuration supplied directs. The scenario's random seed is used if the analysis has none of its own.
+func (i *UncertaintyConfigInterpreter) Interpret(config *appData.UncertaintyConfig,
+	scenarioConfig *appData.ScenarioConfig, logHandler logging.Logger) *UncertaintyConfigInterpreter {
+
+	if config.SolutionSetPath == "" {
+		i.errors.Add(errors.New("Missing mandatory uncertainty SolutionSetPath field"))
+	}
+
+	specification := config.Specification
+	if specification.RandomSeed == 0 {
+		specification.RandomSeed = scenarioConfig.RandomSeed
+	}
+
+	analysis, specificationError := specification.Analysis()
+	if specificationError != nil {
+		i.errors.Add(specificationError)
+		return i
+	}
+
+	i.scenario = scenario.NewUncertaintyScenario().
+		WithName(scenarioConfig.Name).
+		WithAnalysis(analysis).
+		WithSolutionSet(config.SolutionSetPath, config.Solutions...).
+		WithOutputPath(scenarioConfig.OutputPath).
+		WithJsonOutput(scenarioConfig.OutputType == appData.JsonOutput).
+		WithLogHandler(logHandler)
+
+	return i
+}
+
+func (i *UncertaintyConfigInterpreter) Scenario() scenario.Scenario {
+	return i.scenario
+}
+
+func (i *UncertaintyConfigInterpreter) Errors() error {
+	if i.errors.Size() > 0 {
+		return i.errors
+	}
+	return nil
+}
@@ -11,5 +11,6 @@ import (
 
 func main() {
 	args := commandline.ParseArguments()
+	bootstrap.ResumeFromCheckpointsIn(args.ResumeFrom)
 	bootstrap.RunExcelCompatibleScenarioFromConfigFile(args.ScenarioFile)
 }
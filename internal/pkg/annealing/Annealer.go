@@ -3,6 +3,9 @@
 package annealing
 
 import (
+	"context"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
@@ -38,3 +41,16 @@ type Annealer interface {
 type Cloneable interface {
 	DeepClone() Annealer
 }
+
+// Resumable is implemented by annealers that can periodically checkpoint their progress, and resume annealing from
+// a checkpoint taken earlier.
+type Resumable interface {
+	SetCheckpointer(checkpointer *checkpoint.Checkpointer)
+	ResumeFrom(checkpoint *checkpoint.Checkpoint)
+}
+
+// Cancellable is implemented by annealers that can be cancelled part-way through annealing, stopping at the end of their
+// current iteration once the supplied context is done.
+type Cancellable interface {
+	SetContext(ctx context.Context)
+}
@@ -3,11 +3,17 @@
 package annealers
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/null"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/termination"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/attributes"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/logging"
@@ -21,9 +27,13 @@ import (
 const (
 	Id               = "Id"
 	CurrentIteration = "CurrentIteration"
+	StoppingReason   = "StoppingReason"
+	Partial          = "Partial"
 )
 
 var _ observer.Observer = new(SimpleAnnealer)
+var _ annealing.Resumable = new(SimpleAnnealer)
+var _ annealing.Cancellable = new(SimpleAnnealer)
 
 type SimpleAnnealer struct {
 	name.IdentifiableContainer
@@ -40,6 +50,15 @@ type SimpleAnnealer struct {
 	maximumIterations uint64
 	currentIteration  uint64
 
+	termination    termination.Criteria
+	stoppingReason string
+
+	cancellation context.Context
+	cancelled    bool
+
+	checkpointer *checkpoint.Checkpointer
+	resumeFrom   *checkpoint.Checkpoint
+
 	baseAttributes attributes.Attributes
 }
 
@@ -51,8 +70,12 @@ func (sa *SimpleAnnealer) Initialise() {
 	sa.SetId("Simple Annealer")
 
 	sa.currentIteration = 0
+	sa.stoppingReason = ""
+	sa.cancellation = context.Background()
+	sa.cancelled = false
 
 	sa.parameters.Initialise()
+	sa.termination.Initialise()
 	sa.assignStateFromParameters()
 
 	sa.baseAttributes = new(attributes.Attributes).
@@ -79,6 +102,7 @@ func (sa *SimpleAnnealer) SetParameters(params parameters.Map) error {
 	sa.parameters.AssignOnlyEnforcedUserValues(params)
 
 	sa.SolutionExplorer().SetParameters(params)
+	sa.termination.SetParameters(params)
 
 	sa.assignStateFromParameters()
 
@@ -95,6 +119,7 @@ func (sa *SimpleAnnealer) ParameterErrors() error {
 	mergedErrors := compositeErrors.New("Kirkpatrick Explorer Parameter Validation")
 
 	mergedErrors.Add(sa.parameters.ValidationErrors())
+	mergedErrors.Add(sa.termination.ParameterErrors())
 	mergedErrors.Add(sa.SolutionExplorer().ParameterErrors())
 
 	if mergedErrors.Size() > 0 {
@@ -115,12 +140,29 @@ func (sa *SimpleAnnealer) Model() model.Model {
 	return sa.SolutionExplorer().Model()
 }
 
+func (sa *SimpleAnnealer) SetCheckpointer(checkpointer *checkpoint.Checkpointer) {
+	sa.checkpointer = checkpointer
+}
+
+func (sa *SimpleAnnealer) ResumeFrom(checkpoint *checkpoint.Checkpoint) {
+	sa.resumeFrom = checkpoint
+}
+
+// SetContext has annealing stop at the end of its current iteration once the supplied context is done, with whatever
+// solutions it has found so far reported as partial.
+func (sa *SimpleAnnealer) SetContext(ctx context.Context) {
+	sa.cancellation = ctx
+}
+
 func (sa *SimpleAnnealer) Anneal() {
 	defer sa.handlePanicRecovery()
 
+	sa.seedFromCheckpoint()
 	sa.SolutionExplorer().Initialise()
 	defer sa.SolutionExplorer().TearDown()
 
+	sa.restoreFromCheckpoint()
+	sa.termination.Start(sa.currentIteration)
 	sa.annealingStarted()
 
 	for done := sa.initialDoneValue(); !done; {
@@ -130,10 +172,67 @@ func (sa *SimpleAnnealer) Anneal() {
 		sa.SolutionExplorer().CoolDown()
 
 		sa.iterationFinished()
+		sa.checkpointIfDue()
 		done = sa.checkIfDone()
 	}
 
 	sa.annealingFinished()
+	sa.saveCheckpoint(!sa.cancelled)
+}
+
+func (sa *SimpleAnnealer) seedFromCheckpoint() {
+	if sa.resumeFrom == nil {
+		return
+	}
+	if seededExplorer, explorerIsSeeded := sa.SolutionExplorer().(rand.Seeded); explorerIsSeeded {
+		seededExplorer.SetRandomSeed(sa.resumeFrom.RandomSeed)
+	}
+}
+
+func (sa *SimpleAnnealer) restoreFromCheckpoint() {
+	if sa.resumeFrom == nil {
+		return
+	}
+
+	checkpointableExplorer, explorerIsCheckpointable := sa.SolutionExplorer().(explorer.Checkpointable)
+	if !explorerIsCheckpointable {
+		sa.LogHandler().Warn(sa.Id() + ": explorer cannot be resumed from a checkpoint, starting afresh")
+		return
+	}
+
+	sa.currentIteration = sa.resumeFrom.CurrentIteration
+	checkpointableExplorer.RestoreState(sa.resumeFrom.Explorer)
+
+	sa.LogHandler().Info(fmt.Sprintf("%s: resumed from checkpoint at iteration [%d]", sa.Id(), sa.currentIteration))
+}
+
+func (sa *SimpleAnnealer) checkpointIfDue() {
+	if sa.checkpointer != nil && sa.checkpointer.IsDue(sa.currentIteration) {
+		sa.saveCheckpoint(false)
+	}
+}
+
+func (sa *SimpleAnnealer) saveCheckpoint(finished bool) {
+	if sa.checkpointer == nil {
+		return
+	}
+
+	checkpointableExplorer, explorerIsCheckpointable := sa.SolutionExplorer().(explorer.Checkpointable)
+	if !explorerIsCheckpointable {
+		return
+	}
+
+	newCheckpoint := checkpoint.New(sa.Id())
+	newCheckpoint.Finished = finished
+	newCheckpoint.CurrentIteration = sa.currentIteration
+	if seededExplorer, explorerIsSeeded := sa.SolutionExplorer().(rand.Seeded); explorerIsSeeded {
+		newCheckpoint.RandomSeed = seededExplorer.RandomSeed()
+	}
+	checkpointableExplorer.CaptureState(&newCheckpoint.Explorer)
+
+	if saveError := sa.checkpointer.Save(newCheckpoint); saveError != nil {
+		sa.LogHandler().Error(errors.Wrap(saveError, sa.Id()+": checkpoint not saved"))
+	}
 }
 
 func (sa *SimpleAnnealer) handlePanicRecovery() {
@@ -160,10 +259,12 @@ func (sa *SimpleAnnealer) iterationStarted() {
 
 func (sa *SimpleAnnealer) iterationFinished() {
 	event := sa.newEvent(observer.FinishedIteration)
+	sa.termination.Observe(sa.currentIteration, event.AllAttributes())
 	sa.EventNotifier().NotifyObserversOfEvent(*event)
 }
 
 func (sa *SimpleAnnealer) annealingFinished() {
+	sa.LogHandler().Info(sa.Id() + ": annealing stopped: " + sa.stoppingReason)
 	event := sa.newEvent(observer.FinishedAnnealing)
 	sa.EventNotifier().NotifyObserversOfEvent(*event)
 }
@@ -188,17 +289,34 @@ func (sa *SimpleAnnealer) EventAttributes(eventType observer.EventType) attribut
 	case observer.FinishedAnnealing:
 		return sa.baseAttributes.
 			Add(CurrentIteration, sa.currentIteration).
+			Add(StoppingReason, sa.stoppingReason).
+			Add(Partial, sa.cancelled).
 			Join(sa.SolutionExplorer().EventAttributes(eventType))
 	}
 	return nil
 }
 
 func (sa *SimpleAnnealer) initialDoneValue() bool {
-	return sa.parameters.GetInt64(MaximumIterations) == 0
+	return sa.checkIfDone()
 }
 
+// checkIfDone reports whether annealing should stop, noting why: either MaximumIterations have been run, annealing has
+// been cancelled, or one of the termination criteria has been met.
 func (sa *SimpleAnnealer) checkIfDone() bool {
-	return sa.currentIteration >= uint64(sa.parameters.GetInt64(MaximumIterations))
+	if sa.currentIteration >= uint64(sa.parameters.GetInt64(MaximumIterations)) {
+		sa.stoppingReason = termination.MaximumIterationsReached
+		return true
+	}
+	if sa.cancellation != nil && sa.cancellation.Err() != nil {
+		sa.stoppingReason = termination.Cancelled
+		sa.cancelled = true
+		return true
+	}
+	if sa.termination.IsMet() {
+		sa.stoppingReason = sa.termination.Reason()
+		return true
+	}
+	return false
 }
 
 func (sa *SimpleAnnealer) AddObserver(observer observer.Observer) error {
@@ -3,12 +3,25 @@
 package annealers
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
+	kirkpatrickCoolant "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
+	suppapitnarmCoolant "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/null"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/termination"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/pkg/attributes"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	. "github.com/onsi/gomega"
 )
@@ -93,6 +106,195 @@ func TestSimpleAnnealer_Anneal(t *testing.T) {
 	actualAfterCurrentIteration := afterAttributes.Value(CurrentIteration).(uint64)
 
 	g.Expect(actualAfterCurrentIteration).To(BeNumerically("==", iterations))
+	g.Expect(afterAttributes.Value(StoppingReason)).To(Equal(termination.MaximumIterationsReached))
+}
+
+func TestSimpleAnnealer_TerminationCriterionMet_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetSolutionExplorer(&halvingTemperatureExplorer{temperature: 1})
+
+	parameterError := annealer.SetParameters(parameters.Map{
+		MaximumIterations:               int64(100),
+		termination.StoppingTemperature: float64(0.1),
+	})
+	g.Expect(parameterError).To(BeNil())
+
+	// when
+	annealer.Anneal()
+
+	// then
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", 4))
+	g.Expect(afterAttributes.Value(StoppingReason)).To(Equal("Temperature fell below [0.1]"))
+	g.Expect(afterAttributes.Value(Partial)).To(BeFalse())
+}
+
+func TestSimpleAnnealer_Cancelled_StopsAtEndOfIteration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{MaximumIterations: int64(100)})
+
+	cancellation, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	annealer.SetContext(cancellation)
+
+	const cancellingIteration = uint64(3)
+	annealer.AddObserver(&cancellingObserver{iteration: cancellingIteration, cancel: cancel})
+
+	// when
+	annealer.Anneal()
+
+	// then
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", cancellingIteration))
+	g.Expect(afterAttributes.Value(StoppingReason)).To(Equal(termination.Cancelled))
+	g.Expect(afterAttributes.Value(Partial)).To(BeTrue())
+}
+
+const (
+	resumableRunId           = "Resumable Run"
+	resumableRandomSeed      = int64(1234567)
+	checkpointedIteration    = uint64(133)
+	resumableIterations      = uint64(400)
+	resumableModelDataPath   = "../../model/models/catchment/testdata/RoutedModel.csv"
+	resumableObjective       = "ParticulateNitrogen"
+	resumableSecondObjective = "DissolvedNitrogen"
+
+	resumableStartingTemperature = float64(1)
+	resumableCoolingFactor       = float64(0.99)
+)
+
+func TestSimpleAnnealer_ResumedKirkpatrickRun_FinishesAsUninterrupted(t *testing.T) {
+	newExplorer := func() explorer.Explorer {
+		return kirkpatrick.New().
+			WithModel(buildResumableModel(t)).
+			WithParameters(parameters.Map{
+				kirkpatrick.DecisionVariableName:       resumableObjective,
+				kirkpatrickCoolant.StartingTemperature: resumableStartingTemperature,
+				kirkpatrickCoolant.CoolingFactor:       resumableCoolingFactor,
+			})
+	}
+	verifyResumedRunFinishesAsUninterrupted(t, newExplorer)
+}
+
+func TestSimpleAnnealer_ResumedSuppapitnarmRun_FinishesAsUninterrupted(t *testing.T) {
+	newExplorer := func() explorer.Explorer {
+		return suppapitnarm.New().
+			WithModel(buildResumableModel(t)).
+			WithParameters(parameters.Map{
+				suppapitnarm.OptimisationDirections: map[string]interface{}{
+					resumableObjective:       "Minimising",
+					resumableSecondObjective: "Minimising",
+				},
+				suppapitnarmCoolant.StartingTemperature: resumableStartingTemperature,
+				suppapitnarmCoolant.CoolingFactor:       resumableCoolingFactor,
+			})
+	}
+	verifyResumedRunFinishesAsUninterrupted(t, newExplorer)
+}
+
+func verifyResumedRunFinishesAsUninterrupted(t *testing.T, newExplorer func() explorer.Explorer) {
+	g := NewGomegaWithT(t)
+
+	// given
+	uninterruptedDirectory := t.TempDir()
+	uninterruptedModel := runResumableAnnealer(g, newExplorer(), uninterruptedDirectory, resumableIterations, nil)
+
+	interruptedDirectory := t.TempDir()
+	runResumableAnnealer(g, newExplorer(), interruptedDirectory, checkpointedIteration, nil)
+
+	interruptedCheckpoint, loadError := checkpoint.Load(checkpoint.FilePath(interruptedDirectory, resumableRunId))
+	g.Expect(loadError).To(BeNil())
+	g.Expect(interruptedCheckpoint.CurrentIteration).To(BeNumerically("==", checkpointedIteration))
+
+	// when
+	resumedDirectory := t.TempDir()
+	resumedModel := runResumableAnnealer(g, newExplorer(), resumedDirectory, resumableIterations, interruptedCheckpoint)
+
+	// then
+	uninterruptedCheckpoint, uninterruptedLoadError := checkpoint.Load(checkpoint.FilePath(uninterruptedDirectory, resumableRunId))
+	g.Expect(uninterruptedLoadError).To(BeNil())
+
+	resumedCheckpoint, resumedLoadError := checkpoint.Load(checkpoint.FilePath(resumedDirectory, resumableRunId))
+	g.Expect(resumedLoadError).To(BeNil())
+
+	g.Expect(resumedCheckpoint.CurrentIteration).To(BeNumerically("==", resumableIterations))
+	g.Expect(resumedCheckpoint.Explorer).To(Equal(uninterruptedCheckpoint.Explorer))
+	g.Expect(decisionVariableValuesOf(resumedModel)).To(Equal(decisionVariableValuesOf(uninterruptedModel)))
+}
+
+func decisionVariableValuesOf(valuedModel model.Model) map[string]float64 {
+	values := make(map[string]float64)
+	for name, decisionVariable := range *valuedModel.DecisionVariables() {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+func runResumableAnnealer(g *GomegaWithT, explorerToRun explorer.Explorer, directory string, iterations uint64,
+	resumeFrom *checkpoint.Checkpoint) model.Model {
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+
+	explorerToRun.(rand.Seeded).SetRandomSeed(resumableRandomSeed)
+	g.Expect(annealer.SetSolutionExplorer(explorerToRun)).To(BeNil())
+	annealer.SetId(resumableRunId)
+	annealer.SetLogHandler(new(DummyLogHandler))
+	g.Expect(annealer.SetParameters(parameters.Map{MaximumIterations: int64(iterations)})).To(BeNil())
+
+	annealer.SetCheckpointer(checkpoint.NewCheckpointer(directory, checkpointedIteration))
+	if resumeFrom != nil {
+		annealer.ResumeFrom(resumeFrom)
+	}
+
+	annealer.Anneal()
+	return explorerToRun.Model()
+}
+
+func buildResumableModel(t *testing.T) *catchment.Model {
+	g := NewGomegaWithT(t)
+
+	resumableModel := catchment.NewModel().WithParameters(parameters.Map{
+		catchmentParameters.DataSourcePath:           resumableModelDataPath,
+		catchmentParameters.ReachDeliveryRatio:       0.7,
+		catchmentParameters.SwapMoveProbability:      0.2,
+		catchmentParameters.IntensityMoveProbability: 0.3,
+	})
+	g.Expect(resumableModel.ParameterErrors()).To(BeNil())
+
+	resumableModel.Initialise(model.AsIs)
+	return resumableModel
+}
+
+type cancellingObserver struct {
+	iteration uint64
+	cancel    context.CancelFunc
+}
+
+func (co *cancellingObserver) ObserveEvent(event observer.Event) {
+	if event.EventType == observer.StartedIteration && event.Attribute(CurrentIteration) == co.iteration {
+		co.cancel()
+	}
+}
+
+type halvingTemperatureExplorer struct {
+	null.Explorer
+	temperature float64
+}
+
+func (e *halvingTemperatureExplorer) CoolDown() {
+	e.temperature /= 2
+}
+
+func (e *halvingTemperatureExplorer) EventAttributes(eventType observer.EventType) attributes.Attributes {
+	return attributes.Attributes{}.Add(explorer.Temperature, e.temperature)
 }
 
 func TestSimpleAnnealer_AddObserver(t *testing.T) {
@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package checkpoint offers the capture, storage and retrieval of an annealing run's state part-way through the run,
+// so that a run that is interrupted can later be resumed from where it was up to.
+package checkpoint
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/move"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	baseArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	"github.com/pkg/errors"
+)
+
+// Keys of the random number generator states an explorer records in its ExplorerState.
+const (
+	CoolantRandomState        = "Coolant"
+	ModelRandomState          = "Model"
+	PotentialModelRandomState = "PotentialModel"
+	ArchiveRandomState        = "Archive"
+)
+
+// Checkpoint is the state of an annealing run, as at the end of its CurrentIteration.
+type Checkpoint struct {
+	RunId            string
+	Finished         bool
+	CurrentIteration uint64
+	RandomSeed       int64
+	Explorer         ExplorerState
+}
+
+func New(runId string) *Checkpoint {
+	newCheckpoint := &Checkpoint{RunId: runId}
+	newCheckpoint.Explorer.RandomStates = make(map[string]rand.State)
+	return newCheckpoint
+}
+
+// ExplorerState is the state a solution explorer needs to resume exploring. Entries not relevant to a given explorer
+// are left empty.
+type ExplorerState struct {
+	Temperature           float64
+	AcceptanceProbability float64
+	CoolingSchedule       schedules.State
+	BestObjectiveValue    float64 `json:",omitempty"`
+
+	ModelEncoding  string
+	RandomStates   map[string]rand.State
+	MoveStatistics move.StatisticsState
+
+	CurrentIteration              uint64       `json:",omitempty"`
+	LastReturnedToBase            uint64       `json:",omitempty"`
+	ReturnToBaseStep              float64      `json:",omitempty"`
+	IterationsUntilReturnToBase   uint64       `json:",omitempty"`
+	ReturnToBaseIsolationFraction float64      `json:",omitempty"`
+	Archive                       []ModelState `json:",omitempty"`
+}
+
+// ArchivedModelStates returns the archive.CompressedModelState entries of the state's Archive, for the model they
+// describe.
+func (s ExplorerState) ArchivedModelStates(describedModel model.Model) ([]*archive.CompressedModelState, error) {
+	actionNumber := len(describedModel.ManagementActions())
+	directions := archive.DirectionsOf(describedModel)
+
+	archivedStates := make([]*archive.CompressedModelState, len(s.Archive))
+	for index, modelState := range s.Archive {
+		compressedState, decodeError := modelState.CompressedModelState(actionNumber)
+		if decodeError != nil {
+			return nil, decodeError
+		}
+		compressedState.Directions = directions
+		archivedStates[index] = compressedState
+	}
+	return archivedStates, nil
+}
+
+// ModelState is a storable form of an archive.CompressedModelState.
+type ModelState struct {
+	Encoding  string
+	Variables []float64
+}
+
+func NewModelState(compressedState *archive.CompressedModelState) ModelState {
+	return ModelState{
+		Encoding:  compressedState.Encoding(),
+		Variables: compressedState.Variables,
+	}
+}
+
+// CompressedModelState returns the archive.CompressedModelState the model state was taken from, given the number of
+// management actions of the model it describes.
+func (s ModelState) CompressedModelState(actionNumber int) (*archive.CompressedModelState, error) {
+	compressedState := &archive.CompressedModelState{
+		Variables: make(dominance.Float64Vector, len(s.Variables)),
+		Actions:   *baseArchive.New(actionNumber),
+	}
+	copy(compressedState.Variables, s.Variables)
+
+	if decodeError := compressedState.Decode(s.Encoding); decodeError != nil {
+		return nil, errors.Wrap(decodeError, "decoding checkpoint model state ["+s.Encoding+"]")
+	}
+	return compressedState, nil
+}
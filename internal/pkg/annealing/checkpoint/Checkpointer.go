@@ -0,0 +1,80 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package checkpoint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	fileExtension = ".checkpoint.json"
+
+	newLinePrefix = ""
+	indent        = "  "
+)
+
+// Checkpointer periodically saves the checkpoints of annealing runs as JSON files in a directory. Each run has its
+// own file, with each new checkpoint for the run replacing the last.
+type Checkpointer struct {
+	directory string
+	interval  uint64
+}
+
+func NewCheckpointer(directory string, interval uint64) *Checkpointer {
+	return &Checkpointer{directory: directory, interval: interval}
+}
+
+// IsDue reports whether a checkpoint should be saved at the end of the supplied iteration.
+func (c *Checkpointer) IsDue(iteration uint64) bool {
+	return c.interval > 0 && iteration%c.interval == 0
+}
+
+// Save writes the checkpoint to its run's file, via a temporary file so that an interruption part-way through
+// writing leaves the previous checkpoint intact.
+func (c *Checkpointer) Save(checkpoint *Checkpoint) error {
+	marshaledCheckpoint, marshalError := json.MarshalIndent(checkpoint, newLinePrefix, indent)
+	if marshalError != nil {
+		return errors.Wrap(marshalError, "marshaling checkpoint")
+	}
+
+	if mkDirError := os.MkdirAll(c.directory, os.ModePerm); mkDirError != nil {
+		return errors.Wrap(mkDirError, "creating checkpoint directory")
+	}
+
+	filePath := FilePath(c.directory, checkpoint.RunId)
+	temporaryFilePath := filePath + ".tmp"
+
+	if writeError := os.WriteFile(temporaryFilePath, marshaledCheckpoint, 0666); writeError != nil {
+		return errors.Wrap(writeError, "writing checkpoint")
+	}
+	if renameError := os.Rename(temporaryFilePath, filePath); renameError != nil {
+		return errors.Wrap(renameError, "replacing checkpoint")
+	}
+	return nil
+}
+
+// FilePath returns the path of the checkpoint file for the identified run within the supplied directory.
+func FilePath(directory string, runId string) string {
+	safeId := strings.Replace(runId, " ", "", -1)
+	safeId = strings.Replace(safeId, "/", "_of_", -1)
+	return filepath.Join(directory, safeId+fileExtension)
+}
+
+// Load reads the checkpoint stored in the supplied file.
+func Load(filePath string) (*Checkpoint, error) {
+	marshaledCheckpoint, readError := os.ReadFile(filePath)
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading checkpoint")
+	}
+
+	checkpoint := New("")
+	if unmarshalError := json.Unmarshal(marshaledCheckpoint, checkpoint); unmarshalError != nil {
+		return nil, errors.Wrap(unmarshalError, "unmarshaling checkpoint ["+filePath+"]")
+	}
+	return checkpoint, nil
+}
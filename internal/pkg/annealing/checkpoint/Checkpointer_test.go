@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package checkpoint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/move"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	baseArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckpointer_IsDue(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	checkpointer := NewCheckpointer(t.TempDir(), 100)
+
+	g.Expect(checkpointer.IsDue(99)).To(BeFalse())
+	g.Expect(checkpointer.IsDue(100)).To(BeTrue())
+	g.Expect(checkpointer.IsDue(200)).To(BeTrue())
+
+	disabledCheckpointer := NewCheckpointer(t.TempDir(), 0)
+	g.Expect(disabledCheckpointer.IsDue(100)).To(BeFalse())
+}
+
+func TestFilePath_RunIdMadeFileSafe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	actualPath := FilePath("output", "Some Scenario (2/4)")
+	expectedPath := filepath.Join("output", "SomeScenario(2_of_4).checkpoint.json")
+
+	g.Expect(actualPath).To(Equal(expectedPath))
+}
+
+func TestCheckpointer_SaveThenLoad_SameCheckpoint(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	directory := t.TempDir()
+	checkpointer := NewCheckpointer(directory, 10)
+
+	expectedCheckpoint := New("Test Run")
+	expectedCheckpoint.CurrentIteration = 20
+	expectedCheckpoint.RandomSeed = 1234567
+	expectedCheckpoint.Explorer.Temperature = 42.5
+	expectedCheckpoint.Explorer.BestObjectiveValue = 12.25
+	expectedCheckpoint.Explorer.ModelEncoding = "abc"
+	expectedCheckpoint.Explorer.RandomStates[CoolantRandomState] = rand.State{Seed: 1, Draws: 2}
+	expectedCheckpoint.Explorer.Archive = []ModelState{{Encoding: "abc", Variables: []float64{1, 2}}}
+	expectedCheckpoint.Explorer.MoveStatistics = move.StatisticsState{
+		Attempted: map[move.Type]uint64{move.SwapType: 3, move.KFlipType: 1},
+		Accepted:  map[move.Type]uint64{move.SwapType: 2},
+	}
+
+	// when
+	saveError := checkpointer.Save(expectedCheckpoint)
+	actualCheckpoint, loadError := Load(FilePath(directory, "Test Run"))
+
+	// then
+	g.Expect(saveError).To(BeNil())
+	g.Expect(loadError).To(BeNil())
+	g.Expect(actualCheckpoint).To(Equal(expectedCheckpoint))
+}
+
+func TestLoad_MissingFile_Error(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, loadError := Load(FilePath(t.TempDir(), "Missing Run"))
+
+	g.Expect(loadError).To(Not(BeNil()))
+}
+
+func TestModelState_CompressedModelState_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const actionNumber = 70
+
+	expectedState := &archive.CompressedModelState{
+		Variables: dominance.Float64Vector{1.5, -2.5},
+		Actions:   *baseArchive.New(actionNumber),
+	}
+	expectedState.Actions.SetValue(3, true)
+	expectedState.Actions.SetValue(67, true)
+
+	// when
+	actualState, decodeError := NewModelState(expectedState).CompressedModelState(actionNumber)
+
+	// then
+	g.Expect(decodeError).To(BeNil())
+	g.Expect(actualState.Variables).To(Equal(expectedState.Variables))
+	g.Expect(actualState.Encoding()).To(Equal(expectedState.Encoding()))
+}
@@ -3,6 +3,7 @@
 package cooling
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
 )
@@ -16,10 +17,23 @@ type TemperatureCoolant interface {
 
 	DecideIfAcceptable(variableChanges []float64) bool
 
+	// AcceptanceProbabilityAt returns the probability of accepting an undesirable change, with the variable changes
+	// supplied, at the temperature supplied, leaving the coolant's own state untouched.
+	AcceptanceProbabilityAt(variableChanges []float64, temperature float64) float64
+
+	SetCoolingFactor(coolingFactor float64)
 	CoolingFactor() float64
 
 	SetAcceptanceProbability(acceptanceProbability float64)
 	AcceptanceProbability() float64
 
+	// ObserveOutcome passes the outcome of the latest iteration to the coolant's schedule, ahead of cooling down after it.
+	ObserveOutcome(outcome schedules.Outcome)
 	CoolDown()
+
+	// ScheduleState returns the progress of the coolant's schedule, for checkpointing.
+	ScheduleState() schedules.State
+	RestoreScheduleState(state schedules.State)
+
+	DeepClone() TemperatureCoolant
 }
@@ -0,0 +1,214 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package calibration derives coolant settings from the changes in decision variable values that a model's random
+// changes produce, sparing users from hand-tuning a StartingTemperature (and CoolingFactor) for every new data set.
+package calibration
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/pkg/errors"
+)
+
+const (
+	bracketingSteps = 64
+	bisectionSteps  = 64
+)
+
+// AcceptanceFunction returns the probability of a coolant accepting an undesirable change, with the decision variable
+// changes supplied, at the temperature supplied.
+type AcceptanceFunction func(variableChanges []float64, temperature float64) float64
+
+// UndesirabilityTest reports whether a change, with the decision variable changes supplied, is one a coolant would be
+// asked to decide the acceptance of. Only such changes inform calibration.
+type UndesirabilityTest func(variableChanges []float64) bool
+
+// Result holds the coolant settings derived by calibration.
+type Result struct {
+	Samples                 int
+	StartingTemperature     float64
+	CoolingFactor           float64
+	CoolingFactorCalibrated bool
+}
+
+func (r Result) String() string {
+	text := fmt.Sprintf("StartingTemperature [%g]", r.StartingTemperature)
+	if r.CoolingFactorCalibrated {
+		text += fmt.Sprintf(", CoolingFactor [%g]", r.CoolingFactor)
+	}
+	return text + fmt.Sprintf(" from [%d] undesirable changes sampled", r.Samples)
+}
+
+type Calibrator struct {
+	parameters    Parameters
+	acceptance    AcceptanceFunction
+	isUndesirable UndesirabilityTest
+}
+
+func New() *Calibrator {
+	return new(Calibrator).Initialise()
+}
+
+func (c *Calibrator) Initialise() *Calibrator {
+	c.parameters.Initialise()
+	c.isUndesirable = HasAnyChange
+	return c
+}
+
+func (c *Calibrator) WithParameters(params parameters.Map) *Calibrator {
+	c.SetParameters(params)
+	return c
+}
+
+func (c *Calibrator) WithAcceptanceFunction(acceptance AcceptanceFunction) *Calibrator {
+	c.acceptance = acceptance
+	return c
+}
+
+func (c *Calibrator) WithUndesirabilityTest(isUndesirable UndesirabilityTest) *Calibrator {
+	c.isUndesirable = isUndesirable
+	return c
+}
+
+func (c *Calibrator) SetParameters(params parameters.Map) error {
+	c.parameters.AssignOnlyEnforcedUserValues(params)
+	if c.IsEnabled() {
+		c.checkTargets()
+	}
+	return c.parameters.ValidationErrors()
+}
+
+func (c *Calibrator) checkTargets() {
+	initialAcceptance := c.parameters.GetFloat64(TargetInitialAcceptance)
+	finalAcceptance := c.parameters.GetFloat64(TargetFinalAcceptance)
+
+	if initialAcceptance <= 0 || initialAcceptance >= 1 {
+		c.parameters.AddValidationErrorMessage("Parameter [" + TargetInitialAcceptance + "] must be greater than 0 and less than 1")
+	}
+
+	if finalAcceptance == 0 {
+		return
+	}
+	if finalAcceptance >= initialAcceptance {
+		c.parameters.AddValidationErrorMessage("Parameter [" + TargetFinalAcceptance + "] must be less than [" + TargetInitialAcceptance + "]")
+	}
+	if c.parameters.GetInt64(MaximumIterations) == 0 {
+		c.parameters.AddValidationErrorMessage("Parameter [" + TargetFinalAcceptance + "] requires [" + MaximumIterations + "] to be greater than 0")
+	}
+}
+
+func (c *Calibrator) ParameterErrors() error {
+	return c.parameters.ValidationErrors()
+}
+
+// IsEnabled reports whether a number of CalibrationSamples has been asked for.
+func (c *Calibrator) IsEnabled() bool {
+	return c.parameters.GetInt64(CalibrationSamples) > 0
+}
+
+// Calibrate tries CalibrationSamples random changes of the model supplied, reverting each, and derives the starting
+// temperature at which the mean acceptance probability of the undesirable changes sampled matches
+// TargetInitialAcceptance. If a TargetFinalAcceptance is given, it also derives the cooling factor that brings the
+// temperature down to where that acceptance is expected after MaximumIterations.
+func (c *Calibrator) Calibrate(sampledModel model.Model, variableNames []string) (Result, error) {
+	samples := c.sampleUndesirableChanges(sampledModel, variableNames)
+	if len(samples) == 0 {
+		return Result{}, errors.New("no undesirable changes found to calibrate coolant with")
+	}
+
+	startingTemperature := c.temperatureFor(samples, c.parameters.GetFloat64(TargetInitialAcceptance))
+	result := Result{Samples: len(samples), StartingTemperature: startingTemperature}
+
+	if finalAcceptance := c.parameters.GetFloat64(TargetFinalAcceptance); finalAcceptance > 0 {
+		finalTemperature := c.temperatureFor(samples, finalAcceptance)
+		iterations := float64(c.parameters.GetInt64(MaximumIterations))
+
+		result.CoolingFactor = math.Pow(finalTemperature/startingTemperature, 1/iterations)
+		result.CoolingFactorCalibrated = true
+	}
+
+	return result, nil
+}
+
+func (c *Calibrator) sampleUndesirableChanges(sampledModel model.Model, variableNames []string) [][]float64 {
+	sampleSize := c.parameters.GetInt64(CalibrationSamples)
+	samples := make([][]float64, 0, sampleSize)
+
+	for sample := int64(0); sample < sampleSize; sample++ {
+		sampledModel.TryRandomChange()
+		if isValid, _ := sampledModel.ChangeIsValid(); isValid {
+			variableChanges := changesOf(sampledModel, variableNames)
+			if c.isUndesirable(variableChanges) {
+				samples = append(samples, variableChanges)
+			}
+		}
+		sampledModel.RevertChange()
+	}
+
+	return samples
+}
+
+func changesOf(changedModel model.Model, variableNames []string) []float64 {
+	variableChanges := make([]float64, len(variableNames))
+	for index, name := range variableNames {
+		variableChanges[index] = changedModel.DecisionVariableChange(name)
+	}
+	return variableChanges
+}
+
+// temperatureFor brackets, then bisects (geometrically) for, the temperature at which the mean acceptance probability
+// of the samples matches the target supplied. Acceptance probabilities rise monotonically with temperature.
+func (c *Calibrator) temperatureFor(samples [][]float64, targetAcceptance float64) float64 {
+	lower := meanMagnitudeOf(samples)
+	upper := lower
+
+	for step := 0; step < bracketingSteps && c.meanAcceptance(samples, upper) < targetAcceptance; step++ {
+		upper *= 2
+	}
+	for step := 0; step < bracketingSteps && c.meanAcceptance(samples, lower) > targetAcceptance; step++ {
+		lower /= 2
+	}
+
+	for step := 0; step < bisectionSteps; step++ {
+		middle := math.Sqrt(lower * upper)
+		if c.meanAcceptance(samples, middle) < targetAcceptance {
+			lower = middle
+		} else {
+			upper = middle
+		}
+	}
+
+	return math.Sqrt(lower * upper)
+}
+
+func (c *Calibrator) meanAcceptance(samples [][]float64, temperature float64) float64 {
+	totalAcceptance := float64(0)
+	for _, variableChanges := range samples {
+		totalAcceptance += c.acceptance(variableChanges, temperature)
+	}
+	return totalAcceptance / float64(len(samples))
+}
+
+func meanMagnitudeOf(samples [][]float64) float64 {
+	totalMagnitude := float64(0)
+	for _, variableChanges := range samples {
+		for _, change := range variableChanges {
+			totalMagnitude += math.Abs(change)
+		}
+	}
+	return totalMagnitude / float64(len(samples))
+}
+
+// HasAnyChange is the default UndesirabilityTest, treating every change of any decision variable value as one a coolant
+// may be asked to decide the acceptance of.
+func HasAnyChange(variableChanges []float64) bool {
+	for _, change := range variableChanges {
+		if change != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package calibration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+const objectiveUnderTest = "Objective_0"
+
+func TestCalibrator_NoSamples_Disabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	calibratorUnderTest := New().WithParameters(parameters.Map{})
+
+	// then
+	g.Expect(calibratorUnderTest.IsEnabled()).To(BeFalse())
+	g.Expect(calibratorUnderTest.ParameterErrors()).To(BeNil())
+}
+
+func TestCalibrator_FinalAcceptanceAboveInitial_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	calibratorUnderTest := New().WithParameters(
+		parameters.Map{
+			CalibrationSamples:      int64(100),
+			TargetInitialAcceptance: float64(0.5),
+			TargetFinalAcceptance:   float64(0.6),
+			MaximumIterations:       int64(1000),
+		},
+	)
+
+	// then
+	g.Expect(calibratorUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(calibratorUnderTest.ParameterErrors().Error()).To(ContainSubstring("must be less than [" + TargetInitialAcceptance + "]"))
+}
+
+func TestCalibrator_FinalAcceptanceWithoutIterations_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	calibratorUnderTest := New().WithParameters(
+		parameters.Map{
+			CalibrationSamples:    int64(100),
+			TargetFinalAcceptance: float64(0.01),
+		},
+	)
+
+	// then
+	g.Expect(calibratorUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(calibratorUnderTest.ParameterErrors().Error()).To(ContainSubstring("requires [" + MaximumIterations + "]"))
+}
+
+func TestCalibrator_Calibrate_StartingTemperatureMatchesTargetAcceptance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sampledModel := buildTestModel()
+	calibratorUnderTest := New().
+		WithParameters(
+			parameters.Map{
+				CalibrationSamples:      int64(50),
+				TargetInitialAcceptance: float64(0.8),
+			},
+		).
+		WithAcceptanceFunction(suppapitnarm.NewCoolant().AcceptanceProbabilityAt)
+
+	// when
+	result, calibrationError := calibratorUnderTest.Calibrate(sampledModel, []string{objectiveUnderTest})
+
+	// then
+	g.Expect(calibrationError).To(BeNil())
+	g.Expect(result.Samples).To(BeNumerically(">", 0))
+	g.Expect(result.Samples).To(BeNumerically("<=", 50))
+	g.Expect(result.CoolingFactorCalibrated).To(BeFalse())
+
+	// every dumb model change alters the objective by exactly 1
+	expectedTemperature := -1 / math.Log(0.8)
+	g.Expect(result.StartingTemperature).To(BeNumerically("~", expectedTemperature, 1e-9))
+}
+
+func TestCalibrator_Calibrate_CoolingFactorMatchesTargetFinalAcceptance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const iterations = 1000
+	sampledModel := buildTestModel()
+	calibratorUnderTest := New().
+		WithParameters(
+			parameters.Map{
+				CalibrationSamples:      int64(50),
+				TargetInitialAcceptance: float64(0.8),
+				TargetFinalAcceptance:   float64(0.01),
+				MaximumIterations:       int64(iterations),
+			},
+		).
+		WithAcceptanceFunction(suppapitnarm.NewCoolant().AcceptanceProbabilityAt)
+
+	// when
+	result, calibrationError := calibratorUnderTest.Calibrate(sampledModel, []string{objectiveUnderTest})
+
+	// then
+	g.Expect(calibrationError).To(BeNil())
+	g.Expect(result.CoolingFactorCalibrated).To(BeTrue())
+
+	finalTemperature := result.StartingTemperature * math.Pow(result.CoolingFactor, iterations)
+	expectedFinalTemperature := -1 / math.Log(0.01)
+	g.Expect(finalTemperature).To(BeNumerically("~", expectedFinalTemperature, 1e-6))
+}
+
+func TestCalibrator_Calibrate_ModelLeftUnchanged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sampledModel := buildTestModel()
+	valueBefore := sampledModel.DecisionVariable(objectiveUnderTest).Value()
+
+	calibratorUnderTest := New().
+		WithParameters(parameters.Map{CalibrationSamples: int64(50)}).
+		WithAcceptanceFunction(suppapitnarm.NewCoolant().AcceptanceProbabilityAt)
+
+	// when
+	calibratorUnderTest.Calibrate(sampledModel, []string{objectiveUnderTest})
+
+	// then
+	g.Expect(sampledModel.DecisionVariable(objectiveUnderTest).Value()).To(BeNumerically("==", valueBefore))
+	g.Expect(sampledModel.ActiveManagementActions()).To(BeEmpty())
+}
+
+func TestCalibrator_Calibrate_NoUndesirableChanges_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	calibratorUnderTest := New().
+		WithParameters(parameters.Map{CalibrationSamples: int64(50)}).
+		WithAcceptanceFunction(suppapitnarm.NewCoolant().AcceptanceProbabilityAt).
+		WithUndesirabilityTest(func(variableChanges []float64) bool { return false })
+
+	// when
+	_, calibrationError := calibratorUnderTest.Calibrate(buildTestModel(), []string{objectiveUnderTest})
+
+	// then
+	g.Expect(calibrationError).To(Not(BeNil()))
+}
+
+func buildTestModel() *modumb.Model {
+	newModel := modumb.NewModel()
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
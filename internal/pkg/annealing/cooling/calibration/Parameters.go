@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package calibration
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+type Parameters struct {
+	parameters.Parameters
+}
+
+func (p *Parameters) Initialise() *Parameters {
+	p.Parameters.
+		Initialise("Coolant Calibration Parameter Validation").
+		Enforcing(ParameterSpecifications())
+	return p
+}
+
+const (
+	CalibrationSamples      = "CalibrationSamples"
+	TargetInitialAcceptance = "TargetInitialAcceptance"
+	TargetFinalAcceptance   = "TargetFinalAcceptance"
+	MaximumIterations       = "MaximumIterations"
+)
+
+func ParameterSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          CalibrationSamples,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0), // no calibration; StartingTemperature used as supplied
+		},
+	).Add(
+		Specification{
+			Key:          TargetInitialAcceptance,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0.8),
+		},
+	).Add(
+		Specification{
+			Key:          TargetFinalAcceptance,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0), // no calibration; CoolingFactor used as supplied
+		},
+	).Add(
+		Specification{
+			Key:          MaximumIterations,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0),
+		},
+	)
+	return specs
+}
@@ -6,8 +6,10 @@ import (
 	"math"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 )
 
 var _ cooling.TemperatureCoolant = NewCoolant()
@@ -22,6 +24,7 @@ func NewCoolant() *Coolant {
 type Coolant struct {
 	rand.RandContainer
 	parameters Parameters
+	schedule   schedules.Schedule
 
 	acceptanceProbability float64
 	temperature           float64
@@ -30,6 +33,7 @@ type Coolant struct {
 
 func (c *Coolant) Initialise() *Coolant {
 	c.parameters.Initialise()
+	c.schedule = schedules.New()
 	return c
 }
 
@@ -43,12 +47,21 @@ func (c *Coolant) SetParameters(params parameters.Map) error {
 
 	c.temperature = c.parameters.GetFloat64(StartingTemperature)
 	c.coolingFactor = c.parameters.GetFloat64(CoolingFactor)
+	c.schedule.SetParameters(params)
 
 	return nil
 }
 
 func (c *Coolant) ParameterErrors() error {
-	return c.parameters.ValidationErrors()
+	mergedErrors := compositeErrors.New("Coolant Parameter Validation")
+
+	mergedErrors.Add(c.parameters.ValidationErrors())
+	mergedErrors.Add(c.schedule.ParameterErrors())
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+	return nil
 }
 
 func (c *Coolant) DecideIfAcceptable(variableChanges []float64) bool {
@@ -58,12 +71,16 @@ func (c *Coolant) DecideIfAcceptable(variableChanges []float64) bool {
 }
 
 func (c *Coolant) calculateAcceptanceProbability(variableChanges []float64) {
+	c.acceptanceProbability = c.AcceptanceProbabilityAt(variableChanges, c.temperature)
+}
+
+func (c *Coolant) AcceptanceProbabilityAt(variableChanges []float64, temperature float64) float64 {
 	numberOfChanges := len(variableChanges)
 
 	probabilities := make([]float64, numberOfChanges)
 	for index, individualChange := range variableChanges {
 		absoluteChangeInObjectiveValue := math.Abs(individualChange)
-		probabilities[index] = math.Exp(-absoluteChangeInObjectiveValue / c.temperature)
+		probabilities[index] = math.Exp(-absoluteChangeInObjectiveValue / temperature)
 	}
 
 	finalProbability := float64(0)
@@ -74,7 +91,7 @@ func (c *Coolant) calculateAcceptanceProbability(variableChanges []float64) {
 	numberOfChangesAsFloat := float64(numberOfChanges)
 	finalProbability = finalProbability / numberOfChangesAsFloat
 
-	c.acceptanceProbability = finalProbability
+	return finalProbability
 }
 
 func (c *Coolant) Temperature() float64 {
@@ -89,6 +106,10 @@ func (c *Coolant) CoolingFactor() float64 {
 	return c.coolingFactor
 }
 
+func (c *Coolant) SetCoolingFactor(coolingFactor float64) {
+	c.coolingFactor = coolingFactor
+}
+
 func (c *Coolant) SetAcceptanceProbability(acceptanceProbability float64) {
 	c.acceptanceProbability = acceptanceProbability
 }
@@ -97,6 +118,28 @@ func (c *Coolant) AcceptanceProbability() float64 {
 	return c.acceptanceProbability
 }
 
+func (c *Coolant) ObserveOutcome(outcome schedules.Outcome) {
+	c.schedule.Observe(outcome)
+}
+
 func (c *Coolant) CoolDown() {
-	c.temperature *= c.coolingFactor
+	c.temperature = c.schedule.NextTemperature(c.temperature, c.coolingFactor)
+}
+
+// ScheduleState returns the progress of the coolant's schedule, for checkpointing.
+func (c *Coolant) ScheduleState() schedules.State {
+	var state schedules.State
+	c.schedule.CaptureState(&state)
+	return state
+}
+
+func (c *Coolant) RestoreScheduleState(state schedules.State) {
+	c.schedule.RestoreState(state)
+}
+
+func (c *Coolant) DeepClone() cooling.TemperatureCoolant {
+	clone := *c
+	clone.schedule = c.schedule.DeepClone()
+	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	return &clone
 }
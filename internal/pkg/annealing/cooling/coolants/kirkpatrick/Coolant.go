@@ -5,13 +5,16 @@ package kirkpatrick
 import (
 	"math"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 )
 
 type Coolant struct {
 	rand.RandContainer
 	parameters Parameters
+	schedule   schedules.Schedule
 
 	AcceptanceProbability float64
 	Temperature           float64
@@ -20,6 +23,7 @@ type Coolant struct {
 
 func (c *Coolant) Initialise() *Coolant {
 	c.parameters.Initialise()
+	c.schedule = schedules.New()
 	return c
 }
 
@@ -28,12 +32,21 @@ func (c *Coolant) WithParameters(params parameters.Map) *Coolant {
 
 	c.Temperature = c.parameters.GetFloat64(StartingTemperature)
 	c.CoolingFactor = c.parameters.GetFloat64(CoolingFactor)
+	c.schedule.SetParameters(params)
 
 	return c
 }
 
 func (c *Coolant) ParameterErrors() error {
-	return c.parameters.ValidationErrors()
+	mergedErrors := compositeErrors.New("Coolant Parameter Validation")
+
+	mergedErrors.Add(c.parameters.ValidationErrors())
+	mergedErrors.Add(c.schedule.ParameterErrors())
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+	return nil
 }
 
 func (c *Coolant) DecideIfAcceptable(objectiveFunctionChange float64) bool {
@@ -43,10 +56,39 @@ func (c *Coolant) DecideIfAcceptable(objectiveFunctionChange float64) bool {
 }
 
 func (c *Coolant) calculateAcceptanceProbability(objectiveFunctionChange float64) {
+	c.AcceptanceProbability = c.AcceptanceProbabilityAt(objectiveFunctionChange, c.Temperature)
+}
+
+// AcceptanceProbabilityAt returns the probability of accepting an undesirable objective function change at the
+// temperature supplied.
+func (c *Coolant) AcceptanceProbabilityAt(objectiveFunctionChange float64, temperature float64) float64 {
 	absoluteChangeInObjectiveValue := math.Abs(objectiveFunctionChange)
-	c.AcceptanceProbability = math.Exp(-absoluteChangeInObjectiveValue / c.Temperature)
+	return math.Exp(-absoluteChangeInObjectiveValue / temperature)
+}
+
+// ObserveOutcome passes the outcome of the latest iteration to the coolant's schedule, ahead of cooling down after it.
+func (c *Coolant) ObserveOutcome(outcome schedules.Outcome) {
+	c.schedule.Observe(outcome)
 }
 
 func (c *Coolant) CoolDown() {
-	c.Temperature *= c.CoolingFactor
+	c.Temperature = c.schedule.NextTemperature(c.Temperature, c.CoolingFactor)
+}
+
+// ScheduleState returns the progress of the coolant's schedule, for checkpointing.
+func (c *Coolant) ScheduleState() schedules.State {
+	var state schedules.State
+	c.schedule.CaptureState(&state)
+	return state
+}
+
+func (c *Coolant) RestoreScheduleState(state schedules.State) {
+	c.schedule.RestoreState(state)
+}
+
+// DeepClone returns a copy of the coolant with its own schedule, so that stateful schedules are not shared.
+func (c *Coolant) DeepClone() Coolant {
+	clone := *c
+	clone.schedule = c.schedule.DeepClone()
+	return clone
 }
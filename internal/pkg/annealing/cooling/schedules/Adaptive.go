@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	TargetAcceptanceRate = "TargetAcceptanceRate"
+	AdaptationWindow     = "AdaptationWindow"
+	AdaptationGain       = "AdaptationGain"
+)
+
+// Adaptive steers the temperature towards that at which changes are accepted at a TargetAcceptanceRate. At the end of
+// every AdaptationWindow iterations, the temperature is scaled by exp(gain * (target - observed acceptance rate)),
+// cooling when changes were accepted too often, and warming when too seldom.
+type Adaptive struct {
+	parameters.Parameters
+
+	iterations uint64
+	accepted   uint64
+}
+
+func (a *Adaptive) Initialise() *Adaptive {
+	a.Parameters.
+		Initialise("Adaptive Cooling Schedule Parameter Validation").
+		Enforcing(AdaptiveSpecifications())
+	return a
+}
+
+func AdaptiveSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          TargetAcceptanceRate,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0.44), // following Lam & Delosme's optimal acceptance rate
+		},
+	).Add(
+		Specification{
+			Key:          AdaptationWindow,
+			Validator:    isPositiveInteger,
+			DefaultValue: int64(100),
+		},
+	).Add(
+		Specification{
+			Key:          AdaptationGain,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(1),
+		},
+	)
+	return specs
+}
+
+func (a *Adaptive) SetParameters(params parameters.Map) error {
+	a.AssignOnlyEnforcedUserValues(params)
+	return a.ValidationErrors()
+}
+
+func (a *Adaptive) ParameterErrors() error {
+	return a.ValidationErrors()
+}
+
+func (a *Adaptive) Observe(outcome Outcome) {
+	a.iterations++
+	if outcome.Accepted {
+		a.accepted++
+	}
+}
+
+func (a *Adaptive) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	if a.iterations < uint64(a.GetInt64(AdaptationWindow)) {
+		return temperature
+	}
+
+	acceptanceRate := float64(a.accepted) / float64(a.iterations)
+	a.iterations, a.accepted = 0, 0
+
+	adjustment := a.GetFloat64(AdaptationGain) * (a.GetFloat64(TargetAcceptanceRate) - acceptanceRate)
+	return temperature * math.Exp(adjustment)
+}
+
+func (a *Adaptive) CaptureState(state *State) {
+	state.Iterations = a.iterations
+	state.Accepted = a.accepted
+}
+
+func (a *Adaptive) RestoreState(state State) {
+	a.iterations = state.Iterations
+	a.accepted = state.Accepted
+}
+
+func (a *Adaptive) DeepClone() Schedule {
+	clone := *a
+	return &clone
+}
+
+func isPositiveInteger(key string, value interface{}) error {
+	return IsIntegerWithInclusiveBounds(key, value, 1, math.MaxInt64)
+}
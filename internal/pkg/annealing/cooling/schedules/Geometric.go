@@ -0,0 +1,23 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import "github.com/LindsayBradford/crem/internal/pkg/parameters"
+
+// Geometric multiplies the temperature by the coolant's CoolingFactor every iteration. It needs no parameters of its
+// own.
+type Geometric struct{}
+
+func (g *Geometric) SetParameters(params parameters.Map) error { return nil }
+func (g *Geometric) ParameterErrors() error                    { return nil }
+func (g *Geometric) Observe(outcome Outcome)                   {}
+func (g *Geometric) CaptureState(state *State)                 {}
+func (g *Geometric) RestoreState(state State)                  {}
+
+func (g *Geometric) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	return temperature * coolingFactor
+}
+
+func (g *Geometric) DeepClone() Schedule {
+	return new(Geometric)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	TemperatureDecrement = "TemperatureDecrement"
+	TemperatureFloor     = "TemperatureFloor"
+)
+
+// Linear lowers the temperature by a TemperatureDecrement every iteration, never going below a TemperatureFloor.
+type Linear struct {
+	parameters.Parameters
+}
+
+func (l *Linear) Initialise() *Linear {
+	l.Parameters.
+		Initialise("Linear Cooling Schedule Parameter Validation").
+		Enforcing(LinearSpecifications())
+	return l
+}
+
+func LinearSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          TemperatureDecrement,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(1),
+		},
+	).Add(
+		Specification{
+			Key:          TemperatureFloor,
+			Validator:    isPositiveDecimal,
+			DefaultValue: float64(0.001),
+		},
+	)
+	return specs
+}
+
+func (l *Linear) SetParameters(params parameters.Map) error {
+	l.AssignOnlyEnforcedUserValues(params)
+	return l.ValidationErrors()
+}
+
+func (l *Linear) ParameterErrors() error {
+	return l.ValidationErrors()
+}
+
+func (l *Linear) Observe(outcome Outcome) {}
+
+func (l *Linear) CaptureState(state *State) {}
+
+func (l *Linear) RestoreState(state State) {}
+
+func (l *Linear) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	return math.Max(temperature-l.GetFloat64(TemperatureDecrement), l.GetFloat64(TemperatureFloor))
+}
+
+func (l *Linear) DeepClone() Schedule {
+	clone := *l
+	return &clone
+}
+
+func isPositiveDecimal(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, math.SmallestNonzeroFloat64, math.MaxFloat64)
+}
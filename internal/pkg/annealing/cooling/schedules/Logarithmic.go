@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const LogarithmicOffset = "LogarithmicOffset"
+
+// Logarithmic follows T(k) = T(0) * ln(c) / ln(k + c), for offset c, cooling quickly at first then ever more slowly.
+type Logarithmic struct {
+	parameters.Parameters
+	step uint64
+}
+
+func (l *Logarithmic) Initialise() *Logarithmic {
+	l.Parameters.
+		Initialise("Logarithmic Cooling Schedule Parameter Validation").
+		Enforcing(LogarithmicSpecifications())
+	return l
+}
+
+func LogarithmicSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          LogarithmicOffset,
+			Validator:    isGreaterThanOne,
+			DefaultValue: float64(2),
+		},
+	)
+	return specs
+}
+
+func (l *Logarithmic) SetParameters(params parameters.Map) error {
+	l.AssignOnlyEnforcedUserValues(params)
+	return l.ValidationErrors()
+}
+
+func (l *Logarithmic) ParameterErrors() error {
+	return l.ValidationErrors()
+}
+
+func (l *Logarithmic) Observe(outcome Outcome) {}
+
+func (l *Logarithmic) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	offset := l.GetFloat64(LogarithmicOffset)
+	step := float64(l.step)
+	l.step++
+	return temperature * math.Log(step+offset) / math.Log(step+1+offset)
+}
+
+func (l *Logarithmic) CaptureState(state *State) {
+	state.Step = l.step
+}
+
+func (l *Logarithmic) RestoreState(state State) {
+	l.step = state.Step
+}
+
+func (l *Logarithmic) DeepClone() Schedule {
+	clone := *l
+	return &clone
+}
+
+func isGreaterThanOne(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, math.Nextafter(1, 2), math.MaxFloat64)
+}
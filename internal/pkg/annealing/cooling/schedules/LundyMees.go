@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const LundyMeesBeta = "LundyMeesBeta"
+
+// LundyMees follows T(k+1) = T(k) / (1 + beta * T(k)), as proposed by Lundy and Mees (1986).
+type LundyMees struct {
+	parameters.Parameters
+}
+
+func (lm *LundyMees) Initialise() *LundyMees {
+	lm.Parameters.
+		Initialise("Lundy-Mees Cooling Schedule Parameter Validation").
+		Enforcing(LundyMeesSpecifications())
+	return lm
+}
+
+func LundyMeesSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          LundyMeesBeta,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(0.001),
+		},
+	)
+	return specs
+}
+
+func (lm *LundyMees) SetParameters(params parameters.Map) error {
+	lm.AssignOnlyEnforcedUserValues(params)
+	return lm.ValidationErrors()
+}
+
+func (lm *LundyMees) ParameterErrors() error {
+	return lm.ValidationErrors()
+}
+
+func (lm *LundyMees) Observe(outcome Outcome) {}
+
+func (lm *LundyMees) CaptureState(state *State) {}
+
+func (lm *LundyMees) RestoreState(state State) {}
+
+func (lm *LundyMees) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	return temperature / (1 + lm.GetFloat64(LundyMeesBeta)*temperature)
+}
+
+func (lm *LundyMees) DeepClone() Schedule {
+	clone := *lm
+	return &clone
+}
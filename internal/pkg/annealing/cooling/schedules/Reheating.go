@@ -0,0 +1,90 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	ReheatingInterval = "ReheatingInterval"
+	ReheatingFactor   = "ReheatingFactor"
+)
+
+// Reheating multiplies the temperature by a ReheatingFactor once ReheatingInterval iterations pass without an
+// improvement being found, letting a search that has frozen around a local optimum escape it.
+type Reheating struct {
+	parameters.Parameters
+	iterationsWithoutImprovement uint64
+}
+
+func (r *Reheating) Initialise() *Reheating {
+	r.Parameters.
+		Initialise("Reheating Parameter Validation").
+		Enforcing(ReheatingSpecifications())
+	return r
+}
+
+func ReheatingSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          ReheatingInterval,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0), // never reheat
+		},
+	).Add(
+		Specification{
+			Key:          ReheatingFactor,
+			Validator:    isAtLeastOne,
+			DefaultValue: float64(2),
+		},
+	)
+	return specs
+}
+
+func (r *Reheating) SetParameters(params parameters.Map) error {
+	r.AssignOnlyEnforcedUserValues(params)
+	return r.ValidationErrors()
+}
+
+func (r *Reheating) ParameterErrors() error {
+	return r.ValidationErrors()
+}
+
+func (r *Reheating) IsEnabled() bool {
+	return r.GetInt64(ReheatingInterval) > 0
+}
+
+func (r *Reheating) Observe(outcome Outcome) {
+	if outcome.Improved {
+		r.iterationsWithoutImprovement = 0
+		return
+	}
+	r.iterationsWithoutImprovement++
+}
+
+// IsDue reports whether ReheatingInterval iterations have passed without improvement.
+func (r *Reheating) IsDue() bool {
+	return r.IsEnabled() && r.iterationsWithoutImprovement >= uint64(r.GetInt64(ReheatingInterval))
+}
+
+func (r *Reheating) Reheat(temperature float64) float64 {
+	r.iterationsWithoutImprovement = 0
+	return temperature * r.GetFloat64(ReheatingFactor)
+}
+
+func (r *Reheating) CaptureState(state *State) {
+	state.IterationsWithoutImprovement = r.iterationsWithoutImprovement
+}
+
+func (r *Reheating) RestoreState(state State) {
+	r.iterationsWithoutImprovement = state.IterationsWithoutImprovement
+}
+
+func isAtLeastOne(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, 1, math.MaxFloat64)
+}
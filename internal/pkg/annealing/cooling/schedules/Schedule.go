@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package schedules offers the cooling schedules a coolant can follow, each deciding the temperature of the next
+// iteration from that of the current one. A schedule is selected via the CoolingSchedule parameter, and may be wrapped
+// so that it reheats once improvements stop being found.
+package schedules
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+)
+
+// Outcome describes how an iteration's change fared, for schedules that adapt to the progress of annealing.
+type Outcome struct {
+	Accepted bool
+	Improved bool
+}
+
+// Schedule decides how a coolant's temperature changes from one iteration to the next.
+type Schedule interface {
+	parameters.Container
+
+	// Observe notes the outcome of the iteration about to be cooled down after.
+	Observe(outcome Outcome)
+
+	// NextTemperature returns the temperature to follow the one supplied. The coolant's coolingFactor is supplied for
+	// schedules that decay geometrically.
+	NextTemperature(temperature float64, coolingFactor float64) float64
+
+	// CaptureState records the schedule's progress through annealing, for checkpointing.
+	CaptureState(state *State)
+
+	// RestoreState resumes the schedule from progress previously captured.
+	RestoreState(state State)
+
+	DeepClone() Schedule
+}
+
+// State is the progress a schedule has made through annealing, as captured in checkpoints. Entries not relevant to the
+// schedule are left empty.
+type State struct {
+	Step                         uint64 `json:",omitempty"`
+	Iterations                   uint64 `json:",omitempty"`
+	Accepted                     uint64 `json:",omitempty"`
+	IterationsWithoutImprovement uint64 `json:",omitempty"`
+}
+
+// New returns the schedule named by the CoolingSchedule parameter (Geometric by default), reheating if a
+// ReheatingInterval is given.
+func New() *Selected {
+	return new(Selected).Initialise()
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+func TestSelected_NoParameters_Geometric(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+
+	// when
+	scheduleUnderTest.SetParameters(parameters.Map{})
+
+	// then
+	g.Expect(scheduleUnderTest.ParameterErrors()).To(BeNil())
+	g.Expect(scheduleUnderTest.Name()).To(Equal(GeometricSchedule))
+	g.Expect(scheduleUnderTest.NextTemperature(100, 0.9)).To(BeNumerically("~", 90, 1e-9))
+}
+
+func TestSelected_UnknownSchedule_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+
+	// when
+	scheduleUnderTest.SetParameters(parameters.Map{CoolingSchedule: "Exponentially Whimsical"})
+
+	// then
+	g.Expect(scheduleUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(scheduleUnderTest.ParameterErrors().Error()).To(ContainSubstring("[" + CoolingSchedule + "] must be one of"))
+}
+
+func TestSelected_InvalidScheduleParameter_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+
+	// when
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			CoolingSchedule:      AdaptiveSchedule,
+			TargetAcceptanceRate: float64(1.5),
+		},
+	)
+
+	// then
+	g.Expect(scheduleUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(scheduleUnderTest.ParameterErrors().Error()).To(ContainSubstring(TargetAcceptanceRate))
+}
+
+func TestLinear_NextTemperature_DecrementsToFloor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			CoolingSchedule:      LinearSchedule,
+			TemperatureDecrement: float64(4),
+			TemperatureFloor:     float64(1),
+		},
+	)
+
+	// when
+	temperatures := coolRepeatedly(scheduleUnderTest, 10, 3)
+
+	// then
+	g.Expect(scheduleUnderTest.ParameterErrors()).To(BeNil())
+	g.Expect(temperatures).To(Equal([]float64{6, 2, 1}))
+}
+
+func TestLogarithmic_NextTemperature_FollowsInverseLogarithm(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const startingTemperature = 100
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(parameters.Map{CoolingSchedule: LogarithmicSchedule})
+
+	// when
+	temperatures := coolRepeatedly(scheduleUnderTest, startingTemperature, 5)
+
+	// then
+	for index, temperature := range temperatures {
+		step := float64(index + 1)
+		expectedTemperature := startingTemperature * math.Log(2) / math.Log(step+2)
+		g.Expect(temperature).To(BeNumerically("~", expectedTemperature, 1e-9))
+	}
+}
+
+func TestLundyMees_NextTemperature_FollowsRecurrence(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			CoolingSchedule: LundyMeesSchedule,
+			LundyMeesBeta:   float64(0.01),
+		},
+	)
+
+	// when
+	actualTemperature := scheduleUnderTest.NextTemperature(100, 0.5)
+
+	// then
+	g.Expect(actualTemperature).To(BeNumerically("~", 50, 1e-9))
+}
+
+func TestAdaptive_NextTemperature_SteersTowardsTargetAcceptance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			CoolingSchedule:      AdaptiveSchedule,
+			TargetAcceptanceRate: float64(0.5),
+			AdaptationWindow:     int64(4),
+		},
+	)
+
+	// when
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true}, 3)
+	midWindowTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true}, 1)
+	oftenAcceptedTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: false}, 4)
+	seldomAcceptedTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	// then
+	g.Expect(midWindowTemperature).To(BeNumerically("==", 10))
+	g.Expect(oftenAcceptedTemperature).To(BeNumerically("~", 10*math.Exp(-0.5), 1e-9))
+	g.Expect(seldomAcceptedTemperature).To(BeNumerically("~", 10*math.Exp(0.5), 1e-9))
+}
+
+func TestReheating_NoImprovementForInterval_Reheats(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			ReheatingInterval: int64(3),
+			ReheatingFactor:   float64(4),
+		},
+	)
+
+	// when
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true, Improved: false}, 2)
+	cooledTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true, Improved: false}, 1)
+	reheatedTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true, Improved: false}, 2)
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true, Improved: true}, 1)
+	improvedTemperature := scheduleUnderTest.NextTemperature(10, 0.5)
+
+	// then
+	g.Expect(scheduleUnderTest.ParameterErrors()).To(BeNil())
+	g.Expect(cooledTemperature).To(BeNumerically("==", 5))
+	g.Expect(reheatedTemperature).To(BeNumerically("==", 40))
+	g.Expect(improvedTemperature).To(BeNumerically("==", 5))
+}
+
+func TestSelected_DeepClone_StateNotShared(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New()
+	scheduleUnderTest.SetParameters(
+		parameters.Map{
+			CoolingSchedule:  AdaptiveSchedule,
+			AdaptationWindow: int64(2),
+		},
+	)
+	clone := scheduleUnderTest.DeepClone()
+
+	// when
+	observeRepeatedly(scheduleUnderTest, Outcome{Accepted: true}, 2)
+
+	// then
+	g.Expect(clone.NextTemperature(10, 0.5)).To(BeNumerically("==", 10))
+	g.Expect(scheduleUnderTest.NextTemperature(10, 0.5)).To(BeNumerically("<", 10))
+}
+
+func TestSelected_RestoreState_ResumesWhereCaptured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleParameters := parameters.Map{
+		CoolingSchedule:   AdaptiveSchedule,
+		AdaptationWindow:  int64(4),
+		ReheatingInterval: int64(5),
+	}
+
+	capturedSchedule := New()
+	capturedSchedule.SetParameters(scheduleParameters)
+	observeRepeatedly(capturedSchedule, Outcome{Accepted: true}, 3)
+
+	var capturedState State
+	capturedSchedule.CaptureState(&capturedState)
+
+	// when
+	restoredSchedule := New()
+	restoredSchedule.SetParameters(scheduleParameters)
+	restoredSchedule.RestoreState(capturedState)
+
+	observeRepeatedly(capturedSchedule, Outcome{Accepted: false}, 2)
+	observeRepeatedly(restoredSchedule, Outcome{Accepted: false}, 2)
+
+	// then
+	g.Expect(capturedState).To(Equal(State{Iterations: 3, Accepted: 3, IterationsWithoutImprovement: 3}))
+	g.Expect(restoredSchedule.NextTemperature(10, 0.5)).To(Equal(capturedSchedule.NextTemperature(10, 0.5)))
+	g.Expect(restoredSchedule.NextTemperature(10, 0.5)).To(Equal(capturedSchedule.NextTemperature(10, 0.5)))
+}
+
+func TestLogarithmic_RestoreState_ResumesStep(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	capturedSchedule := new(Logarithmic).Initialise()
+	coolRepeatedly(capturedSchedule, 100, 3)
+
+	var capturedState State
+	capturedSchedule.CaptureState(&capturedState)
+
+	// when
+	restoredSchedule := new(Logarithmic).Initialise()
+	restoredSchedule.RestoreState(capturedState)
+
+	// then
+	g.Expect(capturedState.Step).To(BeNumerically("==", 3))
+	g.Expect(restoredSchedule.NextTemperature(50, 1)).To(Equal(capturedSchedule.NextTemperature(50, 1)))
+}
+
+func coolRepeatedly(schedule Schedule, temperature float64, times int) []float64 {
+	temperatures := make([]float64, times)
+	for index := range temperatures {
+		temperature = schedule.NextTemperature(temperature, 1)
+		temperatures[index] = temperature
+	}
+	return temperatures
+}
+
+func observeRepeatedly(schedule Schedule, outcome Outcome, times int) {
+	for index := 0; index < times; index++ {
+		schedule.Observe(outcome)
+	}
+}
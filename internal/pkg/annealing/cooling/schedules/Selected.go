@@ -0,0 +1,131 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedules
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+const CoolingSchedule = "CoolingSchedule"
+
+const (
+	GeometricSchedule   = "Geometric"
+	LinearSchedule      = "Linear"
+	LogarithmicSchedule = "Logarithmic"
+	LundyMeesSchedule   = "LundyMees"
+	AdaptiveSchedule    = "Adaptive"
+)
+
+var scheduleBuilders = map[string]func() Schedule{
+	GeometricSchedule:   func() Schedule { return new(Geometric) },
+	LinearSchedule:      func() Schedule { return new(Linear).Initialise() },
+	LogarithmicSchedule: func() Schedule { return new(Logarithmic).Initialise() },
+	LundyMeesSchedule:   func() Schedule { return new(LundyMees).Initialise() },
+	AdaptiveSchedule:    func() Schedule { return new(Adaptive).Initialise() },
+}
+
+type SelectionParameters struct {
+	parameters.Parameters
+}
+
+func (p *SelectionParameters) Initialise() *SelectionParameters {
+	p.Parameters.
+		Initialise("Cooling Schedule Parameter Validation").
+		Enforcing(SelectionSpecifications())
+	return p
+}
+
+func SelectionSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          CoolingSchedule,
+			Validator:    isScheduleName,
+			DefaultValue: GeometricSchedule,
+		},
+	)
+	return specs
+}
+
+func isScheduleName(key string, value interface{}) error {
+	if nameError := IsString(key, value); nameError != nil && !nameError.(ValidationError).IsValid() {
+		return nameError
+	}
+	if _, isKnown := scheduleBuilders[value.(string)]; !isKnown {
+		return NewInvalidSpecificationError("Parameter [" + key + "] must be one of \"" + GeometricSchedule + "\", \"" +
+			LinearSchedule + "\", \"" + LogarithmicSchedule + "\", \"" + LundyMeesSchedule + "\" or \"" + AdaptiveSchedule + "\"")
+	}
+	return NewValidSpecificationError(key, value)
+}
+
+// Selected is the Schedule named by the CoolingSchedule parameter, wrapped to reheat if a ReheatingInterval is given.
+type Selected struct {
+	parameters SelectionParameters
+	schedule   Schedule
+	reheating  Reheating
+}
+
+func (s *Selected) Initialise() *Selected {
+	s.parameters.Initialise()
+	s.schedule = new(Geometric)
+	s.reheating.Initialise()
+	return s
+}
+
+func (s *Selected) SetParameters(params parameters.Map) error {
+	s.parameters.AssignOnlyEnforcedUserValues(params)
+
+	s.schedule = scheduleBuilders[s.parameters.GetString(CoolingSchedule)]()
+	s.schedule.SetParameters(params)
+	s.reheating.SetParameters(params)
+
+	return s.ParameterErrors()
+}
+
+func (s *Selected) ParameterErrors() error {
+	mergedErrors := compositeErrors.New("Cooling Schedule Parameter Validation")
+
+	mergedErrors.Add(s.parameters.ValidationErrors())
+	mergedErrors.Add(s.schedule.ParameterErrors())
+	mergedErrors.Add(s.reheating.ParameterErrors())
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+	return nil
+}
+
+// Name returns the name of the schedule selected.
+func (s *Selected) Name() string {
+	return s.parameters.GetString(CoolingSchedule)
+}
+
+func (s *Selected) Observe(outcome Outcome) {
+	s.schedule.Observe(outcome)
+	s.reheating.Observe(outcome)
+}
+
+func (s *Selected) NextTemperature(temperature float64, coolingFactor float64) float64 {
+	if s.reheating.IsDue() {
+		return s.reheating.Reheat(temperature)
+	}
+	return s.schedule.NextTemperature(temperature, coolingFactor)
+}
+
+func (s *Selected) CaptureState(state *State) {
+	s.schedule.CaptureState(state)
+	s.reheating.CaptureState(state)
+}
+
+func (s *Selected) RestoreState(state State) {
+	s.schedule.RestoreState(state)
+	s.reheating.RestoreState(state)
+}
+
+func (s *Selected) DeepClone() Schedule {
+	clone := *s
+	clone.schedule = s.schedule.DeepClone()
+	return &clone
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package exact
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+)
+
+// Names of the measures of a Report, and of an optimality gap, as reported alongside annealed solutions.
+const (
+	ExactSolutionsFound               = "ExactSolutionsFound"
+	ExactGenerationalDistance         = "ExactGenerationalDistance"
+	ExactInvertedGenerationalDistance = "ExactInvertedGenerationalDistance"
+	ExactHypervolumeRatio             = "ExactHypervolumeRatio"
+	ExactOptimum                      = "ExactOptimum"
+	ExactOptimalityGap                = "ExactOptimalityGap"
+)
+
+// Report quantifies how closely an annealed front approximates the exact Pareto front of the same model. Distances and
+// hypervolumes are measured with each decision variable normalised over the exact front's range, 0 being its best
+// value and 1 its worst, so that variables of differing magnitudes weigh equally.
+type Report struct {
+	ExactFrontSize    int
+	AnnealedFrontSize int
+
+	// ExactSolutionsFound counts the exact front's solutions matched in every decision variable by an annealed solution.
+	ExactSolutionsFound int
+
+	// GenerationalDistance is the mean distance from each annealed solution to its nearest exact solution.
+	GenerationalDistance float64
+
+	// InvertedGenerationalDistance is the mean distance from each exact solution to its nearest annealed solution.
+	InvertedGenerationalDistance float64
+
+	ExactHypervolume    float64
+	AnnealedHypervolume float64
+
+	// HypervolumeRatio is the annealed front's hypervolume as a proportion of the exact front's, 1 being a perfect match.
+	HypervolumeRatio float64
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf(
+		"Found [%d] of [%d] exact solutions with [%d] annealed, generational distance [%g], "+
+			"inverted generational distance [%g], hypervolume ratio [%g]",
+		r.ExactSolutionsFound, r.ExactFrontSize, r.AnnealedFrontSize,
+		r.GenerationalDistance, r.InvertedGenerationalDistance, r.HypervolumeRatio,
+	)
+}
+
+// Compare reports on the gap between an annealed front and the exact front of the same model, as found by
+// Solver.ParetoFront.
+func Compare(annealed *archive.NonDominanceModelArchive, exact *archive.NonDominanceModelArchive) Report {
+	report := Report{
+		ExactFrontSize:    exact.Len(),
+		AnnealedFrontSize: annealed.Len(),
+	}
+
+	if exact.IsEmpty() {
+		return report
+	}
+
+	normaliser := archive.NewNormaliser(exact)
+	exactPoints := normaliser.NormaliseAll(exact)
+	annealedPoints := normaliser.NormaliseAll(annealed)
+
+	for _, exactPoint := range exactPoints {
+		if archive.NearestDistance(exactPoint, annealedPoints) == 0 {
+			report.ExactSolutionsFound++
+		}
+	}
+
+	report.GenerationalDistance = archive.GenerationalDistance(annealedPoints, exactPoints)
+	report.InvertedGenerationalDistance = archive.GenerationalDistance(exactPoints, annealedPoints)
+
+	reference := normaliser.ReferencePoint(archive.HypervolumeMargin)
+	report.ExactHypervolume = archive.Hypervolume(exactPoints, reference)
+	report.AnnealedHypervolume = archive.Hypervolume(annealedPoints, reference)
+	if report.ExactHypervolume > 0 {
+		report.HypervolumeRatio = report.AnnealedHypervolume / report.ExactHypervolume
+	}
+
+	return report
+}
+
+// OptimalityGap returns how far short of the optimum a value falls, as a proportion of the optimum, when optimising
+// in the direction supplied. Where the optimum is zero, the absolute shortfall is returned instead.
+func OptimalityGap(value float64, optimum float64, direction dominance.Direction) float64 {
+	shortfall := optimum - value
+	if direction == dominance.Minimising {
+		shortfall = value - optimum
+	}
+
+	if optimum == 0 {
+		return shortfall
+	}
+	return shortfall / math.Abs(optimum)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package exact
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func TestCompare_ExactFrontAgainstItself_PerfectMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	exactFront := buildExactFront(g)
+
+	// when
+	report := Compare(exactFront, exactFront)
+
+	// then
+	g.Expect(report.ExactSolutionsFound).To(Equal(exactFront.Len()))
+	g.Expect(report.GenerationalDistance).To(BeNumerically("==", 0))
+	g.Expect(report.InvertedGenerationalDistance).To(BeNumerically("==", 0))
+	g.Expect(report.ExactHypervolume).To(BeNumerically(">", 0))
+	g.Expect(report.HypervolumeRatio).To(BeNumerically("~", 1, 1e-9))
+}
+
+func TestCompare_PartialFront_ReportsGap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	exactFront := buildExactFront(g)
+
+	annealedFront := archive.New()
+	annealedFront.Restore(exactFront.Archive()[:1])
+
+	// when
+	report := Compare(annealedFront, exactFront)
+
+	// then
+	g.Expect(report.AnnealedFrontSize).To(Equal(1))
+	g.Expect(report.ExactSolutionsFound).To(BeNumerically(">=", 1))
+	g.Expect(report.ExactSolutionsFound).To(BeNumerically("<", exactFront.Len()))
+	g.Expect(report.GenerationalDistance).To(BeNumerically("==", 0))
+	g.Expect(report.InvertedGenerationalDistance).To(BeNumerically(">", 0))
+	g.Expect(report.HypervolumeRatio).To(BeNumerically("<", 1))
+	g.Expect(report.String()).To(ContainSubstring("with [1] annealed"))
+}
+
+func TestOptimalityGap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(OptimalityGap(110, 100, dominance.Minimising)).To(BeNumerically("~", 0.1, 1e-9))
+	g.Expect(OptimalityGap(90, 100, dominance.Maximising)).To(BeNumerically("~", 0.1, 1e-9))
+	g.Expect(OptimalityGap(100, 100, dominance.Minimising)).To(BeNumerically("==", 0))
+	g.Expect(OptimalityGap(5, 0, dominance.Minimising)).To(BeNumerically("==", 5))
+}
+
+func buildExactFront(g *GomegaWithT) *archive.NonDominanceModelArchive {
+	front, frontError := New().WithModel(buildTestModel(g)).ParetoFront()
+	g.Expect(frontError).To(BeNil())
+	return front
+}
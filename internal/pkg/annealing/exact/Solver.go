@@ -0,0 +1,167 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package exact offers an exhaustive solver for models with few enough management actions that every combination of
+// them can be visited. It finds the exact optimum of a single decision variable, or the exact Pareto front across all
+// of a model's decision variables, as a yardstick against which annealed solutions can be judged. Only models whose
+// management actions are simply active or inactive are solved; those applying actions at partial intensities, or
+// scheduling them over a planning horizon, are refused.
+package exact
+
+import (
+	"math/bits"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	"github.com/pkg/errors"
+)
+
+// DefaultMaximumActions bounds the management actions a model may offer before the solver refuses to visit every
+// combination of them. Each action added doubles the model states visited.
+const DefaultMaximumActions = 20
+
+type Solver struct {
+	model.ContainedModel
+	maximumActions int
+	directions     dominance.Directions
+}
+
+func New() *Solver {
+	return new(Solver).Initialise()
+}
+
+func (s *Solver) Initialise() *Solver {
+	s.maximumActions = DefaultMaximumActions
+	s.SetModel(model.NewNullModel())
+	return s
+}
+
+func (s *Solver) WithModel(model model.Model) *Solver {
+	s.SetModel(model)
+	return s
+}
+
+func (s *Solver) WithMaximumActions(maximumActions int) *Solver {
+	s.maximumActions = maximumActions
+	return s
+}
+
+// WithDirections supplies the optimisation direction of each decision variable (in sorted name order) that Pareto
+// fronts are found with, overriding those the model's decision variables carry.
+func (s *Solver) WithDirections(directions dominance.Directions) *Solver {
+	s.directions = directions
+	return s
+}
+
+// Optimum returns the valid model state holding the best value of the named decision variable, when optimised in the
+// direction supplied. Where several states tie, the first visited is returned.
+func (s *Solver) Optimum(variableName string, direction dominance.Direction) (*archive.CompressedModelState, error) {
+	var optimum *archive.CompressedModelState
+	var optimumValue float64
+
+	visitingError := s.visitEveryValidState(func() {
+		value := s.Model().DecisionVariable(variableName).Value()
+		if optimum == nil || isPreferred(value, optimumValue, direction) {
+			optimum = s.compressModel()
+			optimumValue = value
+		}
+	})
+
+	if visitingError != nil {
+		return nil, visitingError
+	}
+	if optimum == nil {
+		return nil, errors.New("no valid model state found")
+	}
+	return optimum, nil
+}
+
+func isPreferred(value float64, otherValue float64, direction dominance.Direction) bool {
+	if direction == dominance.Maximising {
+		return value > otherValue
+	}
+	return value < otherValue
+}
+
+// ParetoFront returns an archive of every valid model state left non-dominated across all of the model's decision
+// variables.
+func (s *Solver) ParetoFront() (*archive.NonDominanceModelArchive, error) {
+	front := archive.New()
+	front.SetId(s.Model().Id())
+
+	visitingError := s.visitEveryValidState(func() {
+		front.AttemptToArchiveState(s.compressModel())
+	})
+
+	if visitingError != nil {
+		return nil, visitingError
+	}
+	return front, nil
+}
+
+func (s *Solver) compressModel() *archive.CompressedModelState {
+	compressedModel := new(archive.ModelCompressor).Compress(s.Model())
+	compressedModel.SetId(s.Model().Id())
+	if s.directions != nil {
+		compressedModel.Directions = s.directions
+	}
+	return compressedModel
+}
+
+// visitEveryValidState walks the model through every combination of its management actions in Gray code order, so
+// that each step toggles a single action, calling visit for each combination the model deems valid. The model is
+// returned to its original state afterwards.
+func (s *Solver) visitEveryValidState(visit func()) error {
+	if solvableError := s.checkModelIsSolvable(); solvableError != nil {
+		return solvableError
+	}
+
+	compressor := new(archive.ModelCompressor)
+	originalState := compressor.Compress(s.Model())
+	defer compressor.Decompress(originalState, s.Model())
+
+	s.visitIfValid(visit)
+	combinations := uint64(1) << uint(len(s.Model().ManagementActions()))
+	for step := uint64(1); step < combinations; step++ {
+		s.toggleAction(bits.TrailingZeros64(step))
+		s.visitIfValid(visit)
+	}
+	return nil
+}
+
+// checkModelIsSolvable returns an error if the model offers too many management actions to visit every combination of,
+// or offers more to each action than being active or inactive, which the solver does not visit.
+func (s *Solver) checkModelIsSolvable() error {
+	actions := s.Model().ManagementActions()
+	if len(actions) > s.maximumActions {
+		return errors.Errorf(
+			"model offers [%d] management actions, more than the [%d] allowed for exact solving",
+			len(actions), s.maximumActions,
+		)
+	}
+
+	for _, managementAction := range actions {
+		if len(managementAction.IntensityLevels()) > 1 {
+			return errors.Errorf(
+				"management action [%s] of planning unit [%v] offers partial intensities, which exact solving does not visit",
+				managementAction.Type(), managementAction.PlanningUnit(),
+			)
+		}
+	}
+
+	if scheduledModel, isScheduled := s.Model().(model.Scheduled); isScheduled && scheduledModel.Schedule() != nil {
+		return errors.New("model schedules management actions over a planning horizon, which exact solving does not visit")
+	}
+	return nil
+}
+
+func (s *Solver) toggleAction(index int) {
+	isActive := s.Model().ManagementActions()[index].IsActive()
+	s.Model().SetManagementAction(index, !isActive)
+}
+
+func (s *Solver) visitIfValid(visit func()) {
+	if isValid, _ := s.Model().ChangeIsValid(); isValid {
+		visit()
+	}
+}
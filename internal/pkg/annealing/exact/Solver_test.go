@@ -0,0 +1,176 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package exact
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+const objectiveUnderTest = "SedimentProduction"
+
+func TestSolver_TooManyActions_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	solverUnderTest := New().WithModel(buildTestModel(g)).WithMaximumActions(2)
+
+	// when
+	optimum, optimumError := solverUnderTest.Optimum(objectiveUnderTest, dominance.Minimising)
+	front, frontError := solverUnderTest.ParetoFront()
+
+	// then
+	g.Expect(optimum).To(BeNil())
+	g.Expect(optimumError).To(Not(BeNil()))
+	g.Expect(optimumError.Error()).To(ContainSubstring("[2]"))
+
+	g.Expect(front).To(BeNil())
+	g.Expect(frontError).To(Not(BeNil()))
+}
+
+func TestSolver_PlanningHorizon_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModelWith(g, parameters.Map{"PlanningHorizon": int64(5)})
+	solverUnderTest := New().WithModel(modelUnderTest)
+
+	// when
+	front, frontError := solverUnderTest.ParetoFront()
+
+	// then
+	g.Expect(front).To(BeNil())
+	g.Expect(frontError).To(Not(BeNil()))
+	g.Expect(frontError.Error()).To(ContainSubstring("planning horizon"))
+}
+
+func TestSolver_PartialIntensities_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	partialAction := new(action.SimpleManagementAction).
+		WithPlanningUnit(1).
+		WithType("Partial").
+		WithIntensityLevels(0.5, 1)
+	modelUnderTest := &partialIntensityModel{Model: buildTestModel(g), partialAction: partialAction}
+	solverUnderTest := New().WithModel(modelUnderTest)
+
+	// when
+	optimum, optimumError := solverUnderTest.Optimum(objectiveUnderTest, dominance.Minimising)
+
+	// then
+	g.Expect(optimum).To(BeNil())
+	g.Expect(optimumError).To(Not(BeNil()))
+	g.Expect(optimumError.Error()).To(ContainSubstring("partial intensities"))
+}
+
+type partialIntensityModel struct {
+	*catchment.Model
+	partialAction action.ManagementAction
+}
+
+func (m *partialIntensityModel) ManagementActions() []action.ManagementAction {
+	return append(m.Model.ManagementActions(), m.partialAction)
+}
+
+func TestSolver_Optimum_BeatsEveryActionAlone(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	solverUnderTest := New().WithModel(modelUnderTest)
+
+	// when
+	optimum, optimumError := solverUnderTest.Optimum(objectiveUnderTest, dominance.Minimising)
+
+	// then
+	g.Expect(optimumError).To(BeNil())
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+
+	optimumValue := valueOf(modelUnderTest, optimum)
+	for index := range modelUnderTest.ManagementActions() {
+		modelUnderTest.SetManagementAction(index, true)
+		g.Expect(optimumValue).To(BeNumerically("<=", modelUnderTest.DecisionVariable(objectiveUnderTest).Value()))
+		modelUnderTest.SetManagementAction(index, false)
+	}
+}
+
+func TestSolver_Optimum_RespectsDirection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	asIsValue := modelUnderTest.DecisionVariable(objectiveUnderTest).Value()
+	solverUnderTest := New().WithModel(modelUnderTest)
+
+	// when
+	minimum, _ := solverUnderTest.Optimum(objectiveUnderTest, dominance.Minimising)
+	maximum, _ := solverUnderTest.Optimum(objectiveUnderTest, dominance.Maximising)
+
+	// then
+	g.Expect(valueOf(modelUnderTest, minimum)).To(BeNumerically("<", asIsValue))
+	g.Expect(valueOf(modelUnderTest, maximum)).To(BeNumerically("==", asIsValue))
+	g.Expect(maximum.Encoding()).To(Equal(new(archive.ModelCompressor).Compress(modelUnderTest).Encoding()))
+}
+
+func TestSolver_ParetoFront_NonDominantAndHoldsOptimum(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	modelUnderTest.SetId("ExactTest")
+	solverUnderTest := New().WithModel(modelUnderTest)
+
+	optimum, _ := solverUnderTest.Optimum(objectiveUnderTest, dominance.Minimising)
+
+	// when
+	front, frontError := solverUnderTest.ParetoFront()
+
+	// then
+	g.Expect(frontError).To(BeNil())
+	g.Expect(front.Id()).To(Equal("ExactTest"))
+	g.Expect(front.Len()).To(BeNumerically(">", 1))
+	g.Expect(front.IsNonDominant()).To(BeTrue())
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+
+	objectiveIndex := variableIndexOf(modelUnderTest, objectiveUnderTest)
+	g.Expect(front.ArchiveSummary()[objectiveIndex].Minimum).To(BeNumerically("==", optimum.Variables[objectiveIndex]))
+}
+
+func buildTestModel(g *GomegaWithT) *catchment.Model {
+	return buildTestModelWith(g, parameters.Map{})
+}
+
+// buildTestModelWith builds a catchment model over the greedy explorer's test data, with the extra parameters supplied.
+func buildTestModelWith(g *GomegaWithT, extraParameters parameters.Map) *catchment.Model {
+	modelParameters := parameters.Map{"DataSourcePath": "../explorer/greedy/testdata/ValidModel.csv"}
+	for name, value := range extraParameters {
+		modelParameters[name] = value
+	}
+
+	newModel := catchment.NewModel().WithParameters(modelParameters)
+	g.Expect(newModel.ParameterErrors()).To(BeNil())
+
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
+
+func valueOf(model model.Model, state *archive.CompressedModelState) float64 {
+	return state.Variables[variableIndexOf(model, objectiveUnderTest)]
+}
+
+func variableIndexOf(model model.Model, variableName string) int {
+	for index, name := range model.DecisionVariables().SortedKeys() {
+		if name == variableName {
+			return index
+		}
+	}
+	return -1
+}
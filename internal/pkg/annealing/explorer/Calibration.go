@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package explorer
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/calibration"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+)
+
+const (
+	CalibratedStartingTemperature = "CalibratedStartingTemperature"
+	CalibratedCoolingFactor       = "CalibratedCoolingFactor"
+)
+
+// NotifyCalibration tells the notifier's observers of the coolant settings derived by calibration.
+func NotifyCalibration(notifier observer.EventNotifier, result calibration.Result) {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Calibrated Coolant").
+		WithAttribute(CalibratedStartingTemperature, result.StartingTemperature)
+
+	if result.CoolingFactorCalibrated {
+		event.WithAttribute(CalibratedCoolingFactor, result.CoolingFactor)
+	}
+
+	notifier.NotifyObserversOfEvent(*event)
+}
+
+// CalibrationAttributes returns the coolant settings derived by calibration as attributes, for reporting alongside
+// the outcome of annealing. No attributes are returned if the coolant was not calibrated.
+func CalibrationAttributes(result *calibration.Result) attributes.Attributes {
+	calibrationAttributes := attributes.Attributes{}
+	if result == nil {
+		return calibrationAttributes
+	}
+
+	calibrationAttributes = calibrationAttributes.Add(CalibratedStartingTemperature, result.StartingTemperature)
+	if result.CoolingFactorCalibrated {
+		calibrationAttributes = calibrationAttributes.Add(CalibratedCoolingFactor, result.CoolingFactor)
+	}
+	return calibrationAttributes
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package explorer
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/move"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+// Checkpointable defines an explorer that can record its state in a checkpoint, and later restore that state once
+// initialised, so that exploration continues as though it had never been interrupted.
+type Checkpointable interface {
+	CaptureState(state *checkpoint.ExplorerState)
+	RestoreState(state checkpoint.ExplorerState)
+}
+
+// CaptureModel records the management action state of the supplied model, along with the state of its random number
+// generator (if it has one) under the supplied key, and its move statistics (if it counts its moves). The model is then
+// rebuilt from what was recorded (see RebuildModel), so that it carries on exactly as a model restored from the
+// checkpoint would.
+func CaptureModel(modelToCapture model.Model, state *checkpoint.ExplorerState, randomStateKey string) {
+	state.ModelEncoding = new(archive.ModelCompressor).Compress(modelToCapture).Encoding()
+	CaptureModelRandomState(modelToCapture, state, randomStateKey)
+	if countingModel, isCounting := modelToCapture.(move.StatisticsContainer); isCounting {
+		countingModel.MoveStatistics().CaptureState(&state.MoveStatistics)
+	}
+	RebuildModel(modelToCapture, state.ModelEncoding)
+}
+
+// CaptureModelRandomState records the state of the supplied model's random number generator (if it has one) under
+// the supplied key.
+func CaptureModelRandomState(modelToCapture model.Model, state *checkpoint.ExplorerState, randomStateKey string) {
+	if randomisedModel, isRandomised := modelToCapture.(rand.Container); isRandomised {
+		state.RandomStates[randomStateKey] = randomisedModel.RandomNumberGenerator().State()
+	}
+}
+
+// RestoreModel rebuilds the supplied model to the management action state recorded (see RebuildModel), returning its
+// random number generator (if it has one) to the state recorded under the supplied key, and its move statistics (if it
+// counts its moves) to those recorded. It panics if the recorded state does not suit the model.
+func RestoreModel(modelToRestore model.Model, state checkpoint.ExplorerState, randomStateKey string) {
+	RebuildModel(modelToRestore, state.ModelEncoding)
+	RestoreModelRandomState(modelToRestore, state, randomStateKey)
+	if countingModel, isCounting := modelToRestore.(move.StatisticsContainer); isCounting {
+		countingModel.MoveStatistics().RestoreState(state.MoveStatistics)
+	}
+}
+
+// RebuildModel re-initialises the supplied model and applies the encoded management action state to it, keeping its
+// random number generator and move statistics. A model's decision variables are updated incrementally as its actions
+// change, so their values depend on the order of those changes; rebuilding gives every model with the same encoding
+// the same decision variable values, however it came by them. It panics if the encoding does not suit the model.
+func RebuildModel(modelToRebuild model.Model, encoding string) {
+	modelState := checkpoint.ModelState{Encoding: encoding}
+	compressedState, decodeError := modelState.CompressedModelState(len(modelToRebuild.ManagementActions()))
+	if decodeError != nil {
+		panic(decodeError)
+	}
+
+	randomisedModel, isRandomised := modelToRebuild.(rand.Container)
+	var randomState rand.State
+	if isRandomised {
+		randomState = randomisedModel.RandomNumberGenerator().State()
+	}
+	countingModel, isCounting := modelToRebuild.(move.StatisticsContainer)
+	var statistics move.StatisticsState
+	if isCounting {
+		countingModel.MoveStatistics().CaptureState(&statistics)
+	}
+
+	modelToRebuild.Initialise(model.AsIs)
+	new(archive.ModelCompressor).Decompress(compressedState, modelToRebuild)
+
+	if isRandomised {
+		randomisedModel.SetRandomNumberGenerator(rand.NewFromState(randomState))
+	}
+	if isCounting {
+		countingModel.MoveStatistics().RestoreState(statistics)
+	}
+}
+
+// RestoreModelRandomState returns the supplied model's random number generator (if it has one) to the state
+// recorded under the supplied key.
+func RestoreModelRandomState(modelToRestore model.Model, state checkpoint.ExplorerState, randomStateKey string) {
+	randomState, hasRandomState := state.RandomStates[randomStateKey]
+	if !hasRandomState {
+		return
+	}
+	if randomisedModel, isRandomised := modelToRestore.(rand.Container); isRandomised {
+		randomisedModel.SetRandomNumberGenerator(rand.NewFromState(randomState))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/attributes"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/name"
@@ -23,8 +24,25 @@ const (
 	Temperature   = "Temperature"
 	CoolingFactor = "CoolingFactor"
 
+	ObjectiveValue        = "ObjectiveValue"
+	ObjectiveVariable     = "ObjectiveVariable"
+	OptimisationDirection = "OptimisationDirection"
+	ArchiveChanged        = "ArchiveChanged"
+	ExplorationExhausted  = "ExplorationExhausted"
+
 	AcceptanceProbability = "AcceptanceProbability"
 	ChangeAccepted        = "ChangeAccepted"
+
+	RandomSeed = "RandomSeed"
+)
+
+// Streams of random numbers an explorer derives from its random seed. Each consumer draws from its own stream, so
+// that how often one consumer draws has no bearing on the sequence seen by another.
+const (
+	CoolantRandomStream uint64 = iota
+	ModelRandomStream
+	PotentialModelRandomStream
+	ArchiveRandomStream
 )
 
 type Explorer interface {
@@ -44,6 +62,14 @@ type Explorer interface {
 	EventAttributes(eventType observer.EventType) attributes.Attributes
 }
 
+// SeedModel hands the supplied model a random number generator derived from seed for the given stream, if the model
+// accepts one.
+func SeedModel(modelToSeed model.Model, seed int64, stream uint64) {
+	if randomisedModel, isRandomised := modelToSeed.(rand.Container); isRandomised {
+		randomisedModel.SetRandomNumberGenerator(rand.NewDerived(seed, stream))
+	}
+}
+
 // Container defines an interface embedding an Explorer
 type Container interface {
 	SolutionExplorer() Explorer
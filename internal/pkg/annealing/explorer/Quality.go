@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package explorer
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+)
+
+const (
+	Hypervolume          = "Hypervolume"
+	Spread               = "Spread"
+	Spacing              = "Spacing"
+	GenerationalDistance = "GenerationalDistance"
+)
+
+// NotifyQuality tells the notifier's observers of the quality metrics measured of an archive, at the iteration
+// supplied.
+func NotifyQuality(notifier observer.EventNotifier, iteration uint64, metrics archive.QualityMetrics) {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Measured Archive Quality").
+		WithAttribute("Iteration", iteration).
+		JoiningAttributes(QualityAttributes(&metrics))
+
+	notifier.NotifyObserversOfEvent(*event)
+}
+
+// QualityAttributes returns the quality metrics measured of an archive as attributes, for reporting alongside the
+// outcome of annealing. No attributes are returned if no metrics were measured.
+func QualityAttributes(metrics *archive.QualityMetrics) attributes.Attributes {
+	qualityAttributes := attributes.Attributes{}
+	if metrics == nil {
+		return qualityAttributes
+	}
+
+	return qualityAttributes.
+		Add(Hypervolume, metrics.Hypervolume).
+		Add(Spread, metrics.Spread).
+		Add(Spacing, metrics.Spacing).
+		Add(GenerationalDistance, metrics.GenerationalDistance)
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package greedy offers a deterministic, budget-constrained baseline explorer. It ranks every management action by how
+// much activating it alone improves the objective per unit of cost, then activates actions greedily in rank order,
+// skipping any that would breach the cost limit or the model's own bounds. Each activation is a step along a
+// marginal-cost curve, reported at the end of annealing as a solution set. Annealing stops once every ranked action
+// has been tried.
+package greedy
+
+import (
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/LindsayBradford/crem/pkg/name"
+)
+
+const (
+	ObjectiveValue    = explorer.ObjectiveValue
+	CostValue         = "CostValue"
+	RankedActions     = "RankedActions"
+	ActivatedActions  = "ActivatedActions"
+	MarginalCostCurve = "MarginalCostCurve"
+)
+
+var _ explorer.Explorer = new(Explorer)
+
+// rankedAction is a management action's effect, when activated alone against the as-is model.
+type rankedAction struct {
+	index       int
+	improvement float64
+	cost        float64
+}
+
+// isFree reports whether the action improves the objective at no cost.
+func (ra rankedAction) isFree() bool {
+	return ra.cost <= 0
+}
+
+func (ra rankedAction) improvementPerCost() float64 {
+	return ra.improvement / ra.cost
+}
+
+type Explorer struct {
+	name.NameContainer
+	name.IdentifiableContainer
+
+	model.ContainedModel
+	loggers.ContainedLogger
+	rand.SeedContainer
+
+	observer.SynchronousAnnealingEventNotifier
+
+	parameters            Parameters
+	objectiveVariableName string
+	costVariableName      string
+	direction             dominance.Direction
+	costLimit             float64
+
+	ranking    []rankedAction
+	nextRank   int
+	curveSteps []*archive.CompressedModelState
+}
+
+func New() *Explorer {
+	newExplorer := new(Explorer)
+	newExplorer.parameters.Initialise()
+	newExplorer.SetModel(model.NewNullModel())
+	return newExplorer
+}
+
+func (e *Explorer) WithModel(model model.Model) *Explorer {
+	e.SetModel(model)
+	return e
+}
+
+func (e *Explorer) WithParameters(params parameters.Map) *Explorer {
+	e.SetParameters(params)
+	return e
+}
+
+func (e *Explorer) SetParameters(params parameters.Map) error {
+	e.parameters.AssignOnlyEnforcedUserValues(params)
+
+	e.direction, _ = dominance.ParseDirection(e.parameters.GetString(OptimisationDirection))
+	e.costLimit = e.parameters.GetFloat64(MaximumImplementationCost)
+
+	e.objectiveVariableName = e.checkedDecisionVariableFromParam(DecisionVariableName)
+	e.costVariableName = e.checkedDecisionVariableFromParam(CostDecisionVariable)
+
+	return e.parameters.ValidationErrors()
+}
+
+func (e *Explorer) checkedDecisionVariableFromParam(key string) string {
+	decisionVariableName := e.parameters.GetString(key)
+	if !e.Model().OffersDecisionVariable(decisionVariableName) {
+		e.parameters.AddValidationErrorMessage("decision variable [" + decisionVariableName + "] not recognised by model")
+	}
+	return decisionVariableName
+}
+
+func (e *Explorer) ParameterErrors() error {
+	mergedErrors := compositeErrors.New("Greedy Explorer Parameter Validation")
+
+	mergedErrors.Add(e.parameters.ValidationErrors())
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+
+	return nil
+}
+
+func (e *Explorer) Initialise() {
+	e.LogHandler().Debug(e.Id() + ": Initialising Greedy Explorer")
+
+	e.Model().Initialise(model.AsIs)
+	e.rankActions()
+
+	e.nextRank = 0
+	e.curveSteps = make([]*archive.CompressedModelState, 0, len(e.ranking))
+
+	e.notifyInitialisation()
+}
+
+// rankActions measures the effect of activating each management action alone, and ranks those that improve the
+// objective. Actions improving the objective for free come first (largest improvement first), then the rest by
+// improvement per unit of cost. Ties keep model order, so the ranking is deterministic.
+func (e *Explorer) rankActions() {
+	e.ranking = make([]rankedAction, 0)
+
+	for index := range e.Model().ManagementActions() {
+		if candidate := e.measureAction(index); candidate.improvement > 0 {
+			e.ranking = append(e.ranking, candidate)
+		}
+	}
+
+	sort.SliceStable(e.ranking, func(i, j int) bool {
+		first, second := e.ranking[i], e.ranking[j]
+		if first.isFree() != second.isFree() {
+			return first.isFree()
+		}
+		if first.isFree() {
+			return first.improvement > second.improvement
+		}
+		return first.improvementPerCost() > second.improvementPerCost()
+	})
+}
+
+func (e *Explorer) measureAction(index int) rankedAction {
+	objectiveBefore, costBefore := e.ObjectiveValue(), e.CostValue()
+
+	e.Model().SetManagementAction(index, true)
+	objectiveAfter, costAfter := e.ObjectiveValue(), e.CostValue()
+	e.Model().SetManagementAction(index, false)
+
+	return rankedAction{
+		index:       index,
+		improvement: e.improvementBetween(objectiveBefore, objectiveAfter),
+		cost:        costAfter - costBefore,
+	}
+}
+
+func (e *Explorer) improvementBetween(before float64, after float64) float64 {
+	if e.direction == dominance.Maximising {
+		return after - before
+	}
+	return before - after
+}
+
+func (e *Explorer) notifyInitialisation() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Initialising").
+		WithAttribute(explorer.OptimisationDirection, e.direction.String()).
+		WithAttribute(explorer.ObjectiveVariable, e.objectiveVariableName).
+		WithAttribute("CostVariable", e.costVariableName).
+		WithAttribute(RankedActions, len(e.ranking))
+
+	e.NotifyObserversOfEvent(*event)
+}
+
+func (e *Explorer) ObjectiveValue() float64 {
+	return e.Model().DecisionVariable(e.objectiveVariableName).Value()
+}
+
+func (e *Explorer) CostValue() float64 {
+	return e.Model().DecisionVariable(e.costVariableName).Value()
+}
+
+// TryRandomChange activates the next affordable action in rank order, recording the result as the next step of the
+// marginal-cost curve. Once the ranking is exhausted, it changes nothing, and reports itself exhausted so that
+// annealing stops.
+func (e *Explorer) TryRandomChange() {
+	for e.nextRank < len(e.ranking) {
+		candidate := e.ranking[e.nextRank]
+		e.nextRank++
+
+		if e.tryActivating(candidate) {
+			e.recordCurveStep()
+			return
+		}
+	}
+}
+
+// IsExhausted reports whether every ranked action has been tried.
+func (e *Explorer) IsExhausted() bool {
+	return e.nextRank >= len(e.ranking)
+}
+
+func (e *Explorer) tryActivating(candidate rankedAction) bool {
+	e.Model().SetManagementAction(candidate.index, true)
+
+	if e.costLimitExceeded() {
+		e.Model().SetManagementAction(candidate.index, false)
+		e.note("Skipping action exceeding cost limit")
+		return false
+	}
+
+	if isValid, _ := e.Model().ChangeIsValid(); !isValid {
+		e.Model().SetManagementAction(candidate.index, false)
+		e.note("Skipping action invalidating model")
+		return false
+	}
+
+	return true
+}
+
+func (e *Explorer) costLimitExceeded() bool {
+	return e.costLimit != noCostLimit && e.CostValue() > e.costLimit
+}
+
+func (e *Explorer) recordCurveStep() {
+	compressedModel := new(archive.ModelCompressor).Compress(e.Model())
+	compressedModel.SetId(e.Model().Id())
+	e.curveSteps = append(e.curveSteps, compressedModel)
+
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Activated action").
+		WithAttribute(ObjectiveValue, e.ObjectiveValue()).
+		WithAttribute(CostValue, e.CostValue())
+
+	e.NotifyObserversOfEvent(*event)
+}
+
+func (e *Explorer) note(text string) {
+	event := observer.NewEvent(observer.Explorer).WithNote(text)
+	e.NotifyObserversOfEvent(*event)
+}
+
+// CoolDown does nothing, there being no temperature to cool.
+func (e *Explorer) CoolDown() {}
+
+// MarginalCostCurve returns the model states after each activation so far, in activation order, as a solution set.
+// Every step is kept, even where an earlier step dominates a later one.
+func (e *Explorer) MarginalCostCurve() *archive.NonDominanceModelArchive {
+	curve := archive.New()
+	curve.SetId(e.Id())
+	curve.Restore(append([]*archive.CompressedModelState{}, e.curveSteps...))
+	return curve
+}
+
+func (e *Explorer) EventAttributes(eventType observer.EventType) attributes.Attributes {
+	baseAttributes := attributes.Attributes{}.
+		Add(ObjectiveValue, e.ObjectiveValue()).
+		Add(CostValue, e.CostValue()).
+		Add(ActivatedActions, len(e.curveSteps))
+
+	switch eventType {
+	case observer.StartedAnnealing:
+		return baseAttributes.Add(RankedActions, len(e.ranking))
+	case observer.StartedIteration, observer.Explorer:
+		return baseAttributes
+	case observer.FinishedIteration:
+		return baseAttributes.Add(explorer.ExplorationExhausted, e.IsExhausted())
+	case observer.FinishedAnnealing:
+		return baseAttributes.
+			Add(MarginalCostCurve, *e.MarginalCostCurve()).
+			Add(explorer.RandomSeed, e.RandomSeed())
+	}
+	return nil
+}
+
+func (e *Explorer) DeepClone() explorer.Explorer {
+	clone := *e
+	clone.SetModel(e.Model().DeepClone())
+	clone.ranking = nil
+	clone.curveSteps = nil
+	return &clone
+}
+
+func (e *Explorer) TearDown() {
+	e.LogHandler().Debug(e.Id() + ": Triggering tear-down of Greedy Explorer")
+	e.Model().TearDown()
+}
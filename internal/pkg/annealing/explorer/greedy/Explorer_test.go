@@ -0,0 +1,202 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package greedy
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	objectiveUnderTest = "SedimentProduction"
+	costUnderTest      = "ImplementationCost"
+)
+
+func TestExplorer_InvalidParameters_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	explorerUnderTest := New().
+		WithParameters(
+			parameters.Map{
+				OptimisationDirection:     "Sideways",
+				MaximumImplementationCost: float64(-1),
+			},
+		)
+
+	// then
+	g.Expect(explorerUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(explorerUnderTest.ParameterErrors().Error()).To(ContainSubstring("Sideways"))
+	g.Expect(explorerUnderTest.ParameterErrors().Error()).To(ContainSubstring(MaximumImplementationCost))
+}
+
+func TestExplorer_Initialise_RanksByImprovementPerCost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{})
+
+	// when
+	explorerUnderTest.Initialise()
+
+	// then
+	g.Expect(explorerUnderTest.ranking).To(Not(BeEmpty()))
+	g.Expect(explorerUnderTest.ObjectiveValue()).To(BeNumerically(">", 0))
+	g.Expect(explorerUnderTest.Model().ActiveManagementActions()).To(BeEmpty())
+
+	for rank, candidate := range explorerUnderTest.ranking {
+		g.Expect(candidate.improvement).To(BeNumerically(">", 0))
+		if rank == 0 || candidate.isFree() {
+			continue
+		}
+		previous := explorerUnderTest.ranking[rank-1]
+		if !previous.isFree() {
+			g.Expect(candidate.improvementPerCost()).To(BeNumerically("<=", previous.improvementPerCost()))
+		}
+	}
+}
+
+func TestExplorer_NoCostLimit_ActivatesEveryRankedAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{})
+	explorerUnderTest.Initialise()
+	asIsObjective := explorerUnderTest.ObjectiveValue()
+	rankedActions := len(explorerUnderTest.ranking)
+
+	// when
+	for change := 0; change < rankedActions+2; change++ {
+		explorerUnderTest.TryRandomChange()
+	}
+
+	// then
+	g.Expect(explorerUnderTest.curveSteps).To(HaveLen(rankedActions))
+	g.Expect(explorerUnderTest.Model().ActiveManagementActions()).To(HaveLen(rankedActions))
+	g.Expect(explorerUnderTest.ObjectiveValue()).To(BeNumerically("<", asIsObjective))
+}
+
+func TestExplorer_CostLimit_Respected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	unlimitedExplorer := buildTestExplorer(g, parameters.Map{})
+	unlimitedExplorer.Initialise()
+	for change := 0; change < len(unlimitedExplorer.ranking); change++ {
+		unlimitedExplorer.TryRandomChange()
+	}
+	costLimit := unlimitedExplorer.CostValue() / 2
+
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{MaximumImplementationCost: costLimit})
+	explorerUnderTest.Initialise()
+
+	// when
+	for change := 0; change < len(explorerUnderTest.ranking); change++ {
+		explorerUnderTest.TryRandomChange()
+	}
+
+	// then
+	g.Expect(explorerUnderTest.CostValue()).To(BeNumerically("<=", costLimit))
+	g.Expect(len(explorerUnderTest.curveSteps)).To(BeNumerically("<", len(unlimitedExplorer.curveSteps)))
+}
+
+func TestExplorer_MaximisingSediment_NoActionsRanked(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{OptimisationDirection: "Maximising"})
+
+	// when
+	explorerUnderTest.Initialise()
+	explorerUnderTest.TryRandomChange()
+
+	// then
+	g.Expect(explorerUnderTest.ranking).To(BeEmpty())
+	g.Expect(explorerUnderTest.curveSteps).To(BeEmpty())
+}
+
+func TestExplorer_FinishedAnnealing_ReportsMarginalCostCurve(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{})
+	explorerUnderTest.SetId("GreedyTest")
+	explorerUnderTest.Initialise()
+
+	explorerUnderTest.TryRandomChange()
+	explorerUnderTest.TryRandomChange()
+
+	// when
+	finishedAttributes := explorerUnderTest.EventAttributes(observer.FinishedAnnealing)
+
+	// then
+	curve, isCurve := finishedAttributes.Value(MarginalCostCurve).(archive.NonDominanceModelArchive)
+	g.Expect(isCurve).To(BeTrue())
+	g.Expect(curve.Id()).To(Equal("GreedyTest"))
+	g.Expect(curve.Len()).To(BeNumerically("==", 2))
+	g.Expect(curve.Archive()[1].MatchesStateOf(explorerUnderTest.Model())).To(BeTrue())
+}
+
+func TestExplorer_RankingTried_ReportsExhausted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{})
+	explorerUnderTest.Initialise()
+	rankedActions := len(explorerUnderTest.ranking)
+
+	// when
+	for change := 0; change < rankedActions-1; change++ {
+		explorerUnderTest.TryRandomChange()
+	}
+	partlyTriedAttributes := explorerUnderTest.EventAttributes(observer.FinishedIteration)
+
+	explorerUnderTest.TryRandomChange()
+	triedAttributes := explorerUnderTest.EventAttributes(observer.FinishedIteration)
+
+	// then
+	g.Expect(partlyTriedAttributes.Value(explorer.ExplorationExhausted)).To(BeFalse())
+	g.Expect(triedAttributes.Value(explorer.ExplorationExhausted)).To(BeTrue())
+}
+
+func TestExplorer_FinishedAnnealing_ReportsRandomSeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(g, parameters.Map{})
+	explorerUnderTest.SetRandomSeed(42)
+	explorerUnderTest.Initialise()
+
+	// when
+	finishedAttributes := explorerUnderTest.EventAttributes(observer.FinishedAnnealing)
+
+	// then
+	g.Expect(finishedAttributes.Value(explorer.RandomSeed)).To(Equal(int64(42)))
+}
+
+func buildTestExplorer(g *GomegaWithT, params parameters.Map) *Explorer {
+	params[DecisionVariableName] = objectiveUnderTest
+	params[CostDecisionVariable] = costUnderTest
+
+	newExplorer := New().WithModel(buildTestModel(g)).WithParameters(params)
+	newExplorer.SetLogHandler(loggers.DefaultTestingLogger)
+
+	g.Expect(newExplorer.ParameterErrors()).To(BeNil())
+	return newExplorer
+}
+
+func buildTestModel(g *GomegaWithT) *catchment.Model {
+	newModel := catchment.NewModel().WithParameters(parameters.Map{"DataSourcePath": "testdata/ValidModel.csv"})
+	g.Expect(newModel.ParameterErrors()).To(BeNil())
+
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
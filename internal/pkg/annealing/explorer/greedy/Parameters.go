@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package greedy
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+type Parameters struct {
+	parameters.Parameters
+}
+
+func (p *Parameters) Initialise() *Parameters {
+	p.Parameters.
+		Initialise("Greedy Explorer Parameter Validation").
+		Enforcing(ParameterSpecifications())
+	return p
+}
+
+const (
+	DecisionVariableName      = "DecisionVariable"
+	OptimisationDirection     = "OptimisationDirection"
+	CostDecisionVariable      = "CostDecisionVariable"
+	MaximumImplementationCost = "MaximumImplementationCost"
+
+	defaultCostDecisionVariable = "ImplementationCost"
+	noCostLimit                 = float64(0)
+)
+
+func ParameterSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          DecisionVariableName,
+			Validator:    IsString,
+			DefaultValue: "SedimentProduction",
+		},
+	).Add(
+		Specification{
+			Key:          OptimisationDirection,
+			Validator:    isOptimisationDirection,
+			DefaultValue: dominance.Minimising.String(),
+		},
+	).Add(
+		Specification{
+			Key:          CostDecisionVariable,
+			Validator:    IsString,
+			DefaultValue: defaultCostDecisionVariable,
+		},
+	).Add(
+		Specification{
+			Key:          MaximumImplementationCost,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: noCostLimit,
+		},
+	)
+	return specs
+}
+
+func isOptimisationDirection(key string, value interface{}) error {
+	valueAsString, typeIsOk := value.(string)
+	if !typeIsOk {
+		return NewInvalidSpecificationError("Parameter [" + key + "] must be a string value")
+	}
+	if _, parsingError := dominance.ParseDirection(valueAsString); parsingError != nil {
+		return NewInvalidSpecificationError(parsingError.Error())
+	}
+	return NewValidSpecificationError(key, value)
+}
@@ -3,10 +3,13 @@
 package kirkpatrick
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
 	"math"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/calibration"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
@@ -21,10 +24,12 @@ import (
 
 const (
 	CompressedModel        = "CompressedModel"
-	ObjectiveValue         = "ObjectiveValue"
+	ObjectiveValue         = explorer.ObjectiveValue
 	ChangeInObjectiveValue = "ChangeInObjectiveValue"
 )
 
+var _ explorer.Checkpointable = new(Explorer)
+
 type Explorer struct {
 	name.NameContainer
 	name.IdentifiableContainer
@@ -33,6 +38,10 @@ type Explorer struct {
 	loggers.ContainedLogger
 
 	kirkpatrick.Coolant
+	rand.SeedContainer
+
+	calibrator  calibration.Calibrator
+	calibration *calibration.Result
 
 	scenarioId string
 
@@ -45,6 +54,7 @@ type Explorer struct {
 	changeInvalid        bool
 	reasonChangeInvalid  string
 	objectiveValueChange float64
+	bestObjectiveValue   float64
 
 	observer.SynchronousAnnealingEventNotifier
 
@@ -55,6 +65,7 @@ func New() *Explorer {
 	newExplorer := new(Explorer)
 	newExplorer.parameters.Initialise()
 	newExplorer.Coolant.Initialise()
+	newExplorer.calibrator.Initialise()
 	newExplorer.SetModel(model.NewNullModel())
 	return newExplorer
 }
@@ -64,20 +75,64 @@ func (ke *Explorer) Initialise() {
 
 	ke.notifyInitialisation()
 
-	ke.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	ke.SetRandomNumberGenerator(rand.NewDerived(ke.RandomSeed(), explorer.CoolantRandomStream))
 	ke.Model().Initialise(model.Random)
+	explorer.SeedModel(ke.Model(), ke.RandomSeed(), explorer.ModelRandomStream)
 	ke.Model().Randomize()
+	ke.calibrateCoolant()
+	ke.bestObjectiveValue = ke.ObjectiveValue()
 
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(ObjectiveValue, ke.ObjectiveValue()).
 		Add(explorer.Temperature, ke.Temperature)
 }
 
+// calibrateCoolant derives the starting temperature (and optionally cooling factor) from the objective value changes
+// of random changes to the model, if calibration has been asked for.
+func (ke *Explorer) calibrateCoolant() {
+	ke.calibration = nil
+	if !ke.calibrator.IsEnabled() {
+		return
+	}
+
+	result, calibrationError := ke.calibrator.
+		WithAcceptanceFunction(ke.acceptanceProbabilityAt).
+		WithUndesirabilityTest(ke.isUndesirable).
+		Calibrate(ke.Model(), []string{ke.objectiveVariableName})
+	if calibrationError != nil {
+		ke.LogHandler().Warn(ke.scenarioId + ": Coolant calibration failed, keeping supplied values: " + calibrationError.Error())
+		return
+	}
+
+	ke.Temperature = result.StartingTemperature
+	if result.CoolingFactorCalibrated {
+		ke.CoolingFactor = result.CoolingFactor
+	}
+	ke.calibration = &result
+
+	ke.LogHandler().Info(ke.scenarioId + ": Calibrated coolant with " + result.String())
+	explorer.NotifyCalibration(ke, result)
+}
+
+func (ke *Explorer) acceptanceProbabilityAt(variableChanges []float64, temperature float64) float64 {
+	return ke.Coolant.AcceptanceProbabilityAt(variableChanges[0], temperature)
+}
+
+func (ke *Explorer) isUndesirable(variableChanges []float64) bool {
+	switch ke.optimisationDirection {
+	case Maximising:
+		return variableChanges[0] < 0
+	default:
+		return variableChanges[0] > 0
+	}
+}
+
 func (ke *Explorer) notifyInitialisation() {
 	event := observer.NewEvent(observer.Explorer).
 		WithNote("Initialising").
-		WithAttribute("OptimisationDirection", ke.optimisationDirection).
-		WithAttribute("ObjectiveVariable", ke.objectiveVariableName)
+		WithAttribute(explorer.OptimisationDirection, ke.optimisationDirection).
+		WithAttribute(explorer.ObjectiveVariable, ke.objectiveVariableName).
+		WithAttribute(explorer.RandomSeed, ke.RandomSeed())
 
 	ke.NotifyObserversOfEvent(*event)
 }
@@ -101,6 +156,8 @@ func (ke *Explorer) SetParameters(params parameters.Map) error {
 	ke.parameters.AssignOnlyEnforcedUserValues(params)
 	ke.Coolant.WithParameters(params)
 
+	ke.calibrator.SetParameters(params)
+
 	ke.setOptimisationDirectionFromParams()
 	ke.checkDecisionVariableFromParams()
 
@@ -139,6 +196,7 @@ func (ke *Explorer) ParameterErrors() error {
 
 	mergedErrors.Add(ke.parameters.ValidationErrors())
 	mergedErrors.Add(ke.Coolant.ParameterErrors())
+	mergedErrors.Add(ke.calibrator.ParameterErrors())
 
 	if mergedErrors.Size() > 0 {
 		return mergedErrors
@@ -266,12 +324,33 @@ func (ke *Explorer) RevertLastChange() {
 
 func (ke *Explorer) DeepClone() explorer.Explorer {
 	clone := *ke
+	clone.Coolant = ke.Coolant.DeepClone()
 	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
 	modelClone := ke.Model().DeepClone()
 	clone.SetModel(modelClone)
 	return &clone
 }
 
+func (ke *Explorer) CaptureState(state *checkpoint.ExplorerState) {
+	state.Temperature = ke.Temperature
+	state.AcceptanceProbability = ke.AcceptanceProbability
+	state.CoolingSchedule = ke.ScheduleState()
+	state.BestObjectiveValue = ke.bestObjectiveValue
+	state.RandomStates[checkpoint.CoolantRandomState] = ke.RandomNumberGenerator().State()
+
+	explorer.CaptureModel(ke.Model(), state, checkpoint.ModelRandomState)
+}
+
+func (ke *Explorer) RestoreState(state checkpoint.ExplorerState) {
+	ke.Temperature = state.Temperature
+	ke.AcceptanceProbability = state.AcceptanceProbability
+	ke.RestoreScheduleState(state.CoolingSchedule)
+	ke.SetRandomNumberGenerator(rand.NewFromState(state.RandomStates[checkpoint.CoolantRandomState]))
+
+	explorer.RestoreModel(ke.Model(), state, checkpoint.ModelRandomState)
+	ke.bestObjectiveValue = state.BestObjectiveValue
+}
+
 func (ke *Explorer) TearDown() {
 	ke.LogHandler().Debug(ke.scenarioId + ": Triggering tear-down of CompressedModel Explorer")
 	ke.Model().TearDown()
@@ -296,7 +375,11 @@ func (ke *Explorer) EventAttributes(eventType observer.EventType) attributes.Att
 		return ke.baseAttributes.
 			Replace(ObjectiveValue, ke.ObjectiveValue()).
 			Replace(explorer.Temperature, ke.Temperature).
-			Add(CompressedModel, *ke.fetchFinalCompressedModel())
+			Add(CompressedModel, *ke.fetchFinalCompressedModel()).
+			Add(explorer.ObjectiveVariable, ke.objectiveVariableName).
+			Add(explorer.OptimisationDirection, ke.optimisationDirection.String()).
+			Add(explorer.RandomSeed, ke.RandomSeed()).
+			Join(explorer.CalibrationAttributes(ke.calibration))
 	case observer.Explorer:
 		return ke.baseAttributes.
 			Replace(ObjectiveValue, ke.ObjectiveValue()).
@@ -328,10 +411,32 @@ func (ke *Explorer) note(note string) {
 }
 
 func (ke *Explorer) CoolDown() {
+	ke.ObserveOutcome(schedules.Outcome{Accepted: ke.changeAccepted, Improved: ke.changeImprovedBest()})
 	ke.Coolant.CoolDown()
 	ke.notifyCoolDown()
 }
 
+// changeImprovedBest reports whether the latest change was accepted with the best objective value seen yet.
+func (ke *Explorer) changeImprovedBest() bool {
+	if !ke.changeAccepted {
+		return false
+	}
+
+	objectiveValue := ke.ObjectiveValue()
+	improved := false
+	switch ke.optimisationDirection {
+	case Minimising:
+		improved = objectiveValue < ke.bestObjectiveValue
+	case Maximising:
+		improved = objectiveValue > ke.bestObjectiveValue
+	}
+
+	if improved {
+		ke.bestObjectiveValue = objectiveValue
+	}
+	return improved
+}
+
 func (ke *Explorer) notifyCoolDown() {
 	event := observer.NewEvent(observer.Explorer).
 		WithNote("Cooling").
@@ -0,0 +1,81 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package kirkpatrick
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testModelDataPath  = "../../../model/models/catchment/testdata/TestingModel.csv"
+	objectiveUnderTest = "SedimentProduction"
+	testRandomSeed     = int64(1234567)
+
+	iterationsBeforeCapture = 50
+	iterationsAfterResuming = 10
+)
+
+func TestExplorer_RestoreState_KeepsScheduleState(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	uninterruptedExplorer := buildTestExplorer(g)
+	uninterruptedExplorer.Initialise()
+	explore(uninterruptedExplorer, iterationsBeforeCapture)
+
+	capturedState := checkpoint.New("Test Run").Explorer
+	uninterruptedExplorer.CaptureState(&capturedState)
+
+	// when
+	resumedExplorer := buildTestExplorer(g)
+	resumedExplorer.Initialise()
+	resumedExplorer.RestoreState(capturedState)
+
+	// then
+	g.Expect(resumedExplorer.bestObjectiveValue).To(Equal(uninterruptedExplorer.bestObjectiveValue))
+
+	explore(uninterruptedExplorer, iterationsAfterResuming)
+	explore(resumedExplorer, iterationsAfterResuming)
+
+	g.Expect(resumedExplorer.ScheduleState()).To(Equal(uninterruptedExplorer.ScheduleState()))
+	g.Expect(resumedExplorer.Temperature).To(Equal(uninterruptedExplorer.Temperature))
+}
+
+func explore(explorerUnderTest *Explorer, iterations int) {
+	for iteration := 0; iteration < iterations; iteration++ {
+		explorerUnderTest.TryRandomChange()
+		explorerUnderTest.CoolDown()
+	}
+}
+
+func buildTestExplorer(g *GomegaWithT) *Explorer {
+	newExplorer := New().WithModel(buildTestModel(g)).WithParameters(parameters.Map{
+		DecisionVariableName:            objectiveUnderTest,
+		kirkpatrick.StartingTemperature: float64(50),
+		kirkpatrick.CoolingFactor:       float64(0.99),
+		schedules.ReheatingInterval:     int64(iterationsBeforeCapture * 4),
+	})
+	newExplorer.SetLogHandler(loggers.DefaultTestingLogger)
+	newExplorer.SetRandomSeed(testRandomSeed)
+
+	g.Expect(newExplorer.ParameterErrors()).To(BeNil())
+	return newExplorer
+}
+
+func buildTestModel(g *GomegaWithT) *catchment.Model {
+	newModel := catchment.NewModel().WithParameters(parameters.Map{catchmentParameters.DataSourcePath: testModelDataPath})
+	g.Expect(newModel.ParameterErrors()).To(BeNil())
+
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
@@ -4,15 +4,20 @@ package suppapitnarm
 
 import (
 	"fmt"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/calibration"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/schedules"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	errors2 "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	"github.com/LindsayBradford/crem/pkg/name"
@@ -30,6 +35,8 @@ const (
 	LastReturnedToBase              = "LastReturnedToBase"
 )
 
+var _ explorer.Checkpointable = new(Explorer)
+
 type Explorer struct {
 	name.NameContainer
 	name.IdentifiableContainer
@@ -40,15 +47,25 @@ type Explorer struct {
 	loggers.ContainedLogger
 
 	coolant cooling.TemperatureCoolant
+	rand.SeedContainer
+
+	calibrator  calibration.Calibrator
+	calibration *calibration.Result
 
 	scenarioId string
 
-	parameters            Parameters
-	optimisationDirection optimisationDirection
-	objectiveVariableName string
+	parameters             Parameters
+	optimisationDirections map[string]dominance.Direction
+	objectiveVariableName  string
 
 	modelArchive         archive.NonDominanceModelArchive
 	archiveStorageResult archive.StorageResult
+	archiveImproved      bool
+	archiveChanged       bool
+
+	qualityInterval   uint64
+	qualityNormaliser *archive.Normaliser
+	measuredArchive   *archive.NonDominanceModelArchive
 
 	currentIteration   uint64
 	lastReturnedToBase uint64
@@ -74,6 +91,7 @@ func New() *Explorer {
 	newExplorer := new(Explorer)
 	newExplorer.parameters.Initialise()
 	newExplorer.SetCoolant(suppapitnarm.NewCoolant())
+	newExplorer.calibrator.Initialise()
 	newExplorer.modelArchive.Initialise()
 
 	newExplorer.currentModel = model.NewNullModel()
@@ -84,17 +102,30 @@ func New() *Explorer {
 
 func (ke *Explorer) Initialise() {
 	ke.LogHandler().Debug(ke.scenarioId + ": Initialising Solution Explorer")
+	ke.notifyRandomSeed()
+
 	ke.modelArchive.Initialise()
-	ke.coolant.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	ke.modelArchive.SetRandomNumberGenerator(rand.NewDerived(ke.RandomSeed(), explorer.ArchiveRandomStream))
+	ke.coolant.SetRandomNumberGenerator(rand.NewDerived(ke.RandomSeed(), explorer.CoolantRandomStream))
 
 	ke.currentModel.Initialise(model.Random)
+	explorer.SeedModel(ke.currentModel, ke.RandomSeed(), explorer.ModelRandomStream)
 	ke.currentModel.Randomize()
 
 	ke.potentialModel.Initialise(model.Random)
+	explorer.SeedModel(ke.potentialModel, ke.RandomSeed(), explorer.PotentialModelRandomStream)
+
+	ke.directDecisionVariablesOf(ke.currentModel)
+	ke.directDecisionVariablesOf(ke.potentialModel)
+
+	ke.calibrateCoolant()
+	ke.deriveQualityNormaliser()
 
 	ke.deriveIterationsUntilReturnToBase()
 	ke.currentIteration = 1
 
+	ke.measuredArchive = nil
+
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(explorer.Temperature, ke.coolant.Temperature()).
 		Add(ArchiveSize, ke.modelArchive.Len())
@@ -115,6 +146,94 @@ func (ke *Explorer) Initialise() {
 		WithAttribute(observer.Note.String(), "")
 }
 
+// directDecisionVariablesOf sets the optimisation direction of each of the model's decision variables that has one
+// declared in the explorer's parameters.
+func (ke *Explorer) directDecisionVariablesOf(directedModel model.Model) {
+	decisionVariables := directedModel.DecisionVariables()
+	if decisionVariables == nil {
+		return
+	}
+
+	for variableName, direction := range ke.optimisationDirections {
+		decisionVariable, variableFound := (*decisionVariables)[variableName]
+		if !variableFound {
+			ke.LogHandler().Warn(ke.scenarioId + ": Optimisation direction supplied for unknown decision variable [" + variableName + "]")
+			continue
+		}
+		if directedVariable, isDirected := decisionVariable.(variable.Directed); isDirected {
+			directedVariable.SetOptimisationDirection(direction)
+		}
+	}
+}
+
+// deriveQualityNormaliser fixes the bounds archive quality is measured over to the decision variable values of the
+// model in its as-is state, and with every management action active, so that quality metrics compare between
+// iterations and between runs of the same model.
+func (ke *Explorer) deriveQualityNormaliser() {
+	boundingModel := ke.currentModel.DeepClone()
+	boundingModel.Initialise(model.AsIs)
+	asIsBound := ke.modelArchive.Compress(boundingModel).Variables
+
+	for index := range boundingModel.ManagementActions() {
+		boundingModel.SetManagementAction(index, true)
+	}
+	allActiveBound := ke.modelArchive.Compress(boundingModel).Variables
+
+	ke.qualityNormaliser = archive.NewBoundedNormaliser(asIsBound, allActiveBound, archive.DirectionsOf(ke.currentModel))
+}
+
+// calibrateCoolant derives the starting temperature (and optionally cooling factor) from the decision variable changes
+// of random changes to the current model, if calibration has been asked for.
+func (ke *Explorer) calibrateCoolant() {
+	ke.calibration = nil
+	if !ke.calibrator.IsEnabled() {
+		return
+	}
+
+	variableNames := ke.currentModel.DecisionVariables().SortedKeys()
+	result, calibrationError := ke.calibrator.
+		WithAcceptanceFunction(ke.coolant.AcceptanceProbabilityAt).
+		WithUndesirabilityTest(worseningAnyOf(archive.DirectionsOf(ke.currentModel))).
+		Calibrate(ke.currentModel, variableNames)
+	if calibrationError != nil {
+		ke.LogHandler().Warn(ke.scenarioId + ": Coolant calibration failed, keeping supplied values: " + calibrationError.Error())
+		return
+	}
+
+	ke.coolant.SetTemperature(result.StartingTemperature)
+	if result.CoolingFactorCalibrated {
+		ke.coolant.SetCoolingFactor(result.CoolingFactor)
+	}
+	ke.calibration = &result
+
+	ke.LogHandler().Info(ke.scenarioId + ": Calibrated coolant with " + result.String())
+	explorer.NotifyCalibration(ke, result)
+}
+
+// worseningAnyOf returns a test for changes that worsen at least one decision variable, given the optimisation
+// direction of each.
+func worseningAnyOf(directions dominance.Directions) calibration.UndesirabilityTest {
+	return func(variableChanges []float64) bool {
+		for index, change := range variableChanges {
+			if directions[index] == dominance.Maximising && change < 0 {
+				return true
+			}
+			if directions[index] != dominance.Maximising && change > 0 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (ke *Explorer) notifyRandomSeed() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Initialising").
+		WithAttribute(explorer.RandomSeed, ke.RandomSeed())
+
+	ke.NotifyObserversOfEvent(*event)
+}
+
 func (ke *Explorer) WithName(name string) *Explorer {
 	ke.SetName(name)
 	return ke
@@ -148,9 +267,12 @@ func (ke *Explorer) WithParameters(params parameters.Map) *Explorer {
 func (ke *Explorer) SetParameters(params parameters.Map) error {
 	ke.parameters.AssignOnlyEnforcedUserValues(params)
 	ke.coolant.SetParameters(params)
+	ke.calibrator.SetParameters(params)
 
 	ke.returnToBaseStep = float64(ke.parameters.GetInt64(InitialReturnToBaseStep))
 	ke.returnToBaseIsolationFraction = 1
+	ke.optimisationDirections, _ = parseOptimisationDirections(ke.parameters.GetMap(OptimisationDirections))
+	ke.qualityInterval = uint64(ke.parameters.GetInt64(QualityMeasurementInterval))
 
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(explorer.Temperature, ke.coolant.Temperature()).
@@ -172,6 +294,7 @@ func (ke *Explorer) ParameterErrors() error {
 
 	mergedErrors.Add(ke.parameters.ValidationErrors())
 	mergedErrors.Add(ke.coolant.ParameterErrors())
+	mergedErrors.Add(ke.calibrator.ParameterErrors())
 
 	if mergedErrors.Size() > 0 {
 		return mergedErrors
@@ -201,13 +324,47 @@ func (ke *Explorer) TryRandomChange() {
 	ke.NotifyObserversOfEvent(*event)
 
 	ke.archiveStorageResult = ke.modelArchive.AttemptToArchiveState(compressedChangedModelState)
+	ke.archiveImproved = ke.archiveStorageResult == archive.StoredWithNoDominanceDetected ||
+		ke.archiveStorageResult == archive.StoredReplacingDominatedEntries
 
 	ke.AcceptOrRevertChange(variableDifferences)
+	ke.archiveChanged = archiveStored(ke.archiveStorageResult)
 	ke.ReturnToBaseIfRequired(compressedChangedModelState)
+	ke.MeasureQualityIfRequired()
 
 	ke.currentIteration++
 }
 
+// archiveStored reports whether the storage result is one where the archive took in the model state offered.
+func archiveStored(result archive.StorageResult) bool {
+	switch result {
+	case archive.StoredWithNoDominanceDetected, archive.StoredReplacingDominatedEntries,
+		archive.StoredForcingDominatingStateRemoval:
+		return true
+	}
+	return false
+}
+
+// MeasureQualityIfRequired measures the quality of the archive every QualityMeasurementInterval iterations, if an
+// interval has been asked for.
+func (ke *Explorer) MeasureQualityIfRequired() {
+	if ke.qualityInterval == 0 || ke.currentIteration%ke.qualityInterval != 0 {
+		return
+	}
+
+	metrics := ke.ArchiveQuality()
+	ke.measuredArchive = archive.New()
+	ke.measuredArchive.Restore(append([]*archive.CompressedModelState{}, ke.modelArchive.Archive()...))
+
+	explorer.NotifyQuality(ke, ke.currentIteration, metrics)
+}
+
+// ArchiveQuality returns the quality metrics of the archive, with generational distance measured against the archive
+// as it stood at the last interval measurement.
+func (ke *Explorer) ArchiveQuality() archive.QualityMetrics {
+	return ke.modelArchive.MeasureQuality(ke.measuredArchive, ke.qualityNormaliser)
+}
+
 func (ke *Explorer) generatePotentialModel() {
 	ke.note("Creating and Randomizing potential new model off old.")
 	ke.potentialModel.SynchroniseTo(ke.currentModel)
@@ -392,12 +549,71 @@ func (ke *Explorer) deriveIterationsUntilReturnToBase() {
 
 func (ke *Explorer) DeepClone() explorer.Explorer {
 	clone := *ke
-	clone.coolant.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	clone.coolant = ke.coolant.DeepClone()
 	clone.currentModel = ke.currentModel.DeepClone()
 	clone.potentialModel = ke.currentModel.DeepClone()
 	return &clone
 }
 
+func (ke *Explorer) CaptureState(state *checkpoint.ExplorerState) {
+	state.Temperature = ke.coolant.Temperature()
+	state.AcceptanceProbability = ke.coolant.AcceptanceProbability()
+	state.CoolingSchedule = ke.coolant.ScheduleState()
+	state.RandomStates[checkpoint.CoolantRandomState] = ke.coolant.RandomNumberGenerator().State()
+	state.RandomStates[checkpoint.ArchiveRandomState] = ke.modelArchive.RandomNumberGenerator().State()
+
+	explorer.CaptureModel(ke.currentModel, state, checkpoint.ModelRandomState)
+	explorer.CaptureModelRandomState(ke.potentialModel, state, checkpoint.PotentialModelRandomState)
+	ke.rebuildModels(state.ModelEncoding)
+
+	state.CurrentIteration = ke.currentIteration
+	state.LastReturnedToBase = ke.lastReturnedToBase
+	state.ReturnToBaseStep = ke.returnToBaseStep
+	state.IterationsUntilReturnToBase = ke.iterationsUntilReturnToBase
+	state.ReturnToBaseIsolationFraction = ke.returnToBaseIsolationFraction
+
+	state.Archive = make([]checkpoint.ModelState, 0, ke.modelArchive.Len())
+	for _, archivedState := range ke.modelArchive.Archive() {
+		state.Archive = append(state.Archive, checkpoint.NewModelState(archivedState))
+	}
+}
+
+func (ke *Explorer) RestoreState(state checkpoint.ExplorerState) {
+	ke.coolant.SetTemperature(state.Temperature)
+	ke.coolant.SetAcceptanceProbability(state.AcceptanceProbability)
+	ke.coolant.RestoreScheduleState(state.CoolingSchedule)
+	ke.coolant.SetRandomNumberGenerator(rand.NewFromState(state.RandomStates[checkpoint.CoolantRandomState]))
+	ke.modelArchive.SetRandomNumberGenerator(rand.NewFromState(state.RandomStates[checkpoint.ArchiveRandomState]))
+
+	explorer.RestoreModel(ke.currentModel, state, checkpoint.ModelRandomState)
+	ke.rebuildModels(state.ModelEncoding)
+	explorer.RestoreModelRandomState(ke.potentialModel, state, checkpoint.PotentialModelRandomState)
+
+	ke.currentIteration = state.CurrentIteration
+	ke.lastReturnedToBase = state.LastReturnedToBase
+	ke.returnToBaseStep = state.ReturnToBaseStep
+	ke.iterationsUntilReturnToBase = state.IterationsUntilReturnToBase
+	ke.returnToBaseIsolationFraction = state.ReturnToBaseIsolationFraction
+
+	ke.restoreArchive(&state)
+}
+
+// rebuildModels rebuilds the potential model to the current model's encoded state alongside the current model, as its
+// decision variables also depend on the order its actions changed in, and re-directs both models' decision variables.
+func (ke *Explorer) rebuildModels(encoding string) {
+	explorer.RebuildModel(ke.potentialModel, encoding)
+	ke.directDecisionVariablesOf(ke.currentModel)
+	ke.directDecisionVariablesOf(ke.potentialModel)
+}
+
+func (ke *Explorer) restoreArchive(state *checkpoint.ExplorerState) {
+	restoredStates, decodeError := state.ArchivedModelStates(ke.currentModel)
+	if decodeError != nil {
+		panic(decodeError)
+	}
+	ke.modelArchive.Restore(restoredStates)
+}
+
 func (ke *Explorer) Model() model.Model {
 	return ke.currentModel
 }
@@ -429,20 +645,26 @@ func (ke *Explorer) EventAttributes(eventType observer.EventType) attributes.Att
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len())
 	case observer.FinishedAnnealing:
+		finalQuality := ke.ArchiveQuality()
 		return ke.baseAttributes.
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len()).
-			Add(ModelArchive, ke.modelArchive)
+			Add(ModelArchive, ke.modelArchive).
+			Add(explorer.RandomSeed, ke.RandomSeed()).
+			Join(explorer.CalibrationAttributes(ke.calibration)).
+			Join(explorer.QualityAttributes(&finalQuality))
 	case observer.FinishedIteration:
 		return ke.baseAttributes.
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len()).
+			Add(explorer.ArchiveChanged, ke.archiveChanged).
 			Add(LastReturnedToBase, ke.lastReturnedToBase)
 	}
 	return nil
 }
 
 func (ke *Explorer) CoolDown() {
+	ke.coolant.ObserveOutcome(schedules.Outcome{Accepted: ke.changeAccepted, Improved: ke.archiveImproved})
 	ke.coolant.CoolDown()
 	ke.notifyCoolDown()
 }
@@ -3,7 +3,10 @@
 package suppapitnarm
 
 import (
+	"fmt"
+
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 
 	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 )
@@ -24,14 +27,10 @@ const (
 	InitialReturnToBaseStep       = "InitialReturnToBaseStep"
 	MinimumReturnToBaseRate       = "MinimumReturnToBaseRate"
 	ReturnToBaseIsolationFraction = "ReturnToBaseIsolationFraction"
-)
-
-type optimisationDirection int
+	OptimisationDirections        = "OptimisationDirections"
+	QualityMeasurementInterval    = "QualityMeasurementInterval"
 
-const (
-	Invalid optimisationDirection = iota
-	Minimising
-	Maximising
+	measuredOnlyWhenFinished = int64(0)
 )
 
 func ParameterSpecifications() *Specifications {
@@ -60,6 +59,49 @@ func ParameterSpecifications() *Specifications {
 			Validator:    IsDecimalBetweenZeroAndOne,
 			DefaultValue: float64(0.9), // following initial CRP hard-coded default
 		},
+	).Add(
+		Specification{
+			Key:          OptimisationDirections,
+			Validator:    isOptimisationDirectionTable,
+			DefaultValue: parameters.Map{}, // every decision variable minimised
+		},
+	).Add(
+		Specification{
+			Key:          QualityMeasurementInterval,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: measuredOnlyWhenFinished,
+		},
 	)
 	return specs
 }
+
+func isOptimisationDirectionTable(key string, value interface{}) error {
+	if _, parsingError := parseOptimisationDirections(value); parsingError != nil {
+		return NewInvalidSpecificationError("Parameter [" + key + "]: " + parsingError.Error())
+	}
+	return NewValidSpecificationError(key, value)
+}
+
+// parseOptimisationDirections returns the optimisation direction declared against each decision variable name in
+// a table of the form { SedimentProduction = "Minimising", HabitatArea = "Maximising" }.
+func parseOptimisationDirections(value interface{}) (map[string]dominance.Direction, error) {
+	directionTable := parameters.AsMap(value)
+	if directionTable == nil {
+		return nil, fmt.Errorf("must be a table of decision variable names to optimisation directions")
+	}
+
+	directions := make(map[string]dominance.Direction, len(directionTable))
+	for variableName, directionValue := range directionTable {
+		directionAsString, isString := directionValue.(string)
+		if !isString {
+			return nil, fmt.Errorf("optimisation direction of decision variable [%s] must be a string value", variableName)
+		}
+
+		direction, parsingError := dominance.ParseDirection(directionAsString)
+		if parsingError != nil {
+			return nil, fmt.Errorf("decision variable [%s]: %s", variableName, parsingError.Error())
+		}
+		directions[variableName] = direction
+	}
+	return directions, nil
+}
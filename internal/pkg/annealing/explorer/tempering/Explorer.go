@@ -0,0 +1,348 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package tempering offers a parallel tempering (replica exchange) explorer. It runs a number of Kirkpatrick replicas
+// of the model, each at its own rung of a temperature ladder, on separate goroutines. Neighbouring replicas are
+// periodically offered the chance to exchange model states, letting good solutions found at high temperatures drift
+// down to the coldest replica, whose model is the one reported.
+package tempering
+
+import (
+	"math"
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/calibration"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/LindsayBradford/crem/pkg/name"
+)
+
+const (
+	Temperatures        = "Temperatures"
+	SwapAcceptanceRates = "SwapAcceptanceRates"
+)
+
+// Random streams of the explorer's seed. Swap decisions draw from their own stream, and each replica is seeded from a
+// stream of its own, clear of those a single-chain explorer uses.
+const (
+	swapRandomStream   = explorer.CoolantRandomStream
+	firstReplicaStream = explorer.ArchiveRandomStream + 1
+)
+
+var _ explorer.Explorer = new(Explorer)
+
+type Explorer struct {
+	name.NameContainer
+	name.IdentifiableContainer
+
+	loggers.ContainedLogger
+
+	rand.SeedContainer
+	rand.RandContainer
+
+	observer.SynchronousAnnealingEventNotifier
+
+	parameters        Parameters
+	replicaParameters parameters.Map
+	replicaErrors     error
+	energySign        float64
+
+	models    []model.Model
+	swapModel model.Model
+	replicas  []*kirkpatrick.Explorer
+
+	changesTried  uint64
+	swapsProposed []uint64
+	swapsAccepted []uint64
+}
+
+func New() *Explorer {
+	newExplorer := new(Explorer)
+	newExplorer.parameters.Initialise()
+	newExplorer.replicaParameters = parameters.Map{}
+	newExplorer.energySign = 1
+	newExplorer.SetModel(model.NewNullModel())
+	return newExplorer
+}
+
+func (e *Explorer) WithModel(coldestModel model.Model) *Explorer {
+	e.SetModel(coldestModel)
+	return e
+}
+
+func (e *Explorer) WithParameters(params parameters.Map) *Explorer {
+	e.SetParameters(params)
+	return e
+}
+
+// Model returns the model of the coldest replica.
+func (e *Explorer) Model() model.Model {
+	return e.models[0]
+}
+
+// SetModel adopts the model supplied for the coldest replica, and clones it for every other replica (and for holding
+// model state mid-swap). Clones are taken here, rather than on initialisation, so that observers later attached to the
+// model supplied are not shared by replicas that run concurrently.
+func (e *Explorer) SetModel(coldestModel model.Model) {
+	e.replicas = nil
+	e.models = []model.Model{coldestModel}
+	e.cloneReplicaModels()
+}
+
+func (e *Explorer) cloneReplicaModels() {
+	replicaNumber := int(e.parameters.GetInt64(Replicas))
+	if len(e.models) == replicaNumber {
+		return
+	}
+
+	prototype := e.models[0]
+	e.models = make([]model.Model, replicaNumber)
+	e.models[0] = prototype
+	for rung := 1; rung < replicaNumber; rung++ {
+		e.models[rung] = prototype.DeepClone()
+	}
+	e.swapModel = prototype.DeepClone()
+}
+
+// SetParameters assigns the explorer's own parameters, and keeps the full set supplied for configuring each
+// Kirkpatrick replica (DecisionVariable, OptimisationDirection, CoolingFactor, ...).
+func (e *Explorer) SetParameters(params parameters.Map) error {
+	e.parameters.AssignOnlyEnforcedUserValues(params)
+	e.checkTemperatureRange()
+
+	e.replicaParameters = replicaParametersFrom(params)
+	e.replicaErrors = kirkpatrick.New().WithModel(e.models[0]).WithParameters(e.replicaParameters).ParameterErrors()
+	e.energySign = energySignFor(params)
+
+	e.cloneReplicaModels()
+
+	return e.parameters.ValidationErrors()
+}
+
+// replicaParametersFrom returns the parameters supplied, less any request for coolant calibration, as replica
+// temperatures come from the temperature ladder.
+func replicaParametersFrom(params parameters.Map) parameters.Map {
+	replicaParams := make(parameters.Map, len(params))
+	for key, value := range params {
+		replicaParams[key] = value
+	}
+	delete(replicaParams, calibration.CalibrationSamples)
+	return replicaParams
+}
+
+func (e *Explorer) checkTemperatureRange() {
+	minimum := e.parameters.GetFloat64(MinimumTemperature)
+	maximum := e.parameters.GetFloat64(MaximumTemperature)
+
+	if minimum <= 0 {
+		e.parameters.AddValidationErrorMessage("Parameter [" + MinimumTemperature + "] must be greater than 0")
+	}
+	if maximum <= minimum {
+		e.parameters.AddValidationErrorMessage("Parameter [" + MaximumTemperature + "] must be greater than [" + MinimumTemperature + "]")
+	}
+}
+
+// energySignFor returns the sign that turns the objective value into an energy to be minimised.
+func energySignFor(params parameters.Map) float64 {
+	directionText, _ := params[kirkpatrick.OptimisationDirection].(string)
+	if direction, _ := dominance.ParseDirection(directionText); direction == dominance.Maximising {
+		return -1
+	}
+	return 1
+}
+
+func (e *Explorer) ParameterErrors() error {
+	mergedErrors := compositeErrors.New("Parallel Tempering Explorer Parameter Validation")
+
+	mergedErrors.Add(e.parameters.ValidationErrors())
+	mergedErrors.Add(e.replicaErrors)
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+
+	return nil
+}
+
+func (e *Explorer) Initialise() {
+	e.LogHandler().Debug(e.Id() + ": Initialising Parallel Tempering Explorer")
+
+	e.SetRandomNumberGenerator(rand.NewDerived(e.RandomSeed(), swapRandomStream))
+
+	ladder := temperatureLadder(
+		e.parameters.GetFloat64(MinimumTemperature),
+		e.parameters.GetFloat64(MaximumTemperature),
+		len(e.models),
+	)
+
+	e.replicas = make([]*kirkpatrick.Explorer, len(e.models))
+	for rung, replicaModel := range e.models {
+		e.replicas[rung] = e.newReplica(replicaModel, rung, ladder[rung])
+	}
+	e.swapModel.Initialise(model.AsIs)
+
+	e.changesTried = 0
+	e.swapsProposed = make([]uint64, len(e.replicas)-1)
+	e.swapsAccepted = make([]uint64, len(e.replicas)-1)
+
+	e.notifyInitialisation()
+}
+
+func (e *Explorer) newReplica(replicaModel model.Model, rung int, temperature float64) *kirkpatrick.Explorer {
+	replica := kirkpatrick.New().
+		WithModel(replicaModel).
+		WithParameters(e.replicaParameters)
+
+	replica.SetId(e.Id())
+	replica.SetLogHandler(e.LogHandler())
+	replica.SetRandomSeed(rand.DeriveSeed(e.RandomSeed(), firstReplicaStream+uint64(rung)))
+	replica.SetTemperature(temperature)
+	replica.Initialise()
+
+	return replica
+}
+
+func (e *Explorer) notifyInitialisation() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Initialising").
+		WithAttribute(Replicas, len(e.replicas)).
+		WithAttribute(Temperatures, e.temperatures()).
+		WithAttribute(explorer.RandomSeed, e.RandomSeed())
+
+	e.NotifyObserversOfEvent(*event)
+}
+
+// TryRandomChange has every replica try a random change of its model concurrently, and once every SwapInterval
+// changes, proposes that neighbouring replicas swap models.
+func (e *Explorer) TryRandomChange() {
+	e.tryReplicaChanges()
+
+	e.changesTried++
+	if e.changesTried%uint64(e.parameters.GetInt64(SwapInterval)) == 0 {
+		e.proposeSwaps()
+	}
+}
+
+func (e *Explorer) tryReplicaChanges() {
+	var changesTried sync.WaitGroup
+	replicaPanics := make([]interface{}, len(e.replicas))
+
+	for rung, replica := range e.replicas {
+		changesTried.Add(1)
+		go func(rung int, replica *kirkpatrick.Explorer) {
+			defer changesTried.Done()
+			defer func() { replicaPanics[rung] = recover() }()
+			replica.TryRandomChange()
+		}(rung, replica)
+	}
+	changesTried.Wait()
+
+	for _, replicaPanic := range replicaPanics {
+		if replicaPanic != nil {
+			panic(replicaPanic)
+		}
+	}
+}
+
+func (e *Explorer) proposeSwaps() {
+	for colderRung := 0; colderRung < len(e.replicas)-1; colderRung++ {
+		e.proposeSwap(colderRung)
+	}
+	e.notifySwaps()
+}
+
+// proposeSwap offers the replica at colderRung and its hotter neighbour the chance to exchange model states, accepting
+// with the Metropolis probability min(1, exp((1/T_colder - 1/T_hotter) * (E_colder - E_hotter))). States, rather than
+// models, are exchanged so that the coldest replica's model stays the one observers were attached to.
+func (e *Explorer) proposeSwap(colderRung int) {
+	colder, hotter := e.replicas[colderRung], e.replicas[colderRung+1]
+	e.swapsProposed[colderRung]++
+
+	exponent := (1/colder.Temperature - 1/hotter.Temperature) * (e.energyOf(colder) - e.energyOf(hotter))
+	if exponent < 0 && math.Exp(exponent) <= e.RandomNumberGenerator().Float64Unitary() {
+		return
+	}
+
+	e.swapsAccepted[colderRung]++
+	e.swapModel.SynchroniseTo(colder.Model())
+	colder.Model().SynchroniseTo(hotter.Model())
+	hotter.Model().SynchroniseTo(e.swapModel)
+}
+
+func (e *Explorer) energyOf(replica *kirkpatrick.Explorer) float64 {
+	return e.energySign * replica.ObjectiveValue()
+}
+
+func (e *Explorer) notifySwaps() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Proposed Replica Swaps").
+		WithAttribute(SwapAcceptanceRates, e.swapAcceptanceRates())
+
+	e.NotifyObserversOfEvent(*event)
+}
+
+// swapAcceptanceRates returns, for each pair of neighbouring replicas (coldest first), the fraction of swaps proposed
+// between them that were accepted.
+func (e *Explorer) swapAcceptanceRates() []float64 {
+	rates := make([]float64, len(e.swapsProposed))
+	for pair, proposed := range e.swapsProposed {
+		if proposed > 0 {
+			rates[pair] = float64(e.swapsAccepted[pair]) / float64(proposed)
+		}
+	}
+	return rates
+}
+
+func (e *Explorer) temperatures() []float64 {
+	temperatures := make([]float64, len(e.replicas))
+	for rung, replica := range e.replicas {
+		temperatures[rung] = replica.Temperature
+	}
+	return temperatures
+}
+
+func (e *Explorer) CoolDown() {
+	for _, replica := range e.replicas {
+		replica.CoolDown()
+	}
+}
+
+// EventAttributes returns the coldest replica's attributes for the event type, joined by the temperature of each
+// replica and the swap acceptance rates between neighbouring replicas.
+func (e *Explorer) EventAttributes(eventType observer.EventType) attributes.Attributes {
+	if len(e.replicas) == 0 {
+		return nil
+	}
+
+	coldestAttributes := e.replicas[0].EventAttributes(eventType)
+	if coldestAttributes == nil {
+		return nil
+	}
+
+	return attributes.Attributes{}.
+		Join(coldestAttributes).
+		Replace(explorer.RandomSeed, e.RandomSeed()).
+		Add(Temperatures, e.temperatures()).
+		Add(SwapAcceptanceRates, e.swapAcceptanceRates())
+}
+
+func (e *Explorer) DeepClone() explorer.Explorer {
+	clone := *e
+	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	clone.SetModel(e.models[0].DeepClone())
+	return &clone
+}
+
+func (e *Explorer) TearDown() {
+	e.LogHandler().Debug(e.Id() + ": Triggering tear-down of Parallel Tempering Explorer")
+	for _, replica := range e.replicas {
+		replica.TearDown()
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package tempering
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+func TestTemperatureLadder_Geometric(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	actualLadder := temperatureLadder(1, 100, 3)
+
+	// then
+	g.Expect(actualLadder).To(HaveLen(3))
+	g.Expect(actualLadder[0]).To(BeNumerically("==", 1))
+	g.Expect(actualLadder[1]).To(BeNumerically("~", 10, 1e-9))
+	g.Expect(actualLadder[2]).To(BeNumerically("==", 100))
+}
+
+func TestExplorer_SetParameters_InvalidTemperatureRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New()
+
+	// when
+	explorerUnderTest.SetParameters(
+		parameters.Map{
+			MinimumTemperature: float64(10),
+			MaximumTemperature: float64(10),
+		},
+	)
+
+	// then
+	g.Expect(explorerUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(explorerUnderTest.ParameterErrors().Error()).To(ContainSubstring("[" + MaximumTemperature + "] must be greater than"))
+}
+
+func TestExplorer_SetParameters_InvalidReplicaNumber_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New()
+
+	// when
+	explorerUnderTest.SetParameters(parameters.Map{Replicas: int64(1)})
+
+	// then
+	g.Expect(explorerUnderTest.ParameterErrors()).To(Not(BeNil()))
+}
+
+func TestExplorer_Initialise_ReplicasOnTemperatureLadder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	coldestModel := buildTestModel()
+	explorerUnderTest := buildTestExplorer(coldestModel)
+
+	// when
+	explorerUnderTest.Initialise()
+
+	// then
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+	g.Expect(explorerUnderTest.Model()).To(BeIdenticalTo(coldestModel))
+
+	actualAttributes := explorerUnderTest.EventAttributes(observer.StartedIteration)
+	actualTemperatures := actualAttributes.Value(Temperatures).([]float64)
+	g.Expect(actualTemperatures).To(HaveLen(3))
+	g.Expect(actualTemperatures[0]).To(BeNumerically("==", 1))
+	g.Expect(actualTemperatures[2]).To(BeNumerically("==", 100))
+	g.Expect(actualAttributes.Value(explorer.Temperature)).To(BeNumerically("==", 1))
+}
+
+func TestExplorer_TryRandomChange_SwapAcceptanceRatesReported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(buildTestModel())
+	explorerUnderTest.Initialise()
+
+	// when
+	tryTestChanges(explorerUnderTest, 4)
+
+	// then
+	actualAttributes := explorerUnderTest.EventAttributes(observer.FinishedIteration)
+	actualRates := actualAttributes.Value(SwapAcceptanceRates).([]float64)
+
+	g.Expect(explorerUnderTest.swapsProposed).To(Equal([]uint64{2, 2}))
+	g.Expect(actualRates).To(HaveLen(2))
+	for _, rate := range actualRates {
+		g.Expect(rate).To(BeNumerically(">=", 0))
+		g.Expect(rate).To(BeNumerically("<=", 1))
+	}
+}
+
+func TestExplorer_ProposeSwap_HotterReplicaBetter_StatesExchanged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	coldestModel := buildTestModel()
+	explorerUnderTest := buildTestExplorer(coldestModel)
+	explorerUnderTest.Initialise()
+
+	deactivateAll(coldestModel)
+	hotterModel := explorerUnderTest.replicas[1].Model()
+	deactivateAll(hotterModel)
+	for index := 0; index < 3*5; index += 3 {
+		hotterModel.SetManagementAction(index, true)
+	}
+
+	colderValue := explorerUnderTest.replicas[0].ObjectiveValue()
+	hotterValue := explorerUnderTest.replicas[1].ObjectiveValue()
+
+	// when
+	explorerUnderTest.proposeSwap(0)
+
+	// then
+	g.Expect(hotterValue).To(BeNumerically("<", colderValue))
+	g.Expect(explorerUnderTest.swapsAccepted[0]).To(BeNumerically("==", 1))
+	g.Expect(explorerUnderTest.Model()).To(BeIdenticalTo(coldestModel))
+	g.Expect(explorerUnderTest.replicas[0].ObjectiveValue()).To(BeNumerically("==", hotterValue))
+	g.Expect(explorerUnderTest.replicas[1].ObjectiveValue()).To(BeNumerically("==", colderValue))
+}
+
+func TestExplorer_TryRandomChange_ReplicaPanic_Propagated(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := buildTestExplorer(buildTestModel())
+	explorerUnderTest.Initialise()
+	explorerUnderTest.replicas[1].SetModel(nil)
+
+	// when
+	tryingRandomChange := func() {
+		explorerUnderTest.TryRandomChange()
+	}
+
+	// then
+	g.Expect(tryingRandomChange).To(Panic())
+}
+
+func tryTestChanges(explorerUnderTest *Explorer, changes int) {
+	for change := 0; change < changes; change++ {
+		explorerUnderTest.TryRandomChange()
+		explorerUnderTest.CoolDown()
+	}
+}
+
+func deactivateAll(modelToDeactivate model.Model) {
+	for index := range modelToDeactivate.ManagementActions() {
+		modelToDeactivate.SetManagementAction(index, false)
+	}
+}
+
+func buildTestModel() *modumb.Model {
+	newModel := modumb.NewModel().WithId("Parallel Tempering Test")
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
+
+func buildTestExplorer(coldestModel *modumb.Model) *Explorer {
+	newExplorer := New().
+		WithModel(coldestModel).
+		WithParameters(
+			parameters.Map{
+				"DecisionVariable":      modumb.Objectives[0],
+				"OptimisationDirection": "Minimising",
+				Replicas:                int64(3),
+				MinimumTemperature:      float64(1),
+				MaximumTemperature:      float64(100),
+				SwapInterval:            int64(2),
+			},
+		)
+
+	newExplorer.SetId("Parallel Tempering Test")
+	newExplorer.SetLogHandler(loggers.DefaultTestingLogger)
+	newExplorer.SetRandomSeed(42)
+	return newExplorer
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package tempering
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+type Parameters struct {
+	parameters.Parameters
+}
+
+func (p *Parameters) Initialise() *Parameters {
+	p.Parameters.
+		Initialise("Parallel Tempering Explorer Parameter Validation").
+		Enforcing(ParameterSpecifications())
+	return p
+}
+
+const (
+	Replicas           = "Replicas"
+	MinimumTemperature = "MinimumTemperature"
+	MaximumTemperature = "MaximumTemperature"
+	SwapInterval       = "SwapInterval"
+
+	minimumReplicas = 2
+	maximumReplicas = 64
+)
+
+func ParameterSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          Replicas,
+			Validator:    isReplicaNumber,
+			DefaultValue: int64(4),
+		},
+	).Add(
+		Specification{
+			Key:          MinimumTemperature,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(1),
+		},
+	).Add(
+		Specification{
+			Key:          MaximumTemperature,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(100),
+		},
+	).Add(
+		Specification{
+			Key:          SwapInterval,
+			Validator:    isPositiveInteger,
+			DefaultValue: int64(10),
+		},
+	)
+	return specs
+}
+
+func isReplicaNumber(key string, value interface{}) error {
+	return IsIntegerWithInclusiveBounds(key, value, minimumReplicas, maximumReplicas)
+}
+
+func isPositiveInteger(key string, value interface{}) error {
+	return IsIntegerWithInclusiveBounds(key, value, 1, math.MaxInt64)
+}
+
+// temperatureLadder returns the temperatures of replicaNumber replicas, rising geometrically from minimum to maximum,
+// so that neighbouring replicas are a constant ratio apart.
+func temperatureLadder(minimum float64, maximum float64, replicaNumber int) []float64 {
+	ladder := make([]float64, replicaNumber)
+	ratio := math.Pow(maximum/minimum, 1/float64(replicaNumber-1))
+
+	ladder[0] = minimum
+	for rung := 1; rung < replicaNumber; rung++ {
+		ladder[rung] = ladder[rung-1] * ratio
+	}
+	ladder[replicaNumber-1] = maximum
+
+	return ladder
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package solution
+
+import (
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/math"
+)
+
+// Comparison details how one solution (To) differs from another (From), taking From as the baseline for every delta.
+type Comparison struct {
+	From string
+	To   string
+
+	DecisionVariables         []DecisionVariableDelta
+	ManagementActions         map[planningunit.Id]ManagementActionDifferences
+	PlanningUnitContributions []PlanningUnitContributionDelta
+
+	PlanningUnitHeading string `json:"-"`
+}
+
+type DecisionVariableDelta struct {
+	Name    string
+	Measure variable.UnitOfMeasure `json:"UnitOfMeasure"`
+	From    float64
+	To      float64
+	Delta   float64
+}
+
+// ManagementActionDifferences lists the management actions of a planning unit active only in the To solution (Added),
+// only in the From solution (Removed), and in both (Common).
+type ManagementActionDifferences struct {
+	Added   ManagementActions
+	Removed ManagementActions
+	Common  ManagementActions
+}
+
+// PlanningUnitContributionDelta is the change in a planning unit's contribution to a decision variable.
+type PlanningUnitContributionDelta struct {
+	DecisionVariable string
+	PlanningUnit     planningunit.Id
+	From             float64
+	To               float64
+	Delta            float64
+}
+
+// Compare returns how the to solution differs from the from solution. Only decision variables present in both are
+// compared, and only planning unit contributions that change are reported.
+func Compare(from *Solution, to *Solution) *Comparison {
+	comparison := &Comparison{
+		From:                      from.Id,
+		To:                        to.Id,
+		DecisionVariables:         make([]DecisionVariableDelta, 0),
+		ManagementActions:         make(map[planningunit.Id]ManagementActionDifferences),
+		PlanningUnitContributions: make([]PlanningUnitContributionDelta, 0),
+		PlanningUnitHeading:       from.PlanningUnitHeading(),
+	}
+
+	comparison.compareDecisionVariables(from, to)
+	comparison.compareManagementActions(from, to)
+
+	return comparison
+}
+
+func (c *Comparison) compareDecisionVariables(from *Solution, to *Solution) {
+	for _, fromVariable := range from.DecisionVariables {
+		toVariable, toHasVariable := VariableNamed(to, fromVariable.Name)
+		if !toHasVariable {
+			continue
+		}
+
+		c.DecisionVariables = append(c.DecisionVariables,
+			DecisionVariableDelta{
+				Name:    fromVariable.Name,
+				Measure: fromVariable.Measure,
+				From:    fromVariable.Value,
+				To:      toVariable.Value,
+				Delta:   Delta(fromVariable.Value, toVariable.Value),
+			},
+		)
+
+		c.comparePlanningUnitContributions(fromVariable, toVariable)
+	}
+}
+
+// VariableNamed returns the solution's decision variable of the name supplied, and false if the solution has none.
+func VariableNamed(solution *Solution, name string) (variable.EncodeableDecisionVariable, bool) {
+	for _, candidate := range solution.DecisionVariables {
+		if candidate.Name == name {
+			return candidate, true
+		}
+	}
+	return variable.EncodeableDecisionVariable{}, false
+}
+
+func (c *Comparison) comparePlanningUnitContributions(fromVariable, toVariable variable.EncodeableDecisionVariable) {
+	fromValues := valuesByPlanningUnit(fromVariable.ValuePerPlanningUnit)
+	toValues := valuesByPlanningUnit(toVariable.ValuePerPlanningUnit)
+
+	unitSet := make(map[planningunit.Id]bool, len(fromValues)+len(toValues))
+	for planningUnit := range fromValues {
+		unitSet[planningUnit] = true
+	}
+	for planningUnit := range toValues {
+		unitSet[planningUnit] = true
+	}
+
+	for _, planningUnit := range sorted(unitSet) {
+		fromValue, toValue := fromValues[planningUnit], toValues[planningUnit]
+		if fromValue == toValue {
+			continue
+		}
+
+		c.PlanningUnitContributions = append(c.PlanningUnitContributions,
+			PlanningUnitContributionDelta{
+				DecisionVariable: fromVariable.Name,
+				PlanningUnit:     planningUnit,
+				From:             fromValue,
+				To:               toValue,
+				Delta:            Delta(fromValue, toValue),
+			},
+		)
+	}
+}
+
+func valuesByPlanningUnit(values variable.PlanningUnitValues) map[planningunit.Id]float64 {
+	valueMap := make(map[planningunit.Id]float64, len(values))
+	for _, value := range values {
+		valueMap[value.PlanningUnit] = value.Value
+	}
+	return valueMap
+}
+
+func (c *Comparison) compareManagementActions(from *Solution, to *Solution) {
+	unitSet := make(map[planningunit.Id]bool, len(from.ActiveManagementActions)+len(to.ActiveManagementActions))
+	for planningUnit := range from.ActiveManagementActions {
+		unitSet[planningUnit] = true
+	}
+	for planningUnit := range to.ActiveManagementActions {
+		unitSet[planningUnit] = true
+	}
+
+	for _, planningUnit := range sorted(unitSet) {
+		differences := differencesBetween(from.ActiveManagementActions[planningUnit], to.ActiveManagementActions[planningUnit])
+		c.ManagementActions[planningUnit] = differences
+	}
+}
+
+// ActionPlanningUnits returns, in order, the planning units with management action differences.
+func (c *Comparison) ActionPlanningUnits() planningunit.Ids {
+	unitSet := make(map[planningunit.Id]bool, len(c.ManagementActions))
+	for planningUnit := range c.ManagementActions {
+		unitSet[planningUnit] = true
+	}
+	return sorted(unitSet)
+}
+
+func differencesBetween(fromActions, toActions ManagementActions) ManagementActionDifferences {
+	differences := ManagementActionDifferences{
+		Added:   make(ManagementActions, 0),
+		Removed: make(ManagementActions, 0),
+		Common:  make(ManagementActions, 0),
+	}
+
+	toActionSet := make(map[ManagementActionType]bool, len(toActions))
+	for _, action := range toActions {
+		toActionSet[action] = true
+	}
+
+	for _, action := range fromActions {
+		if toActionSet[action] {
+			differences.Common = append(differences.Common, action)
+			delete(toActionSet, action)
+		} else {
+			differences.Removed = append(differences.Removed, action)
+		}
+	}
+	for action := range toActionSet {
+		differences.Added = append(differences.Added, action)
+	}
+
+	sort.Sort(differences.Added)
+	sort.Sort(differences.Removed)
+	sort.Sort(differences.Common)
+
+	return differences
+}
+
+func sorted(unitSet map[planningunit.Id]bool) planningunit.Ids {
+	planningUnits := make(planningunit.Ids, 0, len(unitSet))
+	for planningUnit := range unitSet {
+		planningUnits = append(planningUnits, planningUnit)
+	}
+	sort.Slice(planningUnits, func(i, j int) bool { return planningUnits[i] < planningUnits[j] })
+	return planningUnits
+}
+
+// Delta returns to - from, rounded to the finer precision of the two values to shed floating-point noise.
+func Delta(from, to float64) float64 {
+	precision := math.DerivePrecision(from)
+	if toPrecision := math.DerivePrecision(to); toPrecision > precision {
+		precision = toPrecision
+	}
+	return math.RoundFloat(to-from, precision)
+}
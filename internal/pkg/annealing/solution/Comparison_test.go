@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package solution
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	. "github.com/onsi/gomega"
+)
+
+func TestCompare_DecisionVariableDeltas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	fromSolution := NewSolution("from")
+	fromSolution.DecisionVariables = variable.EncodeableDecisionVariables{
+		{Name: "shared", Value: 10.5},
+		{Name: "only in from", Value: 1},
+	}
+
+	toSolution := NewSolution("to")
+	toSolution.DecisionVariables = variable.EncodeableDecisionVariables{
+		{Name: "shared", Value: 7.25},
+	}
+
+	// when
+	comparisonUnderTest := Compare(fromSolution, toSolution)
+
+	// then
+	g.Expect(comparisonUnderTest.From).To(Equal("from"))
+	g.Expect(comparisonUnderTest.To).To(Equal("to"))
+	g.Expect(comparisonUnderTest.DecisionVariables).To(HaveLen(1))
+
+	delta := comparisonUnderTest.DecisionVariables[0]
+	g.Expect(delta.Name).To(Equal("shared"))
+	g.Expect(delta.Delta).To(BeNumerically(equalTo, -3.25))
+}
+
+func TestCompare_PlanningUnitContributions_OnlyChangesReported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	fromSolution := NewSolution("from")
+	fromSolution.DecisionVariables = variable.EncodeableDecisionVariables{
+		{
+			Name: "shared",
+			ValuePerPlanningUnit: variable.PlanningUnitValues{
+				{PlanningUnit: 1, Value: 2},
+				{PlanningUnit: 2, Value: 3},
+			},
+		},
+	}
+
+	toSolution := NewSolution("to")
+	toSolution.DecisionVariables = variable.EncodeableDecisionVariables{
+		{
+			Name: "shared",
+			ValuePerPlanningUnit: variable.PlanningUnitValues{
+				{PlanningUnit: 1, Value: 2},
+				{PlanningUnit: 2, Value: 5},
+			},
+		},
+	}
+
+	// when
+	comparisonUnderTest := Compare(fromSolution, toSolution)
+
+	// then
+	g.Expect(comparisonUnderTest.PlanningUnitContributions).To(HaveLen(1))
+
+	contribution := comparisonUnderTest.PlanningUnitContributions[0]
+	g.Expect(contribution.PlanningUnit).To(Equal(planningunit.Id(2)))
+	g.Expect(contribution.Delta).To(BeNumerically(equalTo, 2))
+}
+
+func TestCompare_ManagementActionDifferences(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	fromSolution := NewSolution("from")
+	fromSolution.ActiveManagementActions[1] = ManagementActions{"kept", "dropped"}
+
+	toSolution := NewSolution("to")
+	toSolution.ActiveManagementActions[1] = ManagementActions{"kept", "introduced"}
+	toSolution.ActiveManagementActions[2] = ManagementActions{"introduced"}
+
+	// when
+	comparisonUnderTest := Compare(fromSolution, toSolution)
+
+	// then
+	g.Expect(comparisonUnderTest.ActionPlanningUnits()).To(Equal(planningunit.Ids{1, 2}))
+
+	firstDifferences := comparisonUnderTest.ManagementActions[1]
+	g.Expect(firstDifferences.Added).To(Equal(ManagementActions{"introduced"}))
+	g.Expect(firstDifferences.Removed).To(Equal(ManagementActions{"dropped"}))
+	g.Expect(firstDifferences.Common).To(Equal(ManagementActions{"kept"}))
+
+	secondDifferences := comparisonUnderTest.ManagementActions[2]
+	g.Expect(secondDifferences.Added).To(Equal(ManagementActions{"introduced"}))
+	g.Expect(secondDifferences.Removed).To(BeEmpty())
+	g.Expect(secondDifferences.Common).To(BeEmpty())
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/schedule"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/math"
@@ -26,6 +27,8 @@ func NewSolution(id string) *Solution {
 	newSolution.ManagementActions = make(map[ManagementActionType]bool, 0)
 	newSolution.ActiveManagementActions = make(map[planningunit.Id]ManagementActions, 0)
 	newSolution.InactiveManagementActions = make(map[planningunit.Id]ManagementActions, 0)
+	newSolution.ActionIntensities = make(map[planningunit.Id]map[ManagementActionType]float64, 0)
+	newSolution.ActionStartYears = make(map[planningunit.Id]map[ManagementActionType]uint, 0)
 
 	return newSolution
 }
@@ -48,16 +51,47 @@ func (m ManagementActions) Less(i, j int) bool {
 
 type Solution struct {
 	Id                        string
+	RandomSeed                int64
 	DecisionVariables         variable.EncodeableDecisionVariables
 	PlanningUnits             planningunit.Ids              `json:"-"`
 	ManagementActions         map[ManagementActionType]bool `json:"-"`
 	ActiveManagementActions   map[planningunit.Id]ManagementActions
 	InactiveManagementActions map[planningunit.Id]ManagementActions `json:"-"`
 
+	// ActionIntensities holds the intensity of each active management action applied at less than full intensity.
+	ActionIntensities map[planningunit.Id]map[ManagementActionType]float64 `json:",omitempty"`
+
+	// ActionStartYears holds the start year of each active management action starting after the first year of a
+	// planning horizon.
+	ActionStartYears map[planningunit.Id]map[ManagementActionType]uint `json:",omitempty"`
+
+	// Schedule holds the yearly costs and loads of the solution over its planning horizon, if it has one.
+	Schedule *schedule.Schedule `json:",omitempty"`
+
 	EncodedActions string `json:"-"`
 	attributes.ContainedAttributes
 }
 
+// ActionIntensity returns the intensity that the solution applies the management action of the type and planning
+// unit supplied at, from 0 (inactive) to 1 (applied in full).
+func (s Solution) ActionIntensity(planningUnit planningunit.Id, actionType ManagementActionType) float64 {
+	if intensity, isPartial := s.ActionIntensities[planningUnit][actionType]; isPartial {
+		return intensity
+	}
+	for _, activeAction := range s.ActiveManagementActions[planningUnit] {
+		if activeAction == actionType {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ActionStartYear returns the year of the planning horizon that the solution starts the management action of the type
+// and planning unit supplied in.
+func (s Solution) ActionStartYear(planningUnit planningunit.Id, actionType ManagementActionType) uint {
+	return s.ActionStartYears[planningUnit][actionType]
+}
+
 func (s Solution) ActionsAsStrings() []string {
 	actionList := make(ManagementActions, 0)
 
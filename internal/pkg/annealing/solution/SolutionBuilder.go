@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 )
 
@@ -15,6 +16,7 @@ type SolutionBuilder struct {
 	id              string
 	model           model.Model
 	compressedModel *archive.CompressedModelState
+	randomSeed      int64
 
 	solution *Solution
 }
@@ -24,6 +26,11 @@ func (sb *SolutionBuilder) WithId(id string) *SolutionBuilder {
 	return sb
 }
 
+func (sb *SolutionBuilder) WithRandomSeed(randomSeed int64) *SolutionBuilder {
+	sb.randomSeed = randomSeed
+	return sb
+}
+
 func (sb *SolutionBuilder) ForModel(model model.Model) *SolutionBuilder {
 	sb.model = model
 	sb.compressedModel = new(archive.ModelCompressor).Compress(model)
@@ -32,10 +39,12 @@ func (sb *SolutionBuilder) ForModel(model model.Model) *SolutionBuilder {
 
 func (sb *SolutionBuilder) Build() *Solution {
 	sb.solution = NewSolution(sb.id)
+	sb.solution.RandomSeed = sb.randomSeed
 	sb.transferAttributes()
 	sb.addDecisionVariables()
 	sb.addPlanningUnits()
 	sb.addPlanningUnitManagementActionMaps()
+	sb.addSchedule()
 
 	return sb.solution
 }
@@ -80,9 +89,41 @@ func (sb *SolutionBuilder) addPlanningUnitManagementActionMaps() {
 		case true:
 			sb.solution.ActiveManagementActions[planningUnit] =
 				append(sb.solution.ActiveManagementActions[planningUnit], actionType)
+			sb.addPartialIntensity(action, actionType)
+			sb.addLaterStartYear(action, actionType)
 		case false:
 			sb.solution.InactiveManagementActions[planningUnit] =
 				append(sb.solution.InactiveManagementActions[planningUnit], actionType)
 		}
 	}
 }
+
+func (sb *SolutionBuilder) addPartialIntensity(managementAction action.ManagementAction, actionType ManagementActionType) {
+	if managementAction.Intensity() == action.FullIntensity {
+		return
+	}
+
+	planningUnit := managementAction.PlanningUnit()
+	if sb.solution.ActionIntensities[planningUnit] == nil {
+		sb.solution.ActionIntensities[planningUnit] = make(map[ManagementActionType]float64)
+	}
+	sb.solution.ActionIntensities[planningUnit][actionType] = managementAction.Intensity()
+}
+
+func (sb *SolutionBuilder) addLaterStartYear(managementAction action.ManagementAction, actionType ManagementActionType) {
+	if managementAction.StartYear() == 0 {
+		return
+	}
+
+	planningUnit := managementAction.PlanningUnit()
+	if sb.solution.ActionStartYears[planningUnit] == nil {
+		sb.solution.ActionStartYears[planningUnit] = make(map[ManagementActionType]uint)
+	}
+	sb.solution.ActionStartYears[planningUnit][actionType] = managementAction.StartYear()
+}
+
+func (sb *SolutionBuilder) addSchedule() {
+	if scheduledModel, isScheduled := sb.model.(model.Scheduled); isScheduled {
+		sb.solution.Schedule = scheduledModel.Schedule()
+	}
+}
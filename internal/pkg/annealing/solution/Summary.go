@@ -1,20 +1,29 @@
 package solution
 
 type Summary struct {
-	SortIndex uint64 `json:"-"`
-	Id        string
-	Variables VariableSetSummary
-	Actions   ActionSummary
-	Note      string
+	SortIndex  uint64 `json:"-"`
+	Id         string
+	Variables  VariableSetSummary
+	Actions    ActionSummary
+	Note       string
+	RandomSeed int64
+
+	// Details are further named values reported of the solution, each written to its own summary column.
+	Details VariableSetSummary `json:",omitempty"`
+
+	// Solution is the solution summarised, kept for encoders needing more of it than its summary.
+	Solution *Solution `json:"-"`
 }
 
 func (s *Solution) Summarise() *Summary {
 	return &Summary{
-		SortIndex: 0,
-		Id:        "",
-		Variables: s.produceVariableSummary(),
-		Actions:   s.produceActionSummary(),
-		Note:      "",
+		SortIndex:  0,
+		Id:         "",
+		Variables:  s.produceVariableSummary(),
+		Actions:    s.produceActionSummary(),
+		Note:       "",
+		RandomSeed: s.RandomSeed,
+		Solution:   s,
 	}
 }
 
@@ -33,8 +42,24 @@ func (s *Summary) WithSortOrder(sortIndex uint64) *Summary {
 	return s
 }
 
+// WithDetail reports a further named value of the solution, appended to any details already reported.
+func (s *Summary) WithDetail(name string, value float64) *Summary {
+	s.Details = append(s.Details, VariableSummary{Name: name, Value: value})
+	return s
+}
+
 type VariableSetSummary []VariableSummary
 
+// Value returns the value of the summary named, and whether the set held it.
+func (vs VariableSetSummary) Value(name string) (float64, bool) {
+	for _, summary := range vs {
+		if summary.Name == name {
+			return summary.Value, true
+		}
+	}
+	return 0, false
+}
+
 type VariableSummary struct {
 	Name  string
 	Value float64
@@ -5,6 +5,7 @@ package encoding
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/csv"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/geojson"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/json"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
@@ -17,12 +18,14 @@ const (
 	CsvOutput       = "CSV"
 	JsonOutput      = "JSON"
 	ExcelOutput     = "EXCEL"
+	GeoJsonOutput   = "GEOJSON"
 )
 
 type Builder struct {
 	loggers.ContainedLogger
 	outputType OutputType
 	outputPath string
+	boundaries *geojson.Boundaries
 }
 
 func (b *Builder) ForOutputType(encoderType OutputType) *Builder {
@@ -35,6 +38,12 @@ func (b *Builder) WithOutputPath(outputPath string) *Builder {
 	return b
 }
 
+// WithBoundaries supplies the planning unit boundaries that GeoJSON output joins solutions to.
+func (b *Builder) WithBoundaries(boundaries *geojson.Boundaries) *Builder {
+	b.boundaries = boundaries
+	return b
+}
+
 func (b *Builder) WithLogHandler(logHandler logging.Logger) *Builder {
 	b.SetLogHandler(logHandler)
 	return b
@@ -48,6 +57,8 @@ func (b *Builder) Build() Encoder {
 		return new(json.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
 	case ExcelOutput:
 		return new(excel.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
+	case GeoJsonOutput:
+		return new(geojson.Encoder).WithBoundaries(b.boundaries).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
 	default:
 		return NullEncoder
 	}
@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package csv
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/strings"
+)
+
+const (
+	categoryHeading = "Category"
+	fromHeading     = "From"
+	toHeading       = "To"
+	changeHeading   = "Change"
+
+	decisionVariableCategory         = "DecisionVariable"
+	managementActionCategory         = "ManagementAction"
+	planningUnitContributionCategory = "PlanningUnitContribution"
+
+	addedChange   = "Added"
+	removedChange = "Removed"
+	commonChange  = "Common"
+)
+
+// ComparisonMarshaler encodes a solution comparison as a single table, one row per decision variable delta,
+// management action difference and planning unit contribution delta, told apart by the row's Category.
+type ComparisonMarshaler struct{}
+
+func (cm *ComparisonMarshaler) Marshal(comparison *solution.Comparison) ([]byte, error) {
+	csvStringAsBytes := ([]byte)(cm.comparisonToCsvString(comparison))
+	return csvStringAsBytes, nil
+}
+
+func (cm *ComparisonMarshaler) comparisonToCsvString(comparison *solution.Comparison) string {
+	builder := new(strings.FluentBuilder)
+
+	builder.Add(join(categoryHeading, comparison.PlanningUnitHeading, nameHeading, fromHeading, toHeading, changeHeading)).
+		Add(newline)
+
+	for _, delta := range comparison.DecisionVariables {
+		builder.Add(join(decisionVariableCategory, "", delta.Name,
+			formatMeasured(delta.Measure, delta.From),
+			formatMeasured(delta.Measure, delta.To),
+			formatMeasured(delta.Measure, delta.Delta)),
+		).Add(newline)
+	}
+
+	for _, planningUnit := range comparison.ActionPlanningUnits() {
+		differences := comparison.ManagementActions[planningUnit]
+		addActionRows(builder, planningUnit.String(), differences.Added, inactiveActionValue, activeActionValue, addedChange)
+		addActionRows(builder, planningUnit.String(), differences.Removed, activeActionValue, inactiveActionValue, removedChange)
+		addActionRows(builder, planningUnit.String(), differences.Common, activeActionValue, activeActionValue, commonChange)
+	}
+
+	for _, delta := range comparison.PlanningUnitContributions {
+		measure := measureOf(comparison, delta.DecisionVariable)
+		builder.Add(join(planningUnitContributionCategory, delta.PlanningUnit.String(), delta.DecisionVariable,
+			formatMeasured(measure, delta.From),
+			formatMeasured(measure, delta.To),
+			formatMeasured(measure, delta.Delta)),
+		).Add(newline)
+	}
+
+	return builder.String()
+}
+
+func addActionRows(builder *strings.FluentBuilder, planningUnit string, actions solution.ManagementActions,
+	fromValue string, toValue string, change string) {
+	for _, action := range actions {
+		builder.Add(join(managementActionCategory, planningUnit, string(action), fromValue, toValue, change)).
+			Add(newline)
+	}
+}
+
+func measureOf(comparison *solution.Comparison, variableName string) variable.UnitOfMeasure {
+	for _, delta := range comparison.DecisionVariables {
+		if delta.Name == variableName {
+			return delta.Measure
+		}
+	}
+	return variable.NotApplicable
+}
+
+func formatMeasured(measure variable.UnitOfMeasure, value float64) string {
+	return formatVariableValue(variable.EncodeableDecisionVariable{Measure: measure}, value)
+}
@@ -20,6 +20,7 @@ type Encoder struct {
 	loggers.ContainedLogger
 	decisionVariableMarshaler DecisionVariableMarshaler
 	managementActionMarshaler ManagementActionMarshaler
+	scheduleMarshaler         ScheduleMarshaler
 	outputPath                string
 }
 
@@ -41,6 +42,12 @@ func (e Encoder) Encode(solution *solution.Solution) error {
 	if managementActionError := e.encodeManagementActions(solution); managementActionError != nil {
 		return errors.Wrap(managementActionError, fileType+" encoding of solution decision variables")
 	}
+	if solution.Schedule == nil {
+		return nil
+	}
+	if scheduleError := e.encodeSchedule(solution); scheduleError != nil {
+		return errors.Wrap(scheduleError, fileType+" encoding of solution schedule")
+	}
 	return nil
 }
 
@@ -66,6 +73,17 @@ func (e Encoder) encodeManagementActions(solution *solution.Solution) error {
 	return e.encodeMarshaled(marshaledSolution, outputPath)
 }
 
+func (e Encoder) encodeSchedule(solution *solution.Solution) error {
+	marshaledSolution, marshalError := e.scheduleMarshaler.Marshal(solution)
+	if marshalError != nil {
+		return errors.Wrap(marshalError, fileType+" marshaling of solution schedule")
+	}
+
+	outputPath := e.deriveOutputPath(solution, "Schedule")
+	e.LogHandler().Debug("Encoding [" + solution.Id + "] schedule to [" + outputPath + "]")
+	return e.encodeMarshaled(marshaledSolution, outputPath)
+}
+
 func (e Encoder) encodeMarshaled(marshaledSolution []byte, outputPath string) error {
 	os.Remove(outputPath)
 
@@ -6,6 +6,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 
+	"strconv"
 	strings2 "strings"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
@@ -55,7 +56,7 @@ func (cm *DecisionVariableMarshaler) decisionVariablesToCsvString(solution *solu
 		Add(join(planningUnits...)).
 		Add(newline)
 
-	variables := solution.DecisionVariables
+	variables := solution.DecisionVariables.WithAccumulated()
 
 	for _, variable := range variables {
 		joinedVariableAttributes := joinAttributes(variable, solution.PlanningUnits)
@@ -176,7 +177,7 @@ func (cm *ManagementActionMarshaler) buildActionCsvValuesForPlanningUnit(
 			actionValue := inactiveActionValue
 			for _, action := range activeActions {
 				if actionMatchesColumnNamed(action, csvHeading) {
-					actionValue = activeActionValue
+					actionValue = formatIntensity(solution.ActionIntensity(planningUnit, action))
 				}
 			}
 
@@ -193,6 +194,11 @@ func (cm *ManagementActionMarshaler) buildActionCsvValuesForPlanningUnit(
 	return values
 }
 
+// formatIntensity renders an action's intensity, so actions applied in full read as activeActionValue.
+func formatIntensity(intensity float64) string {
+	return strconv.FormatFloat(intensity, 'f', -1, 64)
+}
+
 func shouldSkipColumnWith(solution *solution.Solution, csvHeading string) bool {
 	return csvHeading == solution.PlanningUnitHeading()
 }
@@ -0,0 +1,39 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package csv
+
+import (
+	"strconv"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/pkg/strings"
+)
+
+const yearHeading = "Year"
+
+// ScheduleMarshaler encodes a solution's yearly schedule as a table of one row per year of its planning horizon,
+// with a column per scheduled value.
+type ScheduleMarshaler struct{}
+
+func (sm *ScheduleMarshaler) Marshal(solution *solution.Solution) ([]byte, error) {
+	csvStringAsBytes := ([]byte)(sm.scheduleToCsvString(solution))
+	return csvStringAsBytes, nil
+}
+
+func (sm *ScheduleMarshaler) scheduleToCsvString(solution *solution.Solution) string {
+	builder := new(strings.FluentBuilder)
+
+	headings := append([]string{yearHeading}, solution.Schedule.ValueNames...)
+	builder.Add(join(headings...)).Add(newline)
+
+	for _, yearlyValues := range solution.Schedule.Years {
+		rowValues := make([]string, 1, len(headings))
+		rowValues[0] = strconv.FormatUint(uint64(yearlyValues.Year), 10)
+		for _, value := range yearlyValues.Values {
+			rowValues = append(rowValues, defaultConverter.Convert(value))
+		}
+		builder.Add(join(rowValues...)).Add(newline)
+	}
+
+	return builder.String()
+}
@@ -5,12 +5,10 @@ package excel
 import (
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
-	"os"
 	"path"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
-	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
-	"github.com/LindsayBradford/crem/pkg/threading"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/xlsx"
 	"github.com/pkg/errors"
 )
 
@@ -36,8 +34,7 @@ func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
 func (e Encoder) Encode(solution *solution.Solution) error {
 	e.LogHandler().Info("Saving [" + solution.Id + "] as [Excel]")
 
-	dataSet := excel.NewDataSet(solution.FileNameSafeId(), threading.GetMainThreadChannel().Call)
-	defer dataSet.Teardown()
+	dataSet := xlsx.NewDataSet(solution.FileNameSafeId())
 
 	if marshalError := e.marshaler.Marshal(solution, dataSet); marshalError != nil {
 		return errors.Wrap(marshalError, fileType+" marshaling of solution")
@@ -48,11 +45,8 @@ func (e Encoder) Encode(solution *solution.Solution) error {
 	return e.encodeMarshaled(dataSet, outputPath)
 }
 
-func (e Encoder) encodeMarshaled(dataSet *excel.DataSet, outputPath string) error {
-	currentDir, _ := os.Getwd()
-	absolutePath := path.Join(currentDir, outputPath)
-	dataSet.SaveAs(absolutePath)
-	return nil
+func (e Encoder) encodeMarshaled(dataSet *xlsx.DataSet, outputPath string) error {
+	return dataSet.SaveAs(outputPath)
 }
 
 func (e Encoder) deriveOutputPath(solution *solution.Solution) (outputPath string) {
@@ -4,13 +4,15 @@ package excel
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
-	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 )
 
 const (
 	DecisionVariablesTableName = "DecisionVariables"
 	ManagementActionsTableName = "ManagementActions"
+	ScheduleTableName          = "Schedule"
 )
 
 const (
@@ -35,7 +37,7 @@ const (
 
 type Marshaler struct{}
 
-func (m *Marshaler) Marshal(solution *solution.Solution, dataSet *excel.DataSet) error {
+func (m *Marshaler) Marshal(solution *solution.Solution, dataSet dataset.DataSet) error {
 	if variableErr := m.marshalDecisionVariables(solution, dataSet); variableErr != nil {
 		return variableErr
 	}
@@ -44,15 +46,20 @@ func (m *Marshaler) Marshal(solution *solution.Solution, dataSet *excel.DataSet)
 		return variableErr
 	}
 
+	if solution.Schedule != nil {
+		m.marshalSchedule(solution, dataSet)
+	}
+
 	return nil
 }
 
-func (m *Marshaler) marshalDecisionVariables(solution *solution.Solution, dataSet *excel.DataSet) error {
-	table := emptyDecisionVariableTable(solution)
+func (m *Marshaler) marshalDecisionVariables(solution *solution.Solution, dataSet dataset.DataSet) error {
+	decisionVariables := solution.DecisionVariables.WithAccumulated()
+	table := emptyDecisionVariableTable(solution, decisionVariables)
 
 	var offsetColumn uint = unitOfMeasureColumn + 1
 
-	for i, decisionVariable := range solution.DecisionVariables {
+	for i, decisionVariable := range decisionVariables {
 		rowIndex := uint(i)
 		table.SetCell(nameColumn, rowIndex, decisionVariable.Name)
 		table.SetCell(valueColumn, rowIndex, decisionVariable.Value)
@@ -76,7 +83,7 @@ func (m *Marshaler) marshalDecisionVariables(solution *solution.Solution, dataSe
 	return nil
 }
 
-func emptyDecisionVariableTable(solution *solution.Solution) *tables.CsvTableImpl {
+func emptyDecisionVariableTable(solution *solution.Solution, decisionVariables variable.EncodeableDecisionVariables) *tables.CsvTableImpl {
 	table := new(tables.CsvTableImpl)
 
 	headings := variableHeadings(solution)
@@ -85,7 +92,7 @@ func emptyDecisionVariableTable(solution *solution.Solution) *tables.CsvTableImp
 	table.SetName(DecisionVariablesTableName)
 	table.SetColumnAndRowSize(
 		uint(len(headings)),
-		uint(len(solution.DecisionVariables)),
+		uint(len(decisionVariables)),
 	)
 
 	return table
@@ -107,7 +114,7 @@ func variableHeadings(solution *solution.Solution) []string {
 	return finalisedHeadings
 }
 
-func (m *Marshaler) marshalActionState(solution *solution.Solution, dataSet *excel.DataSet) error {
+func (m *Marshaler) marshalActionState(solution *solution.Solution, dataSet dataset.DataSet) error {
 	table, actionHeadings := emptyActionTable(solution)
 
 	for y, planningUnit := range solution.PlanningUnits {
@@ -130,10 +137,10 @@ func (m *Marshaler) marshalActionState(solution *solution.Solution, dataSet *exc
 					continue
 				}
 
-				actionValue := inactiveActionValue
+				var actionValue interface{} = inactiveActionValue
 				for _, action := range activeActions {
 					if actionMatchesColumnNamed(action, csvHeading) {
-						actionValue = activeActionValue
+						actionValue = cellValueOfIntensity(solution.ActionIntensity(planningUnit, action))
 					}
 				}
 
@@ -147,6 +154,14 @@ func (m *Marshaler) marshalActionState(solution *solution.Solution, dataSet *exc
 	return nil
 }
 
+// cellValueOfIntensity returns activeActionValue for actions applied in full, and the intensity of any other.
+func cellValueOfIntensity(intensity float64) interface{} {
+	if intensity == 1 {
+		return activeActionValue
+	}
+	return intensity
+}
+
 func emptyActionTable(solution *solution.Solution) (table *tables.CsvTableImpl, actionHeadings []string) {
 	table = new(tables.CsvTableImpl)
 
@@ -174,3 +189,26 @@ func shouldSkipColumnWith(solution *solution.Solution, csvHeading string) bool {
 func actionMatchesColumnNamed(action solution.ManagementActionType, csvHeading string) bool {
 	return string(action) == csvHeading
 }
+
+const (
+	yearHeading = "Year"
+	yearColumn  = 0
+)
+
+func (m *Marshaler) marshalSchedule(solution *solution.Solution, dataSet dataset.DataSet) {
+	table := new(tables.CsvTableImpl)
+
+	headings := append([]string{yearHeading}, solution.Schedule.ValueNames...)
+	table.SetHeader(headings)
+	table.SetName(ScheduleTableName)
+	table.SetColumnAndRowSize(uint(len(headings)), uint(len(solution.Schedule.Years)))
+
+	for rowIndex, yearlyValues := range solution.Schedule.Years {
+		table.SetCell(yearColumn, uint(rowIndex), uint64(yearlyValues.Year))
+		for valueIndex, value := range yearlyValues.Values {
+			table.SetCell(uint(valueIndex)+yearColumn+1, uint(rowIndex), value)
+		}
+	}
+
+	dataSet.AddTable(table.Name(), table)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/pkg/errors"
+)
+
+// DefaultIdProperty is the boundary feature property assumed to hold the planning unit each boundary outlines,
+// matching the subcatchment identifiers of catchment models.
+const DefaultIdProperty = "SubCatchment"
+
+// Boundaries holds the geometry of each planning unit, as read from a GeoJSON FeatureCollection of planning unit
+// boundaries, ready for joining to solutions.
+type Boundaries struct {
+	geometries map[planningunit.Id]json.RawMessage
+}
+
+// LoadBoundaries reads the GeoJSON FeatureCollection at filePath, identifying the planning unit outlined by each of
+// its features from the feature property named idProperty.
+func LoadBoundaries(filePath string, idProperty string) (*Boundaries, error) {
+	content, readError := os.ReadFile(filePath)
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading planning unit boundaries")
+	}
+
+	var collection featureCollection
+	if unmarshalError := json.Unmarshal(content, &collection); unmarshalError != nil {
+		return nil, errors.Wrap(unmarshalError, "unmarshaling planning unit boundaries of ["+filePath+"]")
+	}
+
+	return newBoundaries(collection, idProperty)
+}
+
+func newBoundaries(collection featureCollection, idProperty string) (*Boundaries, error) {
+	if collection.Type != featureCollectionType {
+		return nil, errors.New("planning unit boundaries are not a GeoJSON " + featureCollectionType)
+	}
+
+	boundaries := &Boundaries{geometries: make(map[planningunit.Id]json.RawMessage, len(collection.Features))}
+	for index, boundary := range collection.Features {
+		planningUnit, idError := planningUnitOf(boundary, idProperty)
+		if idError != nil {
+			return nil, errors.Wrap(idError, fmt.Sprintf("boundary feature [%d]", index))
+		}
+		boundaries.geometries[planningUnit] = boundary.Geometry
+	}
+	return boundaries, nil
+}
+
+func planningUnitOf(boundary feature, idProperty string) (planningunit.Id, error) {
+	switch id := boundary.Properties[idProperty].(type) {
+	case float64:
+		return planningunit.Float64ToId(id), nil
+	case string:
+		parsedId, parseError := strconv.ParseUint(id, 10, 64)
+		if parseError != nil {
+			return 0, errors.Wrap(parseError, "property ["+idProperty+"] is not a planning unit identifier")
+		}
+		return planningunit.Id(parsedId), nil
+	default:
+		return 0, errors.New("missing planning unit identifier property [" + idProperty + "]")
+	}
+}
+
+// Geometry returns the boundary geometry of the planning unit supplied, reporting whether it has one.
+func (b *Boundaries) Geometry(planningUnit planningunit.Id) (json.RawMessage, bool) {
+	geometry, hasGeometry := b.geometries[planningUnit]
+	return geometry, hasGeometry
+}
+
+// MissingFrom returns those planning units supplied that have no boundary.
+func (b *Boundaries) MissingFrom(planningUnits planningunit.Ids) planningunit.Ids {
+	missing := make(planningunit.Ids, 0)
+	for _, planningUnit := range planningUnits {
+		if _, hasGeometry := b.geometries[planningUnit]; !hasGeometry {
+			missing = append(missing, planningUnit)
+		}
+	}
+	return missing
+}
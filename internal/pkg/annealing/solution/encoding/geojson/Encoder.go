@@ -0,0 +1,86 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package geojson
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/pkg/errors"
+)
+
+const fileType = "geojson"
+const fileTypeExtension = "." + fileType
+
+type Encoder struct {
+	loggers.ContainedLogger
+	marshaler  Marshaler
+	outputPath string
+}
+
+func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
+	e.outputPath = outputPath
+	return e
+}
+
+func (e *Encoder) WithBoundaries(boundaries *Boundaries) *Encoder {
+	e.marshaler.WithBoundaries(boundaries)
+	return e
+}
+
+func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
+	e.SetLogHandler(logHandler)
+	return e
+}
+
+func (e Encoder) Encode(solution *solution.Solution) error {
+	if e.marshaler.boundaries == nil {
+		return errors.New(fileType + " encoding of solution [" + solution.Id + "] has no planning unit boundaries")
+	}
+
+	e.LogHandler().Info("Saving [" + solution.Id + "] as [GEOJSON]")
+	e.warnOfMissingBoundaries(solution)
+
+	marshaledSolution, marshalError := e.marshaler.Marshal(solution)
+	if marshalError != nil {
+		return errors.Wrap(marshalError, fileType+" marshaling of solution")
+	}
+
+	outputPath := e.deriveOutputPath(solution)
+	e.LogHandler().Debug("Encoding [" + solution.Id + "] to [" + outputPath + "]")
+	return e.encodeMarshaled(marshaledSolution, outputPath)
+}
+
+func (e Encoder) warnOfMissingBoundaries(solution *solution.Solution) {
+	missing := e.marshaler.boundaries.MissingFrom(solution.PlanningUnits)
+	if len(missing) > 0 {
+		e.LogHandler().Warn(fmt.Sprintf("Saving [%s] as [GEOJSON]: no boundaries for planning units %v, left out",
+			solution.Id, missing))
+	}
+}
+
+func (e Encoder) encodeMarshaled(marshaledSolution []byte, outputPath string) error {
+	file, openError := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if openError != nil {
+		return errors.Wrap(openError, "opening file for "+fileType+" encoding of solution")
+	}
+	defer file.Close()
+
+	bufferedWriter := bufio.NewWriter(file)
+	if _, writeError := bufferedWriter.Write(marshaledSolution); writeError != nil {
+		return errors.Wrap(writeError, "writing marshaled "+fileType+" of solution")
+	}
+
+	bufferedWriter.Flush()
+	return nil
+}
+
+func (e Encoder) deriveOutputPath(solution *solution.Solution) (outputPath string) {
+	safeIdBasedFileName := solution.FileNameSafeId() + fileTypeExtension
+	return path.Join(e.outputPath, safeIdBasedFileName)
+}
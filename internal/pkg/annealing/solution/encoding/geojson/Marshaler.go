@@ -0,0 +1,137 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package geojson
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+// https://tools.ietf.org/html/rfc7946
+
+const (
+	featureCollectionType = "FeatureCollection"
+	featureType           = "Feature"
+
+	idProperty            = "Id"
+	randomSeedProperty    = "RandomSeed"
+	activeActionsProperty = "ActiveActions"
+	actionSeparator       = ", "
+
+	newLinePrefix = ""
+	indent        = "  "
+
+	inactiveActionValue = 0
+	activeActionValue   = 1
+)
+
+type featureCollection struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Features   []feature              `json:"features"`
+}
+
+type feature struct {
+	Type       string                 `json:"type"`
+	Geometry   json.RawMessage        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Marshaler marshals a solution as a GeoJSON FeatureCollection, with a feature per planning unit having a boundary.
+// Each feature's properties are flat, so they may double as shapefile attributes: the planning unit's identifier, its
+// active actions, a 0/1 flag per action type, and its share of each decision variable's value. Planning units without
+// a boundary are left out.
+type Marshaler struct {
+	boundaries *Boundaries
+}
+
+func (m *Marshaler) WithBoundaries(boundaries *Boundaries) *Marshaler {
+	m.boundaries = boundaries
+	return m
+}
+
+func (m *Marshaler) Marshal(s *solution.Solution) ([]byte, error) {
+	collection := featureCollection{
+		Type: featureCollectionType,
+		Properties: map[string]interface{}{
+			idProperty:         s.Id,
+			randomSeedProperty: s.RandomSeed,
+		},
+		Features: m.featuresOf(s),
+	}
+
+	return json.MarshalIndent(collection, newLinePrefix, indent)
+}
+
+func (m *Marshaler) featuresOf(s *solution.Solution) []feature {
+	features := make([]feature, 0, len(s.PlanningUnits))
+	if m.boundaries == nil {
+		return features
+	}
+
+	for _, planningUnit := range s.PlanningUnits {
+		geometry, hasGeometry := m.boundaries.Geometry(planningUnit)
+		if !hasGeometry {
+			continue
+		}
+		features = append(features, feature{
+			Type:       featureType,
+			Geometry:   geometry,
+			Properties: propertiesOf(s, planningUnit),
+		})
+	}
+	return features
+}
+
+func propertiesOf(s *solution.Solution, planningUnit planningunit.Id) map[string]interface{} {
+	properties := map[string]interface{}{
+		s.PlanningUnitHeading(): planningUnit,
+	}
+
+	activeActions := s.ActiveManagementActions[planningUnit]
+	properties[activeActionsProperty] = strings.Join(activeActionsAsStrings(activeActions), actionSeparator)
+	for _, actionType := range s.ActionsAsStrings() {
+		properties[actionType] = actionValue(s, planningUnit, solution.ManagementActionType(actionType))
+	}
+
+	for _, decisionVariable := range s.DecisionVariables.WithAccumulated() {
+		if decisionVariable.ValuePerPlanningUnit != nil {
+			properties[decisionVariable.Name] = planningUnitValueOf(decisionVariable, planningUnit)
+		}
+	}
+	return properties
+}
+
+func activeActionsAsStrings(actions solution.ManagementActions) []string {
+	actionStrings := make([]string, len(actions))
+	for index, action := range actions {
+		actionStrings[index] = string(action)
+	}
+	return actionStrings
+}
+
+// actionValue returns activeActionValue or inactiveActionValue for binary actions, and the intensity of any action
+// applied in part.
+func actionValue(s *solution.Solution, planningUnit planningunit.Id, actionType solution.ManagementActionType) interface{} {
+	switch intensity := s.ActionIntensity(planningUnit, actionType); intensity {
+	case 0:
+		return inactiveActionValue
+	case 1:
+		return activeActionValue
+	default:
+		return intensity
+	}
+}
+
+func planningUnitValueOf(decisionVariable variable.EncodeableDecisionVariable, planningUnit planningunit.Id) float64 {
+	for _, planningUnitValue := range decisionVariable.ValuePerPlanningUnit {
+		if planningUnitValue.PlanningUnit == planningUnit {
+			return planningUnitValue.Value
+		}
+	}
+	return 0
+}
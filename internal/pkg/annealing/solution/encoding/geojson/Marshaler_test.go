@@ -0,0 +1,124 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	boundariesPath             = "testdata/Boundaries.geojson"
+	unidentifiedBoundariesPath = "testdata/UnidentifiedBoundaries.geojson"
+)
+
+func TestLoadBoundaries_NumericAndTextIds_Loaded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	boundaries, loadError := LoadBoundaries(boundariesPath, DefaultIdProperty)
+
+	// then
+	g.Expect(loadError).To(BeNil())
+	g.Expect(boundaries.MissingFrom(planningunit.Ids{1, 2, 3})).To(Equal(planningunit.Ids{3}))
+}
+
+func TestLoadBoundaries_MissingIdProperty_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, loadError := LoadBoundaries(unidentifiedBoundariesPath, DefaultIdProperty)
+
+	// then
+	g.Expect(loadError).To(Not(BeNil()))
+	g.Expect(loadError.Error()).To(ContainSubstring(DefaultIdProperty))
+}
+
+func TestMarshaler_Marshal_FeaturePerBoundedPlanningUnit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	boundaries, loadError := LoadBoundaries(boundariesPath, DefaultIdProperty)
+	g.Expect(loadError).To(BeNil())
+
+	marshalerUnderTest := new(Marshaler).WithBoundaries(boundaries)
+
+	// when
+	marshaled, marshalError := marshalerUnderTest.Marshal(buildTestSolution())
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	var collection featureCollection
+	g.Expect(json.Unmarshal(marshaled, &collection)).To(Succeed())
+
+	g.Expect(collection.Type).To(Equal(featureCollectionType))
+	g.Expect(collection.Properties[idProperty]).To(Equal("Test Solution"))
+	g.Expect(collection.Features).To(HaveLen(2))
+
+	upper := collection.Features[0].Properties
+	g.Expect(upper[solution.DefaultPlanningUnitHeading]).To(BeNumerically("==", 1))
+	g.Expect(upper[activeActionsProperty]).To(Equal("GullyRestoration, RiverBankRestoration"))
+	g.Expect(upper["GullyRestoration"]).To(BeNumerically("==", activeActionValue))
+	g.Expect(upper["SedimentProduction"]).To(BeNumerically("==", 10))
+	g.Expect(upper["SedimentProductionAccumulated"]).To(BeNumerically("==", 10))
+
+	lower := collection.Features[1].Properties
+	g.Expect(lower[activeActionsProperty]).To(BeEmpty())
+	g.Expect(lower["RiverBankRestoration"]).To(BeNumerically("==", inactiveActionValue))
+	g.Expect(lower["SedimentProduction"]).To(BeNumerically("==", 0))
+	g.Expect(lower["SedimentProductionAccumulated"]).To(BeNumerically("==", 8))
+}
+
+func TestMarshaler_Marshal_PartialIntensity(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	boundaries, loadError := LoadBoundaries(boundariesPath, DefaultIdProperty)
+	g.Expect(loadError).To(BeNil())
+
+	testSolution := buildTestSolution()
+	testSolution.ActionIntensities[1] = map[solution.ManagementActionType]float64{"RiverBankRestoration": 0.5}
+
+	marshalerUnderTest := new(Marshaler).WithBoundaries(boundaries)
+
+	// when
+	marshaled, marshalError := marshalerUnderTest.Marshal(testSolution)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	var collection featureCollection
+	g.Expect(json.Unmarshal(marshaled, &collection)).To(Succeed())
+
+	upper := collection.Features[0].Properties
+	g.Expect(upper["GullyRestoration"]).To(BeNumerically("==", activeActionValue))
+	g.Expect(upper["RiverBankRestoration"]).To(BeNumerically("==", 0.5))
+}
+
+func buildTestSolution() *solution.Solution {
+	testSolution := solution.NewSolution("Test Solution")
+	testSolution.PlanningUnits = planningunit.Ids{1, 2, 3}
+
+	testSolution.ManagementActions["GullyRestoration"] = true
+	testSolution.ManagementActions["RiverBankRestoration"] = true
+	testSolution.ActiveManagementActions[1] = solution.ManagementActions{"GullyRestoration", "RiverBankRestoration"}
+	testSolution.InactiveManagementActions[2] = solution.ManagementActions{"GullyRestoration", "RiverBankRestoration"}
+
+	testSolution.DecisionVariables = variable.EncodeableDecisionVariables{
+		{
+			Name:                 "SedimentProduction",
+			Value:                15,
+			ValuePerPlanningUnit: variable.PlanningUnitValues{{PlanningUnit: 1, Value: 10}, {PlanningUnit: 3, Value: 5}},
+			AccumulatedValuePerPlanningUnit: variable.PlanningUnitValues{
+				{PlanningUnit: 1, Value: 10}, {PlanningUnit: 2, Value: 8}, {PlanningUnit: 3, Value: 5},
+			},
+		},
+	}
+	return testSolution
+}
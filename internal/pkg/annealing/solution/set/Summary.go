@@ -7,6 +7,8 @@ import (
 	"strings"
 )
 
+const asIsSuffix = "As-Is"
+
 type Summary map[string]solution.Summary
 
 func (s Summary) Id() string {
@@ -30,11 +32,32 @@ func (s Summary) FileNameSafeId() string {
 	return safeId
 }
 
+// DetailNames returns the names of every detail reported across the summarised solutions, in order of first appearance.
+func (s Summary) DetailNames() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, summary := range s.AsSortedArray() {
+		for _, detail := range summary.Details {
+			if !seen[detail.Name] {
+				seen[detail.Name] = true
+				names = append(names, detail.Name)
+			}
+		}
+	}
+	return names
+}
+
+// justSomeId returns the id of some summarised solution, favouring those other than the as-is solution, whose ids
+// don't follow the "<set> Solution (...)" pattern the set's id is derived from.
 func (s Summary) justSomeId() string {
+	someId := ""
 	for key := range s {
-		return key
+		if !strings.HasSuffix(key, asIsSuffix) {
+			return key
+		}
+		someId = key
 	}
-	return ""
+	return someId
 }
 
 type sortableSummaries []solution.Summary
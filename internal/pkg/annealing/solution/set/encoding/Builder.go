@@ -4,8 +4,10 @@ package encoding
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
+	solutionGeoJson "github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/geojson"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/csv"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/geojson"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/json"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
@@ -17,6 +19,7 @@ type Builder struct {
 	loggers.ContainedLogger
 	outputType encoding.OutputType
 	outputPath string
+	boundaries *solutionGeoJson.Boundaries
 }
 
 func (b *Builder) ForOutputType(encoderType encoding.OutputType) *Builder {
@@ -29,6 +32,12 @@ func (b *Builder) WithOutputPath(outputPath string) *Builder {
 	return b
 }
 
+// WithBoundaries supplies the planning unit boundaries that GeoJSON output joins summarised solutions to.
+func (b *Builder) WithBoundaries(boundaries *solutionGeoJson.Boundaries) *Builder {
+	b.boundaries = boundaries
+	return b
+}
+
 func (b *Builder) WithLogHandler(logHandler logging.Logger) *Builder {
 	b.SetLogHandler(logHandler)
 	return b
@@ -42,6 +51,8 @@ func (b *Builder) Build() Encoder {
 		return new(json.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
 	case encoding.ExcelOutput:
 		return new(excel.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
+	case encoding.GeoJsonOutput:
+		return new(geojson.Encoder).WithBoundaries(b.boundaries).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
 	default:
 		return NullEncoder
 	}
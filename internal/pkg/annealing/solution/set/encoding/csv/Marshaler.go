@@ -6,6 +6,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/pkg/strings"
+	"strconv"
 	strings2 "strings"
 )
 
@@ -15,8 +16,10 @@ const (
 	idHeading      = "Solution"
 	actionsHeading = "Actions"
 	summaryHeading = "Summary"
+	seedHeading    = "RandomSeed"
 	separator      = ", "
 	newline        = "\n"
+	quote          = "\""
 )
 
 var (
@@ -37,6 +40,7 @@ func (cm *SummaryMarshaler) marshalSummary(summary *set.Summary) ([]byte, error)
 
 func (cm *SummaryMarshaler) summaryToCsvString(summary *set.Summary) string {
 	headers := deriveHeaders(summary)
+	detailNames := summary.DetailNames()
 
 	builder := new(strings.FluentBuilder)
 	builder.
@@ -47,7 +51,9 @@ func (cm *SummaryMarshaler) summaryToCsvString(summary *set.Summary) string {
 	for _, solutionSummary := range summary.AsSortedArray() {
 		summaryId := solutionSummary.Id
 		note := solutionSummary.Note
-		summarySet = append(summarySet, joinAttributes(summaryId, solutionSummary.Variables, solutionSummary.Actions, note))
+		seed := strconv.FormatInt(solutionSummary.RandomSeed, 10)
+		details := detailValueList(solutionSummary.Details, detailNames)
+		summarySet = append(summarySet, joinAttributes(summaryId, solutionSummary.Variables, solutionSummary.Actions, note, seed, details))
 	}
 
 	for _, sortedSummary := range summarySet {
@@ -60,17 +66,18 @@ func (cm *SummaryMarshaler) summaryToCsvString(summary *set.Summary) string {
 func deriveHeaders(summary *set.Summary) []string {
 	exampleVariables := justSomeVariables(summary)
 
-	headingNumber := len(exampleVariables) + 3
+	headingNumber := len(exampleVariables) + 4
 	headers := make([]string, headingNumber)
 
 	headers[0] = idHeading
 	for index, variable := range exampleVariables {
 		headers[index+1] = variable.Name
 	}
-	headers[headingNumber-2] = actionsHeading
-	headers[headingNumber-1] = summaryHeading
+	headers[headingNumber-3] = actionsHeading
+	headers[headingNumber-2] = summaryHeading
+	headers[headingNumber-1] = seedHeading
 
-	return headers
+	return append(headers, summary.DetailNames()...)
 }
 
 func justSomeVariables(summary *set.Summary) solution.VariableSetSummary {
@@ -80,10 +87,11 @@ func justSomeVariables(summary *set.Summary) solution.VariableSetSummary {
 	return nil
 }
 
-func joinAttributes(id string, variables []solution.VariableSummary, actions solution.ActionSummary, note string) string {
-	joinedVariableValues := join(variableValueList(variables)...)
-	joinedAttributes := join(id, joinedVariableValues, string(actions), note)
-	return joinedAttributes
+func joinAttributes(id string, variables []solution.VariableSummary, actions solution.ActionSummary, note string, seed string, details []string) string {
+	attributes := append([]string{id}, variableValueList(variables)...)
+	attributes = append(attributes, string(actions), note, seed)
+	attributes = append(attributes, details...)
+	return join(attributes...)
 }
 
 func variableValueList(variables []solution.VariableSummary) []string {
@@ -94,6 +102,30 @@ func variableValueList(variables []solution.VariableSummary) []string {
 	return values
 }
 
+// detailValueList gives the values of the details named in order, leaving blank any detail the solution lacks.
+func detailValueList(details solution.VariableSetSummary, names []string) []string {
+	values := make([]string, len(names))
+	for index, name := range names {
+		if value, present := details.Value(name); present {
+			values[index] = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+	}
+	return values
+}
+
 func join(entries ...string) string {
-	return strings2.Join(entries, separator)
+	quotedEntries := make([]string, len(entries))
+	for index, entry := range entries {
+		quotedEntries[index] = quoteIfNeeded(entry)
+	}
+	return strings2.Join(quotedEntries, separator)
+}
+
+// quoteIfNeeded wraps entries holding separators, quotes, line-breaks or leading spaces in double-quotes (doubling any
+// quotes within), as per RFC 4180, so that solution encodings and notes read back as a single field.
+func quoteIfNeeded(entry string) string {
+	if entry == "" || !strings2.ContainsAny(entry, ",\"\r\n") && entry[0] != ' ' {
+		return entry
+	}
+	return quote + strings2.Replace(entry, quote, quote+quote, -1) + quote
 }
@@ -4,12 +4,10 @@ package excel
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
-	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/xlsx"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
-	"github.com/LindsayBradford/crem/pkg/threading"
 	"github.com/pkg/errors"
-	"os"
 	"path"
 )
 
@@ -35,8 +33,7 @@ func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
 func (e Encoder) Encode(summary *set.Summary) error {
 	e.LogHandler().Info("Saving [" + summary.Id() + "] as [Excel]")
 
-	dataSet := excel.NewDataSet(summary.FileNameSafeId(), threading.GetMainThreadChannel().Call)
-	defer dataSet.Teardown()
+	dataSet := xlsx.NewDataSet(summary.FileNameSafeId())
 
 	if marshalError := e.marshaler.Marshal(summary, dataSet); marshalError != nil {
 		return errors.Wrap(marshalError, fileType+" marshaling of solution")
@@ -48,11 +45,8 @@ func (e Encoder) Encode(summary *set.Summary) error {
 
 }
 
-func (e Encoder) encodeMarshaled(dataSet *excel.DataSet, outputPath string) error {
-	currentDir, _ := os.Getwd()
-	absolutePath := path.Join(currentDir, outputPath)
-	dataSet.SaveAs(absolutePath)
-	return nil
+func (e Encoder) encodeMarshaled(dataSet *xlsx.DataSet, outputPath string) error {
+	return dataSet.SaveAs(outputPath)
 }
 
 func (e Encoder) deriveSummaryOutputPath(summary *set.Summary) (outputPath string) {
@@ -5,7 +5,7 @@ package excel
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
-	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 )
 
@@ -13,14 +13,16 @@ const (
 	idHeading      = "Solution"
 	actionsHeading = "Actions"
 	summaryHeading = "Summary"
+	seedHeading    = "RandomSeed"
 
 	SummaryTableName = "Summary"
 )
 
 type Marshaler struct{}
 
-func (m *Marshaler) Marshal(summary *set.Summary, dataSet *excel.DataSet) error {
+func (m *Marshaler) Marshal(summary *set.Summary, dataSet dataset.DataSet) error {
 	table := emptySummaryTable(summary)
+	detailNames := summary.DetailNames()
 
 	rowIndex := uint(0)
 	for _, value := range summary.AsSortedArray() {
@@ -35,6 +37,16 @@ func (m *Marshaler) Marshal(summary *set.Summary, dataSet *excel.DataSet) error
 		columnOffset := columnIndex + uint(len(value.Variables)+1)
 		table.SetCell(columnOffset, rowIndex, string(value.Actions))
 		table.SetCell(columnOffset+1, rowIndex, value.Note)
+		table.SetCell(columnOffset+2, rowIndex, value.RandomSeed)
+
+		for index, name := range detailNames {
+			detailOffset := columnOffset + 3 + uint(index)
+			if detailValue, present := value.Details.Value(name); present {
+				table.SetCell(detailOffset, rowIndex, detailValue)
+			} else {
+				table.SetCell(detailOffset, rowIndex, "")
+			}
+		}
 
 		rowIndex++
 	}
@@ -61,17 +73,18 @@ func emptySummaryTable(summary *set.Summary) *tables.CsvTableImpl {
 func deriveHeaders(summary *set.Summary) []string {
 	exampleVariables := justSomeVariables(summary)
 
-	headingNumber := len(exampleVariables) + 3
+	headingNumber := len(exampleVariables) + 4
 	headers := make([]string, headingNumber)
 
 	headers[0] = idHeading
 	for index, variable := range exampleVariables {
 		headers[index+1] = variable.Name
 	}
-	headers[headingNumber-2] = actionsHeading
-	headers[headingNumber-1] = summaryHeading
+	headers[headingNumber-3] = actionsHeading
+	headers[headingNumber-2] = summaryHeading
+	headers[headingNumber-1] = seedHeading
 
-	return headers
+	return append(headers, summary.DetailNames()...)
 }
 
 func justSomeVariables(summary *set.Summary) solution.VariableSetSummary {
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package geojson
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/geojson"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/json"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/pkg/errors"
+)
+
+// Encoder saves a solution set summary as JSON, alongside a GeoJSON FeatureCollection of each solution summarised,
+// so that every member of a solution set can be mapped without its solutions being saved in detail.
+type Encoder struct {
+	loggers.ContainedLogger
+	summaryEncoder  json.Encoder
+	solutionEncoder geojson.Encoder
+}
+
+func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
+	e.summaryEncoder.WithOutputPath(outputPath)
+	e.solutionEncoder.WithOutputPath(outputPath)
+	return e
+}
+
+func (e *Encoder) WithBoundaries(boundaries *geojson.Boundaries) *Encoder {
+	e.solutionEncoder.WithBoundaries(boundaries)
+	return e
+}
+
+func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
+	e.SetLogHandler(logHandler)
+	e.summaryEncoder.WithLogHandler(logHandler)
+	e.solutionEncoder.WithLogHandler(logHandler)
+	return e
+}
+
+func (e Encoder) Encode(summary *set.Summary) error {
+	if summaryError := e.summaryEncoder.Encode(summary); summaryError != nil {
+		return summaryError
+	}
+
+	for _, solutionSummary := range summary.AsSortedArray() {
+		if solutionSummary.Solution == nil {
+			continue
+		}
+		if solutionError := e.solutionEncoder.Encode(solutionSummary.Solution); solutionError != nil {
+			return errors.Wrap(solutionError, "geojson encoding of summarised solution")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package termination offers the criteria, beyond reaching MaximumIterations, under which an annealer stops early:
+// the archive going unchanged, the objective value converging, the temperature falling below a floor, or a wall-clock
+// budget running out. Each criterion is disabled unless its parameter is given, and the first met stops annealing.
+// Annealing also always stops once the explorer reports it has no further changes to try.
+package termination
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+// MaximumIterationsReached is the reason given when annealing runs for its full MaximumIterations.
+const MaximumIterationsReached = "Maximum iterations reached"
+
+// Cancelled is the reason given when annealing is cancelled before any other reason to stop arises.
+const Cancelled = "Annealing cancelled"
+
+// ExplorationExhausted is the reason given when the explorer reports it has no further changes to try.
+const ExplorationExhausted = "Explorer has no further changes to try"
+
+// Clock returns the current time, allowing the elapsed-time budget to be tested without waiting on it.
+type Clock func() time.Time
+
+type Criteria struct {
+	parameters Parameters
+	clock      Clock
+
+	startTime             time.Time
+	lastArchiveChange     uint64
+	recentObjectiveValues []float64
+
+	reason string
+}
+
+func New() *Criteria {
+	return new(Criteria).Initialise()
+}
+
+func (c *Criteria) Initialise() *Criteria {
+	c.parameters.Initialise()
+	return c
+}
+
+func (c *Criteria) WithClock(clock Clock) *Criteria {
+	c.clock = clock
+	return c
+}
+
+func (c *Criteria) WithParameters(params parameters.Map) *Criteria {
+	c.SetParameters(params)
+	return c
+}
+
+func (c *Criteria) SetParameters(params parameters.Map) error {
+	c.parameters.AssignOnlyEnforcedUserValues(params)
+	return c.ParameterErrors()
+}
+
+func (c *Criteria) ParameterErrors() error {
+	mergedErrors := compositeErrors.New("Termination Criteria Parameter Validation")
+
+	mergedErrors.Add(c.parameters.ValidationErrors())
+
+	if mergedErrors.Size() > 0 {
+		return mergedErrors
+	}
+	return nil
+}
+
+// Start readies the criteria for annealing that begins (or resumes) after the iteration supplied.
+func (c *Criteria) Start(iteration uint64) {
+	c.startTime = c.now()
+	c.lastArchiveChange = iteration
+	c.recentObjectiveValues = nil
+	c.reason = ""
+}
+
+func (c *Criteria) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock()
+}
+
+// Observe checks each criterion against the attributes reported at the end of the iteration supplied, noting the
+// reason for stopping if any is met.
+func (c *Criteria) Observe(iteration uint64, iterationAttributes attributes.Attributes) {
+	switch {
+	case explorationExhausted(iterationAttributes):
+		c.reason = ExplorationExhausted
+	case c.archiveUnchanged(iteration, iterationAttributes):
+		c.reason = fmt.Sprintf("Archive unchanged for [%d] iterations", iteration-c.lastArchiveChange)
+	case c.objectiveConverged(iterationAttributes):
+		c.reason = fmt.Sprintf("Objective value changed by no more than [%g] over [%d] iterations",
+			c.parameters.GetFloat64(ObjectiveConvergenceEpsilon), c.parameters.GetInt64(ObjectiveConvergenceWindow))
+	case c.temperatureBelowFloor(iterationAttributes):
+		c.reason = fmt.Sprintf("Temperature fell below [%g]", c.parameters.GetFloat64(StoppingTemperature))
+	case c.elapsedTimeExhausted():
+		c.reason = fmt.Sprintf("Elapsed time exceeded [%g] seconds", c.parameters.GetFloat64(MaximumElapsedSeconds))
+	}
+}
+
+// explorationExhausted reports whether the explorer has reported that it has no further changes to try. Unlike the
+// other criteria, it needs no parameter to be enabled, annealing beyond that point changing nothing.
+func explorationExhausted(iterationAttributes attributes.Attributes) bool {
+	isExhausted, isReported := iterationAttributes.Value(explorer.ExplorationExhausted).(bool)
+	return isReported && isExhausted
+}
+
+// archiveUnchanged reports whether MaximumIterationsWithoutArchiveChange have passed since the explorer last
+// reported a change to its archive. Explorers not reporting archive changes never meet this criterion.
+func (c *Criteria) archiveUnchanged(iteration uint64, iterationAttributes attributes.Attributes) bool {
+	archiveChanged, isReported := iterationAttributes.Value(explorer.ArchiveChanged).(bool)
+	if !isReported {
+		return false
+	}
+	if archiveChanged {
+		c.lastArchiveChange = iteration
+	}
+
+	limit := uint64(c.parameters.GetInt64(MaximumIterationsWithoutArchiveChange))
+	return limit != disabled && iteration-c.lastArchiveChange >= limit
+}
+
+// objectiveConverged reports whether the objective value has changed by no more than ObjectiveConvergenceEpsilon
+// across the last ObjectiveConvergenceWindow iterations. Explorers not reporting an objective value never meet this
+// criterion.
+func (c *Criteria) objectiveConverged(iterationAttributes attributes.Attributes) bool {
+	window := int(c.parameters.GetInt64(ObjectiveConvergenceWindow))
+	objectiveValue, isReported := iterationAttributes.Value(explorer.ObjectiveValue).(float64)
+	if window == disabled || !isReported {
+		return false
+	}
+
+	c.recentObjectiveValues = append(c.recentObjectiveValues, objectiveValue)
+	if len(c.recentObjectiveValues) <= window {
+		return false
+	}
+	c.recentObjectiveValues = c.recentObjectiveValues[len(c.recentObjectiveValues)-window-1:]
+
+	change := math.Abs(objectiveValue - c.recentObjectiveValues[0])
+	return change <= c.parameters.GetFloat64(ObjectiveConvergenceEpsilon)
+}
+
+func (c *Criteria) temperatureBelowFloor(iterationAttributes attributes.Attributes) bool {
+	floor := c.parameters.GetFloat64(StoppingTemperature)
+	temperature, isReported := iterationAttributes.Value(explorer.Temperature).(float64)
+	return floor != disabled && isReported && temperature < floor
+}
+
+func (c *Criteria) elapsedTimeExhausted() bool {
+	budget := c.parameters.GetFloat64(MaximumElapsedSeconds)
+	return budget != disabled && c.now().Sub(c.startTime).Seconds() >= budget
+}
+
+// IsMet reports whether any criterion has been met.
+func (c *Criteria) IsMet() bool {
+	return c.reason != ""
+}
+
+// Reason returns why the criteria were met, or an empty string if they have not been.
+func (c *Criteria) Reason() string {
+	return c.reason
+}
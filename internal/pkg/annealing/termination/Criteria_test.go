@@ -0,0 +1,204 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package termination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	. "github.com/onsi/gomega"
+)
+
+func TestCriteria_InvalidParameters_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	criteriaUnderTest := New().WithParameters(parameters.Map{
+		MaximumIterationsWithoutArchiveChange: int64(-1),
+		StoppingTemperature:                   float64(-0.5),
+	})
+
+	// then
+	g.Expect(criteriaUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(criteriaUnderTest.ParameterErrors().Error()).To(ContainSubstring(MaximumIterationsWithoutArchiveChange))
+	g.Expect(criteriaUnderTest.ParameterErrors().Error()).To(ContainSubstring(StoppingTemperature))
+}
+
+func TestCriteria_Defaults_NeverMet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New()
+	criteriaUnderTest.Start(0)
+
+	// when
+	for iteration := uint64(1); iteration <= 100; iteration++ {
+		criteriaUnderTest.Observe(iteration, attributes.Attributes{}.
+			Add(explorer.ArchiveChanged, false).
+			Add(explorer.ObjectiveValue, float64(1)).
+			Add(explorer.Temperature, float64(0)))
+	}
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+	g.Expect(criteriaUnderTest.Reason()).To(BeEmpty())
+}
+
+func TestCriteria_ArchiveUnchanged_MetAfterLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New().WithParameters(parameters.Map{MaximumIterationsWithoutArchiveChange: int64(5)})
+	criteriaUnderTest.Start(0)
+
+	changed := attributes.Attributes{}.Add(explorer.ArchiveChanged, true)
+	unchanged := attributes.Attributes{}.Add(explorer.ArchiveChanged, false)
+
+	// when
+	criteriaUnderTest.Observe(1, changed)
+	for iteration := uint64(2); iteration <= 5; iteration++ {
+		criteriaUnderTest.Observe(iteration, unchanged)
+	}
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+
+	// when
+	criteriaUnderTest.Observe(6, unchanged)
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeTrue())
+	g.Expect(criteriaUnderTest.Reason()).To(ContainSubstring("Archive unchanged for [5] iterations"))
+}
+
+func TestCriteria_ArchiveChangeNotReported_NeverMet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New().WithParameters(parameters.Map{MaximumIterationsWithoutArchiveChange: int64(1)})
+	criteriaUnderTest.Start(0)
+
+	// when
+	for iteration := uint64(1); iteration <= 10; iteration++ {
+		criteriaUnderTest.Observe(iteration, attributes.Attributes{})
+	}
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+}
+
+func TestCriteria_ExplorationExhausted_MetWithoutParameters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New()
+	criteriaUnderTest.Start(0)
+
+	// when
+	criteriaUnderTest.Observe(1, attributes.Attributes{}.Add(explorer.ExplorationExhausted, false))
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+
+	// when
+	criteriaUnderTest.Observe(2, attributes.Attributes{}.Add(explorer.ExplorationExhausted, true))
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeTrue())
+	g.Expect(criteriaUnderTest.Reason()).To(Equal(ExplorationExhausted))
+}
+
+func TestCriteria_ObjectiveConverged_MetOnceWindowStable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New().WithParameters(parameters.Map{
+		ObjectiveConvergenceWindow:  int64(3),
+		ObjectiveConvergenceEpsilon: float64(0.5),
+	})
+	criteriaUnderTest.Start(0)
+
+	objectiveValues := []float64{10, 8, 6, 5.9, 5.8, 5.7}
+
+	// when
+	for index, value := range objectiveValues[:5] {
+		criteriaUnderTest.Observe(uint64(index+1), attributes.Attributes{}.Add(explorer.ObjectiveValue, value))
+	}
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+
+	// when
+	criteriaUnderTest.Observe(6, attributes.Attributes{}.Add(explorer.ObjectiveValue, objectiveValues[5]))
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeTrue())
+	g.Expect(criteriaUnderTest.Reason()).To(ContainSubstring("over [3] iterations"))
+}
+
+func TestCriteria_TemperatureBelowFloor_Met(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New().WithParameters(parameters.Map{StoppingTemperature: float64(1)})
+	criteriaUnderTest.Start(0)
+
+	// when
+	criteriaUnderTest.Observe(1, attributes.Attributes{}.Add(explorer.Temperature, float64(1)))
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+
+	// when
+	criteriaUnderTest.Observe(2, attributes.Attributes{}.Add(explorer.Temperature, float64(0.99)))
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeTrue())
+	g.Expect(criteriaUnderTest.Reason()).To(Equal("Temperature fell below [1]"))
+}
+
+func TestCriteria_ElapsedTimeExhausted_Met(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	testClock := func() time.Time { return now }
+
+	criteriaUnderTest := New().
+		WithClock(testClock).
+		WithParameters(parameters.Map{MaximumElapsedSeconds: float64(60)})
+	criteriaUnderTest.Start(0)
+
+	// when
+	now = now.Add(59 * time.Second)
+	criteriaUnderTest.Observe(1, attributes.Attributes{})
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+
+	// when
+	now = now.Add(time.Second)
+	criteriaUnderTest.Observe(2, attributes.Attributes{})
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeTrue())
+	g.Expect(criteriaUnderTest.Reason()).To(Equal("Elapsed time exceeded [60] seconds"))
+}
+
+func TestCriteria_Start_ClearsReason(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	criteriaUnderTest := New().WithParameters(parameters.Map{StoppingTemperature: float64(1)})
+	criteriaUnderTest.Start(0)
+	criteriaUnderTest.Observe(1, attributes.Attributes{}.Add(explorer.Temperature, float64(0.5)))
+
+	// when
+	criteriaUnderTest.Start(1)
+
+	// then
+	g.Expect(criteriaUnderTest.IsMet()).To(BeFalse())
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package termination
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+type Parameters struct {
+	parameters.Parameters
+}
+
+func (p *Parameters) Initialise() *Parameters {
+	p.Parameters.
+		Initialise("Termination Criteria Parameter Validation").
+		Enforcing(ParameterSpecifications())
+	return p
+}
+
+const (
+	MaximumIterationsWithoutArchiveChange = "MaximumIterationsWithoutArchiveChange"
+	ObjectiveConvergenceWindow            = "ObjectiveConvergenceWindow"
+	ObjectiveConvergenceEpsilon           = "ObjectiveConvergenceEpsilon"
+	StoppingTemperature                   = "StoppingTemperature"
+	MaximumElapsedSeconds                 = "MaximumElapsedSeconds"
+
+	disabled = 0
+)
+
+func ParameterSpecifications() *Specifications {
+	specs := NewSpecifications()
+	specs.Add(
+		Specification{
+			Key:          MaximumIterationsWithoutArchiveChange,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(disabled),
+		},
+	).Add(
+		Specification{
+			Key:          ObjectiveConvergenceWindow,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(disabled),
+		},
+	).Add(
+		Specification{
+			Key:          ObjectiveConvergenceEpsilon,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          StoppingTemperature,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(disabled),
+		},
+	).Add(
+		Specification{
+			Key:          MaximumElapsedSeconds,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(disabled),
+		},
+	)
+	return specs
+}
@@ -25,13 +25,15 @@ var (
 	Kirkpatrick             = AnnealerType{"Kirkpatrick"}
 	Suppapitnarm            = AnnealerType{"Suppapitnarm"}
 	AveragedSuppapitnarm    = AnnealerType{"AveragedSuppapitnarm"}
+	ParallelTempering       = AnnealerType{"ParallelTempering"}
+	Greedy                  = AnnealerType{"Greedy"}
 )
 
 func (at *AnnealerType) UnmarshalText(text []byte) error {
 	context := UnmarshalContext{
 		ConfigKey: "Annealer.Type",
 		ValidValues: []string{
-			Kirkpatrick.Value, Suppapitnarm.Value, AveragedSuppapitnarm.Value,
+			Kirkpatrick.Value, Suppapitnarm.Value, AveragedSuppapitnarm.Value, ParallelTempering.Value, Greedy.Value,
 		},
 		TextToValidate: string(text),
 		AssignmentFunction: func() {
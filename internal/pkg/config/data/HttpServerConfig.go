@@ -18,10 +18,13 @@ const (
 )
 
 type HttpServerConfig struct {
-	AdminPort                uint64
-	ApiPort                  uint64
-	CacheMaximumAgeInSeconds uint64
-	JobQueueLength           uint64
+	AdminPort                   uint64
+	ApiPort                     uint64
+	CacheMaximumAgeInSeconds    uint64
+	JobQueueLength              uint64
+	SessionIdleTimeoutInSeconds uint64
+
+	MaximumUncertaintySampleSize uint64
 
 	Logger LoggingConfig
 }
@@ -7,8 +7,10 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/averaged"
 	coolingSuppapitnarm "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/tempering"
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
@@ -80,6 +82,32 @@ func NewAnnealerConfigInterpreter() *AnnealerConfigInterpreter {
 
 			newAnnealer.SetParameters(config.Parameters)
 
+			return newAnnealer
+		},
+	).RegisteringAnnealer(
+		data.ParallelTempering,
+		func(config data.AnnealerConfig) annealing.Annealer {
+			newAnnealer := new(annealers.ElapsedTimeTrackingAnnealer)
+			newAnnealer.Initialise()
+
+			newExplorer := tempering.New()
+			newAnnealer.SetSolutionExplorer(newExplorer)
+
+			newAnnealer.SetParameters(config.Parameters)
+
+			return newAnnealer
+		},
+	).RegisteringAnnealer(
+		data.Greedy,
+		func(config data.AnnealerConfig) annealing.Annealer {
+			newAnnealer := new(annealers.ElapsedTimeTrackingAnnealer)
+			newAnnealer.Initialise()
+
+			newExplorer := greedy.New()
+			newAnnealer.SetSolutionExplorer(newExplorer)
+
+			newAnnealer.SetParameters(config.Parameters)
+
 			return newAnnealer
 		},
 	)
@@ -3,7 +3,9 @@
 package interpreter
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/tempering"
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
@@ -161,3 +163,258 @@ func TestConfigInterpreter_SuppapitnarmAnnealer_NoErrors(t *testing.T) {
 	expectedExplorerType := &suppapitnarm.Explorer{}
 	g.Expect(actualExplorer).To(BeAssignableToTypeOf(expectedExplorerType))
 }
+
+func TestConfigInterpreter_SuppapitnarmOptimisationDirections_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"OptimisationDirections": map[string]interface{}{
+			"SedimentProduction": "Minimising",
+			"HabitatArea":        "Maximising",
+		},
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Suppapitnarm,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
+
+func TestConfigInterpreter_SuppapitnarmInvalidOptimisationDirections_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"OptimisationDirections": map[string]interface{}{
+			"SedimentProduction": "Sideways",
+		},
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Suppapitnarm,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestConfigInterpreter_ParallelTemperingAnnealer_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MaximumIterations":     int64(100),
+		"DecisionVariable":      "ObjectiveVariable",
+		"OptimisationDirection": "Maximising",
+		"CoolingFactor":         0.99,
+		"Replicas":              int64(6),
+		"MinimumTemperature":    float64(0.5),
+		"MaximumTemperature":    float64(50),
+		"SwapInterval":          int64(20),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.ParallelTempering,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualExplorer := interpreterUnderTest.Annealer().SolutionExplorer()
+	expectedExplorerType := &tempering.Explorer{}
+	g.Expect(actualExplorer).To(BeAssignableToTypeOf(expectedExplorerType))
+}
+
+func TestConfigInterpreter_ParallelTemperingInvalidTemperatureRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MinimumTemperature": float64(50),
+		"MaximumTemperature": float64(5),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.ParallelTempering,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("MaximumTemperature"))
+}
+
+func TestConfigInterpreter_KirkpatrickCalibration_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MaximumIterations":       int64(100),
+		"CalibrationSamples":      int64(500),
+		"TargetInitialAcceptance": float64(0.8),
+		"TargetFinalAcceptance":   float64(0.001),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Kirkpatrick,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
+
+func TestConfigInterpreter_SuppapitnarmInvalidCalibration_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"CalibrationSamples":      int64(500),
+		"TargetInitialAcceptance": float64(1),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Suppapitnarm,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("TargetInitialAcceptance"))
+}
+
+func TestConfigInterpreter_KirkpatrickCoolingSchedule_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"CoolingSchedule":   "LundyMees",
+		"LundyMeesBeta":     float64(0.0001),
+		"ReheatingInterval": int64(1000),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Kirkpatrick,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
+
+func TestConfigInterpreter_SuppapitnarmUnknownCoolingSchedule_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"CoolingSchedule": "Cubic",
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Suppapitnarm,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("CoolingSchedule"))
+}
+
+func TestConfigInterpreter_GreedyAnnealer_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MaximumIterations":         int64(100),
+		"DecisionVariable":          "SedimentProduction",
+		"OptimisationDirection":     "Minimising",
+		"CostDecisionVariable":      "ImplementationCost",
+		"MaximumImplementationCost": float64(1_000_000),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Greedy,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualExplorer := interpreterUnderTest.Annealer().SolutionExplorer()
+	expectedExplorerType := &greedy.Explorer{}
+	g.Expect(actualExplorer).To(BeAssignableToTypeOf(expectedExplorerType))
+}
+
+func TestConfigInterpreter_GreedyNegativeCostLimit_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MaximumImplementationCost": float64(-1),
+	}
+	configUnderTest := data.AnnealerConfig{
+		Type:       data.Greedy,
+		Parameters: parametersUnderTest,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	g.Expect(interpreterUnderTest.Errors().Error()).To(ContainSubstring("MaximumImplementationCost"))
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/pkg/xlsx"
+	"github.com/pkg/errors"
+)
+
+// NewDataSet returns an empty data set, able to load and save xlsx workbooks without Excel. Worksheets laid out as
+// ESRI ASCII grids (a six row header ending in "NODATA_value") become AscTables, with all others becoming CsvTables
+// whose first row is their header, matching the layout expected of Excel data sets.
+func NewDataSet(name string) *DataSet {
+	dataSet := new(DataSet)
+	dataSet.Initialise(name)
+	return dataSet
+}
+
+type headerCellDetail struct {
+	row      uint
+	labelCol uint
+	valueCol uint
+	label    string
+}
+
+var nColsCellDetail = headerCellDetail{1, 1, 2, "ncols"}
+var nRowsCellDetail = headerCellDetail{2, 1, 2, "nrows"}
+var xllCornerCellDetail = headerCellDetail{3, 1, 2, "xllcorner"}
+var yllCornerCellDetail = headerCellDetail{4, 1, 2, "yllcorner"}
+var cellSizeCellDetail = headerCellDetail{5, 1, 2, "cellsize"}
+var noDataCellDetail = headerCellDetail{6, 1, 2, "NODATA_value"}
+
+var ascHeaderCellDetails = []headerCellDetail{
+	nColsCellDetail, nRowsCellDetail, xllCornerCellDetail, yllCornerCellDetail, cellSizeCellDetail, noDataCellDetail,
+}
+
+const ascRowOffset = uint(7)
+const ascColOffset = uint(1)
+
+const csvHeaderRow = uint(1)
+const csvRowOffset = uint(2)
+const csvColOffset = uint(1)
+
+type DataSet struct {
+	dataset.DataSetImpl
+}
+
+// Load adds a table to the data set for each worksheet of the xlsx workbook at the file path supplied, skipping any
+// blank worksheets.
+func (ds *DataSet) Load(xlsxFilePath string) error {
+	workbook, openError := xlsx.Open(xlsxFilePath)
+	if openError != nil {
+		return errors.Wrap(openError, "loading xlsx data set")
+	}
+
+	for _, worksheet := range workbook.Worksheets() {
+		if worksheet.RowCount() == 0 {
+			continue // blank worksheets, like the spare sheets Excel adds to new workbooks, hold no table.
+		}
+		if loadError := ds.loadWorksheet(worksheet); loadError != nil {
+			return errors.Wrap(loadError, "loading xlsx worksheet ["+worksheet.Name()+"]")
+		}
+	}
+	return nil
+}
+
+func (ds *DataSet) loadWorksheet(sheet *xlsx.Worksheet) error {
+	if isAscSheet(sheet) {
+		return ds.loadAscWorksheet(sheet)
+	}
+	return ds.loadCsvWorksheet(sheet)
+}
+
+func isAscSheet(sheet *xlsx.Worksheet) bool {
+	value, isString := sheet.Cell(noDataCellDetail.row, noDataCellDetail.labelCol).(string)
+	return isString && value == noDataCellDetail.label
+}
+
+func (ds *DataSet) loadAscWorksheet(sheet *xlsx.Worksheet) error {
+	newAscTable := new(tables.AscTableImpl)
+
+	newAscHeader, headerError := buildAscHeader(sheet)
+	if headerError != nil {
+		return headerError
+	}
+	newAscTable.SetHeader(newAscHeader)
+	buildAscCellData(newAscTable, sheet)
+
+	return ds.AddTable(sheet.Name(), newAscTable)
+}
+
+func buildAscHeader(sheet *xlsx.Worksheet) (tables.AscHeader, error) {
+	values := make(map[headerCellDetail]float64, len(ascHeaderCellDetails))
+	for _, detail := range ascHeaderCellDetails {
+		value, isDecimal := sheet.Cell(detail.row, detail.valueCol).(float64)
+		if !isDecimal {
+			return tables.AscHeader{}, errors.New(detail.label + " value not retrievable")
+		}
+		values[detail] = value
+	}
+
+	return tables.AscHeader{
+		NumCols:     uint(values[nColsCellDetail]),
+		NumRows:     uint(values[nRowsCellDetail]),
+		XllCorner:   values[xllCornerCellDetail],
+		YllCorner:   values[yllCornerCellDetail],
+		CellSize:    int64(values[cellSizeCellDetail]),
+		NoDataValue: int64(values[noDataCellDetail]),
+	}, nil
+}
+
+func buildAscCellData(table *tables.AscTableImpl, sheet *xlsx.Worksheet) {
+	table.SetName(sheet.Name())
+	table.SetColumnAndRowSize(table.Header().NumCols, table.Header().NumRows)
+
+	for col := ascColOffset; col < table.Header().NumCols+ascColOffset; col++ {
+		for row := ascRowOffset; row < table.Header().NumRows+ascRowOffset; row++ {
+			table.SetCell(col-ascColOffset, row-ascRowOffset, sheet.Cell(row, col))
+		}
+	}
+}
+
+func (ds *DataSet) loadCsvWorksheet(sheet *xlsx.Worksheet) error {
+	newCsvTable := new(tables.CsvTableImpl)
+
+	newCsvHeader, headerError := buildCsvHeader(sheet)
+	if headerError != nil {
+		return headerError
+	}
+	newCsvTable.SetHeader(newCsvHeader)
+	buildCsvCellData(newCsvTable, sheet)
+
+	return ds.AddTable(sheet.Name(), newCsvTable)
+}
+
+func buildCsvHeader(sheet *xlsx.Worksheet) (dataset.TableHeader, error) {
+	newCsvHeader := make(dataset.TableHeader, 0)
+
+	for col := uint(1); col <= sheet.ColumnCount(); col++ {
+		headerValue, isString := sheet.Cell(csvHeaderRow, col).(string)
+		if !isString {
+			return nil, errors.New(fmt.Sprintf("header of column [%d] is not text", col))
+		}
+		newCsvHeader = append(newCsvHeader, headerValue)
+	}
+
+	return newCsvHeader, nil
+}
+
+func buildCsvCellData(table tables.CsvTable, sheet *xlsx.Worksheet) {
+	table.SetName(sheet.Name())
+	colCount := sheet.ColumnCount()
+	rowCount := sheet.RowCount()
+
+	table.SetColumnAndRowSize(colCount, rowCount-1)
+
+	for col := csvColOffset; col < colCount+csvColOffset; col++ {
+		for row := csvRowOffset; row < rowCount+csvRowOffset-1; row++ {
+			table.SetCell(col-csvColOffset, row-csvRowOffset, sheet.Cell(row, col))
+		}
+	}
+}
+
+// SaveAs writes the data set's tables to the file path supplied as an xlsx workbook, a worksheet per table, ordered
+// by table name.
+func (ds *DataSet) SaveAs(xlsxFilePath string) error {
+	workbook := xlsx.NewWorkbook()
+
+	for _, tableName := range ds.sortedTableNames() {
+		ds.storeTableToWorksheet(ds.Tables()[tableName], workbook.AddWorksheet(tableName))
+	}
+
+	if saveError := workbook.SaveAs(xlsxFilePath); saveError != nil {
+		return errors.Wrap(saveError, "saving xlsx data set")
+	}
+	return nil
+}
+
+func (ds *DataSet) sortedTableNames() []string {
+	names := make([]string, 0, len(ds.Tables()))
+	for name := range ds.Tables() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (ds *DataSet) storeTableToWorksheet(table dataset.Table, worksheet *xlsx.Worksheet) {
+	if ascTable, isAscTable := table.(tables.AscTable); isAscTable {
+		storeAscTableToWorksheet(ascTable, worksheet)
+	}
+	if csvTable, isCsvTable := table.(tables.CsvTable); isCsvTable {
+		storeCsvTableToWorksheet(csvTable, worksheet)
+	}
+}
+
+func storeAscTableToWorksheet(table tables.AscTable, worksheet *xlsx.Worksheet) {
+	for _, detail := range ascHeaderCellDetails {
+		worksheet.SetCell(detail.row, detail.labelCol, detail.label)
+		worksheet.SetCell(detail.row, detail.valueCol, fieldForHeaderCellDetail(detail, table.Header()))
+	}
+
+	colSize, rowSize := table.ColumnAndRowSize()
+	for col := uint(0); col < colSize; col++ {
+		for row := uint(0); row < rowSize; row++ {
+			worksheet.SetCell(row+ascRowOffset, col+ascColOffset, table.Cell(col, row))
+		}
+	}
+}
+
+func fieldForHeaderCellDetail(detail headerCellDetail, header tables.AscHeader) interface{} {
+	switch detail {
+	case nColsCellDetail:
+		return header.NumCols
+	case nRowsCellDetail:
+		return header.NumRows
+	case xllCornerCellDetail:
+		return header.XllCorner
+	case yllCornerCellDetail:
+		return header.YllCorner
+	case cellSizeCellDetail:
+		return header.CellSize
+	case noDataCellDetail:
+		return header.NoDataValue
+	}
+	return nil
+}
+
+func storeCsvTableToWorksheet(table tables.CsvTable, worksheet *xlsx.Worksheet) {
+	colCount, rowCount := table.ColumnAndRowSize()
+
+	header := table.Header()
+	for col := uint(0); col < colCount; col++ {
+		worksheet.SetCell(csvHeaderRow, col+csvColOffset, header[col])
+	}
+
+	for col := uint(0); col < colCount; col++ {
+		for row := uint(0); row < rowCount; row++ {
+			worksheet.SetCell(row+csvRowOffset, col+csvColOffset, table.Cell(col, row))
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/pkg/xlsx"
+	. "github.com/onsi/gomega"
+)
+
+// testLoadFixturePath is the Excel-saved workbook shared with the Excel data set tests.
+const testLoadFixturePath = "../excel/testdata/testExcelDataSetLoad.xlsx"
+
+func TestDataSet_NewDataSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	dataSetUnderTest := NewDataSet("expectedName")
+
+	// then
+	g.Expect(dataSetUnderTest.Name()).To(BeIdenticalTo("expectedName"))
+	g.Expect(dataSetUnderTest.Tables()).To(BeEmpty())
+}
+
+func TestDataSet_Load(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := NewDataSet("testXlsxDataSet")
+
+	// when
+	loadError := dataSetUnderTest.Load(testLoadFixturePath)
+
+	// then
+	g.Expect(loadError).To(BeNil())
+	verifyLoadFixtureTables(g, dataSetUnderTest)
+}
+
+func TestDataSet_LoadMissingFile_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := NewDataSet("testXlsxDataSet")
+
+	// when
+	loadError := dataSetUnderTest.Load("testdata/missing.xlsx")
+
+	// then
+	g.Expect(loadError).To(Not(BeNil()))
+}
+
+func TestDataSet_LoadWithBlankWorksheet_BlankWorksheetSkipped(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	workbookPath := filepath.Join(t.TempDir(), "testBlankWorksheet.xlsx")
+
+	workbook := xlsx.NewWorkbook()
+	tableSheet := workbook.AddWorksheet("Table")
+	tableSheet.SetCell(1, 1, "Name")
+	tableSheet.SetCell(2, 1, "entry")
+	workbook.AddWorksheet("Sheet2")
+	g.Expect(workbook.SaveAs(workbookPath)).To(Succeed())
+
+	dataSetUnderTest := NewDataSet("testXlsxDataSet")
+
+	// when
+	loadError := dataSetUnderTest.Load(workbookPath)
+
+	// then
+	g.Expect(loadError).To(BeNil())
+	g.Expect(dataSetUnderTest.Tables()).To(HaveLen(1))
+	g.Expect(dataSetUnderTest.Tables()).To(HaveKey("Table"))
+}
+
+func TestDataSet_SaveAs_LoadsBackSameTables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	savePath := filepath.Join(t.TempDir(), "testXlsxDataSetSave.xlsx")
+
+	dataSetUnderTest := NewDataSet("testXlsxDataSet")
+	g.Expect(dataSetUnderTest.Load(testLoadFixturePath)).To(Succeed())
+
+	// when
+	saveError := dataSetUnderTest.SaveAs(savePath)
+
+	// then
+	g.Expect(saveError).To(BeNil())
+	_, statError := os.Stat(savePath)
+	g.Expect(statError).To(BeNil())
+
+	reloadedDataSet := NewDataSet("reloadedXlsxDataSet")
+	g.Expect(reloadedDataSet.Load(savePath)).To(Succeed())
+	verifyLoadFixtureTables(g, reloadedDataSet)
+}
+
+func verifyLoadFixtureTables(g *GomegaWithT, dataSetUnderTest dataset.DataSet) {
+	loadedTables := dataSetUnderTest.Tables()
+	g.Expect(loadedTables).To(HaveKey("testAscTable"))
+
+	testAscTable := loadedTables["testAscTable"]
+	g.Expect(testAscTable).To(BeAssignableToTypeOf(new(tables.AscTableImpl)))
+	g.Expect(testAscTable.Cell(1, 1)).To(BeNumerically("==", 1))
+	g.Expect(testAscTable.Cell(2, 2)).To(BeNumerically("==", 5))
+	g.Expect(testAscTable.Cell(3, 3)).To(BeNumerically("==", 9))
+
+	actualAscCols, actualAscRows := testAscTable.ColumnAndRowSize()
+	g.Expect(actualAscCols).To(BeNumerically("==", 5))
+	g.Expect(actualAscRows).To(BeNumerically("==", 5))
+
+	g.Expect(loadedTables).To(HaveKey("testCsvTable"))
+
+	typedCsvTable, isCsvTable := loadedTables["testCsvTable"].(tables.CsvTable)
+	g.Expect(isCsvTable).To(BeTrue())
+	g.Expect(typedCsvTable.Header()).To(ContainElement("StringColumn"))
+
+	g.Expect(typedCsvTable.Cell(0, 0)).To(BeNumerically("==", 1))
+	g.Expect(typedCsvTable.Cell(1, 1)).To(BeIdenticalTo("entry2"))
+	g.Expect(typedCsvTable.Cell(2, 2)).To(BeNumerically("==", 3.001))
+	g.Expect(typedCsvTable.Cell(3, 3)).To(BeFalse())
+
+	actualCsvCols, actualCsvRows := typedCsvTable.ColumnAndRowSize()
+	g.Expect(actualCsvCols).To(BeNumerically("==", 4))
+	g.Expect(actualCsvRows).To(BeNumerically("==", 5))
+}
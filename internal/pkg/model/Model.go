@@ -3,9 +3,12 @@
 package model
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/schedule"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/name"
@@ -51,6 +54,38 @@ type Model interface {
 	PlanningUnits() planningunit.Ids
 }
 
+// IntensityAdjustable is implemented by models whose management actions may be applied at partial intensities.
+type IntensityAdjustable interface {
+	SetManagementActionIntensity(index int, intensity float64)
+}
+
+// Scheduled is implemented by models able to report a yearly schedule of their values over a planning horizon.
+type Scheduled interface {
+	Schedule() *schedule.Schedule
+}
+
+// Schedulable is implemented by models whose management actions can be scheduled to start in a given year of a
+// planning horizon.
+type Schedulable interface {
+	SetManagementActionStartYear(index int, startYear uint)
+}
+
+// Sampleable is implemented by models that can be rebuilt over sampled versions of the parameters and data set they
+// were built from.
+type Sampleable interface {
+	// SourceParameters returns a copy of the parameters the model was built from.
+	SourceParameters() parameters.Map
+
+	// SourceDataSet returns the (loaded) data set the model was built from.
+	SourceDataSet() dataset.DataSet
+
+	// Resampled returns a new as-is model, otherwise like this one, built from the parameters and data set supplied.
+	Resampled(parameters parameters.Map, dataSet dataset.DataSet) (Model, error)
+
+	// ValidateParameter returns the reason the value supplied is invalid for the named parameter, or nil if valid.
+	ValidateParameter(name string, value interface{}) error
+}
+
 // ContainedLogger defines an interface embedding a Model
 type Container interface {
 	Model() Model
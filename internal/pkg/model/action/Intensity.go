@@ -0,0 +1,47 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package action
+
+const (
+	// NoIntensity is the intensity of an inactive management action.
+	NoIntensity = 0.0
+
+	// FullIntensity is the intensity of a management action applied in full.
+	FullIntensity = 1.0
+
+	// FullRealisation is the realisation of a management action whose full effect is realised.
+	FullRealisation = 1.0
+)
+
+// EffectiveIntensity returns the intensity of the management action supplied, scaled by the proportion of its
+// effect realised.  Actions not being scheduled are fully realised, leaving their intensity as-is.
+func EffectiveIntensity(action ManagementAction) float64 {
+	return scaledIntensity(action.Intensity(), action.Realisation())
+}
+
+// PreviousEffectiveIntensity returns the effective intensity of the management action supplied before its most
+// recent change.
+func PreviousEffectiveIntensity(action ManagementAction) float64 {
+	return scaledIntensity(action.PreviousIntensity(), action.PreviousRealisation())
+}
+
+func scaledIntensity(intensity float64, realisation float64) float64 {
+	if realisation == FullRealisation {
+		return intensity
+	}
+	return intensity * realisation
+}
+
+// Interpolate returns the value lying the proportion intensity of the way from original (an inactive management
+// action's value) to actioned (its value when applied in full). Original and actioned are returned as-is at
+// intensities of 0 and 1, so binary actions see no rounding drift.
+func Interpolate(original float64, actioned float64, intensity float64) float64 {
+	switch intensity {
+	case NoIntensity:
+		return original
+	case FullIntensity:
+		return actioned
+	default:
+		return original + (actioned-original)*intensity
+	}
+}
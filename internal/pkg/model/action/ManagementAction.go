@@ -28,9 +28,32 @@ type ManagementAction interface {
 	// Type identifies the ManagementActionType of a particular management action
 	Type() ManagementActionType
 
-	// IsActive reports whether a management action is active (true) or not (false).
+	// IsActive reports whether a management action is active (true) or not (false). An action is active whenever
+	// its intensity is above zero.
 	IsActive() bool
 
+	// Intensity reports the level at which a management action is applied, from 0 (inactive) to 1 (fully applied).
+	Intensity() float64
+
+	// PreviousIntensity reports the intensity of a management action before its most recent change.
+	PreviousIntensity() float64
+
+	// IntensityLevels reports the ascending, non-zero intensities that a management action may be applied at.
+	IntensityLevels() []float64
+
+	// StartYear reports the year of a planning horizon, counting from 0, that a management action starts in.
+	StartYear() uint
+
+	// PreviousStartYear reports the start year of a management action before its most recent change.
+	PreviousStartYear() uint
+
+	// Realisation reports the proportion of a management action's full effect realised, given when it starts,
+	// from 0 (none) to 1 (all of it, the default for actions not being scheduled).
+	Realisation() float64
+
+	// PreviousRealisation reports the realisation of a management action before its most recent change.
+	PreviousRealisation() float64
+
 	// ModelVariableName reports tha value of the model variableName stored with the management action.
 	ModelVariableValue(variableName ModelVariableName) float64
 
@@ -43,8 +66,8 @@ type ManagementAction interface {
 
 	InitialisingDeactivation()
 
-	// ToggleActivation activates an inactive ManagementAction and vice-versa,
-	// triggering Reporting method ObserveAction callbacks.
+	// ToggleActivation activates an inactive ManagementAction at its highest intensity level and deactivates an
+	// active one, triggering Reporting method ObserveAction callbacks.
 	ToggleActivation()
 
 	// ToggleActivationUnobserved activates an inactive ManagementAction and vice-versa, without triggering any
@@ -57,4 +80,20 @@ type ManagementAction interface {
 	// SetActivation activates an the ManagementAction as per the value supplied, without triggering any
 	//  Reporting method callbacks. Expected to be called when undoing a change that observers shouldn't react to.
 	SetActivationUnobserved(value bool)
+
+	// SetIntensity applies the ManagementAction at the intensity supplied, triggering Reporting method
+	// ObserveAction callbacks.
+	SetIntensity(intensity float64)
+
+	// SetIntensityUnobserved applies the ManagementAction at the intensity supplied, without triggering any
+	// Reporting method callbacks. Expected to be called when undoing a change that observers shouldn't react to.
+	SetIntensityUnobserved(intensity float64)
+
+	// SetSchedule starts the ManagementAction in the year supplied, realising the proportion of its full effect
+	// given, triggering Reporting method ObserveAction callbacks.
+	SetSchedule(startYear uint, realisation float64)
+
+	// SetScheduleUnobserved starts the ManagementAction in the year supplied, realising the proportion of its full
+	// effect given, without triggering any Reporting method callbacks.
+	SetScheduleUnobserved(startYear uint, realisation float64)
 }
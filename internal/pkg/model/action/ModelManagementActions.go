@@ -157,6 +157,58 @@ func (m *ModelManagementActions) ToggleAction(planningUnit planningunit.Id, acti
 	}
 }
 
+// Toggle toggles the activation of the supplied management action, recording it as the last applied, and
+// alerting any observers of the change.
+func (m *ModelManagementActions) Toggle(action ManagementAction) {
+	m.lastApplied = action
+	m.lastApplied.ToggleActivation()
+}
+
+// ToggleUnobserved toggles the activation of the supplied management action, recording it as the last applied,
+// without triggering any observation of the change.
+func (m *ModelManagementActions) ToggleUnobserved(action ManagementAction) {
+	m.lastApplied = action
+	m.lastApplied.ToggleActivationUnobserved()
+}
+
+// ApplyAtIntensity applies the supplied management action at the intensity given, recording it as the last applied,
+// and alerting any observers of the change.
+func (m *ModelManagementActions) ApplyAtIntensity(action ManagementAction, intensity float64) {
+	m.lastApplied = action
+	m.lastApplied.SetIntensity(intensity)
+}
+
+// ApplyAtIntensityUnobserved applies the supplied management action at the intensity given, recording it as the
+// last applied, without triggering any observation of the change.
+func (m *ModelManagementActions) ApplyAtIntensityUnobserved(action ManagementAction, intensity float64) {
+	m.lastApplied = action
+	m.lastApplied.SetIntensityUnobserved(intensity)
+}
+
+// ScheduleAt starts the supplied management action in the year given, realising the proportion of its effect given,
+// recording it as the last applied, and alerting any observers of the change.
+func (m *ModelManagementActions) ScheduleAt(action ManagementAction, startYear uint, realisation float64) {
+	m.lastApplied = action
+	m.lastApplied.SetSchedule(startYear, realisation)
+}
+
+// ScheduleAtUnobserved starts the supplied management action in the year given, realising the proportion of its
+// effect given, recording it as the last applied, without triggering any observation of the change.
+func (m *ModelManagementActions) ScheduleAtUnobserved(action ManagementAction, startYear uint, realisation float64) {
+	m.lastApplied = action
+	m.lastApplied.SetScheduleUnobserved(startYear, realisation)
+}
+
+// RevertLastChangeUnobserved returns the last recorded management action change to its intensity and schedule before
+// that change, without triggering any observation of the change.
+func (m *ModelManagementActions) RevertLastChangeUnobserved() {
+	previousIntensity := m.lastApplied.PreviousIntensity()
+	previousStartYear, previousRealisation := m.lastApplied.PreviousStartYear(), m.lastApplied.PreviousRealisation()
+
+	m.lastApplied.SetScheduleUnobserved(previousStartYear, previousRealisation)
+	m.lastApplied.SetIntensityUnobserved(previousIntensity)
+}
+
 // ToggleLastActivation allows for the last recorded management action change to have its
 // activation state reverted, alerting any observers  of the change.
 func (m *ModelManagementActions) ToggleLastActivation() {
@@ -202,3 +254,13 @@ func (m *ModelManagementActions) SetActivationUnobserved(index int, value bool)
 	m.lastApplied = m.actions[index]
 	m.actions[index].SetActivationUnobserved(value)
 }
+
+func (m *ModelManagementActions) SetIntensity(index int, intensity float64) {
+	m.lastApplied = m.actions[index]
+	m.actions[index].SetIntensity(intensity)
+}
+
+func (m *ModelManagementActions) SetIntensityUnobserved(index int, intensity float64) {
+	m.lastApplied = m.actions[index]
+	m.actions[index].SetIntensityUnobserved(intensity)
+}
@@ -22,6 +22,13 @@ const NullManagementActionType ManagementActionType = "NullType"
 
 func (a *Null) Type() ManagementActionType                                { return NullManagementActionType }
 func (a *Null) IsActive() bool                                            { return false }
+func (a *Null) Intensity() float64                                        { return 0 }
+func (a *Null) PreviousIntensity() float64                                { return 0 }
+func (a *Null) IntensityLevels() []float64                                { return fullIntensityOnly }
+func (a *Null) StartYear() uint                                           { return 0 }
+func (a *Null) PreviousStartYear() uint                                   { return 0 }
+func (a *Null) Realisation() float64                                      { return FullRealisation }
+func (a *Null) PreviousRealisation() float64                              { return FullRealisation }
 func (a *Null) ModelVariableValue(variableName ModelVariableName) float64 { return 0 }
 func (a *Null) Subscribe(observers ...Observer)                           {}
 func (a *Null) InitialisingActivation()                                   {}
@@ -30,3 +37,7 @@ func (a *Null) ToggleActivation()                                         {}
 func (a *Null) ToggleActivationUnobserved()                               {}
 func (a *Null) SetActivation(value bool)                                  {}
 func (a *Null) SetActivationUnobserved(value bool)                        {}
+func (a *Null) SetIntensity(intensity float64)                            {}
+func (a *Null) SetIntensityUnobserved(intensity float64)                  {}
+func (a *Null) SetSchedule(startYear uint, realisation float64)           {}
+func (a *Null) SetScheduleUnobserved(startYear uint, realisation float64) {}
@@ -4,16 +4,29 @@ package action
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/pkg/errors"
+	"sort"
 )
 
 var _ ManagementAction = new(SimpleManagementAction)
 
 // SimpleManagementAction is a basic, generally useful implementation of the ManagementAction interface, using a
-// fluent interface for its action construction.
+// fluent interface for its action construction. Unless given intensity levels, an action is either inactive or
+// fully applied. Unless scheduled, an action starts in year 0 and is fully realised.
 type SimpleManagementAction struct {
 	planningUnit planningunit.Id
 	actionType   ManagementActionType
-	isActive     bool
+
+	intensity         float64
+	previousIntensity float64
+	intensityLevels   []float64
+
+	startYear         uint
+	previousStartYear uint
+
+	// shortfalls in realisation are held, rather than realisations, so that unscheduled actions are fully realised.
+	realisationShortfall         float64
+	previousRealisationShortfall float64
 
 	variables map[ModelVariableName]float64
 	observers []Observer
@@ -38,6 +51,26 @@ func (sma *SimpleManagementAction) WithVariable(variableName ModelVariableName,
 	return sma
 }
 
+// WithIntensityLevels sets the non-zero intensities, each in (0,1], that the action may be applied at, panicking
+// if any level falls outside that range.
+func (sma *SimpleManagementAction) WithIntensityLevels(levels ...float64) *SimpleManagementAction {
+	if len(levels) == 0 {
+		sma.intensityLevels = nil
+		return sma
+	}
+
+	sortedLevels := make([]float64, len(levels))
+	copy(sortedLevels, levels)
+	sort.Float64s(sortedLevels)
+
+	if sortedLevels[0] <= 0 || sortedLevels[len(sortedLevels)-1] > 1 {
+		panic(errors.New("Management action intensity levels must lie within (0,1]"))
+	}
+
+	sma.intensityLevels = sortedLevels
+	return sma
+}
+
 func (sma *SimpleManagementAction) PlanningUnit() planningunit.Id {
 	return sma.planningUnit
 }
@@ -47,7 +80,7 @@ func (sma *SimpleManagementAction) Type() ManagementActionType {
 }
 
 func (sma *SimpleManagementAction) InitialisingActivation() {
-	if sma.isActive {
+	if sma.IsActive() {
 		return
 	}
 	sma.ToggleActivationUnobserved()
@@ -55,7 +88,7 @@ func (sma *SimpleManagementAction) InitialisingActivation() {
 }
 
 func (sma *SimpleManagementAction) InitialisingDeactivation() {
-	if !sma.isActive {
+	if !sma.IsActive() {
 		return
 	}
 	sma.ToggleActivationUnobserved()
@@ -68,20 +101,89 @@ func (sma *SimpleManagementAction) ToggleActivation() {
 }
 
 func (sma *SimpleManagementAction) ToggleActivationUnobserved() {
-	sma.isActive = !sma.isActive
+	sma.SetActivationUnobserved(!sma.IsActive())
 }
 
 func (sma *SimpleManagementAction) SetActivation(value bool) {
-	sma.isActive = value
+	sma.SetActivationUnobserved(value)
 	sma.notifyObservers()
 }
 
 func (sma *SimpleManagementAction) SetActivationUnobserved(value bool) {
-	sma.isActive = value
+	if value {
+		sma.SetIntensityUnobserved(sma.highestIntensityLevel())
+		return
+	}
+	sma.SetIntensityUnobserved(0)
+}
+
+func (sma *SimpleManagementAction) SetIntensity(intensity float64) {
+	sma.SetIntensityUnobserved(intensity)
+	sma.notifyObservers()
+}
+
+func (sma *SimpleManagementAction) SetIntensityUnobserved(intensity float64) {
+	sma.rememberPreviousState()
+	sma.intensity = intensity
+}
+
+func (sma *SimpleManagementAction) SetSchedule(startYear uint, realisation float64) {
+	sma.SetScheduleUnobserved(startYear, realisation)
+	sma.notifyObservers()
+}
+
+func (sma *SimpleManagementAction) SetScheduleUnobserved(startYear uint, realisation float64) {
+	sma.rememberPreviousState()
+	sma.startYear = startYear
+	sma.realisationShortfall = FullRealisation - realisation
+}
+
+func (sma *SimpleManagementAction) rememberPreviousState() {
+	sma.previousIntensity = sma.intensity
+	sma.previousStartYear = sma.startYear
+	sma.previousRealisationShortfall = sma.realisationShortfall
 }
 
 func (sma *SimpleManagementAction) IsActive() bool {
-	return sma.isActive
+	return sma.intensity > 0
+}
+
+func (sma *SimpleManagementAction) Intensity() float64 {
+	return sma.intensity
+}
+
+func (sma *SimpleManagementAction) PreviousIntensity() float64 {
+	return sma.previousIntensity
+}
+
+func (sma *SimpleManagementAction) IntensityLevels() []float64 {
+	if sma.intensityLevels == nil {
+		return fullIntensityOnly
+	}
+	return sma.intensityLevels
+}
+
+var fullIntensityOnly = []float64{FullIntensity}
+
+func (sma *SimpleManagementAction) StartYear() uint {
+	return sma.startYear
+}
+
+func (sma *SimpleManagementAction) PreviousStartYear() uint {
+	return sma.previousStartYear
+}
+
+func (sma *SimpleManagementAction) Realisation() float64 {
+	return FullRealisation - sma.realisationShortfall
+}
+
+func (sma *SimpleManagementAction) PreviousRealisation() float64 {
+	return FullRealisation - sma.previousRealisationShortfall
+}
+
+func (sma *SimpleManagementAction) highestIntensityLevel() float64 {
+	levels := sma.IntensityLevels()
+	return levels[len(levels)-1]
 }
 
 func (sma *SimpleManagementAction) ModelVariableValue(variableName ModelVariableName) float64 {
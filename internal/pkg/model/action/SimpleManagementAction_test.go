@@ -126,3 +126,87 @@ func TestSimpleManagementAction_ToggleActivationUnobserved(t *testing.T) {
 	g.Expect(actionUnderTest.IsActive()).To(BeTrue())
 	g.Expect(testSpyOne.LastObserved()).To(BeNil())
 }
+
+func TestSimpleManagementAction_DefaultIntensityLevels_Binary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actionUnderTest := NewTestManagementAction()
+
+	// when
+	actionUnderTest.ToggleActivation()
+
+	// then
+	g.Expect(actionUnderTest.IntensityLevels()).To(Equal([]float64{1}))
+	g.Expect(actionUnderTest.Intensity()).To(BeNumerically(equalTo, 1))
+	g.Expect(actionUnderTest.PreviousIntensity()).To(BeNumerically(equalTo, 0))
+
+	// when
+	actionUnderTest.ToggleActivation()
+
+	// then
+	g.Expect(actionUnderTest.IsActive()).To(BeFalse())
+	g.Expect(actionUnderTest.Intensity()).To(BeNumerically(equalTo, 0))
+	g.Expect(actionUnderTest.PreviousIntensity()).To(BeNumerically(equalTo, 1))
+}
+
+func TestSimpleManagementAction_WithIntensityLevels_ToggleUsesHighestLevel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actionUnderTest := NewTestManagementAction().WithIntensityLevels(0.75, 0.25, 0.5)
+
+	// when
+	actionUnderTest.ToggleActivation()
+
+	// then
+	g.Expect(actionUnderTest.IntensityLevels()).To(Equal([]float64{0.25, 0.5, 0.75}))
+	g.Expect(actionUnderTest.Intensity()).To(BeNumerically(equalTo, 0.75))
+}
+
+func TestSimpleManagementAction_SetIntensity_NotifiesObservers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	testSpy := new(spyObserver)
+	actionUnderTest := NewTestManagementAction().WithIntensityLevels(0.5, 1)
+	actionUnderTest.Subscribe(testSpy)
+
+	// when
+	actionUnderTest.SetIntensity(0.5)
+
+	// then
+	g.Expect(actionUnderTest.IsActive()).To(BeTrue())
+	g.Expect(actionUnderTest.Intensity()).To(BeNumerically(equalTo, 0.5))
+	g.Expect(testSpy.LastObserved()).To(Equal(actionUnderTest))
+
+	// when
+	testSpy.Reset()
+	actionUnderTest.SetIntensityUnobserved(1)
+
+	// then
+	g.Expect(actionUnderTest.Intensity()).To(BeNumerically(equalTo, 1))
+	g.Expect(actionUnderTest.PreviousIntensity()).To(BeNumerically(equalTo, 0.5))
+	g.Expect(testSpy.LastObserved()).To(BeNil())
+}
+
+func TestSimpleManagementAction_WithInvalidIntensityLevels_Panics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actionUnderTest := NewTestManagementAction()
+
+	// when
+	invalidLevelsCall := func() { actionUnderTest.WithIntensityLevels(0.5, 1.5) }
+
+	// then
+	g.Expect(invalidLevelsCall).To(Panic())
+}
+
+func TestInterpolate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Interpolate(10, 2, 0)).To(BeNumerically(equalTo, 10))
+	g.Expect(Interpolate(10, 2, 0.25)).To(BeNumerically(equalTo, 8))
+	g.Expect(Interpolate(10, 2, 1)).To(BeNumerically(equalTo, 2))
+}
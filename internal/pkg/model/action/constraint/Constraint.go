@@ -0,0 +1,174 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package constraint offers constraints on which combinations of management actions may be active together, such as
+// actions that exclude each other, actions that need another action in place first, and limits on how many actions a
+// planning unit may have active.
+package constraint
+
+import (
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+// Constraint restricts which management actions may be active together.
+type Constraint interface {
+	// Violations returns each way the actions indexed break the constraint that involves an action of the planning
+	// units given, or none if they don't.
+	Violations(actions *Index, planningUnits planningunit.Ids) []Violation
+}
+
+// Violation describes a constraint broken by the active management action carried, which deactivating would help
+// resolve.
+type Violation struct {
+	Action  action.ManagementAction
+	Message string
+}
+
+// Index finds management actions by their planning unit and type.
+type Index struct {
+	actions       map[planningunit.Id]map[action.ManagementActionType]action.ManagementAction
+	planningUnits planningunit.Ids
+}
+
+// NewIndex returns an index over the management actions supplied.
+func NewIndex(actions action.ManagementActions) *Index {
+	index := &Index{
+		actions: make(map[planningunit.Id]map[action.ManagementActionType]action.ManagementAction),
+	}
+
+	for _, indexedAction := range actions {
+		planningUnit := indexedAction.PlanningUnit()
+		if _, hasPlanningUnit := index.actions[planningUnit]; !hasPlanningUnit {
+			index.actions[planningUnit] = make(map[action.ManagementActionType]action.ManagementAction)
+			index.planningUnits = append(index.planningUnits, planningUnit)
+		}
+		index.actions[planningUnit][indexedAction.Type()] = indexedAction
+	}
+
+	sort.Slice(index.planningUnits, func(i, j int) bool {
+		return index.planningUnits[i] < index.planningUnits[j]
+	})
+	return index
+}
+
+// Action returns the management action of the type given in the planning unit given, or nil if there is none.
+func (i *Index) Action(planningUnit planningunit.Id, actionType action.ManagementActionType) action.ManagementAction {
+	return i.actions[planningUnit][actionType]
+}
+
+// ActiveActionsIn returns the active management actions of the planning unit given, ordered by type.
+func (i *Index) ActiveActionsIn(planningUnit planningunit.Id) action.ManagementActions {
+	activeActions := make(action.ManagementActions, 0)
+	for _, candidate := range i.actions[planningUnit] {
+		if candidate.IsActive() {
+			activeActions = append(activeActions, candidate)
+		}
+	}
+	sort.Sort(activeActions)
+	return activeActions
+}
+
+// PlanningUnits returns the planning units having management actions, in ascending order.
+func (i *Index) PlanningUnits() planningunit.Ids {
+	return i.planningUnits
+}
+
+// scope identifies the planning unit a constraint applies to, with an unscoped constraint applying to every planning
+// unit.
+type scope struct {
+	planningUnit planningunit.Id
+	isScoped     bool
+}
+
+// planningUnitsAmong returns those of the planning units given that the scope covers.
+func (s *scope) planningUnitsAmong(planningUnits planningunit.Ids) planningunit.Ids {
+	if !s.isScoped {
+		return planningUnits
+	}
+	if s.coversAny(planningUnits) {
+		return planningunit.Ids{s.planningUnit}
+	}
+	return nil
+}
+
+// coversAny reports whether the scope covers any of the planning units given.
+func (s *scope) coversAny(planningUnits planningunit.Ids) bool {
+	if !s.isScoped {
+		return len(planningUnits) > 0
+	}
+	for _, planningUnit := range planningUnits {
+		if planningUnit == s.planningUnit {
+			return true
+		}
+	}
+	return false
+}
+
+// Constraints is a set of constraints checked together over a model's management actions.
+type Constraints struct {
+	constraints []Constraint
+	actions     *Index
+}
+
+func New() *Constraints {
+	return &Constraints{actions: NewIndex(nil)}
+}
+
+// Over supplies the management actions the constraints are checked over.
+func (c *Constraints) Over(actions action.ManagementActions) *Constraints {
+	c.actions = NewIndex(actions)
+	return c
+}
+
+func (c *Constraints) With(constraints ...Constraint) *Constraints {
+	c.constraints = append(c.constraints, constraints...)
+	return c
+}
+
+// Size returns the number of constraints in the set.
+func (c *Constraints) Size() int {
+	return len(c.constraints)
+}
+
+// Violations returns each way the current state of the management actions breaks a constraint of the set.
+func (c *Constraints) Violations() []Violation {
+	return c.ViolationsIn(c.actions.PlanningUnits())
+}
+
+// ViolationsIn returns each way the current state of the management actions breaks a constraint of the set that
+// involves an action of the planning units given. Only these can be broken by changing the actions of those planning
+// units, sparing a check of every planning unit.
+func (c *Constraints) ViolationsIn(planningUnits planningunit.Ids) []Violation {
+	violations := make([]Violation, 0)
+	for _, constraint := range c.constraints {
+		violations = append(violations, constraint.Violations(c.actions, planningUnits)...)
+	}
+	return violations
+}
+
+// Hold reports whether the current state of the management actions breaks no constraint of the set.
+func (c *Constraints) Hold() bool {
+	for _, constraint := range c.constraints {
+		if len(constraint.Violations(c.actions, c.actions.PlanningUnits())) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Check adds a message to the errors supplied for each way the current state of the management actions breaks a
+// constraint of the set.
+func (c *Constraints) Check(validationErrors *compositeErrors.CompositeError) {
+	c.CheckIn(c.actions.PlanningUnits(), validationErrors)
+}
+
+// CheckIn adds a message to the errors supplied for each way the current state of the management actions breaks a
+// constraint of the set that involves an action of the planning units given.
+func (c *Constraints) CheckIn(planningUnits planningunit.Ids, validationErrors *compositeErrors.CompositeError) {
+	for _, violation := range c.ViolationsIn(planningUnits) {
+		validationErrors.AddMessage(violation.Message)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	wetland   action.ManagementActionType = "Wetland"
+	hillSlope action.ManagementActionType = "HillSlope"
+	gully     action.ManagementActionType = "Gully"
+	riparian  action.ManagementActionType = "Riparian"
+)
+
+func buildTestAction(planningUnit planningunit.Id, actionType action.ManagementActionType, isActive bool) action.ManagementAction {
+	newAction := new(action.SimpleManagementAction).
+		WithPlanningUnit(planningUnit).
+		WithType(actionType)
+	newAction.SetActivationUnobserved(isActive)
+	return newAction
+}
+
+func TestExclusion_BothActive_ViolatedByOtherAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	activeWetland := buildTestAction(1, wetland, true)
+	activeHillSlope := buildTestAction(1, hillSlope, true)
+	actions := action.ManagementActions{
+		activeWetland, activeHillSlope,
+		buildTestAction(2, wetland, true), buildTestAction(2, hillSlope, false),
+	}
+
+	constraintsUnderTest := New().Over(actions).With(NewExclusion(wetland, hillSlope))
+
+	// when
+	violations := constraintsUnderTest.Violations()
+
+	// then
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action).To(Equal(activeHillSlope))
+	g.Expect(constraintsUnderTest.Hold()).To(BeFalse())
+
+	// when
+	activeHillSlope.SetActivationUnobserved(false)
+
+	// then
+	g.Expect(constraintsUnderTest.Hold()).To(BeTrue())
+}
+
+func TestExclusion_ScopedToPlanningUnit_IgnoresOthers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{
+		buildTestAction(1, wetland, true), buildTestAction(1, hillSlope, true),
+		buildTestAction(2, wetland, true), buildTestAction(2, hillSlope, true),
+	}
+
+	constraintsUnderTest := New().Over(actions).With(NewExclusion(wetland, hillSlope).In(2))
+
+	// when
+	violations := constraintsUnderTest.Violations()
+
+	// then
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action.PlanningUnit()).To(Equal(planningunit.Id(2)))
+}
+
+func TestPrerequisite_RequiredActionElsewhere_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	activeGully := buildTestAction(2, gully, true)
+	upstreamRiparian := buildTestAction(1, riparian, false)
+	actions := action.ManagementActions{upstreamRiparian, activeGully, buildTestAction(2, riparian, true)}
+
+	constraintsUnderTest := New().Over(actions).With(NewPrerequisite(gully, riparian).In(2).RequiredIn(1))
+
+	// when
+	violations := constraintsUnderTest.Violations()
+
+	// then
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action).To(Equal(activeGully))
+
+	// when
+	upstreamRiparian.SetActivationUnobserved(true)
+
+	// then
+	g.Expect(constraintsUnderTest.Hold()).To(BeTrue())
+}
+
+func TestConstraints_ViolationsIn_OnlyThosePlanningUnitsInvolved(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	upstreamRiparian := buildTestAction(1, riparian, false)
+	actions := action.ManagementActions{
+		upstreamRiparian, buildTestAction(1, wetland, true), buildTestAction(1, hillSlope, true),
+		buildTestAction(2, gully, true), buildTestAction(3, wetland, true), buildTestAction(3, hillSlope, true),
+	}
+	constraintsUnderTest := New().Over(actions).With(
+		NewExclusion(wetland, hillSlope),
+		NewPrerequisite(gully, riparian).In(2).RequiredIn(1),
+	)
+
+	// when
+	violations := constraintsUnderTest.ViolationsIn(planningunit.Ids{3})
+
+	// then
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action.PlanningUnit()).To(Equal(planningunit.Id(3)))
+
+	// when
+	violations = constraintsUnderTest.ViolationsIn(planningunit.Ids{1})
+
+	// then
+	g.Expect(violations).To(HaveLen(2))
+	g.Expect(violations[0].Action.PlanningUnit()).To(Equal(planningunit.Id(1)))
+	g.Expect(violations[1].Action.PlanningUnit()).To(Equal(planningunit.Id(2)))
+
+	// when
+	violations = constraintsUnderTest.ViolationsIn(planningunit.Ids{4})
+
+	// then
+	g.Expect(violations).To(BeEmpty())
+	g.Expect(constraintsUnderTest.Violations()).To(HaveLen(3))
+}
+
+func TestPrerequisite_MissingRequiredAction_Violated(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, gully, true), buildTestAction(2, gully, false)}
+	constraintsUnderTest := New().Over(actions).With(NewPrerequisite(gully, riparian))
+
+	// when
+	violations := constraintsUnderTest.Violations()
+
+	// then
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action.PlanningUnit()).To(Equal(planningunit.Id(1)))
+}
+
+func TestMaximumActions_Exceeded_ViolatedByLastActiveAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	activeWetland := buildTestAction(1, wetland, true)
+	actions := action.ManagementActions{
+		buildTestAction(1, gully, true), activeWetland, buildTestAction(1, riparian, false),
+		buildTestAction(2, gully, true), buildTestAction(2, wetland, true),
+	}
+
+	constraintsUnderTest := New().Over(actions).With(NewMaximumActions(1).In(1))
+
+	// when
+	validationErrors := compositeErrors.New("Validation Errors")
+	constraintsUnderTest.Check(validationErrors)
+	violations := constraintsUnderTest.Violations()
+
+	// then
+	g.Expect(validationErrors.Size()).To(BeNumerically("==", 1))
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Action).To(Equal(activeWetland))
+}
+
+func TestConstraints_NoConstraints_AlwaysHold(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, wetland, true), buildTestAction(1, hillSlope, true)}
+	constraintsUnderTest := New().Over(actions)
+
+	// then
+	g.Expect(constraintsUnderTest.Size()).To(BeZero())
+	g.Expect(constraintsUnderTest.Hold()).To(BeTrue())
+	g.Expect(constraintsUnderTest.Violations()).To(BeEmpty())
+}
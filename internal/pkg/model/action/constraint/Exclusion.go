@@ -0,0 +1,45 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+)
+
+var _ Constraint = NewExclusion("", "")
+
+// Exclusion stops management actions of two types being active together in the same planning unit.
+type Exclusion struct {
+	scope
+	actionType      action.ManagementActionType
+	otherActionType action.ManagementActionType
+}
+
+func NewExclusion(actionType action.ManagementActionType, otherActionType action.ManagementActionType) *Exclusion {
+	return &Exclusion{actionType: actionType, otherActionType: otherActionType}
+}
+
+// In limits the exclusion to the planning unit supplied. Without it, the exclusion applies in every planning unit.
+func (e *Exclusion) In(planningUnit planningunit.Id) *Exclusion {
+	e.scope = scope{planningUnit: planningUnit, isScoped: true}
+	return e
+}
+
+func (e *Exclusion) Violations(actions *Index, planningUnits planningunit.Ids) []Violation {
+	var violations []Violation
+	for _, planningUnit := range e.planningUnitsAmong(planningUnits) {
+		firstAction := actions.Action(planningUnit, e.actionType)
+		otherAction := actions.Action(planningUnit, e.otherActionType)
+		if firstAction == nil || otherAction == nil || !firstAction.IsActive() || !otherAction.IsActive() {
+			continue
+		}
+
+		message := fmt.Sprintf("Planning unit [%d] has mutually exclusive actions [%s] and [%s] both active",
+			planningUnit, e.actionType, e.otherActionType)
+		violations = append(violations, Violation{Action: otherAction, Message: message})
+	}
+	return violations
+}
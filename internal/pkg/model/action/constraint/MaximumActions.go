@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+)
+
+var _ Constraint = NewMaximumActions(0)
+
+// MaximumActions limits how many management actions may be active at once in a planning unit.
+type MaximumActions struct {
+	scope
+	limit int
+}
+
+func NewMaximumActions(limit int) *MaximumActions {
+	return &MaximumActions{limit: limit}
+}
+
+// In limits the maximum to the planning unit supplied. Without it, the maximum applies in every planning unit.
+func (m *MaximumActions) In(planningUnit planningunit.Id) *MaximumActions {
+	m.scope = scope{planningUnit: planningUnit, isScoped: true}
+	return m
+}
+
+func (m *MaximumActions) Violations(actions *Index, planningUnits planningunit.Ids) []Violation {
+	var violations []Violation
+	for _, planningUnit := range m.planningUnitsAmong(planningUnits) {
+		activeActions := actions.ActiveActionsIn(planningUnit)
+		if len(activeActions) <= m.limit {
+			continue
+		}
+
+		message := fmt.Sprintf("Planning unit [%d] has [%d] actions active, exceeding its maximum of [%d]",
+			planningUnit, len(activeActions), m.limit)
+		violations = append(violations, Violation{Action: activeActions[len(activeActions)-1], Message: message})
+	}
+	return violations
+}
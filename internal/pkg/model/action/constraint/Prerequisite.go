@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+)
+
+var _ Constraint = NewPrerequisite("", "")
+
+// Prerequisite stops a management action being active unless a required management action is also active, either
+// in the same planning unit, or in another nominated planning unit (such as one upstream).
+type Prerequisite struct {
+	scope
+	actionType         action.ManagementActionType
+	requiredActionType action.ManagementActionType
+	requiredIn         scope
+}
+
+func NewPrerequisite(actionType action.ManagementActionType, requiredActionType action.ManagementActionType) *Prerequisite {
+	return &Prerequisite{actionType: actionType, requiredActionType: requiredActionType}
+}
+
+// In limits the prerequisite to the planning unit supplied. Without it, the prerequisite applies in every planning
+// unit.
+func (p *Prerequisite) In(planningUnit planningunit.Id) *Prerequisite {
+	p.scope = scope{planningUnit: planningUnit, isScoped: true}
+	return p
+}
+
+// RequiredIn nominates the planning unit the required action must be active in. Without it, the required action must
+// be active in the same planning unit.
+func (p *Prerequisite) RequiredIn(planningUnit planningunit.Id) *Prerequisite {
+	p.requiredIn = scope{planningUnit: planningUnit, isScoped: true}
+	return p
+}
+
+func (p *Prerequisite) Violations(actions *Index, planningUnits planningunit.Ids) []Violation {
+	var violations []Violation
+	for _, planningUnit := range p.dependentPlanningUnitsAmong(actions, planningUnits) {
+		dependentAction := actions.Action(planningUnit, p.actionType)
+		if dependentAction == nil || !dependentAction.IsActive() {
+			continue
+		}
+
+		requiredPlanningUnit := planningUnit
+		if p.requiredIn.isScoped {
+			requiredPlanningUnit = p.requiredIn.planningUnit
+		}
+
+		requiredAction := actions.Action(requiredPlanningUnit, p.requiredActionType)
+		if requiredAction != nil && requiredAction.IsActive() {
+			continue
+		}
+
+		message := fmt.Sprintf("Planning unit [%d] has action [%s] active without prerequisite action [%s] active in planning unit [%d]",
+			planningUnit, p.actionType, p.requiredActionType, requiredPlanningUnit)
+		violations = append(violations, Violation{Action: dependentAction, Message: message})
+	}
+	return violations
+}
+
+// dependentPlanningUnitsAmong returns the planning units whose dependent action could be left without its prerequisite
+// by changes to the planning units given. Where the required action is nominated in another planning unit, a change
+// there affects the dependent action of every planning unit the prerequisite applies to.
+func (p *Prerequisite) dependentPlanningUnitsAmong(actions *Index, planningUnits planningunit.Ids) planningunit.Ids {
+	if p.requiredIn.isScoped && p.requiredIn.coversAny(planningUnits) {
+		return p.planningUnitsAmong(actions.PlanningUnits())
+	}
+	return p.planningUnitsAmong(planningUnits)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewCostAware()
+
+// CostFunction returns the cost of implementing the management action supplied.
+type CostFunction func(managementAction action.ManagementAction) float64
+
+// HeadroomFunction returns how far the cost of a model's currently active management actions sits below its bound.
+type HeadroomFunction func() float64
+
+func noCost(managementAction action.ManagementAction) float64 {
+	return 0
+}
+
+func noHeadroom() float64 {
+	return 0
+}
+
+// CostAware generates moves that toggle a single management action, picked at random from only those toggles that
+// keep cost within its bound: deactivating any active action, or activating an inactive action that the remaining
+// headroom can afford.
+type CostAware struct {
+	cost     CostFunction
+	headroom HeadroomFunction
+}
+
+func NewCostAware() *CostAware {
+	return &CostAware{cost: noCost, headroom: noHeadroom}
+}
+
+func (c *CostAware) WithCost(cost CostFunction) *CostAware {
+	c.cost = cost
+	return c
+}
+
+func (c *CostAware) WithHeadroom(headroom HeadroomFunction) *CostAware {
+	c.headroom = headroom
+	return c
+}
+
+func (c *CostAware) Type() Type {
+	return CostAwareType
+}
+
+func (c *CostAware) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	headroom := c.headroom()
+	affordableToggles := actionsMatching(actions, func(candidate action.ManagementAction) bool {
+		return candidate.IsActive() || c.cost(candidate) <= headroom
+	})
+	if len(affordableToggles) == 0 {
+		return emptyMove(CostAwareType)
+	}
+
+	randomIndex := generator.Intn(len(affordableToggles))
+	return Move{Type: CostAwareType, Actions: action.ManagementActions{affordableToggles[randomIndex]}}
+}
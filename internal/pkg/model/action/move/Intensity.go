@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewIntensity()
+
+// Intensity generates moves that shift a management action offering several intensity levels to another of its
+// levels, or switch it off.
+type Intensity struct{}
+
+func NewIntensity() *Intensity {
+	return new(Intensity)
+}
+
+func (i *Intensity) Type() Type {
+	return IntensityType
+}
+
+// Generate picks an action at random from those with more than one intensity level, then one of that action's
+// other intensities (including off) at random.
+func (i *Intensity) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	candidates := actionsMatching(actions, hasSeveralIntensityLevels)
+	if len(candidates) == 0 {
+		return emptyMove(IntensityType)
+	}
+
+	picked := candidates[generator.Intn(len(candidates))]
+	otherIntensities := otherIntensitiesOf(picked)
+	intensity := otherIntensities[generator.Intn(len(otherIntensities))]
+
+	return Move{
+		Type:        IntensityType,
+		Actions:     action.ManagementActions{picked},
+		Intensities: []float64{intensity},
+	}
+}
+
+func hasSeveralIntensityLevels(candidate action.ManagementAction) bool {
+	return len(candidate.IntensityLevels()) > 1
+}
+
+func otherIntensitiesOf(candidate action.ManagementAction) []float64 {
+	intensities := make([]float64, 0, len(candidate.IntensityLevels()))
+	if candidate.IsActive() {
+		intensities = append(intensities, action.NoIntensity)
+	}
+	for _, level := range candidate.IntensityLevels() {
+		if level != candidate.Intensity() {
+			intensities = append(intensities, level)
+		}
+	}
+	return intensities
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewKFlip()
+
+const DefaultKFlipSize = 2
+
+// KFlip generates moves that toggle the activation of k distinct management actions, picked at random.
+type KFlip struct {
+	size int
+}
+
+func NewKFlip() *KFlip {
+	return &KFlip{size: DefaultKFlipSize}
+}
+
+// WithSize sets the number of management actions (k) each move toggles.
+func (k *KFlip) WithSize(size int) *KFlip {
+	k.size = size
+	return k
+}
+
+func (k *KFlip) Type() Type {
+	return KFlipType
+}
+
+// Generate picks k actions via a partial Fisher-Yates shuffle of action indices, toggling every action available
+// where there are fewer than k.
+func (k *KFlip) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	size := k.size
+	if size > len(actions) {
+		size = len(actions)
+	}
+	if size < 1 {
+		return emptyMove(KFlipType)
+	}
+
+	indices := make([]int, len(actions))
+	for index := range indices {
+		indices[index] = index
+	}
+
+	flipped := make(action.ManagementActions, size)
+	for picked := 0; picked < size; picked++ {
+		swapIndex := picked + generator.Intn(len(indices)-picked)
+		indices[picked], indices[swapIndex] = indices[swapIndex], indices[picked]
+		flipped[picked] = actions[indices[picked]]
+	}
+
+	return Move{Type: KFlipType, Actions: flipped}
+}
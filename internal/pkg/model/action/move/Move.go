@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package move offers generators of the neighbourhood moves a model can make when randomly changing its management
+// actions, from toggling a single action through to swapping, or flipping, several actions as one change.
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+// Type identifies the kind of neighbourhood move a generator makes.
+type Type string
+
+func (t Type) String() string {
+	return string(t)
+}
+
+const (
+	SingleToggleType Type = "SingleToggle"
+	SwapType         Type = "Swap"
+	KFlipType        Type = "KFlip"
+	CostAwareType    Type = "CostAware"
+	IntensityType    Type = "Intensity"
+	RescheduleType   Type = "Reschedule"
+)
+
+// Move is a set of management actions whose activation states are toggled together, as a single model change.
+// Moves carrying intensities instead apply each action at the intensity of the same index, and moves carrying start
+// years instead start each action in the year of the same index.
+type Move struct {
+	Type        Type
+	Actions     action.ManagementActions
+	Intensities []float64
+	StartYears  []uint
+}
+
+// IsEmpty reports whether the move toggles no management actions at all.
+func (m Move) IsEmpty() bool {
+	return len(m.Actions) == 0
+}
+
+// SetsIntensities reports whether the move applies its actions at given intensities, rather than toggling them.
+func (m Move) SetsIntensities() bool {
+	return m.Intensities != nil
+}
+
+// SetsStartYears reports whether the move starts its actions in given years, rather than toggling them.
+func (m Move) SetsStartYears() bool {
+	return m.StartYears != nil
+}
+
+// IsCompound reports whether the move toggles more than one management action.
+func (m Move) IsCompound() bool {
+	return len(m.Actions) > 1
+}
+
+// PlanningUnits returns the distinct planning units of the move's management actions, in the order first met.
+func (m Move) PlanningUnits() planningunit.Ids {
+	planningUnits := make(planningunit.Ids, 0, len(m.Actions))
+	seen := make(map[planningunit.Id]bool)
+	for _, moveAction := range m.Actions {
+		if seen[moveAction.PlanningUnit()] {
+			continue
+		}
+		seen[moveAction.PlanningUnit()] = true
+		planningUnits = append(planningUnits, moveAction.PlanningUnit())
+	}
+	return planningUnits
+}
+
+// Generator generates moves of a particular type over the management actions supplied, drawing on the random
+// number generator supplied.  A generator returns an empty move when no move of its type is possible.
+type Generator interface {
+	Type() Type
+	Generate(actions action.ManagementActions, generator *rand.Rand) Move
+}
+
+func emptyMove(moveType Type) Move {
+	return Move{Type: moveType, Actions: action.ManagementActions{}}
+}
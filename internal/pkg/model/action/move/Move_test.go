@@ -0,0 +1,364 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+const equalTo = "=="
+
+const testActionType action.ManagementActionType = "MoveTestType"
+const testCost action.ModelVariableName = "MoveTestCost"
+
+const sampleSize = 50
+
+func buildTestAction(planningUnit planningunit.Id, isActive bool, cost float64) action.ManagementAction {
+	newAction := new(action.SimpleManagementAction).
+		WithPlanningUnit(planningUnit).
+		WithType(testActionType).
+		WithVariable(testCost, cost)
+	newAction.SetActivationUnobserved(isActive)
+	return newAction
+}
+
+func testCostOf(managementAction action.ManagementAction) float64 {
+	return managementAction.ModelVariableValue(testCost)
+}
+
+func TestSingleToggle_Generate_OneAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, false, 0), buildTestAction(2, true, 0)}
+	generatorUnderTest := NewSingleToggle()
+
+	// when
+	move := generatorUnderTest.Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.Type).To(Equal(SingleToggleType))
+	g.Expect(move.Actions).To(HaveLen(1))
+	g.Expect(actions).To(ContainElement(move.Actions[0]))
+}
+
+func TestMove_PlanningUnits_DistinctInOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	moveUnderTest := Move{
+		Type: KFlipType,
+		Actions: action.ManagementActions{
+			buildTestAction(3, true, 1), buildTestAction(1, false, 1), buildTestAction(3, false, 1),
+		},
+	}
+
+	// when
+	planningUnits := moveUnderTest.PlanningUnits()
+
+	// then
+	g.Expect(planningUnits).To(Equal(planningunit.Ids{3, 1}))
+}
+
+func TestSwap_Generate_DeactivatesAndActivatesNearby(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	active := buildTestAction(1, true, 0)
+	inactiveNeighbour := buildTestAction(2, false, 0)
+	inactiveDistant := buildTestAction(3, false, 0)
+	actions := action.ManagementActions{active, inactiveNeighbour, inactiveDistant}
+
+	generatorUnderTest := NewSwap().WithNeighbours(func(planningUnit planningunit.Id) planningunit.Ids {
+		return planningunit.Ids{planningUnit + 1}
+	})
+	generator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		move := generatorUnderTest.Generate(actions, generator)
+
+		// then
+		g.Expect(move.Type).To(Equal(SwapType))
+		g.Expect(move.Actions).To(Equal(action.ManagementActions{active, inactiveNeighbour}))
+	}
+}
+
+func TestSwap_Generate_NoActiveActions_Empty(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, false, 0), buildTestAction(1, false, 0)}
+
+	// when
+	move := NewSwap().Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.IsEmpty()).To(BeTrue())
+}
+
+func TestKFlip_Generate_DistinctActions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{
+		buildTestAction(1, false, 0),
+		buildTestAction(2, true, 0),
+		buildTestAction(3, false, 0),
+		buildTestAction(4, true, 0),
+		buildTestAction(5, false, 0),
+	}
+	const expectedSize = 3
+	generatorUnderTest := NewKFlip().WithSize(expectedSize)
+	generator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		move := generatorUnderTest.Generate(actions, generator)
+
+		// then
+		g.Expect(move.Type).To(Equal(KFlipType))
+		g.Expect(move.IsCompound()).To(BeTrue())
+		g.Expect(move.Actions).To(HaveLen(expectedSize))
+		for index, flipped := range move.Actions {
+			g.Expect(actions).To(ContainElement(flipped))
+			g.Expect(move.Actions[index+1:]).ToNot(ContainElement(flipped))
+		}
+	}
+}
+
+func TestKFlip_Generate_FewerActionsThanSize_FlipsAll(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, false, 0), buildTestAction(2, true, 0)}
+
+	// when
+	move := NewKFlip().WithSize(5).Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.Actions).To(ConsistOf(actions[0], actions[1]))
+}
+
+func TestCostAware_Generate_OnlyAffordableToggles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	activeExpensive := buildTestAction(1, true, 10)
+	inactiveAffordable := buildTestAction(2, false, 3)
+	inactiveUnaffordable := buildTestAction(3, false, 8)
+	actions := action.ManagementActions{activeExpensive, inactiveAffordable, inactiveUnaffordable}
+
+	generatorUnderTest := NewCostAware().
+		WithCost(testCostOf).
+		WithHeadroom(func() float64 { return 5 })
+	generator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		move := generatorUnderTest.Generate(actions, generator)
+
+		// then
+		g.Expect(move.Type).To(Equal(CostAwareType))
+		g.Expect(move.Actions).To(HaveLen(1))
+		g.Expect(move.Actions[0]).ToNot(Equal(inactiveUnaffordable))
+	}
+}
+
+func TestSelector_SingleGenerator_SameSequenceAsGeneratorAlone(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{
+		buildTestAction(1, false, 0),
+		buildTestAction(2, true, 0),
+		buildTestAction(3, false, 0),
+	}
+	selectorUnderTest := NewSelector().
+		WithGenerator(NewSingleToggle(), 1).
+		WithGenerator(NewSwap(), 0)
+
+	selectorGenerator := rand.NewSeeded(1)
+	toggleGenerator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		selectedMove := selectorUnderTest.Generate(actions, selectorGenerator)
+		toggleMove := NewSingleToggle().Generate(actions, toggleGenerator)
+
+		// then
+		g.Expect(selectedMove).To(Equal(toggleMove))
+	}
+}
+
+func TestSelector_GeneratorCannotMove_FallsBackToSingleToggle(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, false, 0), buildTestAction(2, false, 0)}
+	selectorUnderTest := NewSelector().WithGenerator(NewSwap(), 1)
+
+	// when
+	move := selectorUnderTest.Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.Type).To(Equal(SingleToggleType))
+	g.Expect(move.Actions).To(HaveLen(1))
+}
+
+func TestSelector_MixedProbabilities_PicksEachGenerator(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{
+		buildTestAction(1, true, 0),
+		buildTestAction(1, false, 0),
+		buildTestAction(2, false, 0),
+	}
+	selectorUnderTest := NewSelector().
+		WithGenerator(NewSingleToggle(), 0.5).
+		WithGenerator(NewKFlip(), 0.5)
+	generator := rand.NewSeeded(1)
+
+	// when
+	picked := make(map[Type]int)
+	for sample := 0; sample < sampleSize; sample++ {
+		picked[selectorUnderTest.Generate(actions, generator).Type]++
+	}
+
+	// then
+	g.Expect(picked[SingleToggleType]).To(BeNumerically(">", 0))
+	g.Expect(picked[KFlipType]).To(BeNumerically(">", 0))
+	g.Expect(picked[SingleToggleType] + picked[KFlipType]).To(BeNumerically(equalTo, sampleSize))
+}
+
+func TestStatistics_Record_AcceptanceRates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	statisticsUnderTest := new(Statistics).Initialise()
+
+	// when
+	statisticsUnderTest.Record(SwapType, true)
+	statisticsUnderTest.Record(SwapType, false)
+	statisticsUnderTest.Record(SwapType, false)
+	statisticsUnderTest.Record(SwapType, true)
+	statisticsUnderTest.Record(KFlipType, false)
+
+	// then
+	g.Expect(statisticsUnderTest.Types()).To(Equal([]Type{KFlipType, SwapType}))
+	g.Expect(statisticsUnderTest.Attempted(SwapType)).To(BeNumerically(equalTo, 4))
+	g.Expect(statisticsUnderTest.Accepted(SwapType)).To(BeNumerically(equalTo, 2))
+	g.Expect(statisticsUnderTest.AcceptanceRate(SwapType)).To(BeNumerically(equalTo, 0.5))
+	g.Expect(statisticsUnderTest.AcceptanceRate(KFlipType)).To(BeNumerically(equalTo, 0))
+	g.Expect(statisticsUnderTest.AcceptanceRate(CostAwareType)).To(BeNumerically(equalTo, 0))
+	g.Expect(statisticsUnderTest.AttemptedTotal()).To(BeNumerically(equalTo, 5))
+}
+
+func TestStatistics_RestoreState_ResumesWhereCaptured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	capturedStatistics := new(Statistics).Initialise()
+	capturedStatistics.Record(SwapType, true)
+	capturedStatistics.Record(SwapType, false)
+
+	var capturedState StatisticsState
+	capturedStatistics.CaptureState(&capturedState)
+	capturedStatistics.Record(SwapType, true)
+
+	// when
+	restoredStatistics := new(Statistics).Initialise()
+	restoredStatistics.RestoreState(capturedState)
+	restoredStatistics.Record(KFlipType, true)
+
+	// then
+	g.Expect(restoredStatistics.Attempted(SwapType)).To(BeNumerically(equalTo, 2))
+	g.Expect(restoredStatistics.Accepted(SwapType)).To(BeNumerically(equalTo, 1))
+	g.Expect(restoredStatistics.Attempted(KFlipType)).To(BeNumerically(equalTo, 1))
+	g.Expect(capturedState.Attempted).To(Not(HaveKey(KFlipType)))
+}
+
+func TestIntensity_Generate_ShiftsMultiLevelActionToOtherLevel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	binaryAction := buildTestAction(1, true, 0)
+	multiLevelAction := new(action.SimpleManagementAction).
+		WithPlanningUnit(2).
+		WithType(testActionType).
+		WithIntensityLevels(0.5, 1)
+	multiLevelAction.SetIntensityUnobserved(0.5)
+
+	actions := action.ManagementActions{binaryAction, multiLevelAction}
+	generatorUnderTest := NewIntensity()
+	randomNumberGenerator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		move := generatorUnderTest.Generate(actions, randomNumberGenerator)
+
+		// then
+		g.Expect(move.Type).To(Equal(IntensityType))
+		g.Expect(move.SetsIntensities()).To(BeTrue())
+		g.Expect(move.Actions).To(Equal(action.ManagementActions{multiLevelAction}))
+		g.Expect(move.Intensities[0]).To(BeElementOf(0.0, 1.0))
+	}
+}
+
+func TestIntensity_Generate_NoMultiLevelActions_EmptyMove(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, true, 0), buildTestAction(2, false, 0)}
+	generatorUnderTest := NewIntensity()
+
+	// when
+	move := generatorUnderTest.Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.IsEmpty()).To(BeTrue())
+}
+
+func TestReschedule_Generate_MovesActiveActionToOtherYear(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	inactiveAction := buildTestAction(1, false, 0)
+	activeAction := buildTestAction(2, true, 0)
+	activeAction.SetScheduleUnobserved(2, action.FullRealisation)
+
+	actions := action.ManagementActions{inactiveAction, activeAction}
+	generatorUnderTest := NewReschedule().WithYears(4)
+	randomNumberGenerator := rand.NewSeeded(1)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		// when
+		move := generatorUnderTest.Generate(actions, randomNumberGenerator)
+
+		// then
+		g.Expect(move.Type).To(Equal(RescheduleType))
+		g.Expect(move.SetsStartYears()).To(BeTrue())
+		g.Expect(move.Actions).To(Equal(action.ManagementActions{activeAction}))
+		g.Expect(move.StartYears[0]).To(BeElementOf(uint(0), uint(1), uint(3)))
+	}
+}
+
+func TestReschedule_Generate_SingleYearHorizon_EmptyMove(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	actions := action.ManagementActions{buildTestAction(1, true, 0)}
+	generatorUnderTest := NewReschedule().WithYears(1)
+
+	// when
+	move := generatorUnderTest.Generate(actions, rand.NewSeeded(1))
+
+	// then
+	g.Expect(move.IsEmpty()).To(BeTrue())
+}
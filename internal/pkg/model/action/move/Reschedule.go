@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewReschedule()
+
+// Reschedule generates moves that start an active management action in another year of a planning horizon.
+type Reschedule struct {
+	years uint
+}
+
+func NewReschedule() *Reschedule {
+	return new(Reschedule)
+}
+
+// WithYears sets the number of years in the planning horizon that actions are rescheduled within. Without at least
+// two years, there is nothing to reschedule to.
+func (r *Reschedule) WithYears(years uint) *Reschedule {
+	r.years = years
+	return r
+}
+
+func (r *Reschedule) Type() Type {
+	return RescheduleType
+}
+
+// Generate picks an active action at random, then one of the horizon's years, other than the action's current start
+// year, at random.
+func (r *Reschedule) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	if r.years < 2 {
+		return emptyMove(RescheduleType)
+	}
+
+	activeActions := actionsMatching(actions, func(candidate action.ManagementAction) bool {
+		return candidate.IsActive()
+	})
+	if len(activeActions) == 0 {
+		return emptyMove(RescheduleType)
+	}
+
+	picked := activeActions[generator.Intn(len(activeActions))]
+	startYear := uint(generator.Intn(int(r.years - 1)))
+	if startYear >= picked.StartYear() {
+		startYear++
+	}
+
+	return Move{
+		Type:       RescheduleType,
+		Actions:    action.ManagementActions{picked},
+		StartYears: []uint{startYear},
+	}
+}
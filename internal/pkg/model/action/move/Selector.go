@@ -0,0 +1,71 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+// Selector picks one of its generators at random, in proportion to the probability configured for each, to generate
+// the next move. Where the generator picked cannot generate a move, the selector falls back to a single toggle.
+type Selector struct {
+	generators    []Generator
+	probabilities []float64
+	fallback      Generator
+
+	Statistics
+}
+
+func NewSelector() *Selector {
+	newSelector := new(Selector)
+	newSelector.fallback = NewSingleToggle()
+	newSelector.Statistics.Initialise()
+	return newSelector
+}
+
+// WithGenerator adds a generator to those the selector picks from, with the probability supplied.  Generators with
+// no chance of being picked are ignored.
+func (s *Selector) WithGenerator(generator Generator, probability float64) *Selector {
+	if probability <= 0 {
+		return s
+	}
+	s.generators = append(s.generators, generator)
+	s.probabilities = append(s.probabilities, probability)
+	return s
+}
+
+func (s *Selector) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	picked := s.pick(generator)
+	move := picked.Generate(actions, generator)
+	if move.IsEmpty() && picked != s.fallback {
+		move = s.fallback.Generate(actions, generator)
+	}
+	return move
+}
+
+// pick only draws a random number when there is a choice of generator, so that a selector offering just the one
+// draws the same random sequence as the generator alone would.
+func (s *Selector) pick(generator *rand.Rand) Generator {
+	switch len(s.generators) {
+	case 0:
+		return s.fallback
+	case 1:
+		return s.generators[0]
+	}
+
+	total := 0.0
+	for _, probability := range s.probabilities {
+		total += probability
+	}
+
+	draw := generator.Float64Unitary() * total
+	cumulative := 0.0
+	for index, probability := range s.probabilities {
+		cumulative += probability
+		if draw <= cumulative {
+			return s.generators[index]
+		}
+	}
+	return s.generators[len(s.generators)-1]
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewSingleToggle()
+
+// SingleToggle generates moves that toggle the activation of one management action, picked at random.
+type SingleToggle struct{}
+
+func NewSingleToggle() *SingleToggle {
+	return new(SingleToggle)
+}
+
+func (s *SingleToggle) Type() Type {
+	return SingleToggleType
+}
+
+func (s *SingleToggle) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	if len(actions) == 0 {
+		return emptyMove(SingleToggleType)
+	}
+	randomIndex := generator.Intn(len(actions))
+	return Move{Type: SingleToggleType, Actions: action.ManagementActions{actions[randomIndex]}}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import "sort"
+
+// Statistics counts, per move type, how many moves have been attempted, and how many of those were accepted.
+type Statistics struct {
+	attempted map[Type]uint64
+	accepted  map[Type]uint64
+}
+
+// StatisticsState is a storable form of Statistics.
+type StatisticsState struct {
+	Attempted map[Type]uint64 `json:",omitempty"`
+	Accepted  map[Type]uint64 `json:",omitempty"`
+}
+
+// StatisticsContainer defines a model that counts the moves it makes.
+type StatisticsContainer interface {
+	MoveStatistics() *Statistics
+}
+
+func (s *Statistics) Initialise() *Statistics {
+	s.attempted = make(map[Type]uint64)
+	s.accepted = make(map[Type]uint64)
+	return s
+}
+
+// Record counts an attempt of a move of the type supplied, along with whether it was accepted.
+func (s *Statistics) Record(moveType Type, accepted bool) {
+	s.attempted[moveType]++
+	if accepted {
+		s.accepted[moveType]++
+	}
+}
+
+func (s *Statistics) Attempted(moveType Type) uint64 {
+	return s.attempted[moveType]
+}
+
+func (s *Statistics) Accepted(moveType Type) uint64 {
+	return s.accepted[moveType]
+}
+
+// AcceptanceRate reports the proportion of attempted moves of the type supplied that were accepted, being 0 where
+// none have been attempted.
+func (s *Statistics) AcceptanceRate(moveType Type) float64 {
+	if s.attempted[moveType] == 0 {
+		return 0
+	}
+	return float64(s.accepted[moveType]) / float64(s.attempted[moveType])
+}
+
+// AttemptedTotal reports the number of moves attempted across all move types.
+func (s *Statistics) AttemptedTotal() uint64 {
+	total := uint64(0)
+	for _, attempted := range s.attempted {
+		total += attempted
+	}
+	return total
+}
+
+// Types returns, in name order, the move types that have been attempted at least once.
+func (s *Statistics) Types() []Type {
+	types := make([]Type, 0, len(s.attempted))
+	for moveType := range s.attempted {
+		types = append(types, moveType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// CaptureState records the counts so far, for checkpointing.
+func (s *Statistics) CaptureState(state *StatisticsState) {
+	state.Attempted = copyCounts(s.attempted)
+	state.Accepted = copyCounts(s.accepted)
+}
+
+// RestoreState resumes counting from counts previously captured.
+func (s *Statistics) RestoreState(state StatisticsState) {
+	s.attempted = copyCounts(state.Attempted)
+	s.accepted = copyCounts(state.Accepted)
+}
+
+func copyCounts(counts map[Type]uint64) map[Type]uint64 {
+	copiedCounts := make(map[Type]uint64, len(counts))
+	for moveType, count := range counts {
+		copiedCounts[moveType] = count
+	}
+	return copiedCounts
+}
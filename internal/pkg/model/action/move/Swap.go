@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package move
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+var _ Generator = NewSwap()
+
+// NeighbourFunction returns the planning units neighbouring the planning unit supplied.
+type NeighbourFunction func(planningUnit planningunit.Id) planningunit.Ids
+
+func noNeighbours(planningUnit planningunit.Id) planningunit.Ids {
+	return nil
+}
+
+// Swap generates moves that deactivate one active management action, and activate an inactive management action in
+// the same planning unit, or in a planning unit neighbouring it.
+type Swap struct {
+	neighbours NeighbourFunction
+}
+
+func NewSwap() *Swap {
+	return &Swap{neighbours: noNeighbours}
+}
+
+// WithNeighbours supplies the function a swap draws on to find the neighbours of the planning unit it deactivates an
+// action in. Without neighbours, swaps are limited to actions within a single planning unit.
+func (s *Swap) WithNeighbours(neighbours NeighbourFunction) *Swap {
+	s.neighbours = neighbours
+	return s
+}
+
+func (s *Swap) Type() Type {
+	return SwapType
+}
+
+func (s *Swap) Generate(actions action.ManagementActions, generator *rand.Rand) Move {
+	activeActions := actionsMatching(actions, func(candidate action.ManagementAction) bool {
+		return candidate.IsActive()
+	})
+	if len(activeActions) == 0 {
+		return emptyMove(SwapType)
+	}
+	deactivating := activeActions[generator.Intn(len(activeActions))]
+
+	nearbyPlanningUnits := s.planningUnitsNear(deactivating.PlanningUnit())
+	inactiveNearbyActions := actionsMatching(actions, func(candidate action.ManagementAction) bool {
+		return !candidate.IsActive() && nearbyPlanningUnits[candidate.PlanningUnit()]
+	})
+	if len(inactiveNearbyActions) == 0 {
+		return emptyMove(SwapType)
+	}
+	activating := inactiveNearbyActions[generator.Intn(len(inactiveNearbyActions))]
+
+	return Move{Type: SwapType, Actions: action.ManagementActions{deactivating, activating}}
+}
+
+func (s *Swap) planningUnitsNear(planningUnit planningunit.Id) map[planningunit.Id]bool {
+	nearby := map[planningunit.Id]bool{planningUnit: true}
+	for _, neighbour := range s.neighbours(planningUnit) {
+		nearby[neighbour] = true
+	}
+	return nearby
+}
+
+func actionsMatching(actions action.ManagementActions, matches func(action.ManagementAction) bool) action.ManagementActions {
+	matching := make(action.ManagementActions, 0)
+	for _, candidate := range actions {
+		if matches(candidate) {
+			matching = append(matching, candidate)
+		}
+	}
+	return matching
+}
@@ -3,17 +3,43 @@
 package archive
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/pkg/archive"
 	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/name"
+	"github.com/pkg/errors"
+)
+
+const (
+	intensitiesDelimiter    = "|"
+	intensityEntryDelimiter = ","
+	intensityValueDelimiter = "="
 )
 
 type CompressedModelState struct {
 	name.IdentifiableContainer
 
-	Variables dominance.Float64Vector
-	Actions   archive.BooleanArchive
+	Variables  dominance.Float64Vector
+	Directions dominance.Directions
+	Actions    archive.BooleanArchive
+
+	// Intensities holds the intensity of each active action applied at less than full intensity, keyed by action index.
+	Intensities map[int]float64
+
+	// StartYears holds the start year of each active action starting after year 0, keyed by action index.
+	StartYears map[int]uint
+}
+
+// Dominates reports whether the state's variables dominate those of otherState, with each variable optimised in
+// the direction the state holds for it.
+func (c *CompressedModelState) Dominates(otherState *CompressedModelState) bool {
+	return c.Variables.DominatesTowards(&otherState.Variables, c.Directions)
 }
 
 func (c *CompressedModelState) MatchesStateOf(model model.Model) bool {
@@ -52,17 +78,160 @@ func (c *CompressedModelState) actionsMatch(model model.Model) bool {
 }
 
 func (c *CompressedModelState) actionValuesMatch(index int, model model.Model) bool {
-	return c.Actions.Value(index) == model.ManagementActions()[index].IsActive()
+	modelAction := model.ManagementActions()[index]
+	return c.ActionIntensity(index) == modelAction.Intensity() &&
+		(!modelAction.IsActive() || c.ActionStartYear(index) == modelAction.StartYear())
+}
+
+// ActionIntensity returns the intensity the state holds for the action at the index supplied.
+func (c *CompressedModelState) ActionIntensity(index int) float64 {
+	if !c.Actions.Value(index) {
+		return 0
+	}
+	if intensity, isPartial := c.Intensities[index]; isPartial {
+		return intensity
+	}
+	return action.FullIntensity
+}
+
+// ActionStartYear returns the start year the state holds for the action at the index supplied.
+func (c *CompressedModelState) ActionStartYear(index int) uint {
+	return c.StartYears[index]
 }
 
 func (c *CompressedModelState) IsEquivalentTo(otherSate *CompressedModelState) bool {
-	return c.Actions.IsEquivalentTo(&otherSate.Actions)
+	return c.Actions.IsEquivalentTo(&otherSate.Actions) && c.intensitiesMatch(otherSate) && c.startYearsMatch(otherSate)
 }
 
+func (c *CompressedModelState) intensitiesMatch(otherState *CompressedModelState) bool {
+	if len(c.Intensities) != len(otherState.Intensities) {
+		return false
+	}
+	for index, intensity := range c.Intensities {
+		if otherIntensity, hasIntensity := otherState.Intensities[index]; !hasIntensity || otherIntensity != intensity {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CompressedModelState) startYearsMatch(otherState *CompressedModelState) bool {
+	if len(c.StartYears) != len(otherState.StartYears) {
+		return false
+	}
+	for index, startYear := range c.StartYears {
+		if otherStartYear, hasStartYear := otherState.StartYears[index]; !hasStartYear || otherStartYear != startYear {
+			return false
+		}
+	}
+	return true
+}
+
+// Encoding returns the hexadecimal encoding of the state's action activations, followed, where any action is applied
+// at partial intensity, by a "|" and a comma-separated list of index=intensity entries.  Where any action starts
+// after year 0, a further "|" and comma-separated list of index=year entries follows.
 func (c *CompressedModelState) Encoding() string {
-	return c.Actions.Encoding()
+	if len(c.Intensities) == 0 && len(c.StartYears) == 0 {
+		return c.Actions.Encoding()
+	}
+	encoding := c.Actions.Encoding() + intensitiesDelimiter + c.encodeIntensities()
+	if len(c.StartYears) == 0 {
+		return encoding
+	}
+	return encoding + intensitiesDelimiter + c.encodeStartYears()
+}
+
+func (c *CompressedModelState) encodeIntensities() string {
+	indices := make([]int, 0, len(c.Intensities))
+	for index := range c.Intensities {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	entries := make([]string, len(indices))
+	for entry, index := range indices {
+		entries[entry] = fmt.Sprintf("%d%s%v", index, intensityValueDelimiter, c.Intensities[index])
+	}
+	return strings.Join(entries, intensityEntryDelimiter)
+}
+
+func (c *CompressedModelState) encodeStartYears() string {
+	indices := make([]int, 0, len(c.StartYears))
+	for index := range c.StartYears {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	entries := make([]string, len(indices))
+	for entry, index := range indices {
+		entries[entry] = fmt.Sprintf("%d%s%d", index, intensityValueDelimiter, c.StartYears[index])
+	}
+	return strings.Join(entries, intensityEntryDelimiter)
 }
 
 func (c *CompressedModelState) Decode(encoding string) error {
-	return c.Actions.Decode(encoding)
+	encodings := strings.SplitN(encoding, intensitiesDelimiter, 3)
+	if decodeError := c.Actions.Decode(encodings[0]); decodeError != nil {
+		return decodeError
+	}
+
+	c.Intensities = nil
+	c.StartYears = nil
+	if len(encodings) > 1 && encodings[1] != "" {
+		if decodeError := c.decodeIntensities(encodings[1]); decodeError != nil {
+			return decodeError
+		}
+	}
+	if len(encodings) > 2 && encodings[2] != "" {
+		return c.decodeStartYears(encodings[2])
+	}
+	return nil
+}
+
+func (c *CompressedModelState) decodeIntensities(encoding string) error {
+	intensities := make(map[int]float64)
+	for _, entry := range strings.Split(encoding, intensityEntryDelimiter) {
+		components := strings.Split(entry, intensityValueDelimiter)
+		if len(components) != 2 {
+			return errors.New("malformed action intensity entry [" + entry + "]")
+		}
+
+		index, indexError := strconv.Atoi(components[0])
+		if indexError != nil || index < 0 || index >= c.Actions.Len() || !c.Actions.Value(index) {
+			return errors.New("action intensity entry [" + entry + "] does not match an active action")
+		}
+
+		intensity, intensityError := strconv.ParseFloat(components[1], 64)
+		if intensityError != nil || intensity <= 0 || intensity >= action.FullIntensity {
+			return errors.New("action intensity entry [" + entry + "] has an invalid intensity")
+		}
+
+		intensities[index] = intensity
+	}
+	c.Intensities = intensities
+	return nil
+}
+
+func (c *CompressedModelState) decodeStartYears(encoding string) error {
+	startYears := make(map[int]uint)
+	for _, entry := range strings.Split(encoding, intensityEntryDelimiter) {
+		components := strings.Split(entry, intensityValueDelimiter)
+		if len(components) != 2 {
+			return errors.New("malformed action start year entry [" + entry + "]")
+		}
+
+		index, indexError := strconv.Atoi(components[0])
+		if indexError != nil || index < 0 || index >= c.Actions.Len() || !c.Actions.Value(index) {
+			return errors.New("action start year entry [" + entry + "] does not match an active action")
+		}
+
+		startYear, startYearError := strconv.ParseUint(components[1], 10, 64)
+		if startYearError != nil || startYear == 0 {
+			return errors.New("action start year entry [" + entry + "] has an invalid start year")
+		}
+
+		startYears[index] = uint(startYear)
+	}
+	c.StartYears = startYears
+	return nil
 }
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"math"
+	"sort"
+)
+
+// Hypervolume returns the volume dominated by the points supplied and bounded by the reference point, with smaller
+// values preferred in every dimension. Points are sliced along their last dimension, each slice's volume derived
+// recursively from the remaining dimensions. The cost grows steeply with front size and dimensions, which suits the
+// modest fronts annealing and exact solving produce.
+func Hypervolume(points [][]float64, reference []float64) float64 {
+	return sliceVolumeOf(nonDominatedWithin(points, reference), reference)
+}
+
+// sliceVolumeOf returns the hypervolume of mutually non-dominated points, each better than the reference point.
+func sliceVolumeOf(points [][]float64, reference []float64) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+
+	lastDimension := len(reference) - 1
+	switch lastDimension {
+	case 0:
+		return reference[0] - minimumOf(points, 0)
+	case 1:
+		return areaOf(points, reference)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i][lastDimension] < points[j][lastDimension]
+	})
+
+	var volume float64
+	slice := make([][]float64, 0, len(points))
+	for index, point := range points {
+		slice = joiningFront(slice, point[:lastDimension])
+
+		upperBound := reference[lastDimension]
+		if index+1 < len(points) {
+			upperBound = points[index+1][lastDimension]
+		}
+		if depth := upperBound - point[lastDimension]; depth > 0 {
+			volume += depth * sliceVolumeOf(slice, reference[:lastDimension])
+		}
+	}
+	return volume
+}
+
+// areaOf sweeps two dimensional points in order of their first dimension, summing the rectangles each dominates
+// beyond those already swept.
+func areaOf(points [][]float64, reference []float64) float64 {
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i][0] < points[j][0]
+	})
+
+	var area float64
+	lowestSoFar := reference[1]
+	for index, point := range points {
+		lowestSoFar = math.Min(lowestSoFar, point[1])
+		rightBound := reference[0]
+		if index+1 < len(points) {
+			rightBound = points[index+1][0]
+		}
+		area += (rightBound - point[0]) * (reference[1] - lowestSoFar)
+	}
+	return area
+}
+
+// nonDominatedWithin returns those points strictly better than the reference point in every dimension that no other
+// such point dominates, keeping only the first of any duplicates.
+func nonDominatedWithin(points [][]float64, reference []float64) [][]float64 {
+	front := make([][]float64, 0, len(points))
+	for _, point := range points {
+		if isBetterThan(point, reference) {
+			front = joiningFront(front, point)
+		}
+	}
+	return front
+}
+
+// joiningFront returns the front with the point added, unless some member of the front already weakly dominates it.
+// Any members the point dominates are dropped.
+func joiningFront(front [][]float64, point []float64) [][]float64 {
+	for _, member := range front {
+		if weaklyDominates(member, point) {
+			return front
+		}
+	}
+
+	survivors := front[:0]
+	for _, member := range front {
+		if !weaklyDominates(point, member) {
+			survivors = append(survivors, member)
+		}
+	}
+	return append(survivors, point)
+}
+
+func isBetterThan(point []float64, reference []float64) bool {
+	for index := range reference {
+		if point[index] >= reference[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func weaklyDominates(point []float64, otherPoint []float64) bool {
+	for index := range point {
+		if point[index] > otherPoint[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func minimumOf(points [][]float64, dimension int) float64 {
+	minimum := points[0][dimension]
+	for _, point := range points[1:] {
+		if point[dimension] < minimum {
+			minimum = point[dimension]
+		}
+	}
+	return minimum
+}
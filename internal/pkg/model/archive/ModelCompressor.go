@@ -4,6 +4,8 @@ package archive
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/archive"
 	"github.com/LindsayBradford/crem/pkg/dominance"
 )
@@ -12,8 +14,11 @@ type ModelCompressor struct{}
 
 func (mc *ModelCompressor) Compress(model model.Model) *CompressedModelState {
 	return &CompressedModelState{
-		Variables: compressVariables(model),
-		Actions:   compressActions(model),
+		Variables:   compressVariables(model),
+		Directions:  DirectionsOf(model),
+		Actions:     compressActions(model),
+		Intensities: compressIntensities(model),
+		StartYears:  compressStartYears(model),
 	}
 }
 
@@ -27,6 +32,17 @@ func compressVariables(model model.Model) dominance.Float64Vector {
 	return compressedVariables
 }
 
+// DirectionsOf returns the optimisation direction of each of the model's decision variables, in the same order
+// as compressed model state variables.
+func DirectionsOf(model model.Model) dominance.Directions {
+	variableKeys := model.DecisionVariables().SortedKeys()
+	directions := make(dominance.Directions, len(variableKeys))
+	for index := range variableKeys {
+		directions[index] = variable.OptimisationDirectionOf(model.DecisionVariable(variableKeys[index]))
+	}
+	return directions
+}
+
 func compressActions(model model.Model) archive.BooleanArchive {
 	actions := model.ManagementActions()
 	compressedActions := *archive.New(len(actions))
@@ -38,9 +54,49 @@ func compressActions(model model.Model) archive.BooleanArchive {
 	return compressedActions
 }
 
-func (mc *ModelCompressor) Decompress(condensedModelState *CompressedModelState, model model.Model) {
+// compressIntensities returns the intensities of any active actions applied at less than full intensity, or nil
+// where there are none.
+func compressIntensities(model model.Model) map[int]float64 {
+	var intensities map[int]float64
+	for index, managementAction := range model.ManagementActions() {
+		if !managementAction.IsActive() || managementAction.Intensity() == action.FullIntensity {
+			continue
+		}
+		if intensities == nil {
+			intensities = make(map[int]float64)
+		}
+		intensities[index] = managementAction.Intensity()
+	}
+	return intensities
+}
+
+// compressStartYears returns the start years of any active actions starting after year 0, or nil where there are none.
+func compressStartYears(model model.Model) map[int]uint {
+	var startYears map[int]uint
+	for index, managementAction := range model.ManagementActions() {
+		if !managementAction.IsActive() || managementAction.StartYear() == 0 {
+			continue
+		}
+		if startYears == nil {
+			startYears = make(map[int]uint)
+		}
+		startYears[index] = managementAction.StartYear()
+	}
+	return startYears
+}
+
+func (mc *ModelCompressor) Decompress(condensedModelState *CompressedModelState, modelToChange model.Model) {
+	adjustableModel, isAdjustable := modelToChange.(model.IntensityAdjustable)
+	schedulableModel, isSchedulable := modelToChange.(model.Schedulable)
 	for index := 0; index < condensedModelState.Actions.Len(); index++ {
+		if isSchedulable {
+			schedulableModel.SetManagementActionStartYear(index, condensedModelState.ActionStartYear(index))
+		}
+		if isAdjustable {
+			adjustableModel.SetManagementActionIntensity(index, condensedModelState.ActionIntensity(index))
+			continue
+		}
 		compressedActionState := condensedModelState.Actions.Value(index)
-		model.SetManagementAction(index, compressedActionState)
+		modelToChange.SetManagementAction(index, compressedActionState)
 	}
 }
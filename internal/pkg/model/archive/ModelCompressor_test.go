@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	. "github.com/onsi/gomega"
 )
@@ -25,6 +28,24 @@ func TestModelCompressor_Compress(t *testing.T) {
 	g.Expect(compressedModelState.MatchesStateOf(testModel)).To(BeTrue())
 }
 
+func TestModelCompressor_Compress_CarriesOptimisationDirections(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	compressorUnderTest := new(ModelCompressor)
+	testModel := buildMultiObjectiveDumbModel()
+
+	maximisedVariable := testModel.DecisionVariable("Objective_1").(variable.Directed)
+	maximisedVariable.SetOptimisationDirection(dominance.Maximising)
+
+	// when
+	compressedModelState := compressorUnderTest.Compress(testModel)
+
+	// then
+	expectedDirections := dominance.Directions{dominance.Minimising, dominance.Maximising, dominance.Minimising}
+	g.Expect(compressedModelState.Directions).To(Equal(expectedDirections))
+}
+
 func TestModelCompressor_Decompress_InitialModel(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -76,3 +97,94 @@ func buildMultiObjectiveDumbModel() *modumb.Model {
 	model.Initialise(model2.Random)
 	return model
 }
+
+func TestCompressedModelState_Encoding_PartialIntensities_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	stateUnderTest := &CompressedModelState{Actions: *archive.New(3)}
+	stateUnderTest.Actions.SetValue(0, true)
+	stateUnderTest.Actions.SetValue(2, true)
+	stateUnderTest.Intensities = map[int]float64{2: 0.25}
+
+	decodedState := &CompressedModelState{Actions: *archive.New(3)}
+
+	// when
+	encoding := stateUnderTest.Encoding()
+	decodeError := decodedState.Decode(encoding)
+
+	// then
+	g.Expect(encoding).To(Equal("5|2=0.25"))
+	g.Expect(decodeError).To(BeNil())
+	g.Expect(decodedState.IsEquivalentTo(stateUnderTest)).To(BeTrue())
+	g.Expect(decodedState.ActionIntensity(0)).To(BeNumerically("==", 1))
+	g.Expect(decodedState.ActionIntensity(1)).To(BeNumerically("==", 0))
+	g.Expect(decodedState.ActionIntensity(2)).To(BeNumerically("==", 0.25))
+}
+
+func TestCompressedModelState_Encoding_StartYears_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	stateUnderTest := &CompressedModelState{Actions: *archive.New(3)}
+	stateUnderTest.Actions.SetValue(0, true)
+	stateUnderTest.Actions.SetValue(2, true)
+	stateUnderTest.StartYears = map[int]uint{2: 3}
+
+	partialState := &CompressedModelState{Actions: *archive.New(3)}
+	partialState.Actions.SetValue(0, true)
+	partialState.Intensities = map[int]float64{0: 0.5}
+	partialState.StartYears = map[int]uint{0: 1}
+
+	decodedState := &CompressedModelState{Actions: *archive.New(3)}
+
+	// when
+	encoding := stateUnderTest.Encoding()
+	decodeError := decodedState.Decode(encoding)
+
+	// then
+	g.Expect(encoding).To(Equal("5||2=3"))
+	g.Expect(decodeError).To(BeNil())
+	g.Expect(decodedState.IsEquivalentTo(stateUnderTest)).To(BeTrue())
+	g.Expect(decodedState.ActionStartYear(0)).To(BeNumerically("==", 0))
+	g.Expect(decodedState.ActionStartYear(2)).To(BeNumerically("==", 3))
+
+	// when
+	partialEncoding := partialState.Encoding()
+	decodeError = decodedState.Decode(partialEncoding)
+
+	// then
+	g.Expect(partialEncoding).To(Equal("1|0=0.5|0=1"))
+	g.Expect(decodeError).To(BeNil())
+	g.Expect(decodedState.IsEquivalentTo(partialState)).To(BeTrue())
+	g.Expect(decodedState.IsEquivalentTo(stateUnderTest)).To(BeFalse())
+}
+
+func TestCompressedModelState_Decode_BinaryEncoding_ClearsIntensities(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	stateUnderTest := &CompressedModelState{Actions: *archive.New(3)}
+	stateUnderTest.Intensities = map[int]float64{2: 0.25}
+
+	// when
+	decodeError := stateUnderTest.Decode("1")
+
+	// then
+	g.Expect(decodeError).To(BeNil())
+	g.Expect(stateUnderTest.Intensities).To(BeNil())
+	g.Expect(stateUnderTest.Encoding()).To(Equal("1"))
+}
+
+func TestCompressedModelState_Decode_IntensityOfInactiveAction_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	stateUnderTest := &CompressedModelState{Actions: *archive.New(3)}
+
+	// when
+	decodeError := stateUnderTest.Decode("1|1=0.5")
+
+	// then
+	g.Expect(decodeError).To(Not(BeNil()))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/name"
 	"math"
 	"sort"
@@ -42,6 +43,21 @@ func New() *NonDominanceModelArchive {
 	return new(NonDominanceModelArchive).Initialise()
 }
 
+// Merge returns a new archive, identified by id, holding the non-dominated union of the entries of the archives
+// supplied. Entries with identical management actions are stored only once, favouring the earliest archive supplied.
+func Merge(id string, archives ...*NonDominanceModelArchive) *NonDominanceModelArchive {
+	mergedArchive := New()
+	mergedArchive.SetId(id)
+
+	for _, archiveToMerge := range archives {
+		for _, modelState := range archiveToMerge.Archive() {
+			mergedArchive.AttemptToArchiveState(modelState)
+		}
+	}
+
+	return mergedArchive
+}
+
 const notIsolated = math.MaxFloat64
 
 type NonDominanceModelArchive struct {
@@ -68,6 +84,11 @@ func (a *NonDominanceModelArchive) Archive() []*CompressedModelState {
 	return a.archive
 }
 
+// Restore replaces the contents of the archive with the supplied model states, as previously retrieved via Archive().
+func (a *NonDominanceModelArchive) Restore(modelStates []*CompressedModelState) {
+	a.archive = modelStates
+}
+
 func (a *NonDominanceModelArchive) Compress(model model.Model) *CompressedModelState {
 	return a.compressor.Compress(model)
 }
@@ -90,7 +111,7 @@ func (a *NonDominanceModelArchive) AttemptToArchiveState(modelState *CompressedM
 
 	nonDominatedArray := make([]*CompressedModelState, 0)
 	for currentIndex := 0; currentIndex < len(a.archive); currentIndex++ {
-		if modelState.Variables.Dominates(&a.archive[currentIndex].Variables) {
+		if modelState.Dominates(a.archive[currentIndex]) {
 			storageState = StoredReplacingDominatedEntries
 		} else {
 			nonDominatedArray = append(nonDominatedArray, a.archive[currentIndex])
@@ -113,8 +134,7 @@ func (a *NonDominanceModelArchive) ForceIntoArchive(model model.Model) StorageRe
 func (a *NonDominanceModelArchive) ForceModelStateIntoArchive(modelState *CompressedModelState) StorageResult {
 	nonDominatedArray := make([]*CompressedModelState, 0)
 	for currentIndex := 0; currentIndex < len(a.archive); currentIndex++ {
-		currentState := &a.archive[currentIndex].Variables
-		if !currentState.Dominates(&modelState.Variables) {
+		if !a.archive[currentIndex].Dominates(modelState) {
 			nonDominatedArray = append(nonDominatedArray, a.archive[currentIndex])
 		}
 	}
@@ -127,10 +147,10 @@ func (a *NonDominanceModelArchive) ForceModelStateIntoArchive(modelState *Compre
 
 func (a *NonDominanceModelArchive) newModelStateCannotBeArchived(modelState *CompressedModelState) StorageResult {
 	for currentIndex := 0; currentIndex < len(a.archive); currentIndex++ {
-		if a.archive[currentIndex].Variables.Dominates(&modelState.Variables) {
+		if a.archive[currentIndex].Dominates(modelState) {
 			return RejectedWithStoredEntryDominanceDetected
 		}
-		if a.archive[currentIndex].Actions.IsEquivalentTo(&modelState.Actions) {
+		if a.archive[currentIndex].IsEquivalentTo(modelState) {
 			return RejectedWithDuplicateEntryDetected
 		}
 	}
@@ -152,7 +172,8 @@ func (a *NonDominanceModelArchive) IsNonDominant() bool {
 
 	for currentIndex := 0; currentIndex < len(a.archive); currentIndex++ {
 		for downstreamIndex := currentIndex + 1; downstreamIndex < len(a.archive)-1; downstreamIndex++ {
-			if a.archive[currentIndex].Variables.DominancePresent(&a.archive[downstreamIndex].Variables) {
+			currentState, downstreamState := a.archive[currentIndex], a.archive[downstreamIndex]
+			if currentState.Dominates(downstreamState) || downstreamState.Dominates(currentState) {
 				return false // Shouldn't happen if AttemptToArchiveState() is successfully ensuring non-dominance holds regardless.
 			}
 		}
@@ -223,7 +244,7 @@ func (a *NonDominanceModelArchive) calculatedModelStateIsolation(state *Compress
 
 func (a *NonDominanceModelArchive) isIsolated(summary Summary, state *CompressedModelState) bool {
 	for index, variableSummary := range summary {
-		if variableSummary.Minimum == state.Variables[index] {
+		if variableSummary.BestFor(state.Directions.Of(index)) == state.Variables[index] {
 			return false
 		}
 	}
@@ -288,3 +309,11 @@ type VariableSummary struct {
 	Maximum float64
 	Range   float64
 }
+
+// BestFor returns the summarised value most preferred when optimising in the direction supplied.
+func (s *VariableSummary) BestFor(direction dominance.Direction) float64 {
+	if direction == dominance.Maximising {
+		return s.Maximum
+	}
+	return s.Minimum
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	. "github.com/onsi/gomega"
 )
@@ -322,6 +324,74 @@ func TestNonDominanceModelArchiveSummary_Archive_SummaryValid(t *testing.T) {
 	g.Expect(summary[2].Range).To(BeNumerically(equalTo, 3))
 }
 
+func TestMerge_NonDominatedUnionWithoutDuplicates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	firstArchive := New()
+	firstArchive.AttemptToArchiveState(buildTestState(0, 1, 5))
+	firstArchive.AttemptToArchiveState(buildTestState(1, 5, 1))
+
+	secondArchive := New()
+	secondArchive.AttemptToArchiveState(buildTestState(0, 1, 5))
+	secondArchive.AttemptToArchiveState(buildTestState(2, 4, 4))
+	thirdArchive := New()
+	thirdArchive.AttemptToArchiveState(buildTestState(3, 0.5, 4.5))
+
+	// when
+	mergedArchive := Merge("Merged", firstArchive, secondArchive, thirdArchive)
+	showArchiveState(t, mergedArchive)
+
+	// then
+	g.Expect(mergedArchive.Id()).To(Equal("Merged"))
+	g.Expect(mergedArchive.IsNonDominant()).To(BeTrue())
+	g.Expect(mergedArchive.Len()).To(BeNumerically(equalTo, 3))
+
+	g.Expect(mergedArchive.Archive()[0]).To(BeIdenticalTo(firstArchive.Archive()[1]))
+	g.Expect(mergedArchive.Archive()[1]).To(BeIdenticalTo(secondArchive.Archive()[1]))
+	g.Expect(mergedArchive.Archive()[2]).To(BeIdenticalTo(thirdArchive.Archive()[0]))
+}
+
+func TestNonDominanceModelArchive_DirectionsRespected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	minimisingArchive := New()
+	minimisingArchive.AttemptToArchiveState(buildTestState(0, 1, 5))
+
+	directions := dominance.Directions{dominance.Minimising, dominance.Maximising}
+	maximisingArchive := New()
+	maximisingArchive.AttemptToArchiveState(buildDirectedTestState(0, directions, 1, 5))
+
+	// when
+	minimisingResult := minimisingArchive.AttemptToArchiveState(buildTestState(1, 2, 4))
+	maximisingResult := maximisingArchive.AttemptToArchiveState(buildDirectedTestState(1, directions, 2, 4))
+
+	// then
+	g.Expect(minimisingResult).To(Equal(StoredWithNoDominanceDetected))
+	g.Expect(minimisingArchive.Len()).To(BeNumerically(equalTo, 2))
+
+	g.Expect(maximisingResult).To(Equal(RejectedWithStoredEntryDominanceDetected))
+	g.Expect(maximisingArchive.Len()).To(BeNumerically(equalTo, 1))
+}
+
+func buildDirectedTestState(activeAction int, directions dominance.Directions, variableValues ...float64) *CompressedModelState {
+	state := buildTestState(activeAction, variableValues...)
+	state.Directions = directions
+	return state
+}
+
+func buildTestState(activeAction int, variableValues ...float64) *CompressedModelState {
+	const actionNumber = 4
+
+	state := &CompressedModelState{
+		Variables: variableValues,
+		Actions:   *archive.New(actionNumber),
+	}
+	state.Actions.SetValue(activeAction, true)
+	return state
+}
+
 func buildSilentMultiObjectiveDumbModel() *modumb.Model {
 	model := modumb.NewModel().WithId("Test Mo Dumb Model")
 	model.AddObserver(loggers.DefaultTestingAnnealingObserver)
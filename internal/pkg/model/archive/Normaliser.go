@@ -0,0 +1,116 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/pkg/dominance"
+)
+
+// Normaliser maps decision variable values onto a range, either that spanned across an archive or fixed bounds,
+// oriented so that smaller values are always preferred: 0 is the range's best value of a variable, and 1 its worst.
+// Variables holding a single value across the range are scaled by the magnitude of that value instead.
+type Normaliser struct {
+	best       []float64
+	scale      []float64
+	directions dominance.Directions
+}
+
+// NewNormaliser returns a normaliser over the range of the archive supplied, which must not be empty.
+func NewNormaliser(frame *NonDominanceModelArchive) *Normaliser {
+	summary := frame.ArchiveSummary()
+	directions := frame.Archive()[0].Directions
+
+	n := &Normaliser{
+		best:       make([]float64, len(summary)),
+		scale:      make([]float64, len(summary)),
+		directions: directions,
+	}
+
+	for index := range n.best {
+		n.best[index] = summary[index].BestFor(directions.Of(index))
+		n.scale[index] = scaleOf(summary[index], n.best[index])
+	}
+	return n
+}
+
+// NewBoundedNormaliser returns a normaliser over fixed bounds, being the values of each decision variable in the two
+// bounding states supplied, so that archives normalised by it can be compared with each other.
+func NewBoundedNormaliser(oneBound dominance.Float64Vector, otherBound dominance.Float64Vector, directions dominance.Directions) *Normaliser {
+	n := &Normaliser{
+		best:       make([]float64, len(oneBound)),
+		scale:      make([]float64, len(oneBound)),
+		directions: directions,
+	}
+
+	for index := range n.best {
+		boundSummary := &VariableSummary{
+			Minimum: math.Min(oneBound[index], otherBound[index]),
+			Maximum: math.Max(oneBound[index], otherBound[index]),
+			Range:   math.Abs(oneBound[index] - otherBound[index]),
+		}
+		n.best[index] = boundSummary.BestFor(directions.Of(index))
+		n.scale[index] = scaleOf(boundSummary, n.best[index])
+	}
+	return n
+}
+
+func scaleOf(variableSummary *VariableSummary, best float64) float64 {
+	if variableSummary.Range > 0 {
+		return variableSummary.Range
+	}
+	if best != 0 {
+		return math.Abs(best)
+	}
+	return 1
+}
+
+// NormaliseAll returns the normalised variables of every entry of the archive supplied, in archive order.
+func (n *Normaliser) NormaliseAll(archive *NonDominanceModelArchive) [][]float64 {
+	points := make([][]float64, 0, archive.Len())
+	for _, state := range archive.Archive() {
+		points = append(points, n.Normalise(state.Variables))
+	}
+	return points
+}
+
+func (n *Normaliser) Normalise(variables dominance.Float64Vector) []float64 {
+	point := make([]float64, len(variables))
+	for index, value := range variables {
+		shortfall := value - n.best[index]
+		if n.directions.Of(index) == dominance.Maximising {
+			shortfall = n.best[index] - value
+		}
+		point[index] = shortfall / n.scale[index]
+	}
+	return point
+}
+
+// ReferencePoint returns a hypervolume reference point lying the margin supplied beyond the worst normalised value
+// of every variable, so that entries at the extremes of the range still contribute volume.
+func (n *Normaliser) ReferencePoint(margin float64) []float64 {
+	reference := make([]float64, len(n.best))
+	for index := range reference {
+		reference[index] = 1 + margin
+	}
+	return reference
+}
+
+// NearestDistance returns the euclidean distance from the point to the nearest of the other points supplied, or
+// positive infinity if there are none.
+func NearestDistance(point []float64, otherPoints [][]float64) float64 {
+	nearest := math.Inf(1)
+	for _, otherPoint := range otherPoints {
+		nearest = math.Min(nearest, euclideanDistance(point, otherPoint))
+	}
+	return nearest
+}
+
+func euclideanDistance(point []float64, otherPoint []float64) float64 {
+	var sumOfSquares float64
+	for index := range point {
+		sumOfSquares += math.Pow(point[index]-otherPoint[index], 2)
+	}
+	return math.Sqrt(sumOfSquares)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"fmt"
+	"math"
+)
+
+// HypervolumeMargin places the hypervolume reference point just beyond the worst normalised value of the range
+// normalised over, so that entries at the extremes of the range still contribute volume.
+const HypervolumeMargin = 0.1
+
+// QualityMetrics summarise how well an archive approximates a Pareto front, absent the true front to measure against.
+// Each is measured with decision variables normalised over fixed bounds (see NewBoundedNormaliser), so that metrics
+// measured with the same bounds compare between archives.
+type QualityMetrics struct {
+	// Hypervolume is the normalised volume the archive dominates, larger being better.
+	Hypervolume float64
+
+	// Spread is the mean absolute deviation of each entry's distance to its nearest neighbour, relative to the mean of
+	// those distances. 0 is a perfectly even distribution of entries.
+	Spread float64
+
+	// Spacing is the standard deviation of each entry's manhattan distance to its nearest neighbour, as per Schott.
+	// 0 is a perfectly even distribution of entries.
+	Spacing float64
+
+	// GenerationalDistance is the mean distance from each entry to the nearest entry of an earlier archive, 0 being
+	// no movement of the front between the two.
+	GenerationalDistance float64
+}
+
+func (qm QualityMetrics) String() string {
+	return fmt.Sprintf("hypervolume [%g] spread [%g] spacing [%g] generational distance [%g]",
+		qm.Hypervolume, qm.Spread, qm.Spacing, qm.GenerationalDistance)
+}
+
+// MeasureQuality returns the quality metrics of the archive, normalised by the normaliser supplied. Generational
+// distance is measured against the previous archive supplied, and is 0 if there is none.
+func (a *NonDominanceModelArchive) MeasureQuality(previous *NonDominanceModelArchive, normaliser *Normaliser) QualityMetrics {
+	var metrics QualityMetrics
+	if a.IsEmpty() {
+		return metrics
+	}
+
+	points := normaliser.NormaliseAll(a)
+
+	metrics.Hypervolume = Hypervolume(points, normaliser.ReferencePoint(HypervolumeMargin))
+	metrics.Spread = spreadOf(nearestNeighbourDistances(points, euclideanDistance))
+	metrics.Spacing = spacingOf(nearestNeighbourDistances(points, manhattanDistance))
+
+	if previous != nil && !previous.IsEmpty() {
+		metrics.GenerationalDistance = GenerationalDistance(points, normaliser.NormaliseAll(previous))
+	}
+	return metrics
+}
+
+// GenerationalDistance returns the mean distance from each of the points to the nearest of the reference points.
+func GenerationalDistance(points [][]float64, referencePoints [][]float64) float64 {
+	if len(points) == 0 || len(referencePoints) == 0 {
+		return 0
+	}
+
+	var distanceSum float64
+	for _, point := range points {
+		distanceSum += NearestDistance(point, referencePoints)
+	}
+	return distanceSum / float64(len(points))
+}
+
+func nearestNeighbourDistances(points [][]float64, distance func([]float64, []float64) float64) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+
+	distances := make([]float64, len(points))
+	for index, point := range points {
+		distances[index] = math.Inf(1)
+		for otherIndex, otherPoint := range points {
+			if otherIndex != index {
+				distances[index] = math.Min(distances[index], distance(point, otherPoint))
+			}
+		}
+	}
+	return distances
+}
+
+func spreadOf(distances []float64) float64 {
+	mean := meanOf(distances)
+	if mean == 0 {
+		return 0
+	}
+
+	var deviationSum float64
+	for _, distance := range distances {
+		deviationSum += math.Abs(distance - mean)
+	}
+	return deviationSum / (float64(len(distances)) * mean)
+}
+
+func spacingOf(distances []float64) float64 {
+	if len(distances) < 2 {
+		return 0
+	}
+
+	mean := meanOf(distances)
+	var squaredDeviationSum float64
+	for _, distance := range distances {
+		squaredDeviationSum += math.Pow(distance-mean, 2)
+	}
+	return math.Sqrt(squaredDeviationSum / float64(len(distances)-1))
+}
+
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func manhattanDistance(point []float64, otherPoint []float64) float64 {
+	var distance float64
+	for index := range point {
+		distance += math.Abs(point[index] - otherPoint[index])
+	}
+	return distance
+}
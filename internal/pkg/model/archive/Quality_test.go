@@ -0,0 +1,167 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+const tolerance = 1e-9
+
+func TestHypervolume_TwoDimensions_MatchesKnownVolume(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	points := [][]float64{{0, 1}, {1, 0}, {1, 1}, {0, 1}}
+	reference := []float64{2, 2}
+
+	// when
+	actualVolume := Hypervolume(points, reference)
+
+	// then
+	g.Expect(actualVolume).To(BeNumerically("~", 3, tolerance))
+}
+
+func TestHypervolume_ThreeDimensions_MatchesInclusionExclusion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	points := [][]float64{{0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+	reference := []float64{2, 2, 2}
+
+	// when
+	actualVolume := Hypervolume(points, reference)
+
+	// then
+	expectedVolume := float64(3*2 - 3*1 + 1)
+	g.Expect(actualVolume).To(BeNumerically("~", expectedVolume, tolerance))
+}
+
+func TestHypervolume_PointsBeyondReference_Ignored(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	points := [][]float64{{0.5, 0.5, 0.5}, {3, 0, 0}}
+	reference := []float64{1, 1, 1}
+
+	// when
+	actualVolume := Hypervolume(points, reference)
+
+	// then
+	g.Expect(actualVolume).To(BeNumerically("~", 0.125, tolerance))
+}
+
+func TestMeasureQuality_EmptyArchive_AllZero(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	metrics := New().MeasureQuality(nil, nil)
+
+	// then
+	g.Expect(metrics).To(Equal(QualityMetrics{}))
+}
+
+func TestMeasureQuality_EvenFront_NoSpreadOrSpacing(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	archiveUnderTest := archiveOf(minimisingBoth, []float64{0, 2}, []float64{1, 1}, []float64{2, 0})
+	normaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{2, 2}, minimisingBoth)
+
+	// when
+	metrics := archiveUnderTest.MeasureQuality(nil, normaliser)
+
+	// then
+	g.Expect(metrics.Hypervolume).To(BeNumerically("~", 0.46, tolerance))
+	g.Expect(metrics.Spread).To(BeNumerically("~", 0, tolerance))
+	g.Expect(metrics.Spacing).To(BeNumerically("~", 0, tolerance))
+	g.Expect(metrics.GenerationalDistance).To(BeNumerically("==", 0))
+}
+
+func TestMeasureQuality_UnevenFront_SpreadAndSpacing(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	archiveUnderTest := archiveOf(minimisingBoth, []float64{0, 4}, []float64{1, 3}, []float64{4, 0})
+	normaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{4, 4}, minimisingBoth)
+
+	// when
+	metrics := archiveUnderTest.MeasureQuality(nil, normaliser)
+
+	// then
+	g.Expect(metrics.Spread).To(BeNumerically(">", 0))
+	g.Expect(metrics.Spacing).To(BeNumerically(">", 0))
+}
+
+func TestMeasureQuality_DirectionsRespected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	minimisingArchive := archiveOf(minimisingBoth, []float64{0, 2}, []float64{1, 1}, []float64{2, 0})
+	maximisingArchive := archiveOf(maximisingFirst, []float64{0, 2}, []float64{-1, 1}, []float64{-2, 0})
+
+	minimisingNormaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{2, 2}, minimisingBoth)
+	maximisingNormaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{-2, 2}, maximisingFirst)
+
+	// when
+	minimisingMetrics := minimisingArchive.MeasureQuality(nil, minimisingNormaliser)
+	maximisingMetrics := maximisingArchive.MeasureQuality(nil, maximisingNormaliser)
+
+	// then
+	g.Expect(maximisingMetrics.Hypervolume).To(BeNumerically("~", minimisingMetrics.Hypervolume, tolerance))
+}
+
+func TestMeasureQuality_PreviousArchive_GenerationalDistance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	previousArchive := archiveOf(minimisingBoth, []float64{0, 2}, []float64{2, 0})
+	archiveUnderTest := archiveOf(minimisingBoth, []float64{0, 2}, []float64{1, 1}, []float64{2, 0})
+	normaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{2, 2}, minimisingBoth)
+
+	// when
+	metrics := archiveUnderTest.MeasureQuality(previousArchive, normaliser)
+
+	// then
+	expectedDistance := math.Sqrt(0.5) / 3
+	g.Expect(metrics.GenerationalDistance).To(BeNumerically("~", expectedDistance, tolerance))
+}
+
+func TestMeasureQuality_FixedBounds_ComparableBetweenArchives(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	normaliser := NewBoundedNormaliser([]float64{0, 0}, []float64{2, 2}, minimisingBoth)
+	widerArchive := archiveOf(minimisingBoth, []float64{0, 2}, []float64{2, 0})
+	betterArchive := archiveOf(minimisingBoth, []float64{0, 1}, []float64{1, 0})
+
+	// when
+	widerMetrics := widerArchive.MeasureQuality(nil, normaliser)
+	betterMetrics := betterArchive.MeasureQuality(nil, normaliser)
+
+	// then
+	g.Expect(betterMetrics.Hypervolume).To(BeNumerically(">", widerMetrics.Hypervolume))
+}
+
+var (
+	minimisingBoth  = dominance.Directions{dominance.Minimising, dominance.Minimising}
+	maximisingFirst = dominance.Directions{dominance.Maximising, dominance.Minimising}
+)
+
+func archiveOf(directions dominance.Directions, variableValues ...[]float64) *NonDominanceModelArchive {
+	states := make([]*CompressedModelState, len(variableValues))
+	for index, values := range variableValues {
+		states[index] = &CompressedModelState{
+			Variables:  dominance.Float64Vector(values),
+			Directions: directions,
+		}
+	}
+
+	newArchive := New()
+	newArchive.Restore(states)
+	return newArchive
+}
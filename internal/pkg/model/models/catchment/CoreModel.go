@@ -6,6 +6,7 @@ import (
 	errors2 "errors"
 	"fmt"
 	catchmentDataSet "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvednitrogen"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/opportunitycost"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
@@ -16,12 +17,15 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/move"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/implementationcost"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatenitrogen"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/sedimentproduction"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/schedule"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	baseParameters "github.com/LindsayBradford/crem/internal/pkg/parameters"
@@ -32,6 +36,7 @@ import (
 )
 
 var _ model.Model = new(CoreModel)
+var _ rand.Container = new(CoreModel)
 
 func NewCoreModel() *CoreModel {
 	newModel := new(CoreModel)
@@ -41,6 +46,7 @@ func NewCoreModel() *CoreModel {
 
 	newModel.parameters.Initialise()
 	newModel.managementActions.Initialise()
+	newModel.constraints = constraint.New()
 	newModel.ContainedDecisionVariables.Initialise()
 
 	return newModel
@@ -52,11 +58,23 @@ type CoreModel struct {
 	parameters parameters.Parameters
 
 	managementActions action.ModelManagementActions
+	constraints       *constraint.Constraints
+
+	moves                 *move.Selector
+	pendingMove           *move.Move
+	valuesBeforeMove      map[string]float64
+	intensitiesBeforeMove []float64
+	startYearsBeforeMove  []uint
+
+	horizon      schedule.Horizon
+	yearlyBudget variable.Bounds
 
 	planningUnitTable tables.CsvTable
 	gulliesTable      tables.CsvTable
 	actionsTable      tables.CsvTable
 
+	network *network.Network
+
 	variable.ContainedDecisionVariables
 
 	inputDataSet *catchmentDataSet.DataSetImpl
@@ -118,6 +136,37 @@ func (m *CoreModel) validateModelParameters() {
 
 		m.parameters.AddValidationErrorMessage(errorText)
 	}
+
+	m.validateMoveProbabilities()
+	m.validatePlanningHorizon()
+}
+
+func (m *CoreModel) validateMoveProbabilities() {
+	probabilitySum := m.parameters.GetFloat64(parameters.SwapMoveProbability) +
+		m.parameters.GetFloat64(parameters.KFlipMoveProbability) +
+		m.parameters.GetFloat64(parameters.CostAwareMoveProbability) +
+		m.parameters.GetFloat64(parameters.IntensityMoveProbability) +
+		m.parameters.GetFloat64(parameters.RescheduleMoveProbability)
+
+	if probabilitySum > 1 {
+		errorText := fmt.Sprintf("Sum of [%s], [%s], [%s], [%s] and [%s] must not exceed 1.",
+			parameters.SwapMoveProbability,
+			parameters.KFlipMoveProbability,
+			parameters.CostAwareMoveProbability,
+			parameters.IntensityMoveProbability,
+			parameters.RescheduleMoveProbability,
+		)
+		m.parameters.AddValidationErrorMessage(errorText)
+	}
+
+	if m.parameters.GetFloat64(parameters.CostAwareMoveProbability) > 0 &&
+		!m.parameters.HasEntry(parameters.MaximumImplementationCost) {
+		errorText := fmt.Sprintf("[%s] requires [%s] to be set.",
+			parameters.CostAwareMoveProbability,
+			parameters.MaximumImplementationCost,
+		)
+		m.parameters.AddValidationErrorMessage(errorText)
+	}
 }
 
 func (m *CoreModel) ParameterErrors() error {
@@ -129,9 +178,16 @@ func (m *CoreModel) Initialise(initialisationType model.InitialisationType) {
 	m.planningUnitTable = m.fetchCsvTable(catchmentDataSet.SubcatchmentsTableName)
 	m.gulliesTable = m.fetchCsvTable(catchmentDataSet.GulliesTableName)
 	m.actionsTable = m.fetchCsvTable(catchmentDataSet.ActionsTableName)
+	m.validateInputDataSet()
+
+	m.network = new(network.Network).Initialise(m.planningUnitTable, m.parameters)
+	m.buildHorizon()
+	m.buildMoveSelector()
 
 	m.buildDecisionVariables()
 	m.buildAndObserveManagementActions()
+	m.scheduleActionsAtHorizonStart()
+	m.buildConstraints()
 	m.InitialiseActions(initialisationType)
 
 	firstAction := m.ManagementActions()[0]
@@ -142,6 +198,33 @@ func (m *CoreModel) Initialise(initialisationType model.InitialisationType) {
 		WithAttribute("IsActive", firstAction.IsActive())
 }
 
+// buildMoveSelector configures the neighbourhood moves that TryRandomChange picks from, with single toggles taking
+// whatever probability the other moves leave unclaimed.
+func (m *CoreModel) buildMoveSelector() {
+	swapProbability := m.parameters.GetFloat64(parameters.SwapMoveProbability)
+	kFlipProbability := m.parameters.GetFloat64(parameters.KFlipMoveProbability)
+	costAwareProbability := m.parameters.GetFloat64(parameters.CostAwareMoveProbability)
+	intensityProbability := m.parameters.GetFloat64(parameters.IntensityMoveProbability)
+	rescheduleProbability := m.parameters.GetFloat64(parameters.RescheduleMoveProbability)
+	singleToggleProbability := 1 - swapProbability - kFlipProbability - costAwareProbability - intensityProbability -
+		rescheduleProbability
+
+	kFlipSize := int(m.parameters.GetInt64(parameters.KFlipMoveSize))
+
+	m.moves = move.NewSelector().
+		WithGenerator(move.NewSingleToggle(), singleToggleProbability).
+		WithGenerator(move.NewSwap().WithNeighbours(m.network.Neighbours), swapProbability).
+		WithGenerator(move.NewKFlip().WithSize(kFlipSize), kFlipProbability).
+		WithGenerator(move.NewCostAware().WithCost(actions.ImplementationCostOf).WithHeadroom(m.implementationCostHeadroom), costAwareProbability).
+		WithGenerator(move.NewIntensity(), intensityProbability).
+		WithGenerator(move.NewReschedule().WithYears(m.horizon.Years), rescheduleProbability)
+}
+
+func (m *CoreModel) implementationCostHeadroom() float64 {
+	costLimit := m.parameters.GetFloat64(parameters.MaximumImplementationCost)
+	return costLimit - m.DecisionVariable(implementationcost.VariableName).Value()
+}
+
 func (m *CoreModel) fetchCsvTable(tableName string) tables.CsvTable {
 	namedTable, namedTableError := m.inputDataSet.Table(tableName)
 	if namedTableError != nil {
@@ -155,8 +238,15 @@ func (m *CoreModel) fetchCsvTable(tableName string) tables.CsvTable {
 	return namedCsvTable
 }
 
+func (m *CoreModel) validateInputDataSet() {
+	if validationErrors := m.inputDataSet.Validate(); validationErrors != nil {
+		panic(validationErrors)
+	}
+}
+
 func (m *CoreModel) buildDecisionVariables() {
 	sedimentProduction := new(sedimentproduction.SedimentProduction).
+		WithNetwork(m.network).
 		Initialise(m.inputDataSet, m.parameters).
 		WithObservers(m)
 
@@ -166,6 +256,7 @@ func (m *CoreModel) buildDecisionVariables() {
 
 	particulateNitrogen := new(particulatenitrogen.ParticulateNitrogenProduction).
 		WithSedimentProductionVariable(sedimentProduction).
+		WithNetwork(m.network).
 		Initialise(m.planningUnitTable, m.actionsTable, m.parameters).
 		WithObservers(m)
 
@@ -174,6 +265,7 @@ func (m *CoreModel) buildDecisionVariables() {
 	}
 
 	dissolvedNitrogen := new(dissolvednitrogen.DissolvedNitrogenProduction).
+		WithNetwork(m.network).
 		Initialise(m.planningUnitTable, m.actionsTable, m.parameters).
 		WithObservers(m)
 
@@ -182,14 +274,14 @@ func (m *CoreModel) buildDecisionVariables() {
 	}
 
 	implementationCost := new(implementationcost.ImplementationCost).
-		Initialise().WithObservers(m)
+		Initialise().WithHorizon(m.horizon).WithObservers(m)
 
 	if m.parameters.HasEntry(parameters.MaximumImplementationCost) {
 		implementationCost.SetMaximum(m.parameters.GetFloat64(parameters.MaximumImplementationCost))
 	}
 
 	opportunityCost := new(opportunitycost.OpportunityCost).
-		Initialise().WithObservers(m)
+		Initialise().WithHorizon(m.horizon).WithObservers(m)
 
 	if m.parameters.HasEntry(parameters.MaximumOpportunityCost) {
 		opportunityCost.SetMaximum(m.parameters.GetFloat64(parameters.MaximumOpportunityCost))
@@ -255,6 +347,21 @@ func (m *CoreModel) buildWetlandsEstablishments() []action.ManagementAction {
 	return wetlandsEstablishments
 }
 
+// buildConstraints applies any constraints of the input data set's constraints table over the model's management
+// actions.
+func (m *CoreModel) buildConstraints() {
+	m.constraints = constraint.New().Over(m.managementActions.Actions())
+	if m.inputDataSet.ConstraintsTable == nil {
+		return
+	}
+
+	tableConstraints, constraintsError := actions.ConstraintsFrom(m.inputDataSet.ConstraintsTable)
+	if constraintsError != nil {
+		panic(constraintsError)
+	}
+	m.constraints.With(tableConstraints...)
+}
+
 func (m *CoreModel) buildActionObservers() []action.Observer {
 	observers := make([]action.Observer, 0)
 	observers = append(observers, m)
@@ -361,6 +468,20 @@ func (m *CoreModel) RandomlyValidlyActivateActions() {
 		}
 
 		m.noteManagementAction("Activated random action", actionChanged)
+		if !m.ConstraintsHold() {
+			attemptNote = fmt.Sprintf("Attempt [%d]: Activation broke action constraints. Reverting.", actionNumber-attemptLimit+1)
+			m.note(attemptNote)
+			actionChanged.InitialisingDeactivation()
+			attemptLimit--
+			continue
+		}
+		if !m.scheduleNewlyActivated(actionChanged) {
+			attemptNote = fmt.Sprintf("Attempt [%d]: No year has budget left for activation. Reverting.", actionNumber-attemptLimit+1)
+			m.note(attemptNote)
+			actionChanged.InitialisingDeactivation()
+			attemptLimit--
+			continue
+		}
 		isValid, _ = m.ChangeIsValid()
 
 		if isValid {
@@ -388,11 +509,24 @@ func (m *CoreModel) randomlyDeactivateActionsFromAllActiveStart() {
 	m.RandomlyValidlyDeactivateActions()
 }
 
+// InitialiseAllActionsToActive activates every management action, short of those that must stay inactive for the
+// model's action constraints to hold, or that no year of the planning horizon has budget left for.
 func (m *CoreModel) InitialiseAllActionsToActive() {
 	m.note("Initialising all actions as active")
 	for _, action := range m.managementActions.Actions() {
 		action.InitialisingActivation()
 	}
+	m.deactivateActionsBreakingConstraints()
+	m.scheduleWithinYearlyBudget()
+}
+
+// deactivateActionsBreakingConstraints deactivates, one at a time, an action of the first constraint violation found
+// until no constraint is broken. Deactivating can itself break prerequisites, so violations are found afresh each time.
+func (m *CoreModel) deactivateActionsBreakingConstraints() {
+	for violations := m.constraints.Violations(); len(violations) > 0; violations = m.constraints.Violations() {
+		m.note("Deactivating action: " + violations[0].Message)
+		violations[0].Action.InitialisingDeactivation()
+	}
 }
 
 func (m *CoreModel) RandomlyValidlyDeactivateActions() {
@@ -410,6 +544,13 @@ func (m *CoreModel) RandomlyValidlyDeactivateActions() {
 		}
 
 		m.noteManagementAction("Deactivate random action", actionChanged)
+		if !m.ConstraintsHold() {
+			attemptNote = fmt.Sprintf("Attempt [%d]: Deactivation broke action constraints. Reverting.", numberToAttempt-attemptsLeft+1)
+			m.note(attemptNote)
+			actionChanged.InitialisingActivation()
+			attemptsLeft--
+			continue
+		}
 		isValid, _ = m.ChangeIsValid()
 
 		if isValid {
@@ -439,6 +580,17 @@ func (m *CoreModel) randomlyInitialiseActionsUnbounded() {
 			m.noteManagementAction("Randomly activating action", action)
 		}
 	}
+	m.deactivateActionsBreakingConstraints()
+	m.scheduleWithinYearlyBudget()
+}
+
+// RandomNumberGenerator returns the generator the model draws on when randomly changing its management actions.
+func (m *CoreModel) RandomNumberGenerator() *rand.Rand {
+	return m.managementActions.RandomNumberGenerator()
+}
+
+func (m *CoreModel) SetRandomNumberGenerator(generator *rand.Rand) {
+	m.managementActions.SetRandomNumberGenerator(generator)
 }
 
 func (m *CoreModel) ManagementActions() []action.ManagementAction {
@@ -463,6 +615,26 @@ func (m *CoreModel) SetManagementActionUnobserved(index int, value bool) {
 	}
 }
 
+// SetManagementActionIntensity applies the management action at the index supplied at the intensity given,
+// accepting the resulting change.
+func (m *CoreModel) SetManagementActionIntensity(index int, intensity float64) {
+	if m.ManagementActions()[index].Intensity() != intensity {
+		m.managementActions.SetIntensity(index, intensity)
+		m.AcceptChange()
+	}
+}
+
+func (m *CoreModel) SetManagementActionIntensityUnobserved(index int, intensity float64) {
+	if m.ManagementActions()[index].Intensity() != intensity {
+		m.managementActions.SetIntensityUnobserved(index, intensity)
+		m.AcceptChange()
+	}
+}
+
+func (m *CoreModel) Network() *network.Network {
+	return m.network
+}
+
 func (m *CoreModel) PlanningUnits() planningunit.Ids {
 	_, rows := m.planningUnitTable.ColumnAndRowSize()
 	planningUnits := make(planningunit.Ids, rows)
@@ -481,6 +653,7 @@ func (m *CoreModel) AcceptChange() {
 	if !m.initialising {
 		m.noteManagementAction("Accepting Action", m.managementActions.LastAppliedAction())
 	}
+	m.concludeMove(true)
 }
 
 func (m *CoreModel) RevertChange() {
@@ -488,7 +661,12 @@ func (m *CoreModel) RevertChange() {
 		m.noteManagementAction("Rejecting Action", m.managementActions.LastAppliedAction())
 	}
 	m.ContainedDecisionVariables.RejectAll()
-	m.managementActions.ToggleLastActivationUnobserved()
+	if m.pendingMove != nil {
+		m.revertMove()
+	} else {
+		m.managementActions.RevertLastChangeUnobserved()
+	}
+	m.concludeMove(false)
 }
 
 func (m *CoreModel) DoRandomChange() {
@@ -503,13 +681,157 @@ func (m *CoreModel) ToggleAction(planningUnit planningunit.Id, actionType action
 }
 
 func (m *CoreModel) TryRandomChange() {
-	m.managementActions.RandomlyToggleOneActivation()
+	nextMove := m.moves.Generate(m.managementActions.Actions(), m.RandomNumberGenerator())
+	m.tryMove(nextMove)
 	m.noteManagementAction("Trying Action", m.managementActions.LastAppliedAction())
 }
 
+// tryMove toggles each action of the move supplied in turn, applies each at the move's intensity for it, or starts
+// each in the move's start year for it. Actions the move activates are first started in the earliest year with budget
+// left for them. Decision variables hold only one undoable change at a time, so the changes of all but the last action
+// of a compound move are applied as they are made, with variable values before the move kept to report the change of
+// the move as a whole.
+func (m *CoreModel) tryMove(nextMove move.Move) {
+	m.pendingMove = &nextMove
+	m.valuesBeforeMove = nil
+	if nextMove.IsCompound() {
+		m.valuesBeforeMove = m.decisionVariableValues()
+	}
+
+	m.intensitiesBeforeMove = make([]float64, len(nextMove.Actions))
+	m.startYearsBeforeMove = make([]uint, len(nextMove.Actions))
+	for index, moveAction := range nextMove.Actions {
+		if index > 0 {
+			m.ContainedDecisionVariables.AcceptAll()
+		}
+		m.intensitiesBeforeMove[index] = moveAction.Intensity()
+		m.startYearsBeforeMove[index] = moveAction.StartYear()
+		if nextMove.SetsStartYears() {
+			startYear := nextMove.StartYears[index]
+			m.managementActions.ScheduleAt(moveAction, startYear, m.horizon.Realisation(startYear))
+			continue
+		}
+		if nextMove.SetsIntensities() {
+			m.scheduleForActivation(moveAction, nextMove.Intensities[index])
+			m.managementActions.ApplyAtIntensity(moveAction, nextMove.Intensities[index])
+			continue
+		}
+		intensityLevels := moveAction.IntensityLevels()
+		m.scheduleForActivation(moveAction, intensityLevels[len(intensityLevels)-1])
+		m.managementActions.Toggle(moveAction)
+	}
+}
+
+// revertMove returns the actions of the pending move to their intensities and start years before the move in reverse
+// order, the last unobserved as its change was never applied, and the rest observed, so that their already-applied
+// changes are reversed.
+func (m *CoreModel) revertMove() {
+	moveActions := m.pendingMove.Actions
+	for index := len(moveActions) - 1; index >= 0; index-- {
+		isLastAction := index == len(moveActions)-1
+		if m.pendingMove.SetsStartYears() {
+			m.revertStartYear(moveActions[index], m.startYearsBeforeMove[index], isLastAction)
+		} else {
+			m.revertIntensity(moveActions[index], m.intensitiesBeforeMove[index], isLastAction)
+			m.revertStartYearOfInactive(moveActions[index], m.startYearsBeforeMove[index])
+		}
+		if !isLastAction {
+			m.ContainedDecisionVariables.AcceptAll()
+		}
+	}
+}
+
+func (m *CoreModel) revertIntensity(moveAction action.ManagementAction, intensityBeforeMove float64, unobserved bool) {
+	if unobserved {
+		m.managementActions.ApplyAtIntensityUnobserved(moveAction, intensityBeforeMove)
+		return
+	}
+	m.managementActions.ApplyAtIntensity(moveAction, intensityBeforeMove)
+}
+
+// revertStartYearOfInactive returns an action the move activated, now deactivated again, to its start year before the
+// move. Being inactive, its start year changes no decision variable, so is reverted unobserved.
+func (m *CoreModel) revertStartYearOfInactive(moveAction action.ManagementAction, startYearBeforeMove uint) {
+	if moveAction.IsActive() || moveAction.StartYear() == startYearBeforeMove {
+		return
+	}
+	moveAction.SetScheduleUnobserved(startYearBeforeMove, m.horizon.Realisation(startYearBeforeMove))
+}
+
+func (m *CoreModel) revertStartYear(moveAction action.ManagementAction, startYearBeforeMove uint, unobserved bool) {
+	realisationBeforeMove := m.horizon.Realisation(startYearBeforeMove)
+	if unobserved {
+		m.managementActions.ScheduleAtUnobserved(moveAction, startYearBeforeMove, realisationBeforeMove)
+		return
+	}
+	m.managementActions.ScheduleAt(moveAction, startYearBeforeMove, realisationBeforeMove)
+}
+
+func (m *CoreModel) decisionVariableValues() map[string]float64 {
+	values := make(map[string]float64)
+	for _, name := range m.DecisionVariableNames() {
+		values[name] = m.DecisionVariable(name).Value()
+	}
+	return values
+}
+
+func (m *CoreModel) concludeMove(accepted bool) {
+	if m.pendingMove == nil {
+		return
+	}
+
+	m.moves.Record(m.pendingMove.Type, accepted)
+	m.pendingMove = nil
+	m.valuesBeforeMove = nil
+	m.intensitiesBeforeMove = nil
+	m.startYearsBeforeMove = nil
+
+	reportingInterval := uint64(m.parameters.GetInt64(parameters.MoveStatisticsReportingInterval))
+	if reportingInterval > 0 && m.moves.AttemptedTotal()%reportingInterval == 0 {
+		m.noteMoveStatistics()
+	}
+}
+
+// DecisionVariableChange reports the change that the change being tried makes to the named decision variable, across
+// every action of a compound move.
+func (m *CoreModel) DecisionVariableChange(variableName string) float64 {
+	change := m.ContainedDecisionVariables.DecisionVariableChange(variableName)
+	if valueBefore, isCompound := m.valuesBeforeMove[variableName]; isCompound {
+		return m.DecisionVariable(variableName).Value() + change - valueBefore
+	}
+	return change
+}
+
+// MoveStatistics returns the number of moves attempted and accepted so far, per move type.
+func (m *CoreModel) MoveStatistics() *move.Statistics {
+	return &m.moves.Statistics
+}
+
+func (m *CoreModel) noteMoveStatistics() {
+	if !m.HasObservers() || m.moves == nil || m.moves.AttemptedTotal() == 0 {
+		return
+	}
+
+	event := observer.NewEvent(observer.Model).WithNote("Move Statistics")
+	for _, moveType := range m.moves.Types() {
+		event.WithAttribute(moveType.String()+"Attempted", m.moves.Attempted(moveType)).
+			WithAttribute(moveType.String()+"Accepted", m.moves.Accepted(moveType)).
+			WithAttribute(moveType.String()+"AcceptanceRate", m.moves.AcceptanceRate(moveType))
+	}
+	m.NotifyObserversOfEvent(*event)
+}
+
+// UndoChange undoes the last change made. A change made by a move is undone the way RevertChange does, as toggling
+// the last action applied would not undo swaps, k-flips, intensity changes or reschedules.
 func (m *CoreModel) UndoChange() {
 	m.noteManagementAction("Undoing Action", m.managementActions.LastAppliedAction())
-	m.managementActions.ToggleLastActivation()
+	if m.pendingMove == nil {
+		m.managementActions.ToggleLastActivation()
+		return
+	}
+	m.ContainedDecisionVariables.RejectAll()
+	m.revertMove()
+	m.concludeMove(false)
 }
 
 func (m *CoreModel) ObserveAction(action action.ManagementAction) {
@@ -585,11 +907,45 @@ func (m *CoreModel) capChangeOverRange(value float64) float64 {
 func (m *CoreModel) DeepClone() model.Model {
 	clone := *m
 	clone.managementActions.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	clone.buildMoveSelector()
 	return &clone
 }
 
+// ChangeIsValid reports whether the change being tried keeps decision variables within their bounds, and the model's
+// management actions within their constraints and yearly budget. For a pending move, only constraints involving the
+// planning units it touches are checked, as no others can have been broken by it.
 func (m *CoreModel) ChangeIsValid() (bool, *compositeErrors.CompositeError) {
-	return m.checkValidityWith(m.undoableValueBoundsChecker)
+	return m.checkValidityWith(func(validationErrors *compositeErrors.CompositeError) {
+		m.undoableValueBoundsChecker(validationErrors)
+		m.constraintsChecker(validationErrors)
+		m.yearlyBudgetChecker(validationErrors)
+	})
+}
+
+func (m *CoreModel) constraintsChecker(validationErrors *compositeErrors.CompositeError) {
+	if m.pendingMove == nil {
+		m.constraints.Check(validationErrors)
+		return
+	}
+	m.constraints.CheckIn(m.pendingMove.PlanningUnits(), validationErrors)
+}
+
+// ConstraintsHold reports whether the current state of the model's management actions breaks none of its action
+// constraints.
+func (m *CoreModel) ConstraintsHold() bool {
+	return m.constraints.Hold()
+}
+
+// ActionViolations returns the ways the current state of the model's management actions breaks its action
+// constraints or yearly budget, or nil if neither is broken.
+func (m *CoreModel) ActionViolations() *compositeErrors.CompositeError {
+	violations := compositeErrors.New("Action Violations")
+	m.constraints.Check(violations)
+	m.yearlyBudgetChecker(violations)
+	if violations.Size() > 0 {
+		return violations
+	}
+	return nil
 }
 
 func (m *CoreModel) checkValidityWith(validationFunction func(*compositeErrors.CompositeError)) (bool, *compositeErrors.CompositeError) {
@@ -616,7 +972,11 @@ func (m *CoreModel) checkVariableUndoableValueBounds(variableName string, valida
 }
 
 func (m *CoreModel) StateIsValid() (bool, *compositeErrors.CompositeError) {
-	return m.checkValidityWith(m.actualValueBoundsChecker)
+	return m.checkValidityWith(func(validationErrors *compositeErrors.CompositeError) {
+		m.actualValueBoundsChecker(validationErrors)
+		m.constraints.Check(validationErrors)
+		m.yearlyBudgetChecker(validationErrors)
+	})
 }
 
 func (m *CoreModel) actualValueBoundsChecker(validationErrors *compositeErrors.CompositeError) {
@@ -641,7 +1001,7 @@ func checkBounds(possiblyBoundVariable variable.UndoableDecisionVariable, value
 }
 
 func (m *CoreModel) TearDown() {
-	// deliberately does nothing.
+	m.noteMoveStatistics()
 }
 
 func (m *CoreModel) IsEquivalentTo(otherModel model.Model) bool {
@@ -661,7 +1021,10 @@ func (m *CoreModel) checkActions(otherModel model.Model) bool {
 		assert.That(myActions[index].PlanningUnit() == otherActions[index].PlanningUnit()).Holds()
 		assert.That(myActions[index].Type() == otherActions[index].Type()).Holds()
 
-		if myActions[index].IsActive() != otherActions[index].IsActive() {
+		if myActions[index].Intensity() != otherActions[index].Intensity() {
+			return false
+		}
+		if myActions[index].IsActive() && myActions[index].StartYear() != otherActions[index].StartYear() {
 			return false
 		}
 	}
@@ -682,6 +1045,7 @@ func (m *CoreModel) checkVariables(otherModel model.Model) bool {
 
 func (m *CoreModel) SynchroniseTo(otherModel model.Model) {
 	for index, action := range otherModel.ManagementActions() {
-		m.SetManagementAction(index, action.IsActive())
+		m.SetManagementActionStartYear(index, action.StartYear())
+		m.SetManagementActionIntensity(index, action.Intensity())
 	}
 }
@@ -12,12 +12,15 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/csv"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/move"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/implementationcost"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/sedimentproduction"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	"github.com/LindsayBradford/crem/pkg/math"
 	. "github.com/onsi/gomega"
@@ -135,6 +138,66 @@ func TestCoreModel_Initialise_InvalidDataSet_Errors(t *testing.T) {
 	g.Expect(newModelRunner).To(Panic())
 }
 
+func TestCoreModel_Initialise_MisnamedColumns_ErrorsCollected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load("testdata/MisnamedColumnsModel.csv")
+
+	g.Expect(loadError).To(BeNil())
+
+	// when
+	var initialisationError error
+	func() {
+		defer func() { initialisationError, _ = recover().(error) }()
+		NewCoreModel().WithSourceDataSet(sourceDataSet).Initialise(model2.AsIs)
+	}()
+
+	// then
+	g.Expect(initialisationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+
+	const subCatchmentMissing, gullyColumnsMissing, actionColumnsMissing = 1, 3, 14
+	compositeError := initialisationError.(*compositeErrors.CompositeError)
+	g.Expect(compositeError.Size()).To(BeNumerically(equalTo, subCatchmentMissing+gullyColumnsMissing+actionColumnsMissing))
+
+	g.Expect(compositeError.Error()).To(ContainSubstring("Subcatchments table is missing required column [Subcatchment]"))
+	g.Expect(compositeError.Error()).To(ContainSubstring("Gullies table is missing required column [Volume]"))
+	g.Expect(compositeError.Error()).To(ContainSubstring("Actions table is missing required column [ActionType]"))
+}
+
+func TestCoreModel_Initialise_ReorderedColumns_SameVariableValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	validModel := buildModelFrom("testdata/ValidModel.csv", g)
+	reorderedModel := buildModelFrom("testdata/ReorderedModel.csv", g)
+
+	// when
+	validModel.Initialise(model2.AsIs)
+	reorderedModel.Initialise(model2.AsIs)
+
+	// then
+	validVariables := *validModel.DecisionVariables()
+	reorderedVariables := *reorderedModel.DecisionVariables()
+
+	g.Expect(len(reorderedModel.ManagementActions())).To(BeNumerically(equalTo, len(validModel.ManagementActions())))
+	g.Expect(reorderedVariables).To(HaveLen(len(validVariables)))
+	for name, validVariable := range validVariables {
+		g.Expect(reorderedVariables).To(HaveKey(name))
+		g.Expect(reorderedVariables[name].Value()).To(BeNumerically(equalTo, validVariable.Value()), name)
+	}
+}
+
+func buildModelFrom(modelFilePath string, g *GomegaWithT) *CoreModel {
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load(modelFilePath)
+
+	g.Expect(loadError).To(BeNil())
+
+	return NewCoreModel().WithSourceDataSet(sourceDataSet)
+}
+
 func TestCoreModel_WithDefaultParameters_NoErrors(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -292,6 +355,32 @@ func TestCoreModel_CompressionOfChanged_AsExpected(t *testing.T) {
 	g.Expect(modelUnderTest.IsEquivalentTo(decompressedModel)).To(BeTrue())
 }
 
+func TestCoreModel_SameRandomSeed_SameRandomisation(t *testing.T) {
+	// given
+	g := NewGomegaWithT(t)
+	const seedUnderTest = 42
+	modelArchive := new(archive.NonDominanceModelArchive).Initialise()
+
+	firstModel := buildTestingModel(g)
+	secondModel := firstModel.DeepClone()
+	secondModel.Initialise(model2.AsIs)
+
+	// when
+
+	firstModel.SetRandomNumberGenerator(rand.NewSeeded(seedUnderTest))
+	firstModel.Randomize()
+
+	secondModel.(*CoreModel).SetRandomNumberGenerator(rand.NewSeeded(seedUnderTest))
+	secondModel.Randomize()
+
+	// then
+
+	firstEncoding := modelArchive.Compress(firstModel).Encoding()
+	secondEncoding := modelArchive.Compress(secondModel).Encoding()
+
+	g.Expect(firstEncoding).To(Equal(secondEncoding))
+}
+
 func TestCoreModel_ParticulateNitrogen_NoRoundingErrors(t *testing.T) {
 	// given
 	g := NewGomegaWithT(t)
@@ -423,6 +512,45 @@ func TestCoreModel_ToggleHillSlopeRestoration_AsExpected(t *testing.T) {
 	verifyActionToggle(t, modelUnderTest, planningUnit, actions.HillSlopeRestorationType, g)
 }
 
+func TestCoreModel_Wetland_TrapsLoadsRoutedFromUpstream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildRoutedModelDataSet(g), parameters.Map{}, g)
+	sediment := modelUnderTest.DecisionVariable(sedimentproduction.VariableName).(*sedimentproduction.SedimentProduction)
+	upstreamSediment := func() float64 { return sediment.ValuesPerPlanningUnit()[17] }
+
+	referenceModel := buildModelUnderTest(buildRoutedModelDataSet(g), parameters.Map{}, g)
+	referenceModel.ToggleAction(17, actions.GullyRestorationType)
+	referenceModel.AcceptChange()
+	referenceSediment := referenceModel.DecisionVariable(sedimentproduction.VariableName).(*sedimentproduction.SedimentProduction)
+
+	sedimentBefore := sediment.Value()
+	g.Expect(upstreamSediment()).To(BeNumerically(">", 0))
+
+	// when
+	modelUnderTest.ToggleAction(22, actions.WetlandsEstablishmentType)
+	modelUnderTest.AcceptChange()
+
+	// then
+	g.Expect(upstreamSediment()).To(BeNumerically(equalTo, 0))
+	g.Expect(sediment.AccumulatedValuesPerPlanningUnit()[22]).To(BeNumerically("~", sediment.ValuesPerPlanningUnit()[22], 1e-6))
+	g.Expect(sediment.Value()).To(BeNumerically("<", sedimentBefore))
+
+	// when
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(22, actions.WetlandsEstablishmentType)
+	modelUnderTest.AcceptChange()
+
+	// then
+	g.Expect(upstreamSediment()).To(BeNumerically("~", referenceSediment.ValuesPerPlanningUnit()[17], 1e-6))
+	g.Expect(sediment.Value()).To(BeNumerically("~", referenceSediment.Value(), 1e-6))
+	g.Expect(sediment.AccumulatedValuesPerPlanningUnit()[22]).To(
+		BeNumerically("~", referenceSediment.AccumulatedValuesPerPlanningUnit()[22], 1e-6),
+	)
+}
+
 func TestCoreModel_Bounded_InitialisationValid(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -534,6 +662,244 @@ func TestCoreModel_MoreThanOneBoundedParameter_ParameterErrors(t *testing.T) {
 	g.Expect(errors).To(Not(BeNil()))
 }
 
+func TestCoreModel_KFlipMoves_RevertRestoresActionsAndCost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"KFlipMoveProbability": 1.0,
+		"KFlipMoveSize":        int64(3),
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	activeActionsBefore := modelUnderTest.ActiveManagementActions()
+	costBefore := modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()
+
+	const moves = 20
+	for attempt := 0; attempt < moves; attempt++ {
+		// when
+		modelUnderTest.TryRandomChange()
+		modelUnderTest.RevertChange()
+
+		// then
+		g.Expect(modelUnderTest.ActiveManagementActions()).To(Equal(activeActionsBefore))
+		g.Expect(modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()).To(BeNumerically("~", costBefore, 1e-6))
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Attempted(move.KFlipType)).To(BeNumerically(equalTo, moves))
+	g.Expect(modelUnderTest.MoveStatistics().Accepted(move.KFlipType)).To(BeNumerically(equalTo, 0))
+}
+
+func TestCoreModel_KFlipMoves_ChangeReportedAcrossWholeMove(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"KFlipMoveProbability": 1.0,
+		"KFlipMoveSize":        int64(4),
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	variableNames := []string{sedimentproduction.VariableName, implementationcost.VariableName}
+
+	for attempt := 0; attempt < 20; attempt++ {
+		valuesBefore := make([]float64, len(variableNames))
+		reportedChanges := make([]float64, len(variableNames))
+
+		// when
+		for index, name := range variableNames {
+			valuesBefore[index] = modelUnderTest.DecisionVariable(name).Value()
+		}
+		modelUnderTest.TryRandomChange()
+		for index, name := range variableNames {
+			reportedChanges[index] = modelUnderTest.DecisionVariableChange(name)
+		}
+		modelUnderTest.AcceptChange()
+
+		// then
+		for index, name := range variableNames {
+			actualChange := modelUnderTest.DecisionVariable(name).Value() - valuesBefore[index]
+			g.Expect(reportedChanges[index]).To(BeNumerically("~", actualChange, 1e-6))
+		}
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Accepted(move.KFlipType)).To(BeNumerically(equalTo, 20))
+}
+
+func TestCoreModel_SwapMoves_RevertRestoresActionsAndCost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"SwapMoveProbability": 1.0,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(18, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+
+	activeActionsBefore := modelUnderTest.ActiveManagementActions()
+	costBefore := modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()
+
+	const moves = 20
+	for attempt := 0; attempt < moves; attempt++ {
+		// when
+		modelUnderTest.TryRandomChange()
+		modelUnderTest.RevertChange()
+
+		// then
+		g.Expect(modelUnderTest.ActiveManagementActions()).To(Equal(activeActionsBefore))
+		g.Expect(modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()).To(BeNumerically("~", costBefore, 1e-6))
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Attempted(move.SwapType)).To(BeNumerically(equalTo, moves))
+}
+
+func TestCoreModel_SwapMoves_UndoRestoresActionsAndCost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"SwapMoveProbability": 1.0,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(18, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+
+	activeActionsBefore := modelUnderTest.ActiveManagementActions()
+	costBefore := modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()
+
+	const moves = 20
+	for attempt := 0; attempt < moves; attempt++ {
+		// when
+		modelUnderTest.TryRandomChange()
+		modelUnderTest.UndoChange()
+
+		// then
+		g.Expect(modelUnderTest.ActiveManagementActions()).To(Equal(activeActionsBefore))
+		g.Expect(modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()).To(BeNumerically("~", costBefore, 1e-6))
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Attempted(move.SwapType)).To(BeNumerically(equalTo, moves))
+}
+
+func TestCoreModel_CostAwareMoves_StayWithinCostBound(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"MaximumImplementationCost": expectedMaximumImplementationCost,
+		"CostAwareMoveProbability":  1.0,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+
+	for attempt := 0; attempt < 50; attempt++ {
+		// when
+		modelUnderTest.TryRandomChange()
+		changeIsValid, _ := modelUnderTest.ChangeIsValid()
+
+		// then
+		g.Expect(changeIsValid).To(BeTrue())
+		modelUnderTest.AcceptChange()
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Attempted(move.CostAwareType)).To(BeNumerically(equalTo, 50))
+}
+
+func TestCoreModel_InvalidMoveProbabilities_ParameterErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	excessiveProbabilities := parameters.Map{
+		"SwapMoveProbability":  0.6,
+		"KFlipMoveProbability": 0.6,
+	}
+	t.Log(buildInvalidModelUnderTest(buildTestingModelDataSet(g), excessiveProbabilities, g))
+
+	unboundedCostAware := parameters.Map{
+		"CostAwareMoveProbability": 0.5,
+	}
+	t.Log(buildInvalidModelUnderTest(buildTestingModelDataSet(g), unboundedCostAware, g))
+}
+
+func TestCoreModel_Constraints_ChangeValidityAsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildConstrainedModelDataSet(g), parameters.Map{}, g)
+	modelUnderTest.ToggleAction(22, actions.WetlandsEstablishmentType)
+	modelUnderTest.AcceptChange()
+
+	// when
+	modelUnderTest.ToggleAction(22, actions.RiverBankRestorationType)
+	exclusionValid, exclusionErrors := modelUnderTest.ChangeIsValid()
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(exclusionValid).To(BeFalse())
+	g.Expect(exclusionErrors.Error()).To(ContainSubstring("mutually exclusive actions"))
+
+	// when
+	modelUnderTest.ToggleAction(18, actions.GullyRestorationType)
+	prerequisiteValid, _ := modelUnderTest.ChangeIsValid()
+	modelUnderTest.RevertChange()
+
+	modelUnderTest.ToggleAction(17, actions.RiverBankRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(18, actions.GullyRestorationType)
+	prerequisiteMetValid, _ := modelUnderTest.ChangeIsValid()
+	modelUnderTest.AcceptChange()
+
+	// then
+	g.Expect(prerequisiteValid).To(BeFalse())
+	g.Expect(prerequisiteMetValid).To(BeTrue())
+
+	// when
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(17, actions.HillSlopeRestorationType)
+	maximumValid, _ := modelUnderTest.ChangeIsValid()
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(maximumValid).To(BeFalse())
+	g.Expect(modelUnderTest.ConstraintsHold()).To(BeTrue())
+	g.Expect(modelUnderTest.ActionViolations()).To(BeNil())
+}
+
+func TestCoreModel_Constraints_InitialiseAllActive_ConstraintsHold(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildConstrainedModelDataSet(g), parameters.Map{}, g)
+
+	// when
+	modelUnderTest.InitialiseAllActionsToActive()
+
+	// then
+	stateValid, stateErrors := modelUnderTest.StateIsValid()
+	if stateErrors != nil {
+		t.Log(stateErrors)
+	}
+	g.Expect(stateValid).To(BeTrue())
+	g.Expect(len(modelUnderTest.ActiveManagementActions())).To(BeNumerically(">", 0))
+}
+
+func TestCoreModel_Constraints_RandomisationConstraintsHold(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildConstrainedModelDataSet(g), parameters.Map{}, g)
+
+	for attempt := 0; attempt < 20; attempt++ {
+		// when
+		modelUnderTest.Randomize()
+
+		// then
+		g.Expect(modelUnderTest.ConstraintsHold()).To(BeTrue())
+	}
+}
+
 func buildTestingModel(g *GomegaWithT) *CoreModel {
 	sourceDataSet := buildTestingModelDataSet(g)
 
@@ -600,6 +966,22 @@ func buildTestingModelDataSet(g *GomegaWithT) *csv.DataSet {
 	return sourceDataSet
 }
 
+func buildRoutedModelDataSet(g *GomegaWithT) *csv.DataSet {
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load("testdata/RoutedModel.csv")
+
+	g.Expect(loadError).To(BeNil())
+	return sourceDataSet
+}
+
+func buildConstrainedModelDataSet(g *GomegaWithT) *csv.DataSet {
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load("testdata/ConstrainedModel.csv")
+
+	g.Expect(loadError).To(BeNil())
+	return sourceDataSet
+}
+
 func verifyActionToggle(t *testing.T, modelUnderTest *CoreModel, planningUnit planningunit.Id, actionType action.ManagementActionType, g *GomegaWithT) {
 	firstSolution := new(solution.SolutionBuilder).
 		WithId("testingBuilder").
@@ -636,3 +1018,325 @@ func solutionVariable(solution *solution.Solution, variableName string) *variabl
 	}
 	return nil
 }
+
+func TestCoreModel_PlanningHorizon_CostsDiscountedToStartYear(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon": int64(5),
+		"DiscountRate":    0.1,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	gullyIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	gullyCost := actions.ImplementationCostOf(modelUnderTest.ManagementActions()[gullyIndex])
+
+	// when
+	modelUnderTest.SetManagementAction(gullyIndex, true)
+
+	// then
+	implementationCost := modelUnderTest.DecisionVariable(implementationcost.VariableName)
+	g.Expect(implementationCost.Value()).To(BeNumerically("~", gullyCost, 0.01))
+
+	// when
+	modelUnderTest.SetManagementActionStartYear(gullyIndex, 2)
+
+	// then
+	g.Expect(implementationCost.Value()).To(BeNumerically("~", gullyCost/(1.1*1.1), 0.01))
+	g.Expect(modelUnderTest.Schedule().Value(2, implementationcost.VariableName)).To(BeNumerically("~", gullyCost, 0.01))
+	g.Expect(modelUnderTest.Schedule().Value(0, implementationcost.VariableName)).To(BeZero())
+}
+
+func TestCoreModel_RescheduleMoves_RevertRestoresScheduleAndValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":           int64(5),
+		"EstablishmentPeriod":       int64(3),
+		"DiscountRate":              0.05,
+		"RescheduleMoveProbability": 1.0,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+	modelUnderTest.ToggleAction(18, actions.RiverBankRestorationType)
+	modelUnderTest.AcceptChange()
+
+	startYearsBefore := startYearsOf(modelUnderTest.ActiveManagementActions())
+	valuesBefore := modelUnderTest.decisionVariableValues()
+
+	const moves = 20
+	for attempt := 0; attempt < moves; attempt++ {
+		// when
+		modelUnderTest.TryRandomChange()
+		modelUnderTest.RevertChange()
+
+		// then
+		g.Expect(startYearsOf(modelUnderTest.ActiveManagementActions())).To(Equal(startYearsBefore))
+		for name, valueBefore := range valuesBefore {
+			g.Expect(modelUnderTest.DecisionVariable(name).Value()).To(BeNumerically("~", valueBefore, 1e-6))
+		}
+	}
+
+	g.Expect(modelUnderTest.MoveStatistics().Attempted(move.RescheduleType)).To(BeNumerically(equalTo, moves))
+}
+
+func TestCoreModel_RescheduleMoves_LaterStartReducesCostAndRealisation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":           int64(5),
+		"EstablishmentPeriod":       int64(2),
+		"DiscountRate":              0.05,
+		"RescheduleMoveProbability": 1.0,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+
+	costBefore := modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()
+	sedimentBefore := modelUnderTest.DecisionVariable(sedimentproduction.VariableName).Value()
+
+	// when
+	modelUnderTest.TryRandomChange()
+	costChange := modelUnderTest.DecisionVariableChange(implementationcost.VariableName)
+	sedimentChange := modelUnderTest.DecisionVariableChange(sedimentproduction.VariableName)
+	modelUnderTest.AcceptChange()
+
+	// then
+	rescheduledAction := modelUnderTest.ActiveManagementActions()[0]
+	g.Expect(rescheduledAction.StartYear()).To(BeNumerically(">", 0))
+	g.Expect(rescheduledAction.Realisation()).To(BeNumerically("~", modelUnderTest.Horizon().Realisation(rescheduledAction.StartYear()), 1e-12))
+	g.Expect(costChange).To(BeNumerically("<", 0))
+	g.Expect(sedimentChange).To(BeNumerically(">", 0))
+	g.Expect(modelUnderTest.DecisionVariable(implementationcost.VariableName).Value()).To(BeNumerically("~", costBefore+costChange, 1e-6))
+	g.Expect(modelUnderTest.DecisionVariable(sedimentproduction.VariableName).Value()).To(BeNumerically("~", sedimentBefore+sedimentChange, 1e-6))
+}
+
+func TestCoreModel_YearlyBudget_ValidityAsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parameters.Map{"PlanningHorizon": int64(3)}, g)
+	firstIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	secondIndex := indexOfAction(modelUnderTest, 18, actions.GullyRestorationType)
+	yearlyBudget := actions.ImplementationCostOf(modelUnderTest.ManagementActions()[firstIndex])
+	if secondCost := actions.ImplementationCostOf(modelUnderTest.ManagementActions()[secondIndex]); secondCost > yearlyBudget {
+		yearlyBudget = secondCost
+	}
+
+	modelUnderTest = buildModelUnderTest(buildTestingModelDataSet(g),
+		parameters.Map{"PlanningHorizon": int64(3), "MaximumYearlyImplementationCost": yearlyBudget}, g)
+	modelUnderTest.SetManagementAction(firstIndex, true)
+
+	// when
+	modelUnderTest.ToggleAction(18, actions.GullyRestorationType)
+	sameYearValid, sameYearErrors := modelUnderTest.ChangeIsValid()
+	modelUnderTest.AcceptChange()
+
+	// then
+	g.Expect(sameYearValid).To(BeFalse())
+	g.Expect(sameYearErrors.Error()).To(ContainSubstring("in year [0]"))
+	g.Expect(modelUnderTest.ActionViolations().Error()).To(ContainSubstring("in year [0]"))
+
+	// when
+	modelUnderTest.SetManagementActionStartYear(secondIndex, 1)
+	laterYearValid, _ := modelUnderTest.StateIsValid()
+
+	// then
+	g.Expect(laterYearValid).To(BeTrue())
+	g.Expect(modelUnderTest.ActionViolations()).To(BeNil())
+}
+
+func TestCoreModel_YearlyBudget_ActivatingMove_StartsInEarliestYearWithRoom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parameters.Map{"PlanningHorizon": int64(3)}, g)
+	firstIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	secondIndex := indexOfAction(modelUnderTest, 18, actions.GullyRestorationType)
+	yearlyBudget := actions.ImplementationCostOf(modelUnderTest.ManagementActions()[firstIndex])
+	if secondCost := actions.ImplementationCostOf(modelUnderTest.ManagementActions()[secondIndex]); secondCost > yearlyBudget {
+		yearlyBudget = secondCost
+	}
+
+	modelUnderTest = buildModelUnderTest(buildTestingModelDataSet(g),
+		parameters.Map{"PlanningHorizon": int64(3), "MaximumYearlyImplementationCost": yearlyBudget}, g)
+	modelUnderTest.SetManagementAction(firstIndex, true)
+	secondAction := modelUnderTest.ManagementActions()[secondIndex]
+	valuesBefore := modelUnderTest.decisionVariableValues()
+
+	// when
+	modelUnderTest.tryMove(move.Move{Type: move.SingleToggleType, Actions: action.ManagementActions{secondAction}})
+	isValid, _ := modelUnderTest.ChangeIsValid()
+
+	// then
+	g.Expect(isValid).To(BeTrue())
+	g.Expect(secondAction.IsActive()).To(BeTrue())
+	g.Expect(secondAction.StartYear()).To(BeNumerically(equalTo, 1))
+
+	// when
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(secondAction.IsActive()).To(BeFalse())
+	g.Expect(secondAction.StartYear()).To(BeNumerically(equalTo, 0))
+	g.Expect(modelUnderTest.decisionVariableValues()).To(Equal(valuesBefore))
+}
+
+func TestCoreModel_YearlyBudget_InitialiseAllActive_WithinBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const yearlyBudget = 20_000.0
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":                 int64(4),
+		"MaximumYearlyImplementationCost": yearlyBudget,
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+
+	// when
+	modelUnderTest.InitialiseAllActionsToActive()
+
+	// then
+	g.Expect(len(modelUnderTest.ActiveManagementActions())).To(BeNumerically(">", 0))
+	for year, yearlyCost := range modelUnderTest.yearlyImplementationCosts() {
+		g.Expect(year).To(BeNumerically("<", 4))
+		g.Expect(yearlyCost).To(BeNumerically("<=", yearlyBudget))
+	}
+}
+
+func TestCoreModel_Schedule_LoadsRampInAndModelUnchanged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":     int64(4),
+		"EstablishmentPeriod": int64(2),
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	sedimentBeforeActions := modelUnderTest.DecisionVariable(sedimentproduction.VariableName).Value()
+	gullyIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	modelUnderTest.SetManagementAction(gullyIndex, true)
+	modelUnderTest.SetManagementActionStartYear(gullyIndex, 1)
+
+	valuesBefore := modelUnderTest.decisionVariableValues()
+	realisationBefore := modelUnderTest.ManagementActions()[gullyIndex].Realisation()
+
+	// when
+	scheduleUnderTest := modelUnderTest.Schedule()
+
+	// then
+	g.Expect(scheduleUnderTest.Years).To(HaveLen(4))
+	sedimentIn := func(year uint) float64 {
+		return scheduleUnderTest.Value(year, sedimentproduction.VariableName)
+	}
+	g.Expect(sedimentIn(0)).To(BeNumerically("~", sedimentBeforeActions, 1e-6))
+	g.Expect(sedimentIn(1)).To(BeNumerically("<", sedimentIn(0)))
+	g.Expect(sedimentIn(2)).To(BeNumerically("<", sedimentIn(1)))
+	g.Expect(sedimentIn(3)).To(BeNumerically("~", sedimentIn(2), 1e-6))
+
+	g.Expect(modelUnderTest.decisionVariableValues()).To(Equal(valuesBefore))
+	g.Expect(modelUnderTest.ManagementActions()[gullyIndex].Realisation()).To(Equal(realisationBefore))
+}
+
+func TestCoreModel_Schedule_PendingChangeStillRevertible(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":     int64(4),
+		"EstablishmentPeriod": int64(2),
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	gullyIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	modelUnderTest.SetManagementAction(gullyIndex, true)
+	riverBankAction := modelUnderTest.ManagementActions()[indexOfAction(modelUnderTest, 18, actions.RiverBankRestorationType)]
+	valuesBefore := modelUnderTest.decisionVariableValues()
+
+	modelUnderTest.ToggleAction(18, actions.RiverBankRestorationType)
+
+	// when
+	modelUnderTest.Schedule()
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(riverBankAction.IsActive()).To(BeFalse())
+	for name, valueBefore := range valuesBefore {
+		g.Expect(modelUnderTest.DecisionVariable(name).Value()).To(BeNumerically("~", valueBefore, 1e-6))
+	}
+}
+
+func TestCoreModel_NoPlanningHorizon_NoSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+
+	// then
+	g.Expect(modelUnderTest.Schedule()).To(BeNil())
+}
+
+func TestCoreModel_SchedulingWithoutPlanningHorizon_ParameterErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	unscheduledParameters := []parameters.Map{
+		{"RescheduleMoveProbability": 0.1},
+		{"EstablishmentPeriod": int64(2)},
+		{"DiscountRate": 0.05},
+		{"MaximumYearlyImplementationCost": 1_000.0},
+	}
+
+	for _, parametersUnderTest := range unscheduledParameters {
+		parameterErrors := buildInvalidModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+		g.Expect(parameterErrors.Error()).To(ContainSubstring("requires [PlanningHorizon]"))
+	}
+}
+
+func TestCoreModel_PlanningHorizon_CompressionRoundTripsStartYears(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	parametersUnderTest := parameters.Map{
+		"PlanningHorizon":     int64(5),
+		"EstablishmentPeriod": int64(2),
+	}
+	modelUnderTest := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	gullyIndex := indexOfAction(modelUnderTest, 17, actions.GullyRestorationType)
+	modelUnderTest.SetManagementAction(gullyIndex, true)
+	modelUnderTest.SetManagementActionStartYear(gullyIndex, 3)
+
+	compressor := new(archive.ModelCompressor)
+	compressedModel := compressor.Compress(modelUnderTest)
+
+	otherModel := buildModelUnderTest(buildTestingModelDataSet(g), parametersUnderTest, g)
+	decodedState := compressor.Compress(otherModel)
+	g.Expect(decodedState.Decode(compressedModel.Encoding())).To(BeNil())
+
+	// when
+	compressor.Decompress(decodedState, otherModel)
+
+	// then
+	g.Expect(decodedState.ActionStartYear(gullyIndex)).To(BeNumerically(equalTo, 3))
+	g.Expect(otherModel.ManagementActions()[gullyIndex].StartYear()).To(BeNumerically(equalTo, 3))
+	g.Expect(otherModel.IsEquivalentTo(modelUnderTest)).To(BeTrue())
+}
+
+func indexOfAction(modelUnderTest *CoreModel, planningUnit planningunit.Id, actionType action.ManagementActionType) int {
+	for index, managementAction := range modelUnderTest.ManagementActions() {
+		if managementAction.PlanningUnit() == planningUnit && managementAction.Type() == actionType {
+			return index
+		}
+	}
+	return -1
+}
+
+func startYearsOf(managementActions []action.ManagementAction) []uint {
+	startYears := make([]uint, len(managementActions))
+	for index, managementAction := range managementActions {
+		startYears[index] = managementAction.StartYear()
+	}
+	return startYears
+}
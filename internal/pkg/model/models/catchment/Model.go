@@ -12,7 +12,9 @@ import (
 	"strings"
 
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/xlsx"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/constraint"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	baseParameters "github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
@@ -20,6 +22,7 @@ import (
 )
 
 var _ model.Model = NewModel()
+var _ model.Sampleable = NewModel()
 
 func NewModel() *Model {
 	newModel := new(Model)
@@ -28,6 +31,7 @@ func NewModel() *Model {
 
 	newModel.parameters.Initialise()
 	newModel.managementActions.Initialise()
+	newModel.constraints = constraint.New()
 	newModel.ContainedDecisionVariables.Initialise()
 
 	return newModel
@@ -82,7 +86,9 @@ func (m *Model) loadSourceDataSet() error {
 	switch pathExtension {
 	case ".csv":
 		return m.loadCsvSourceDataSet(dataSourcePath)
-	case ".xlsx":
+	case ".xlsx", ".xlsm":
+		return m.loadXlsxSourceDataSet(dataSourcePath)
+	case ".xls":
 		return m.loadExcelSourceDataSet(dataSourcePath)
 	default:
 		return errors2.New("Source data file not supported: Initialisation failed")
@@ -103,6 +109,22 @@ func (m *Model) loadCsvSourceDataSet(dataSourcePath string) error {
 	return nil
 }
 
+func (m *Model) loadXlsxSourceDataSet(dataSourcePath string) error {
+	dataSet := xlsx.NewDataSet("DataSetImpl")
+
+	loadError := dataSet.Load(dataSourcePath)
+	if loadError != nil {
+		return loadError
+	}
+
+	m.sourceDataSet = dataSet
+	m.WithSourceDataSet(m.sourceDataSet)
+
+	return nil
+}
+
+// loadExcelSourceDataSet loads legacy binary workbooks via Excel itself, so is limited to Windows desktops with Excel
+// installed.
 func (m *Model) loadExcelSourceDataSet(dataSourcePath string) error {
 	dataSet := excel.NewDataSet("DataSetImpl", m.oleFunctionWrapper)
 
@@ -131,6 +153,45 @@ func (m *Model) DeepClone() model.Model {
 	return &clone
 }
 
+// SourceParameters returns a copy of the parameters the model was built from.
+func (m *Model) SourceParameters() baseParameters.Map {
+	return m.parameters.Values()
+}
+
+// SourceDataSet returns the data set the model was built from, once loaded by initialisation.
+func (m *Model) SourceDataSet() dataset.DataSet {
+	return m.sourceDataSet
+}
+
+// Resampled returns a new as-is model built from the parameters and data set supplied, in place of those this model
+// was built from, or an error if the parameters are invalid.
+func (m *Model) Resampled(params baseParameters.Map, dataSet dataset.DataSet) (model.Model, error) {
+	sample := NewModel().
+		WithOleFunctionWrapper(m.oleFunctionWrapper).
+		WithParameters(params)
+	sample.SetName(m.Name())
+
+	if paramErrors := sample.ParameterErrors(); paramErrors != nil {
+		return nil, paramErrors
+	}
+
+	sample.sourceDataSet = dataSet
+	sample.sourceDataLoaded = true
+	sample.CoreModel.WithSourceDataSet(dataSet)
+
+	sample.Initialise(model.AsIs)
+	if paramErrors := sample.ParameterErrors(); paramErrors != nil {
+		return nil, paramErrors
+	}
+	return sample, nil
+}
+
+// ValidateParameter returns the reason the value supplied is invalid for the named parameter, or nil if valid.
+func (m *Model) ValidateParameter(name string, value interface{}) error {
+	return m.parameters.Validate(name, value)
+}
+
 func (m *Model) TearDown() {
+	m.CoreModel.TearDown()
 	m.sourceDataSet.Teardown()
 }
@@ -0,0 +1,41 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package catchment
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	baseParameters "github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	explorerFixturePath = "../../../../../cmd/cremexplorer/testdata/testInputExcelDataSet.xlsx"
+	engineFixturePath   = "../../../../../cmd/cremengine/testdata/testInputExcelDataSet.xlsx"
+)
+
+func TestModel_Initialise_ExplorerFixture_NoErrors(t *testing.T) {
+	verifyFixtureInitialises(t, explorerFixturePath)
+}
+
+func TestModel_Initialise_EngineFixture_NoErrors(t *testing.T) {
+	verifyFixtureInitialises(t, engineFixturePath)
+}
+
+func verifyFixtureInitialises(t *testing.T, fixturePath string) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := NewModel().WithParameters(baseParameters.Map{parameters.DataSourcePath: fixturePath})
+	g.Expect(modelUnderTest.ParameterErrors()).To(BeNil())
+
+	// when
+	initialisation := func() { modelUnderTest.Initialise(model.AsIs) }
+
+	// then
+	g.Expect(initialisation).To(Not(Panic()))
+	g.Expect(modelUnderTest.ParameterErrors()).To(BeNil())
+	g.Expect(modelUnderTest.ManagementActions()).To(Not(BeEmpty()))
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package catchment
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvednitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/implementationcost"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/opportunitycost"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatenitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/sedimentproduction"
+	"github.com/LindsayBradford/crem/internal/pkg/model/schedule"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+var _ model.Scheduled = new(CoreModel)
+var _ model.Schedulable = new(CoreModel)
+
+// Names of the values reported in the model's yearly schedule, beyond those named for its decision variables.
+const (
+	DiscountedImplementationCost = "Discounted" + implementationcost.VariableName
+	DiscountedOpportunityCost    = "Discounted" + opportunitycost.VariableName
+)
+
+func (m *CoreModel) validatePlanningHorizon() {
+	if m.parameters.GetInt64(parameters.PlanningHorizon) > 0 {
+		return
+	}
+
+	requireHorizonFor := func(parameterName string) {
+		errorText := fmt.Sprintf("[%s] requires [%s] to be set.", parameterName, parameters.PlanningHorizon)
+		m.parameters.AddValidationErrorMessage(errorText)
+	}
+
+	if m.parameters.GetFloat64(parameters.RescheduleMoveProbability) > 0 {
+		requireHorizonFor(parameters.RescheduleMoveProbability)
+	}
+	if m.parameters.GetInt64(parameters.EstablishmentPeriod) > 0 {
+		requireHorizonFor(parameters.EstablishmentPeriod)
+	}
+	if m.parameters.GetFloat64(parameters.DiscountRate) > 0 {
+		requireHorizonFor(parameters.DiscountRate)
+	}
+	if m.parameters.HasEntry(parameters.MaximumYearlyImplementationCost) {
+		requireHorizonFor(parameters.MaximumYearlyImplementationCost)
+	}
+}
+
+func (m *CoreModel) buildHorizon() {
+	m.horizon = schedule.Horizon{
+		Years:              uint(m.parameters.GetInt64(parameters.PlanningHorizon)),
+		EstablishmentYears: uint(m.parameters.GetInt64(parameters.EstablishmentPeriod)),
+		DiscountRate:       m.parameters.GetFloat64(parameters.DiscountRate),
+	}
+
+	m.yearlyBudget = variable.Bounds{}
+	if m.parameters.HasEntry(parameters.MaximumYearlyImplementationCost) {
+		m.yearlyBudget.SetMaximum(m.parameters.GetFloat64(parameters.MaximumYearlyImplementationCost))
+	}
+}
+
+// scheduleActionsAtHorizonStart starts every management action in the first year of the planning horizon, realising
+// as much of its effect as the horizon allows an action started then.
+func (m *CoreModel) scheduleActionsAtHorizonStart() {
+	if !m.horizon.IsSet() {
+		return
+	}
+	for _, managementAction := range m.managementActions.Actions() {
+		managementAction.SetScheduleUnobserved(0, m.horizon.Realisation(0))
+	}
+}
+
+func (m *CoreModel) hasYearlyBudget() bool {
+	return m.parameters.HasEntry(parameters.MaximumYearlyImplementationCost)
+}
+
+// yearlyImplementationCosts returns the undiscounted cost of implementing the model's active management actions,
+// summed by the year each action starts in.
+func (m *CoreModel) yearlyImplementationCosts() map[uint]float64 {
+	yearlyCosts := make(map[uint]float64)
+	for _, activeAction := range m.managementActions.ActiveActions() {
+		yearlyCosts[activeAction.StartYear()] += actions.ImplementationCostOf(activeAction) * activeAction.Intensity()
+	}
+	return yearlyCosts
+}
+
+func (m *CoreModel) yearlyBudgetChecker(validationErrors *compositeErrors.CompositeError) {
+	if !m.hasYearlyBudget() {
+		return
+	}
+	yearlyCosts := m.yearlyImplementationCosts()
+	for year := uint(0); year < m.horizon.Years; year++ {
+		yearlyCost := yearlyCosts[year]
+		if !m.yearlyBudget.WithinBounds(yearlyCost) {
+			message := fmt.Sprintf("%s in year [%d] %s", implementationcost.VariableName, year,
+				m.yearlyBudget.BoundErrorAsText(yearlyCost))
+			validationErrors.AddMessage(message)
+		}
+	}
+}
+
+// earliestYearWithRoomFor returns the earliest year of the planning horizon whose budget, given the yearly costs
+// supplied, has room left for an action costing actionCost, reporting false if no year does.
+func (m *CoreModel) earliestYearWithRoomFor(actionCost float64, yearlyCosts map[uint]float64) (uint, bool) {
+	for year := uint(0); year < m.horizon.Years; year++ {
+		if m.yearlyBudget.WithinBounds(yearlyCosts[year] + actionCost) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// scheduleWithinYearlyBudget starts each active management action in turn in the earliest year with budget left for
+// it, deactivating any action that no year has room for.
+func (m *CoreModel) scheduleWithinYearlyBudget() {
+	if !m.hasYearlyBudget() {
+		return
+	}
+
+	m.note("Scheduling active actions within yearly implementation cost limit")
+	yearlyCosts := make(map[uint]float64)
+	for _, activeAction := range m.managementActions.ActiveActions() {
+		actionCost := actions.ImplementationCostOf(activeAction) * activeAction.Intensity()
+		year, hasRoom := m.earliestYearWithRoomFor(actionCost, yearlyCosts)
+		if !hasRoom {
+			activeAction.InitialisingDeactivation()
+			continue
+		}
+		m.scheduleAction(activeAction, year)
+		yearlyCosts[year] += actionCost
+	}
+	m.deactivateActionsBreakingConstraints()
+}
+
+// scheduleNewlyActivated starts the just-activated management action supplied in the earliest year with budget left
+// for it, reporting false if no year has room.
+func (m *CoreModel) scheduleNewlyActivated(activatedAction action.ManagementAction) bool {
+	if !m.hasYearlyBudget() {
+		return true
+	}
+
+	actionCost := actions.ImplementationCostOf(activatedAction) * activatedAction.Intensity()
+	yearlyCosts := m.yearlyImplementationCosts()
+	yearlyCosts[activatedAction.StartYear()] -= actionCost
+
+	year, hasRoom := m.earliestYearWithRoomFor(actionCost, yearlyCosts)
+	if hasRoom {
+		m.scheduleAction(activatedAction, year)
+	}
+	return hasRoom
+}
+
+// scheduleForActivation starts the inactive management action supplied, about to be activated at the intensity given
+// by a move, in the earliest year with budget left for it. Being inactive, the action's new start year changes no
+// decision variable until it is activated. If no year has room, the start year is left as is, for the yearly budget
+// check to reject the move.
+func (m *CoreModel) scheduleForActivation(inactiveAction action.ManagementAction, intensity float64) {
+	if !m.hasYearlyBudget() || inactiveAction.IsActive() || intensity <= 0 {
+		return
+	}
+
+	actionCost := actions.ImplementationCostOf(inactiveAction) * intensity
+	year, hasRoom := m.earliestYearWithRoomFor(actionCost, m.yearlyImplementationCosts())
+	if hasRoom && year != inactiveAction.StartYear() {
+		inactiveAction.SetScheduleUnobserved(year, m.horizon.Realisation(year))
+	}
+}
+
+func (m *CoreModel) scheduleAction(managementAction action.ManagementAction, startYear uint) {
+	if managementAction.StartYear() == startYear {
+		return
+	}
+	managementAction.SetSchedule(startYear, m.horizon.Realisation(startYear))
+	m.ContainedDecisionVariables.AcceptAll()
+}
+
+// SetManagementActionStartYear starts the management action at the index supplied in the year given, accepting the
+// resulting change. Start years are ignored for models without a planning horizon.
+func (m *CoreModel) SetManagementActionStartYear(index int, startYear uint) {
+	if !m.horizon.IsSet() || m.ManagementActions()[index].StartYear() == startYear {
+		return
+	}
+	m.managementActions.ScheduleAt(m.ManagementActions()[index], startYear, m.horizon.Realisation(startYear))
+	m.AcceptChange()
+}
+
+// Horizon returns the planning horizon that the model's management actions are scheduled over.
+func (m *CoreModel) Horizon() schedule.Horizon {
+	return m.horizon
+}
+
+// Schedule returns, for each year of the planning horizon, the costs incurred by management actions starting that
+// year, and the loads produced given how far each active action has ramped in by that year, or nil if the model has
+// no planning horizon.
+func (m *CoreModel) Schedule() *schedule.Schedule {
+	if !m.horizon.IsSet() {
+		return nil
+	}
+
+	yearlySchedule := schedule.New(
+		implementationcost.VariableName, DiscountedImplementationCost,
+		opportunitycost.VariableName, DiscountedOpportunityCost,
+		sedimentproduction.VariableName, particulatenitrogen.VariableName, dissolvednitrogen.VariableName,
+	)
+
+	yearlyLoads := m.yearlyLoads()
+	for year := uint(0); year < m.horizon.Years; year++ {
+		implementationCost, opportunityCost := m.costsStartingIn(year)
+		discountFactor := m.horizon.DiscountFactor(year)
+		loads := yearlyLoads[year]
+
+		yearlySchedule.AddYear(year,
+			implementationCost, implementationCost*discountFactor,
+			opportunityCost, opportunityCost*discountFactor,
+			loads[sedimentproduction.VariableName],
+			loads[particulatenitrogen.VariableName],
+			loads[dissolvednitrogen.VariableName],
+		)
+	}
+	return yearlySchedule
+}
+
+func (m *CoreModel) costsStartingIn(year uint) (implementationCost float64, opportunityCost float64) {
+	for _, activeAction := range m.managementActions.ActiveActions() {
+		if activeAction.StartYear() != year {
+			continue
+		}
+		implementationCost += actions.ImplementationCostOf(activeAction) * activeAction.Intensity()
+		opportunityCost += actions.OpportunityCostOf(activeAction) * activeAction.Intensity()
+	}
+	return implementationCost, opportunityCost
+}
+
+// yearlyLoads returns decision variable values for each year of the planning horizon. Each active action is, in turn
+// for each year, realised only as far as it has ramped in by that year. Realisations are changed on a clone of the
+// model, so that the model itself (including any change being tried on it) is left as found.
+func (m *CoreModel) yearlyLoads() []map[string]float64 {
+	clone := m.scheduleClone()
+	clone.initialising = true
+
+	activeActions := clone.managementActions.ActiveActions()
+	loads := make([]map[string]float64, clone.horizon.Years)
+	for year := uint(0); year < clone.horizon.Years; year++ {
+		for _, activeAction := range activeActions {
+			rampProportion := clone.horizon.RampProportion(activeAction.StartYear(), year)
+			if activeAction.Realisation() == rampProportion {
+				continue
+			}
+			activeAction.SetSchedule(activeAction.StartYear(), rampProportion)
+			clone.ContainedDecisionVariables.AcceptAll()
+		}
+		loads[year] = clone.decisionVariableValues()
+	}
+	return loads
+}
+
+// scheduleClone returns a model built afresh from the parameters and data set of this model, with each management
+// action at the intensity and start year of its counterpart here.
+func (m *CoreModel) scheduleClone() *CoreModel {
+	clone := NewCoreModel()
+	clone.parameters.AssignAllUserValues(m.parameters.Values())
+	clone.inputDataSet = m.inputDataSet
+	clone.Initialise(model.AsIs)
+	clone.SynchroniseTo(m)
+	return clone
+}
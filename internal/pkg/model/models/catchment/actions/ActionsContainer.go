@@ -5,6 +5,7 @@ package actions
 import (
 	"fmt"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 	"strconv"
@@ -14,23 +15,6 @@ import (
 type ActionType string
 
 const (
-	subCatchmentIndex                = 0
-	filterIndex                      = 1
-	opportunityCostIndex             = 2
-	implementationCostIndex          = 3
-	particulateNitrogenOriginalIndex = 4
-	particulateNitrogenActionedIndex = 5
-	hillSlopeErosionOriginalIndex    = 6
-	hillSlopeErosionActionedIndex    = 7
-	fineSedimentOriginalIndex        = 8
-	fineSedimentActionedIndex        = 9
-
-	dissolvedNitrogenOriginalIndex            = 10
-	dissolvedNitrogenActionedIndex            = 11
-	dissolvedNitrogenRemovalEfficiencyIndex   = 12
-	particulateNitrogenRemovalEfficiencyIndex = 13
-	sedimentRemovalEfficiencyIndex            = 14
-
 	RiparianType  ActionType = "Riparian"
 	HillSlopeType ActionType = "Hillslope"
 	GullyType     ActionType = "Gully"
@@ -52,11 +36,13 @@ const (
 	DissolvedNitrogenRemovalEfficiency   = "DissolvedNitrogenRemovalEfficiency"
 	ParticulateNitrogenRemovalEfficiency = "ParticulateNitrogenRemovalEfficiency"
 	SedimentRemovalEfficiency            = "SedimentRemovalEfficiency"
+	IntensityLevelsAttribute             = "IntensityLevels"
 )
 
 type Container struct {
 	filter     ActionType
 	actionsMap map[string]float64
+	levelsMap  map[string][]float64
 }
 
 func (c *Container) WithFilter(filter ActionType) *Container {
@@ -66,41 +52,48 @@ func (c *Container) WithFilter(filter ActionType) *Container {
 
 func (c *Container) WithActionsTable(actionsTable tables.CsvTable) *Container {
 	_, rowCount := actionsTable.ColumnAndRowSize()
+	columns := dataset.ColumnsOf(actionsTable)
 	c.actionsMap = make(map[string]float64, 0)
+	c.levelsMap = make(map[string][]float64, 0)
 
 	for rowNumber := uint(0); rowNumber < rowCount; rowNumber++ {
 
-		sourceType := ActionType(actionsTable.CellString(filterIndex, rowNumber))
+		sourceType := ActionType(actionsTable.CellString(columns.Index(dataset.ActionTypeHeading), rowNumber))
 		if c.filter != UndefinedType && sourceType != c.filter {
 			continue
 		}
 
-		subCatchment := planningunit.Id(actionsTable.CellFloat64(subCatchmentIndex, rowNumber))
+		subCatchment := planningunit.Id(actionsTable.CellFloat64(columns.Index(dataset.SubcatchmentHeading), rowNumber))
 
-		mapAttribute := func(index uint, attribute string) {
-			value := actionsTable.CellFloat64(index, rowNumber)
+		mapAttribute := func(heading string, attribute string) {
+			value := actionsTable.CellFloat64(columns.Index(heading), rowNumber)
 			mapKey := c.DeriveMapKey(subCatchment, sourceType, attribute)
 			c.actionsMap[mapKey] = value
 		}
 
-		mapAttribute(opportunityCostIndex, OpportunityCostAttribute)
-		mapAttribute(implementationCostIndex, ImplementationCostAttribute)
+		mapAttribute(dataset.OpportunityCostHeading, OpportunityCostAttribute)
+		mapAttribute(dataset.ImplementationCostHeading, ImplementationCostAttribute)
+
+		mapAttribute(dataset.ParticulateNitrogenOriginalHeading, ParticulateNitrogenOriginalAttribute)
+		mapAttribute(dataset.ParticulateNitrogenActionedHeading, ParticulateNitrogenActionedAttribute)
 
-		mapAttribute(particulateNitrogenOriginalIndex, ParticulateNitrogenOriginalAttribute)
-		mapAttribute(particulateNitrogenActionedIndex, ParticulateNitrogenActionedAttribute)
+		mapAttribute(dataset.HillslopeErosionOriginalHeading, HillSlopeErosionOriginalAttribute)
+		mapAttribute(dataset.HillslopeErosionActionedHeading, HillSlopeErosionActionedAttribute)
 
-		mapAttribute(hillSlopeErosionOriginalIndex, HillSlopeErosionOriginalAttribute)
-		mapAttribute(hillSlopeErosionActionedIndex, HillSlopeErosionActionedAttribute)
+		mapAttribute(dataset.FineSedimentOriginalHeading, FineSedimentOriginalAttribute)
+		mapAttribute(dataset.FineSedimentActionedHeading, FineSedimentActionedAttribute)
 
-		mapAttribute(fineSedimentOriginalIndex, FineSedimentOriginalAttribute)
-		mapAttribute(fineSedimentActionedIndex, FineSedimentActionedAttribute)
+		mapAttribute(dataset.DissolvedNitrogenOriginalHeading, DissolvedNitrogenOriginalAttribute)
+		mapAttribute(dataset.DissolvedNitrogenActionedHeading, DissolvedNitrogenActionedAttribute)
 
-		mapAttribute(dissolvedNitrogenOriginalIndex, DissolvedNitrogenOriginalAttribute)
-		mapAttribute(dissolvedNitrogenActionedIndex, DissolvedNitrogenActionedAttribute)
+		mapAttribute(dataset.DissolvedNitrogenRemovalEfficiencyHeading, DissolvedNitrogenRemovalEfficiency)
+		mapAttribute(dataset.ParticulateNitrogenRemovalEfficiencyHeading, ParticulateNitrogenRemovalEfficiency)
+		mapAttribute(dataset.SedimentRemovalEfficiencyHeading, SedimentRemovalEfficiency)
 
-		mapAttribute(dissolvedNitrogenRemovalEfficiencyIndex, DissolvedNitrogenRemovalEfficiency)
-		mapAttribute(particulateNitrogenRemovalEfficiencyIndex, ParticulateNitrogenRemovalEfficiency)
-		mapAttribute(sedimentRemovalEfficiencyIndex, SedimentRemovalEfficiency)
+		if columns.Has(dataset.IntensityLevelsHeading) {
+			levels, _ := dataset.ParseIntensityLevels(actionsTable.CellString(columns.Index(dataset.IntensityLevelsHeading), rowNumber))
+			c.levelsMap[c.DeriveMapKey(subCatchment, sourceType, IntensityLevelsAttribute)] = levels
+		}
 	}
 	return c
 }
@@ -223,6 +216,13 @@ func (c *Container) sedimentNitrogenRemovalEfficiency(planningUnit planningunit.
 	return c.actionsMap[key]
 }
 
+// intensityLevels returns the intensity levels listed for the planning unit's action, or none where the action is
+// either off or applied in full.
+func (c *Container) intensityLevels(planningUnit planningunit.Id) []float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, IntensityLevelsAttribute)
+	return c.levelsMap[key]
+}
+
 func (c *Container) Map() map[string]float64 {
 	return c.actionsMap
 }
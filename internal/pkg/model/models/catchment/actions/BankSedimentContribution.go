@@ -7,37 +7,27 @@ import (
 	"math"
 
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 )
 
-const (
-	planningUnitIndex    = 0
-	riverLengthIndex     = 2
-	channelSlopeIndex    = 3
-	bankFullFlowIndex    = 4
-	bankHeightIndex      = 6
-	floodPlainWidthIndex = 7
-
-	riparianVegetationIndex = 8
-	subCatchmentAreaIndex   = 9
-	riparianBufferAreaIndex = 10
-)
-
 type sedimentTracker struct {
 	partialSedimentContribution      float64
 	originalIntactRiparianVegetation float64
 }
 
 type BankSedimentContribution struct {
-	planningUnitTable tables.CsvTable
-	parameters        parameters.Parameters
+	planningUnitTable   tables.CsvTable
+	planningUnitColumns dataset.Columns
+	parameters          parameters.Parameters
 
 	contributionMap map[planningunit.Id]sedimentTracker
 }
 
 func (bsc *BankSedimentContribution) Initialise(planningUnitTable tables.CsvTable, parameters parameters.Parameters) {
 	bsc.planningUnitTable = planningUnitTable
+	bsc.planningUnitColumns = dataset.ColumnsOf(planningUnitTable)
 	bsc.parameters = parameters
 	bsc.populateContributionMap()
 }
@@ -52,7 +42,7 @@ func (bsc *BankSedimentContribution) populateContributionMap() {
 }
 
 func (bsc *BankSedimentContribution) populateContributionMapEntry(rowNumber uint) {
-	planningUnit := bsc.planningUnitTable.CellFloat64(planningUnitIndex, rowNumber)
+	planningUnit := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	mapKey := planningunit.Float64ToId(planningUnit)
 
 	bsc.contributionMap[mapKey] = sedimentTracker{
@@ -62,8 +52,8 @@ func (bsc *BankSedimentContribution) populateContributionMapEntry(rowNumber uint
 }
 
 func (bsc *BankSedimentContribution) partialBankSedimentContribution(rowNumber uint) float64 {
-	riverLength := bsc.planningUnitTable.CellFloat64(riverLengthIndex, rowNumber)
-	bankHeight := bsc.planningUnitTable.CellFloat64(bankHeightIndex, rowNumber)
+	riverLength := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.ChannelLengthHeading), rowNumber)
+	bankHeight := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.ChannelDepthHeading), rowNumber)
 
 	sedimentDensity := bsc.parameters.GetFloat64(parameters.SedimentDensity)
 	suspendedSedimentProportion := bsc.parameters.GetFloat64(parameters.SuspendedSedimentProportion)
@@ -72,14 +62,14 @@ func (bsc *BankSedimentContribution) partialBankSedimentContribution(rowNumber u
 
 	waterDensity := bsc.parameters.GetFloat64(parameters.WaterDensity)
 	localAcceleration := bsc.parameters.GetFloat64(parameters.LocalAcceleration)
-	bankFullFlow := bsc.planningUnitTable.CellFloat64(bankFullFlowIndex, rowNumber)
-	channelSlope := bsc.planningUnitTable.CellFloat64(channelSlopeIndex, rowNumber)
+	bankFullFlow := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.BankfullFlowHeading), rowNumber)
+	channelSlope := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.ChannelSlopeHeading), rowNumber)
 
 	channelDischarge := waterDensity * localAcceleration * bankFullFlow * channelSlope
 
 	riparianVegetationImpact := float64(1) // This is the value that changes as we anneal, leaving in formula for now for traceability.
 
-	floodPlainWidth := bsc.planningUnitTable.CellFloat64(floodPlainWidthIndex, rowNumber)
+	floodPlainWidth := bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.FloodplainWidthHeading), rowNumber)
 	floodPlainWidthRelationship := 1 - math.Exp(-1.5*math.Pow(10, -2.0)*floodPlainWidth)
 
 	return bankErosionFudgeFactor * channelDischarge * riparianVegetationImpact *
@@ -87,7 +77,7 @@ func (bsc *BankSedimentContribution) partialBankSedimentContribution(rowNumber u
 }
 
 func (bsc *BankSedimentContribution) originalIntactRiparianVegetation(rowNumber uint) float64 {
-	return bsc.planningUnitTable.CellFloat64(riparianVegetationIndex, rowNumber)
+	return bsc.planningUnitTable.CellFloat64(bsc.planningUnitColumns.Index(dataset.ProportionOfRiparianVegetationHeading), rowNumber)
 }
 
 func (bsc *BankSedimentContribution) OriginalSedimentContribution() float64 {
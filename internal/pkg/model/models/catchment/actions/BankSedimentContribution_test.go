@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	. "github.com/onsi/gomega"
 )
@@ -15,7 +16,19 @@ const expectedRiparianVegetationProportion = float64(0.5)
 const equalTo = "=="
 
 const expectedRowNumber = 3
-const expectedColumnNumber = 11
+
+// testTableHeadings are deliberately out of the GIS export's column order, as columns are located by heading.
+var testTableHeadings = []string{
+	dataset.RiparianBufferAreaHeading,
+	dataset.ChannelSlopeHeading,
+	dataset.SubcatchmentHeading,
+	dataset.ProportionOfRiparianVegetationHeading,
+	dataset.FloodplainWidthHeading,
+	dataset.ChannelLengthHeading,
+	dataset.SubcatchmentAreaHeading,
+	dataset.BankfullFlowHeading,
+	dataset.ChannelDepthHeading,
+}
 
 const (
 	defaultRiverLength        = float64(5)
@@ -57,7 +70,7 @@ func TestBankSedimentContribution_Initialise(t *testing.T) {
 
 	// then
 	g.Expect(actualRows).To(BeNumerically(equalTo, expectedRowNumber))
-	g.Expect(actualColumns).To(BeNumerically(equalTo, expectedColumnNumber))
+	g.Expect(actualColumns).To(BeNumerically(equalTo, len(testTableHeadings)))
 
 	// when
 	contributionUnderTest.Initialise(testDataTable, *dummyParameters)
@@ -92,18 +105,20 @@ func TestBankSedimentContribution_OriginalSedimentContribution(t *testing.T) {
 
 func buildTestTable() tables.CsvTable {
 	newTable := new(tables.CsvTableImpl)
-	newTable.SetColumnAndRowSize(expectedColumnNumber, expectedRowNumber)
+	newTable.SetHeader(testTableHeadings)
+	newTable.SetColumnAndRowSize(uint(len(testTableHeadings)), expectedRowNumber)
+	columns := dataset.ColumnsOf(newTable)
 
 	for currentRow := uint(0); currentRow < expectedRowNumber; currentRow++ {
-		newTable.SetCell(planningUnitIndex, currentRow, float64(currentRow))
-		newTable.SetCell(riverLengthIndex, currentRow, defaultRiverLength)
-		newTable.SetCell(channelSlopeIndex, currentRow, defaultRiverSlope)
-		newTable.SetCell(bankHeightIndex, currentRow, defaultBankHeight)
-		newTable.SetCell(floodPlainWidthIndex, currentRow, defaultFloodPlainWidth)
-		newTable.SetCell(bankFullFlowIndex, currentRow, defaultBankFullFlow)
-		newTable.SetCell(riparianVegetationIndex, currentRow, expectedRiparianVegetationProportion)
-		newTable.SetCell(subCatchmentAreaIndex, currentRow, defaultPlanningUnitArea)
-		newTable.SetCell(riparianBufferAreaIndex, currentRow, defaultRiparianBufferArea)
+		newTable.SetCell(columns.Index(dataset.SubcatchmentHeading), currentRow, float64(currentRow))
+		newTable.SetCell(columns.Index(dataset.ChannelLengthHeading), currentRow, defaultRiverLength)
+		newTable.SetCell(columns.Index(dataset.ChannelSlopeHeading), currentRow, defaultRiverSlope)
+		newTable.SetCell(columns.Index(dataset.ChannelDepthHeading), currentRow, defaultBankHeight)
+		newTable.SetCell(columns.Index(dataset.FloodplainWidthHeading), currentRow, defaultFloodPlainWidth)
+		newTable.SetCell(columns.Index(dataset.BankfullFlowHeading), currentRow, defaultBankFullFlow)
+		newTable.SetCell(columns.Index(dataset.ProportionOfRiparianVegetationHeading), currentRow, expectedRiparianVegetationProportion)
+		newTable.SetCell(columns.Index(dataset.SubcatchmentAreaHeading), currentRow, defaultPlanningUnitArea)
+		newTable.SetCell(columns.Index(dataset.RiparianBufferAreaHeading), currentRow, defaultRiparianBufferArea)
 	}
 
 	return newTable
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+var managementActionTypes = map[ActionType]action.ManagementActionType{
+	GullyType:     GullyRestorationType,
+	HillSlopeType: HillSlopeRestorationType,
+	RiparianType:  RiverBankRestorationType,
+	WetlandType:   WetlandsEstablishmentType,
+}
+
+// ConstraintsFrom returns the constraints listed in the (already validated) constraints table supplied, or an error
+// naming any action types in the table that the catchment model doesn't offer.
+func ConstraintsFrom(constraintsTable tables.CsvTable) ([]constraint.Constraint, error) {
+	constraintErrors := compositeErrors.New("Catchment constraints")
+	columns := dataset.ColumnsOf(constraintsTable)
+
+	_, rowCount := constraintsTable.ColumnAndRowSize()
+	constraints := make([]constraint.Constraint, 0, rowCount)
+
+	for row := uint(0); row < rowCount; row++ {
+		cellOf := func(heading string) string {
+			return constraintsTable.CellString(columns.Index(heading), row)
+		}
+
+		actionTypeOf := func(heading string) action.ManagementActionType {
+			rawType := ActionType(strings.TrimSpace(cellOf(heading)))
+			managementActionType, isKnown := managementActionTypes[rawType]
+			if !isKnown {
+				message := fmt.Sprintf("%s table data row [%d] column [%s] has unknown action type [%s]",
+					dataset.ConstraintsTableName, row+1, heading, rawType)
+				constraintErrors.AddMessage(message)
+			}
+			return managementActionType
+		}
+
+		subcatchment, isEverySubcatchment, _ := dataset.ParseOptionalWholeNumber(cellOf(dataset.SubcatchmentHeading))
+
+		switch strings.TrimSpace(cellOf(dataset.ConstraintHeading)) {
+		case dataset.ExclusionConstraint:
+			exclusion := constraint.NewExclusion(actionTypeOf(dataset.ActionTypeHeading), actionTypeOf(dataset.OtherActionTypeHeading))
+			if !isEverySubcatchment {
+				exclusion.In(planningunit.Id(subcatchment))
+			}
+			constraints = append(constraints, exclusion)
+		case dataset.PrerequisiteConstraint:
+			prerequisite := constraint.NewPrerequisite(actionTypeOf(dataset.ActionTypeHeading), actionTypeOf(dataset.OtherActionTypeHeading))
+			if !isEverySubcatchment {
+				prerequisite.In(planningunit.Id(subcatchment))
+			}
+			if columns.Has(dataset.OtherSubcatchmentHeading) {
+				otherSubcatchment, isSameSubcatchment, _ := dataset.ParseOptionalWholeNumber(cellOf(dataset.OtherSubcatchmentHeading))
+				if !isSameSubcatchment {
+					prerequisite.RequiredIn(planningunit.Id(otherSubcatchment))
+				}
+			}
+			constraints = append(constraints, prerequisite)
+		case dataset.MaximumActionsConstraint:
+			limit, _, _ := dataset.ParseOptionalWholeNumber(cellOf(dataset.LimitHeading))
+			maximumActions := constraint.NewMaximumActions(int(limit))
+			if !isEverySubcatchment {
+				maximumActions.In(planningunit.Id(subcatchment))
+			}
+			constraints = append(constraints, maximumActions)
+		}
+	}
+
+	if constraintErrors.Size() > 0 {
+		return nil, constraintErrors
+	}
+	return constraints, nil
+}
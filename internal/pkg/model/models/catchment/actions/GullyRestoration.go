@@ -67,6 +67,11 @@ func (g *GullyRestoration) WithActionedDissolvedNitrogen(costInDollars float64)
 	return g.WithVariable(DissolvedNitrogenActionedAttribute, costInDollars)
 }
 
+func (g *GullyRestoration) WithIntensityLevels(levels ...float64) *GullyRestoration {
+	g.SimpleManagementAction.WithIntensityLevels(levels...)
+	return g
+}
+
 func (g *GullyRestoration) WithVariable(variableName action.ModelVariableName, value float64) *GullyRestoration {
 	g.SimpleManagementAction.WithVariable(variableName, value)
 	return g
@@ -73,5 +73,6 @@ func (g *GullyRestorationGroup) createManagementAction(planningUnit planningunit
 			WithOriginalDissolvedNitrogen(originalDissolvedNitrogen).
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
 			WithImplementationCost(costInDollars).
-			WithOpportunityCost(opportunityCostInDollars)
+			WithOpportunityCost(opportunityCostInDollars).
+			WithIntensityLevels(g.intensityLevels(planningUnit)...)
 }
@@ -4,17 +4,11 @@ package actions
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 )
 
-const (
-	gullyIdIndex             = 0
-	gulliesPlanningUnitIndex = 1
-	gullyErosionVolumeIndex  = 2
-	gullyChannelLength       = 3
-)
-
 type gullySedimentTracker struct {
 	GullyId            float64
 	SedimentProduction float64
@@ -22,14 +16,16 @@ type gullySedimentTracker struct {
 }
 
 type GullySedimentContribution struct {
-	gulliesTable tables.CsvTable
-	parameters   parameters.Parameters
+	gulliesTable   tables.CsvTable
+	gulliesColumns dataset.Columns
+	parameters     parameters.Parameters
 
 	contributionMap map[planningunit.Id][]gullySedimentTracker
 }
 
 func (bsc *GullySedimentContribution) Initialise(gulliesTable tables.CsvTable, parameters parameters.Parameters) {
 	bsc.gulliesTable = gulliesTable
+	bsc.gulliesColumns = dataset.ColumnsOf(gulliesTable)
 	bsc.parameters = parameters
 	bsc.populateContributionMap()
 }
@@ -44,11 +40,11 @@ func (bsc *GullySedimentContribution) populateContributionMap() {
 }
 
 func (bsc *GullySedimentContribution) populateContributionMapEntry(rowNumber uint) {
-	planningUnit := bsc.gulliesTable.CellFloat64(gulliesPlanningUnitIndex, rowNumber)
+	planningUnit := bsc.gulliesTable.CellFloat64(bsc.gulliesColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	mapKey := planningunit.Float64ToId(planningUnit)
 
 	newGullyTracker := gullySedimentTracker{
-		GullyId:            bsc.gulliesTable.CellFloat64(gullyIdIndex, rowNumber),
+		GullyId:            bsc.gulliesTable.CellFloat64(bsc.gulliesColumns.Index(dataset.GullyIdentifierHeading), rowNumber),
 		SedimentProduction: bsc.gullySediment(rowNumber),
 		ChannelLength:      bsc.channelLength(rowNumber),
 	}
@@ -62,7 +58,7 @@ func (bsc *GullySedimentContribution) populateContributionMapEntry(rowNumber uin
 }
 
 func (bsc *GullySedimentContribution) gullyVolume(rowNumber uint) float64 {
-	return bsc.gulliesTable.CellFloat64(gullyErosionVolumeIndex, rowNumber)
+	return bsc.gulliesTable.CellFloat64(bsc.gulliesColumns.Index(dataset.GullyVolumeHeading), rowNumber)
 }
 
 func (bsc *GullySedimentContribution) gullySediment(rowNumber uint) float64 {
@@ -86,7 +82,7 @@ func (bsc *GullySedimentContribution) SedimentFromVolume(gullyVolume float64) fl
 }
 
 func (bsc *GullySedimentContribution) channelLength(rowNumber uint) float64 {
-	return bsc.gulliesTable.CellFloat64(gullyChannelLength, rowNumber)
+	return bsc.gulliesTable.CellFloat64(bsc.gulliesColumns.Index(dataset.GullyChannelLengthHeading), rowNumber)
 }
 
 func (bsc *GullySedimentContribution) OriginalSedimentContribution() float64 {
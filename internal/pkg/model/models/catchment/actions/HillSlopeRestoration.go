@@ -63,6 +63,11 @@ func (h *HillSlopeRestoration) WithActionedDissolvedNitrogen(costInDollars float
 	return h.WithVariable(DissolvedNitrogenActionedAttribute, costInDollars)
 }
 
+func (h *HillSlopeRestoration) WithIntensityLevels(levels ...float64) *HillSlopeRestoration {
+	h.SimpleManagementAction.WithIntensityLevels(levels...)
+	return h
+}
+
 func (h *HillSlopeRestoration) WithVariable(variableName action.ModelVariableName, value float64) *HillSlopeRestoration {
 	h.SimpleManagementAction.WithVariable(variableName, value)
 	return h
@@ -5,14 +5,16 @@ package actions
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/pkg/math"
 )
 
 type HillSlopeRestorationGroup struct {
-	planningUnitTable tables.CsvTable
-	parameters        parameters.Parameters
+	planningUnitTable   tables.CsvTable
+	planningUnitColumns dataset.Columns
+	parameters          parameters.Parameters
 
 	actionMap map[planningunit.Id]*HillSlopeRestoration
 	Container
@@ -25,6 +27,7 @@ func (h *HillSlopeRestorationGroup) WithParameters(parameters parameters.Paramet
 
 func (h *HillSlopeRestorationGroup) WithPlanningUnitTable(planningUnitTable tables.CsvTable) *HillSlopeRestorationGroup {
 	h.planningUnitTable = planningUnitTable
+	h.planningUnitColumns = dataset.ColumnsOf(planningUnitTable)
 	return h
 }
 
@@ -52,7 +55,7 @@ func (h *HillSlopeRestorationGroup) createManagementActions() {
 }
 
 func (h *HillSlopeRestorationGroup) createManagementAction(rowNumber uint) {
-	planningUnit := h.planningUnitTable.CellFloat64(planningUnitIndex, rowNumber)
+	planningUnit := h.planningUnitTable.CellFloat64(h.planningUnitColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	planningUnitAsId := planningunit.Float64ToId(planningUnit)
 
 	originalBufferVegetation := h.originalBufferVegetation(rowNumber)
@@ -87,7 +90,8 @@ func (h *HillSlopeRestorationGroup) createManagementAction(rowNumber uint) {
 			WithOriginalDissolvedNitrogen(originalDissolvedNitrogen).
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
 			WithOpportunityCost(opportunityCostInDollars).
-			WithImplementationCost(implementationCostInDollars)
+			WithImplementationCost(implementationCostInDollars).
+			WithIntensityLevels(h.intensityLevels(planningUnitAsId)...)
 }
 
 func (h *HillSlopeRestorationGroup) actionNeededFor(planningUnit planningunit.Id, worstCaseRiparianFilter float64) bool {
@@ -107,7 +111,7 @@ func (h *HillSlopeRestorationGroup) actionNeededFor(planningUnit planningunit.Id
 }
 
 func (h *HillSlopeRestorationGroup) originalBufferVegetation(rowNumber uint) float64 {
-	proportionOfRiparianVegetation := h.planningUnitTable.CellFloat64(riparianVegetationIndex, rowNumber)
+	proportionOfRiparianVegetation := h.planningUnitTable.CellFloat64(h.planningUnitColumns.Index(dataset.ProportionOfRiparianVegetationHeading), rowNumber)
 	return proportionOfRiparianVegetation
 }
 
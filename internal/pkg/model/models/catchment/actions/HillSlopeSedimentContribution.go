@@ -8,10 +8,6 @@ import (
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 )
 
-const (
-	hillSlopeAreaIndex = 11
-)
-
 type hillSlopeSedimentTracker struct {
 	area                     float64
 	originalSedimentProduced float64
@@ -19,8 +15,9 @@ type hillSlopeSedimentTracker struct {
 }
 
 type HillSlopeSedimentContribution struct {
-	planningUnitTable tables.CsvTable
-	parameters        parameters.Parameters
+	planningUnitTable   tables.CsvTable
+	planningUnitColumns dataset.Columns
+	parameters          parameters.Parameters
 
 	contributionMap       map[planningunit.Id]hillSlopeSedimentTracker
 	sedimentDeliveryRatio float64
@@ -30,6 +27,7 @@ type HillSlopeSedimentContribution struct {
 
 func (h *HillSlopeSedimentContribution) Initialise(dataSet *dataset.DataSetImpl, parameters parameters.Parameters) {
 	h.planningUnitTable = dataSet.SubCatchmentsTable
+	h.planningUnitColumns = dataset.ColumnsOf(dataSet.SubCatchmentsTable)
 	h.Container.WithFilter(HillSlopeType).WithActionsTable(dataSet.ActionsTable)
 	h.parameters = parameters
 	h.populateContributionMap()
@@ -46,7 +44,7 @@ func (h *HillSlopeSedimentContribution) populateContributionMap() {
 }
 
 func (h *HillSlopeSedimentContribution) populateContributionMapEntry(rowNumber uint) {
-	subCatchment := h.planningUnitTable.CellFloat64(planningUnitIndex, rowNumber)
+	subCatchment := h.planningUnitTable.CellFloat64(h.planningUnitColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	mapKey := planningunit.Float64ToId(subCatchment)
 
 	h.contributionMap[mapKey] = hillSlopeSedimentTracker{
@@ -57,7 +55,7 @@ func (h *HillSlopeSedimentContribution) populateContributionMapEntry(rowNumber u
 }
 
 func (h *HillSlopeSedimentContribution) hillSlopeArea(rowNumber uint) float64 {
-	return h.planningUnitTable.CellFloat64(hillSlopeAreaIndex, rowNumber)
+	return h.planningUnitTable.CellFloat64(h.planningUnitColumns.Index(dataset.HillslopeAreaHeading), rowNumber)
 }
 
 func (h *HillSlopeSedimentContribution) OriginalSubCatchmentSedimentContribution(id planningunit.Id) float64 {
@@ -0,0 +1,48 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package actions
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/pkg/errors"
+)
+
+// ImplementationCostOf returns the cost, in dollars, of implementing the catchment management action supplied.
+func ImplementationCostOf(managementAction action.ManagementAction) float64 {
+	return managementAction.ModelVariableValue(implementationCostVariableOf(managementAction.Type()))
+}
+
+func implementationCostVariableOf(actionType action.ManagementActionType) action.ModelVariableName {
+	switch actionType {
+	case RiverBankRestorationType:
+		return RiverBankRestorationCost
+	case GullyRestorationType:
+		return GullyRestorationCost
+	case HillSlopeRestorationType:
+		return HillSlopeRestorationCost
+	case WetlandsEstablishmentType:
+		return WetlandsEstablishmentCost
+	default:
+		panic(errors.New("No implementation cost for management action type [" + string(actionType) + "]"))
+	}
+}
+
+// OpportunityCostOf returns the opportunity cost, in dollars, of the catchment management action supplied.
+func OpportunityCostOf(managementAction action.ManagementAction) float64 {
+	return managementAction.ModelVariableValue(opportunityCostVariableOf(managementAction.Type()))
+}
+
+func opportunityCostVariableOf(actionType action.ManagementActionType) action.ModelVariableName {
+	switch actionType {
+	case RiverBankRestorationType:
+		return RiverBankRestorationOpportunityCost
+	case GullyRestorationType:
+		return GullyRestorationOpportunityCost
+	case HillSlopeRestorationType:
+		return HillSlopeRestorationOpportunityCost
+	case WetlandsEstablishmentType:
+		return WetlandsEstablishmentOpportunityCost
+	default:
+		panic(errors.New("No opportunity cost for management action type [" + string(actionType) + "]"))
+	}
+}
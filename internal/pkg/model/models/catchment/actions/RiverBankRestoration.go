@@ -91,6 +91,11 @@ func (r *RiverBankRestoration) WithDissolvedNitrogenRemovalEfficiency(removalEff
 	return r.WithVariable(DissolvedNitrogenRemovalEfficiency, removalEfficiency)
 }
 
+func (r *RiverBankRestoration) WithIntensityLevels(levels ...float64) *RiverBankRestoration {
+	r.SimpleManagementAction.WithIntensityLevels(levels...)
+	return r
+}
+
 func (r *RiverBankRestoration) WithVariable(variableName action.ModelVariableName, value float64) *RiverBankRestoration {
 	r.SimpleManagementAction.WithVariable(variableName, value)
 	return r
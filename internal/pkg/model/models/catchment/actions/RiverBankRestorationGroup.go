@@ -5,12 +5,14 @@ package actions
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 )
 
 type RiverBankRestorationGroup struct {
 	planningUnitTable        tables.CsvTable
+	planningUnitColumns      dataset.Columns
 	parameters               parameters.Parameters
 	bankSedimentContribution BankSedimentContribution
 
@@ -20,6 +22,7 @@ type RiverBankRestorationGroup struct {
 
 func (r *RiverBankRestorationGroup) WithPlanningUnitTable(planningUnitTable tables.CsvTable) *RiverBankRestorationGroup {
 	r.planningUnitTable = planningUnitTable
+	r.planningUnitColumns = dataset.ColumnsOf(planningUnitTable)
 	return r
 }
 
@@ -54,7 +57,7 @@ func (r *RiverBankRestorationGroup) createManagementActions() {
 }
 
 func (r *RiverBankRestorationGroup) createManagementAction(rowNumber uint) {
-	planningUnit := r.planningUnitTable.CellFloat64(planningUnitIndex, rowNumber)
+	planningUnit := r.planningUnitTable.CellFloat64(r.planningUnitColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	planningUnitAsId := planningunit.Float64ToId(planningUnit)
 
 	originalBufferVegetation := r.originalBufferVegetation(rowNumber)
@@ -96,11 +99,12 @@ func (r *RiverBankRestorationGroup) createManagementAction(rowNumber uint) {
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
 			WithDissolvedNitrogenRemovalEfficiency(dissolvedNitrogenRemovalEfficiency).
 			WithImplementationCost(implementationCostInDollars).
-			WithOpportunityCost(opportunityCostInDollars)
+			WithOpportunityCost(opportunityCostInDollars).
+			WithIntensityLevels(r.intensityLevels(planningUnitAsId)...)
 }
 
 func (r *RiverBankRestorationGroup) originalBufferVegetation(rowNumber uint) float64 {
-	proportionOfRiparianVegetation := r.planningUnitTable.CellFloat64(riparianVegetationIndex, rowNumber)
+	proportionOfRiparianVegetation := r.planningUnitTable.CellFloat64(r.planningUnitColumns.Index(dataset.ProportionOfRiparianVegetationHeading), rowNumber)
 	return proportionOfRiparianVegetation
 }
 
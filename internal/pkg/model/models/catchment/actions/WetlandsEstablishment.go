@@ -51,6 +51,11 @@ func (w *WetlandsEstablishment) WithSedimentRemovalEfficiency(removalEfficiency
 	return w.WithVariable(SedimentRemovalEfficiency, removalEfficiency)
 }
 
+func (w *WetlandsEstablishment) WithIntensityLevels(levels ...float64) *WetlandsEstablishment {
+	w.SimpleManagementAction.WithIntensityLevels(levels...)
+	return w
+}
+
 func (w *WetlandsEstablishment) WithVariable(variableName action.ModelVariableName, value float64) *WetlandsEstablishment {
 	w.SimpleManagementAction.WithVariable(variableName, value)
 	return w
@@ -5,13 +5,15 @@ package actions
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 )
 
 type WetlandsEstablishmentGroup struct {
-	planningUnitTable tables.CsvTable
-	parameters        parameters.Parameters
+	planningUnitTable   tables.CsvTable
+	planningUnitColumns dataset.Columns
+	parameters          parameters.Parameters
 
 	actionMap map[planningunit.Id]*WetlandsEstablishment
 	Container
@@ -19,6 +21,7 @@ type WetlandsEstablishmentGroup struct {
 
 func (w *WetlandsEstablishmentGroup) WithPlanningUnitTable(planningUnitTable tables.CsvTable) *WetlandsEstablishmentGroup {
 	w.planningUnitTable = planningUnitTable
+	w.planningUnitColumns = dataset.ColumnsOf(planningUnitTable)
 	return w
 }
 
@@ -53,7 +56,7 @@ func (w *WetlandsEstablishmentGroup) createManagementActions() {
 }
 
 func (w *WetlandsEstablishmentGroup) createManagementAction(rowNumber uint) {
-	planningUnit := w.planningUnitTable.CellFloat64(planningUnitIndex, rowNumber)
+	planningUnit := w.planningUnitTable.CellFloat64(w.planningUnitColumns.Index(dataset.SubcatchmentHeading), rowNumber)
 	planningUnitAsId := planningunit.Float64ToId(planningUnit)
 
 	if !w.mapsToPlanningUnit(planningUnitAsId) {
@@ -74,5 +77,6 @@ func (w *WetlandsEstablishmentGroup) createManagementAction(rowNumber uint) {
 			WithOpportunityCost(opportunityCostInDollars).
 			WithDissolvedNitrogenRemovalEfficiency(dissolvedNitrogenRemovalEfficiency).
 			WithParticulateNitrogenRemovalEfficiency(particulateNitrogenRemovalEfficiency).
-			WithSedimentRemovalEfficiency(sedimentRemovalEfficiency)
+			WithSedimentRemovalEfficiency(sedimentRemovalEfficiency).
+			WithIntensityLevels(w.intensityLevels(planningUnitAsId)...)
 }
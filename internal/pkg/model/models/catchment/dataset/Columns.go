@@ -0,0 +1,260 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// Subcatchments table column headings.
+const (
+	SubcatchmentHeading                   = "Subcatchment"
+	DownstreamIdHeading                   = "DownstreamId"
+	ChannelLengthHeading                  = "ChannelLength"
+	ChannelSlopeHeading                   = "ChannelSlope"
+	BankfullFlowHeading                   = "BankfullFlow"
+	ChannelDepthHeading                   = "ChannelDepth"
+	FloodplainWidthHeading                = "FloodplainWidth"
+	ProportionOfRiparianVegetationHeading = "ProportionOfRiparianVegetation"
+	SubcatchmentAreaHeading               = "SubcatchmentArea"
+	RiparianBufferAreaHeading             = "RiparianBufferArea"
+	HillslopeAreaHeading                  = "HillslopeArea"
+
+	// ReachDeliveryRatioHeading names an optional column that overrides the ReachDeliveryRatio parameter for
+	// individual reaches.
+	ReachDeliveryRatioHeading = "ReachDeliveryRatio"
+)
+
+// Gullies table column headings. The gully's subcatchment is identified under SubcatchmentHeading.
+const (
+	GullyIdentifierHeading = "Identifier"
+	GullyVolumeHeading     = "Volume"
+
+	// GullyChannelLengthHeading matches the (misspelt) heading produced by the GIS export.
+	GullyChannelLengthHeading = "ChannelLengh"
+)
+
+// Actions table column headings. The action's subcatchment is identified under SubcatchmentHeading.
+const (
+	ActionTypeHeading                           = "ActionType"
+	OpportunityCostHeading                      = "OpportunityCost"
+	ImplementationCostHeading                   = "ImplementationCost"
+	ParticulateNitrogenOriginalHeading          = "ParticulateNitrogenOriginal"
+	ParticulateNitrogenActionedHeading          = "ParticulateNitrogenActioned"
+	HillslopeErosionOriginalHeading             = "HillslopeErosionOriginal"
+	HillslopeErosionActionedHeading             = "HillslopeErosionActioned"
+	FineSedimentOriginalHeading                 = "FineSedimentOriginal"
+	FineSedimentActionedHeading                 = "FineSedimentActioned"
+	DissolvedNitrogenOriginalHeading            = "DissolvedNitrogenOriginal"
+	DissolvedNitrogenActionedHeading            = "DissolvedNitrogenActioned"
+	DissolvedNitrogenRemovalEfficiencyHeading   = "DNRemovalEfficiency"
+	ParticulateNitrogenRemovalEfficiencyHeading = "PNRemovalEfficiency"
+	SedimentRemovalEfficiencyHeading            = "SedimentRemovalEfficiency"
+
+	// IntensityLevelsHeading names an optional column listing the intensities an action may be applied at, as
+	// semicolon-separated proportions such as "0.25;0.5;0.75;1". Actions without levels are either off or applied
+	// in full.
+	IntensityLevelsHeading = "IntensityLevels"
+)
+
+// Constraints table column headings. A constraint applies in the subcatchment under SubcatchmentHeading, or in every
+// subcatchment when that cell is blank. Action types are named as in the Actions table.
+const (
+	ConstraintHeading      = "Constraint"
+	OtherActionTypeHeading = "OtherActionType"
+	LimitHeading           = "Limit"
+
+	// OtherSubcatchmentHeading names an optional column giving the subcatchment a prerequisite action must be active
+	// in, when not the subcatchment of the action that requires it.
+	OtherSubcatchmentHeading = "OtherSubcatchment"
+)
+
+// columnSpecification lists the headings a table must have, the subset of those that must hold numeric content, and
+// any optional headings that must hold numeric or intensity level content when present.
+type columnSpecification struct {
+	requiredHeadings               []string
+	nonNumericHeadings             []string
+	optionalNumericHeadings        []string
+	optionalIntensityLevelHeadings []string
+}
+
+func (cs columnSpecification) isNumeric(heading string) bool {
+	for _, nonNumericHeading := range cs.nonNumericHeadings {
+		if heading == nonNumericHeading {
+			return false
+		}
+	}
+	return true
+}
+
+var columnSpecifications = map[string]columnSpecification{
+	SubcatchmentsTableName: {
+		requiredHeadings: []string{
+			SubcatchmentHeading,
+			DownstreamIdHeading,
+			ChannelLengthHeading,
+			ChannelSlopeHeading,
+			BankfullFlowHeading,
+			ChannelDepthHeading,
+			FloodplainWidthHeading,
+			ProportionOfRiparianVegetationHeading,
+			HillslopeAreaHeading,
+		},
+		optionalNumericHeadings: []string{ReachDeliveryRatioHeading},
+	},
+	GulliesTableName: {
+		requiredHeadings: []string{
+			GullyIdentifierHeading,
+			SubcatchmentHeading,
+			GullyVolumeHeading,
+			GullyChannelLengthHeading,
+		},
+	},
+	ActionsTableName: {
+		requiredHeadings: []string{
+			SubcatchmentHeading,
+			ActionTypeHeading,
+			OpportunityCostHeading,
+			ImplementationCostHeading,
+			ParticulateNitrogenOriginalHeading,
+			ParticulateNitrogenActionedHeading,
+			HillslopeErosionOriginalHeading,
+			HillslopeErosionActionedHeading,
+			FineSedimentOriginalHeading,
+			FineSedimentActionedHeading,
+			DissolvedNitrogenOriginalHeading,
+			DissolvedNitrogenActionedHeading,
+			DissolvedNitrogenRemovalEfficiencyHeading,
+			ParticulateNitrogenRemovalEfficiencyHeading,
+			SedimentRemovalEfficiencyHeading,
+		},
+		nonNumericHeadings:             []string{ActionTypeHeading},
+		optionalIntensityLevelHeadings: []string{IntensityLevelsHeading},
+	},
+	ConstraintsTableName: {
+		requiredHeadings: []string{
+			ConstraintHeading,
+			SubcatchmentHeading,
+			ActionTypeHeading,
+			OtherActionTypeHeading,
+			LimitHeading,
+		},
+		nonNumericHeadings: []string{
+			ConstraintHeading,
+			SubcatchmentHeading,
+			ActionTypeHeading,
+			OtherActionTypeHeading,
+			LimitHeading,
+		},
+	},
+}
+
+// Columns maps the headings of a table to the indexes of their columns.
+type Columns map[string]uint
+
+// ColumnsOf returns the columns of the table supplied, keyed by heading.
+func ColumnsOf(table tables.CsvTable) Columns {
+	columns := make(Columns, len(table.Header()))
+	for index, heading := range table.Header() {
+		columns[heading] = uint(index)
+	}
+	return columns
+}
+
+// Has reports whether the columns include one with the heading supplied.
+func (c Columns) Has(heading string) bool {
+	_, hasHeading := c[heading]
+	return hasHeading
+}
+
+// Index returns the column index of the heading supplied, panicking if there is no such column.  Required headings
+// are validated at model initialisation, so a missing heading here is a programming error.
+func (c Columns) Index(heading string) uint {
+	index, hasHeading := c[heading]
+	if !hasHeading {
+		panic(errors.New("Expected table to have a [" + heading + "] column"))
+	}
+	return index
+}
+
+func validateColumnsOf(table tables.CsvTable, tableName string, validationErrors *compositeErrors.CompositeError) {
+	specification := columnSpecifications[tableName]
+	columns := ColumnsOf(table)
+
+	for _, heading := range specification.requiredHeadings {
+		if !columns.Has(heading) {
+			validationErrors.AddMessage(fmt.Sprintf("%s table is missing required column [%s]", tableName, heading))
+			continue
+		}
+		if specification.isNumeric(heading) {
+			validateNumericColumn(table, tableName, heading, columns[heading], validationErrors)
+		}
+	}
+
+	for _, heading := range specification.optionalNumericHeadings {
+		if columns.Has(heading) {
+			validateNumericColumn(table, tableName, heading, columns[heading], validationErrors)
+		}
+	}
+
+	for _, heading := range specification.optionalIntensityLevelHeadings {
+		if columns.Has(heading) {
+			validateIntensityLevelColumn(table, tableName, heading, columns[heading], validationErrors)
+		}
+	}
+}
+
+func validateNumericColumn(table tables.CsvTable, tableName string, heading string, column uint, validationErrors *compositeErrors.CompositeError) {
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		if _, isNumeric := table.Cell(column, row).(float64); !isNumeric {
+			message := fmt.Sprintf("%s table column [%s] has non-numeric value [%v] in data row [%d]",
+				tableName, heading, table.Cell(column, row), row+1)
+			validationErrors.AddMessage(message)
+			return
+		}
+	}
+}
+
+func validateIntensityLevelColumn(table tables.CsvTable, tableName string, heading string, column uint, validationErrors *compositeErrors.CompositeError) {
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		if _, parseError := ParseIntensityLevels(table.CellString(column, row)); parseError != nil {
+			message := fmt.Sprintf("%s table column [%s] has invalid value [%v] in data row [%d]: %s",
+				tableName, heading, table.Cell(column, row), row+1, parseError.Error())
+			validationErrors.AddMessage(message)
+			return
+		}
+	}
+}
+
+// ParseIntensityLevels returns the intensity levels listed in the cell content supplied, or no levels for empty
+// content. Each level must be a proportion in (0,1].
+func ParseIntensityLevels(cellContent string) ([]float64, error) {
+	trimmedContent := strings.TrimSpace(cellContent)
+	if trimmedContent == "" {
+		return nil, nil
+	}
+
+	rawLevels := strings.Split(trimmedContent, intensityLevelDelimiter)
+	levels := make([]float64, len(rawLevels))
+	for index, rawLevel := range rawLevels {
+		level, parseError := strconv.ParseFloat(strings.TrimSpace(rawLevel), 64)
+		if parseError != nil {
+			return nil, errors.New("intensity level [" + rawLevel + "] is not a number")
+		}
+		if level <= 0 || level > 1 {
+			return nil, errors.New("intensity level [" + rawLevel + "] is outside of (0,1]")
+		}
+		levels[index] = level
+	}
+	return levels, nil
+}
+
+const intensityLevelDelimiter = ";"
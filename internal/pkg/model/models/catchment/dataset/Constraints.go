@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// Constraint kinds, named under ConstraintHeading.
+const (
+	// ExclusionConstraint stops the actions under ActionTypeHeading and OtherActionTypeHeading being active together.
+	ExclusionConstraint = "Exclusion"
+
+	// PrerequisiteConstraint stops the action under ActionTypeHeading being active unless the action under
+	// OtherActionTypeHeading is also active.
+	PrerequisiteConstraint = "Prerequisite"
+
+	// MaximumActionsConstraint stops more actions than given under LimitHeading being active in a subcatchment.
+	MaximumActionsConstraint = "MaximumActions"
+)
+
+func validateConstraintRows(table tables.CsvTable, validationErrors *compositeErrors.CompositeError) {
+	columns := ColumnsOf(table)
+	if !columns.Has(ConstraintHeading) || !columns.Has(SubcatchmentHeading) || !columns.Has(ActionTypeHeading) ||
+		!columns.Has(OtherActionTypeHeading) || !columns.Has(LimitHeading) {
+		return // missing columns already reported
+	}
+
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		addRowError := func(problem string) {
+			message := fmt.Sprintf("%s table data row [%d] %s", ConstraintsTableName, row+1, problem)
+			validationErrors.AddMessage(message)
+		}
+
+		cellOf := func(heading string) string {
+			return table.CellString(columns.Index(heading), row)
+		}
+
+		if _, _, parseError := ParseOptionalWholeNumber(cellOf(SubcatchmentHeading)); parseError != nil {
+			addRowError(fmt.Sprintf("column [%s] %s", SubcatchmentHeading, parseError.Error()))
+		}
+
+		switch constraintKind := strings.TrimSpace(cellOf(ConstraintHeading)); constraintKind {
+		case ExclusionConstraint, PrerequisiteConstraint:
+			if strings.TrimSpace(cellOf(ActionTypeHeading)) == "" || strings.TrimSpace(cellOf(OtherActionTypeHeading)) == "" {
+				addRowError(fmt.Sprintf("needs both [%s] and [%s] for a [%s] constraint",
+					ActionTypeHeading, OtherActionTypeHeading, constraintKind))
+			}
+			if constraintKind == PrerequisiteConstraint && columns.Has(OtherSubcatchmentHeading) {
+				otherSubcatchment := table.CellString(columns.Index(OtherSubcatchmentHeading), row)
+				if _, _, parseError := ParseOptionalWholeNumber(otherSubcatchment); parseError != nil {
+					addRowError(fmt.Sprintf("column [%s] %s", OtherSubcatchmentHeading, parseError.Error()))
+				}
+			}
+		case MaximumActionsConstraint:
+			if _, isBlank, parseError := ParseOptionalWholeNumber(cellOf(LimitHeading)); parseError != nil || isBlank {
+				addRowError(fmt.Sprintf("needs a whole number [%s] for a [%s] constraint",
+					LimitHeading, MaximumActionsConstraint))
+			}
+		default:
+			addRowError(fmt.Sprintf("has unknown constraint [%s]. Must be one of [%s], [%s] or [%s]",
+				constraintKind, ExclusionConstraint, PrerequisiteConstraint, MaximumActionsConstraint))
+		}
+	}
+}
+
+// ParseOptionalWholeNumber returns the whole number held in the cell content supplied, reporting blank content
+// separately from content that is not a whole number.
+func ParseOptionalWholeNumber(cellContent string) (value uint64, isBlank bool, parseError error) {
+	trimmedContent := strings.TrimSpace(cellContent)
+	if trimmedContent == "" {
+		return 0, true, nil
+	}
+
+	asFloat, floatError := strconv.ParseFloat(trimmedContent, 64)
+	if floatError != nil || asFloat < 0 || asFloat != float64(uint64(asFloat)) {
+		return 0, false, errors.New("value [" + cellContent + "] is not a whole number")
+	}
+	return uint64(asFloat), false, nil
+}
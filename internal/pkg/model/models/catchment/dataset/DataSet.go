@@ -3,12 +3,16 @@ package dataset
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 )
 
 const (
 	SubcatchmentsTableName = "Subcatchments"
 	GulliesTableName       = "Gullies"
 	ActionsTableName       = "Actions"
+
+	// ConstraintsTableName names an optional table of constraints on which actions may be active together.
+	ConstraintsTableName = "Constraints"
 )
 
 type DataSet interface {
@@ -24,6 +28,9 @@ type DataSetImpl struct {
 	SubCatchmentsTable tables.CsvTable
 	ActionsTable       tables.CsvTable
 	GulliesTable       tables.CsvTable
+
+	// ConstraintsTable is nil when the data set has no constraints table.
+	ConstraintsTable tables.CsvTable
 }
 
 func (c *DataSetImpl) Initialise(wrappedDataSet dataset.DataSet) *DataSetImpl {
@@ -33,5 +40,31 @@ func (c *DataSetImpl) Initialise(wrappedDataSet dataset.DataSet) *DataSetImpl {
 	c.ActionsTable = tables.ToCsvTable(c.DataSet, ActionsTableName)
 	c.GulliesTable = tables.ToCsvTable(c.DataSet, GulliesTableName)
 
+	if _, missingError := c.DataSet.Table(ConstraintsTableName); missingError == nil {
+		c.ConstraintsTable = tables.ToCsvTable(c.DataSet, ConstraintsTableName)
+	}
+
 	return c
 }
+
+// Validate checks that each table has every column the catchment model requires, and that those columns hold
+// numeric content where expected, along with the routing of subcatchments and the content of any constraints table,
+// returning all problems found as a single error, or nil if there are none.
+func (c *DataSetImpl) Validate() error {
+	validationErrors := compositeErrors.New("Catchment data set validation")
+
+	validateColumnsOf(c.SubCatchmentsTable, SubcatchmentsTableName, validationErrors)
+	validateSubcatchmentRows(c.SubCatchmentsTable, validationErrors)
+	validateColumnsOf(c.GulliesTable, GulliesTableName, validationErrors)
+	validateColumnsOf(c.ActionsTable, ActionsTableName, validationErrors)
+
+	if c.ConstraintsTable != nil {
+		validateColumnsOf(c.ConstraintsTable, ConstraintsTableName, validationErrors)
+		validateConstraintRows(c.ConstraintsTable, validationErrors)
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
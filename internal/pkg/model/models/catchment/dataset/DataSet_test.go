@@ -0,0 +1,236 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	. "github.com/onsi/gomega"
+)
+
+func TestDataSetImpl_Validate_ValidTables_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeNil())
+}
+
+func TestDataSetImpl_Validate_NonNumericContent_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	columns := ColumnsOf(dataSetUnderTest.SubCatchmentsTable)
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(ChannelSlopeHeading), 1, "steep")
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(ReachDeliveryRatioHeading), 0, "")
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+	g.Expect(validationError.(*compositeErrors.CompositeError).Size()).To(BeNumerically("==", 2))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [ChannelSlope] has non-numeric value [steep] in data row [2]"))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [ReachDeliveryRatio] has non-numeric value [] in data row [1]"))
+}
+
+func TestDataSetImpl_Validate_ActionTypeNotNumeric_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	columns := ColumnsOf(dataSetUnderTest.ActionsTable)
+	dataSetUnderTest.ActionsTable.SetCell(columns.Index(ActionTypeHeading), 0, "Riparian")
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeNil())
+}
+
+func TestDataSetImpl_Validate_IntensityLevels(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	actionHeadings := append(columnSpecifications[ActionsTableName].requiredHeadings, IntensityLevelsHeading)
+	dataSetUnderTest.ActionsTable = buildTestTable(actionHeadings...)
+	columns := ColumnsOf(dataSetUnderTest.ActionsTable)
+	dataSetUnderTest.ActionsTable.SetCell(columns.Index(IntensityLevelsHeading), 0, "0.25;0.5;1")
+	dataSetUnderTest.ActionsTable.SetCell(columns.Index(IntensityLevelsHeading), 1, "0.5;2")
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+	g.Expect(validationError.(*compositeErrors.CompositeError).Size()).To(BeNumerically("==", 1))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [IntensityLevels] has invalid value [0.5;2] in data row [2]"))
+}
+
+func TestDataSetImpl_Validate_ConstraintsTable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	dataSetUnderTest.ConstraintsTable = buildTestTable(columnSpecifications[ConstraintsTableName].requiredHeadings...)
+	columns := ColumnsOf(dataSetUnderTest.ConstraintsTable)
+
+	setRow := func(row uint, constraint string, subcatchment interface{}, actionType string, otherActionType string, limit interface{}) {
+		dataSetUnderTest.ConstraintsTable.SetCell(columns.Index(ConstraintHeading), row, constraint)
+		dataSetUnderTest.ConstraintsTable.SetCell(columns.Index(SubcatchmentHeading), row, subcatchment)
+		dataSetUnderTest.ConstraintsTable.SetCell(columns.Index(ActionTypeHeading), row, actionType)
+		dataSetUnderTest.ConstraintsTable.SetCell(columns.Index(OtherActionTypeHeading), row, otherActionType)
+		dataSetUnderTest.ConstraintsTable.SetCell(columns.Index(LimitHeading), row, limit)
+	}
+
+	// when
+	setRow(0, ExclusionConstraint, "", "Wetland", "Hillslope", "")
+	setRow(1, MaximumActionsConstraint, float64(17), "", "", float64(2))
+
+	// then
+	g.Expect(dataSetUnderTest.Validate()).To(BeNil())
+
+	// when
+	setRow(0, PrerequisiteConstraint, "upstream", "Gully", "", "")
+	setRow(1, "Forbidden", float64(17), "", "", "")
+
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+	g.Expect(validationError.(*compositeErrors.CompositeError).Size()).To(BeNumerically("==", 3))
+	g.Expect(validationError.Error()).To(ContainSubstring("data row [1] column [Subcatchment] value [upstream] is not a whole number"))
+	g.Expect(validationError.Error()).To(ContainSubstring("data row [1] needs both [ActionType] and [OtherActionType]"))
+	g.Expect(validationError.Error()).To(ContainSubstring("data row [2] has unknown constraint [Forbidden]"))
+}
+
+func TestDataSetImpl_Validate_ReachDeliveryRatioOutOfRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	columns := ColumnsOf(dataSetUnderTest.SubCatchmentsTable)
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(ReachDeliveryRatioHeading), 0, float64(0))
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(ReachDeliveryRatioHeading), 1, float64(1.5))
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+	g.Expect(validationError.(*compositeErrors.CompositeError).Size()).To(BeNumerically("==", 2))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [ReachDeliveryRatio] has value [0] in data row [1]"))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [ReachDeliveryRatio] has value [1.5] in data row [2]"))
+}
+
+func TestDataSetImpl_Validate_DownstreamIdCycle_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSetUnderTest := buildTestDataSet()
+	columns := ColumnsOf(dataSetUnderTest.SubCatchmentsTable)
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(DownstreamIdHeading), 0, float64(2))
+	dataSetUnderTest.SubCatchmentsTable.SetCell(columns.Index(DownstreamIdHeading), 1, float64(1))
+
+	gullyHeadings := columnSpecifications[GulliesTableName].requiredHeadings
+	dataSetUnderTest.GulliesTable = buildTestTable(gullyHeadings[:len(gullyHeadings)-1]...)
+
+	// when
+	validationError := dataSetUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(BeAssignableToTypeOf(new(compositeErrors.CompositeError)))
+	g.Expect(validationError.(*compositeErrors.CompositeError).Size()).To(BeNumerically("==", 2))
+	g.Expect(validationError.Error()).To(ContainSubstring("Gullies table is missing required column"))
+	g.Expect(validationError.Error()).To(ContainSubstring("column [DownstreamId] values form a cycle through subcatchments [1 2]"))
+}
+
+func TestParseOptionalWholeNumber(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	value, isBlank, parseError := ParseOptionalWholeNumber(" 17 ")
+	g.Expect(value).To(BeNumerically("==", 17))
+	g.Expect(isBlank).To(BeFalse())
+	g.Expect(parseError).To(BeNil())
+
+	_, isBlank, parseError = ParseOptionalWholeNumber("")
+	g.Expect(isBlank).To(BeTrue())
+	g.Expect(parseError).To(BeNil())
+
+	_, _, parseError = ParseOptionalWholeNumber("2.5")
+	g.Expect(parseError).To(Not(BeNil()))
+
+	_, _, parseError = ParseOptionalWholeNumber("-1")
+	g.Expect(parseError).To(Not(BeNil()))
+}
+
+func TestParseIntensityLevels(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	levels, parseError := ParseIntensityLevels(" 0.25; 0.5;1 ")
+	g.Expect(parseError).To(BeNil())
+	g.Expect(levels).To(Equal([]float64{0.25, 0.5, 1}))
+
+	levels, parseError = ParseIntensityLevels("")
+	g.Expect(parseError).To(BeNil())
+	g.Expect(levels).To(BeNil())
+
+	_, parseError = ParseIntensityLevels("half")
+	g.Expect(parseError).To(Not(BeNil()))
+
+	_, parseError = ParseIntensityLevels("0")
+	g.Expect(parseError).To(Not(BeNil()))
+}
+
+func TestColumns_Index_MissingHeading_Panics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	columnsUnderTest := ColumnsOf(buildTestTable(SubcatchmentHeading))
+
+	// when
+	indexOfMissingHeading := func() {
+		columnsUnderTest.Index(DownstreamIdHeading)
+	}
+
+	// then
+	g.Expect(columnsUnderTest.Index(SubcatchmentHeading)).To(BeNumerically("==", 0))
+	g.Expect(indexOfMissingHeading).To(Panic())
+}
+
+func buildTestDataSet() *DataSetImpl {
+	subCatchmentHeadings := append([]string{ReachDeliveryRatioHeading}, columnSpecifications[SubcatchmentsTableName].requiredHeadings...)
+	subCatchmentsTable := buildTestTable(subCatchmentHeadings...)
+	subCatchmentsTable.SetCell(ColumnsOf(subCatchmentsTable).Index(ReachDeliveryRatioHeading), 1, float64(0.5))
+
+	return &DataSetImpl{
+		SubCatchmentsTable: subCatchmentsTable,
+		GulliesTable:       buildTestTable(columnSpecifications[GulliesTableName].requiredHeadings...),
+		ActionsTable:       buildTestTable(columnSpecifications[ActionsTableName].requiredHeadings...),
+	}
+}
+
+func buildTestTable(headings ...string) tables.CsvTable {
+	const rowCount = 2
+
+	newTable := new(tables.CsvTableImpl)
+	newTable.SetHeader(headings)
+	newTable.SetColumnAndRowSize(uint(len(headings)), rowCount)
+
+	for row := uint(0); row < rowCount; row++ {
+		for column := range headings {
+			newTable.SetCell(uint(column), row, float64(row+1))
+		}
+	}
+	return newTable
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+// validateSubcatchmentRows checks that any reach delivery ratio given is greater than 0 and no more than 1, and that
+// following DownstreamId values from a subcatchment never leads back to it. Non-numeric values are skipped, being
+// reported already.
+func validateSubcatchmentRows(table tables.CsvTable, validationErrors *compositeErrors.CompositeError) {
+	columns := ColumnsOf(table)
+	if columns.Has(ReachDeliveryRatioHeading) {
+		validateReachDeliveryRatios(table, columns.Index(ReachDeliveryRatioHeading), validationErrors)
+	}
+	if columns.Has(SubcatchmentHeading) && columns.Has(DownstreamIdHeading) {
+		validateDownstreamIds(table, columns, validationErrors)
+	}
+}
+
+func validateReachDeliveryRatios(table tables.CsvTable, column uint, validationErrors *compositeErrors.CompositeError) {
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		ratio, isNumeric := table.Cell(column, row).(float64)
+		if !isNumeric || (ratio > 0 && ratio <= 1) {
+			continue
+		}
+		message := fmt.Sprintf("%s table column [%s] has value [%g] in data row [%d], but must be greater than 0 and no more than 1",
+			SubcatchmentsTableName, ReachDeliveryRatioHeading, ratio, row+1)
+		validationErrors.AddMessage(message)
+	}
+}
+
+func validateDownstreamIds(table tables.CsvTable, columns Columns, validationErrors *compositeErrors.CompositeError) {
+	subcatchmentColumn := columns.Index(SubcatchmentHeading)
+	downstreamIdColumn := columns.Index(DownstreamIdHeading)
+
+	downstreamOf := make(map[planningunit.Id]planningunit.Id)
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		subcatchment, subcatchmentIsNumeric := table.Cell(subcatchmentColumn, row).(float64)
+		downstreamId, downstreamIdIsNumeric := table.Cell(downstreamIdColumn, row).(float64)
+		if subcatchmentIsNumeric && downstreamIdIsNumeric {
+			downstreamOf[planningunit.Float64ToId(subcatchment)] = planningunit.Float64ToId(downstreamId)
+		}
+	}
+
+	cyclicIds := make(planningunit.Ids, 0)
+	for id := range downstreamOf {
+		if leadsBackTo(id, downstreamOf) {
+			cyclicIds = append(cyclicIds, id)
+		}
+	}
+	if len(cyclicIds) == 0 {
+		return
+	}
+
+	sort.Slice(cyclicIds, func(i, j int) bool { return cyclicIds[i] < cyclicIds[j] })
+	message := fmt.Sprintf("%s table column [%s] values form a cycle through subcatchments %v",
+		SubcatchmentsTableName, DownstreamIdHeading, cyclicIds)
+	validationErrors.AddMessage(message)
+}
+
+// leadsBackTo reports whether following DownstreamId values from the subcatchment supplied returns to it. A
+// subcatchment that names itself, or no known subcatchment, as downstream discharges to the end of catchment.
+func leadsBackTo(id planningunit.Id, downstreamOf map[planningunit.Id]planningunit.Id) bool {
+	current := id
+	for step := 0; step < len(downstreamOf); step++ {
+		downstream, isKnown := downstreamOf[current]
+		if !isKnown || downstream == current {
+			return false
+		}
+		if downstream == id {
+			return true
+		}
+		current = downstream
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package network
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/command"
+)
+
+// LocalChange holds a change in the load a subcatchment generates itself, with the load it replaces, so that the
+// change can be done and undone on the local loads of a decision variable.
+type LocalChange struct {
+	planningUnit planningunit.Id
+	doneLoad     float64
+	undoneLoad   float64
+}
+
+// NewLocalChange returns the change of the planning unit's load, amongst the local loads supplied, by the amount given.
+func NewLocalChange(localLoads variable.PlanningUnitValueMap, planningUnit planningunit.Id, change float64) LocalChange {
+	return LocalChange{
+		planningUnit: planningUnit,
+		doneLoad:     localLoads[planningUnit] + change,
+		undoneLoad:   localLoads[planningUnit],
+	}
+}
+
+// Do sets the planning unit's load, amongst the local loads supplied, to its changed value.
+func (c LocalChange) Do(localLoads variable.PlanningUnitValueMap) {
+	if localLoads == nil {
+		return
+	}
+	localLoads[c.planningUnit] = c.doneLoad
+}
+
+// Undo returns the planning unit's load, amongst the local loads supplied, to the value it had before.
+func (c LocalChange) Undo(localLoads variable.PlanningUnitValueMap) {
+	if localLoads == nil {
+		return
+	}
+	localLoads[c.planningUnit] = c.undoneLoad
+}
+
+// LocalChangeCommand is a change per planning unit decision variable command that also changes the load the planning
+// unit generates itself, for variables that keep local loads apart from the loads delivered through the network.
+type LocalChangeCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+
+	localLoads variable.PlanningUnitValueMap
+	local      LocalChange
+}
+
+// SetLocalChange supplies the change the command makes to the command's planning unit load, amongst the local
+// loads supplied.
+func (c *LocalChangeCommand) SetLocalChange(localLoads variable.PlanningUnitValueMap, change float64) {
+	c.localLoads = localLoads
+	c.local = NewLocalChange(localLoads, c.PlanningUnit(), change)
+}
+
+func (c *LocalChangeCommand) Do() command.CommandStatus {
+	if c.BaseCommand.Do() == command.NoChange {
+		return command.NoChange
+	}
+	c.DoUnguarded()
+	return command.Done
+}
+
+func (c *LocalChangeCommand) DoUnguarded() {
+	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.local.Do(c.localLoads)
+}
+
+func (c *LocalChangeCommand) Undo() command.CommandStatus {
+	if c.BaseCommand.Undo() == command.NoChange {
+		return command.NoChange
+	}
+	c.UndoUnguarded()
+	return command.UnDone
+}
+
+func (c *LocalChangeCommand) UndoUnguarded() {
+	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.local.Undo(c.localLoads)
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package network offers a directed tree of catchment planning units, built from the DownstreamId column of the
+// Subcatchments table, and the means to route pollutant loads down that tree to the end of catchment.
+package network
+
+import (
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	catchmentDataSet "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+// ReachDeliveryRatioHeading names an optional Subcatchments table column that overrides the ReachDeliveryRatio
+// parameter for individual reaches.
+const ReachDeliveryRatioHeading = catchmentDataSet.ReachDeliveryRatioHeading
+
+type node struct {
+	id planningunit.Id
+
+	downstream    planningunit.Id
+	hasDownstream bool
+	upstream      planningunit.Ids
+
+	reachDeliveryRatio float64
+}
+
+// Network is a directed tree of subcatchments. Each subcatchment discharges into the subcatchment identified by its
+// DownstreamId, or to the end of catchment if no subcatchment has that identifier. The load leaving a subcatchment is
+// attenuated by that subcatchment's reach delivery ratio on its way downstream.
+type Network struct {
+	nodes map[planningunit.Id]*node
+
+	headwatersFirst planningunit.Ids
+	outlets         planningunit.Ids
+
+	deliveryRatios map[planningunit.Id]float64
+}
+
+func (n *Network) Initialise(subCatchmentsTable tables.CsvTable, parameters catchmentParameters.Parameters) *Network {
+	n.buildNodes(subCatchmentsTable, parameters)
+	n.linkNodes()
+	n.orderNodes()
+	n.deriveDeliveryRatios()
+	return n
+}
+
+func (n *Network) buildNodes(subCatchmentsTable tables.CsvTable, parameters catchmentParameters.Parameters) {
+	_, rowCount := subCatchmentsTable.ColumnAndRowSize()
+	n.nodes = make(map[planningunit.Id]*node, rowCount)
+
+	defaultRatio := parameters.GetFloat64(catchmentParameters.ReachDeliveryRatio)
+	columns := catchmentDataSet.ColumnsOf(subCatchmentsTable)
+	subCatchmentColumn := columns.Index(catchmentDataSet.SubcatchmentHeading)
+	downstreamIdColumn := columns.Index(catchmentDataSet.DownstreamIdHeading)
+	ratioColumn, hasRatioColumn := columns[ReachDeliveryRatioHeading]
+
+	for row := uint(0); row < rowCount; row++ {
+		newNode := &node{
+			id:                 planningunit.Float64ToId(subCatchmentsTable.CellFloat64(subCatchmentColumn, row)),
+			downstream:         planningunit.Float64ToId(subCatchmentsTable.CellFloat64(downstreamIdColumn, row)),
+			reachDeliveryRatio: defaultRatio,
+		}
+
+		if hasRatioColumn {
+			newNode.reachDeliveryRatio = subCatchmentsTable.CellFloat64(ratioColumn, row)
+		}
+
+		n.nodes[newNode.id] = newNode
+	}
+}
+
+func (n *Network) linkNodes() {
+	for _, id := range n.sortedIds() {
+		currentNode := n.nodes[id]
+		downstreamNode, downstreamPresent := n.nodes[currentNode.downstream]
+		if !downstreamPresent || currentNode.downstream == id {
+			n.outlets = append(n.outlets, id)
+			continue
+		}
+		currentNode.hasDownstream = true
+		downstreamNode.upstream = append(downstreamNode.upstream, id)
+	}
+}
+
+func (n *Network) sortedIds() planningunit.Ids {
+	ids := make(planningunit.Ids, 0, len(n.nodes))
+	for id := range n.nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// orderNodes lists subcatchments so that each comes after every subcatchment upstream of it. Data set validation
+// rejects DownstreamId values that form a cycle, so every subcatchment is listed.
+func (n *Network) orderNodes() {
+	remainingUpstream := make(map[planningunit.Id]int, len(n.nodes))
+	ready := make(planningunit.Ids, 0)
+
+	for _, id := range n.sortedIds() {
+		remainingUpstream[id] = len(n.nodes[id].upstream)
+		if remainingUpstream[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	n.headwatersFirst = make(planningunit.Ids, 0, len(n.nodes))
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		n.headwatersFirst = append(n.headwatersFirst, current)
+
+		currentNode := n.nodes[current]
+		if !currentNode.hasDownstream {
+			continue
+		}
+		remainingUpstream[currentNode.downstream]--
+		if remainingUpstream[currentNode.downstream] == 0 {
+			ready = append(ready, currentNode.downstream)
+		}
+	}
+}
+
+func (n *Network) deriveDeliveryRatios() {
+	n.deliveryRatios = make(map[planningunit.Id]float64, len(n.nodes))
+	for index := len(n.headwatersFirst) - 1; index >= 0; index-- {
+		currentNode := n.nodes[n.headwatersFirst[index]]
+		ratio := currentNode.reachDeliveryRatio
+		if currentNode.hasDownstream {
+			ratio *= n.deliveryRatios[currentNode.downstream]
+		}
+		n.deliveryRatios[currentNode.id] = ratio
+	}
+}
+
+// Downstream returns the subcatchment that the supplied subcatchment discharges into, and false if it discharges
+// directly to the end of catchment.
+func (n *Network) Downstream(id planningunit.Id) (planningunit.Id, bool) {
+	if subCatchment, isPresent := n.nodes[id]; isPresent && subCatchment.hasDownstream {
+		return subCatchment.downstream, true
+	}
+	return 0, false
+}
+
+// Upstream returns the subcatchments that discharge directly into the supplied subcatchment.
+func (n *Network) Upstream(id planningunit.Id) planningunit.Ids {
+	if subCatchment, isPresent := n.nodes[id]; isPresent {
+		return subCatchment.upstream
+	}
+	return nil
+}
+
+// Neighbours returns the subcatchments directly connected to the supplied subcatchment, being those immediately
+// upstream of it, and the one it discharges into.
+func (n *Network) Neighbours(id planningunit.Id) planningunit.Ids {
+	neighbours := append(planningunit.Ids{}, n.Upstream(id)...)
+	if downstream, hasDownstream := n.Downstream(id); hasDownstream {
+		neighbours = append(neighbours, downstream)
+	}
+	return neighbours
+}
+
+// Outlets returns the subcatchments that discharge directly to the end of catchment.
+func (n *Network) Outlets() planningunit.Ids {
+	return n.outlets
+}
+
+// ReachDeliveryRatio returns the proportion of load leaving the supplied subcatchment that reaches whatever is
+// immediately downstream of it.
+func (n *Network) ReachDeliveryRatio(id planningunit.Id) float64 {
+	if subCatchment, isPresent := n.nodes[id]; isPresent {
+		return subCatchment.reachDeliveryRatio
+	}
+	return 1
+}
+
+// DeliveryRatio returns the proportion of load generated in the supplied subcatchment that reaches the end of
+// catchment. Planning units outside the network are treated as discharging directly to the end of catchment.
+func (n *Network) DeliveryRatio(id planningunit.Id) float64 {
+	if ratio, isPresent := n.deliveryRatios[id]; isPresent {
+		return ratio
+	}
+	return 1
+}
+
+// Deliver returns how much of a load generated in the supplied subcatchment reaches the end of catchment.
+func (n *Network) Deliver(id planningunit.Id, localLoad float64) float64 {
+	return localLoad * n.DeliveryRatio(id)
+}
+
+// DeliveryRatioThrough returns the proportion of load generated in the supplied subcatchment that reaches the end of
+// catchment, given inflow removals mapping subcatchments to the proportion of the load flowing into them from upstream
+// that they remove (such as by an established wetland). Subcatchments not mapped remove none.
+func (n *Network) DeliveryRatioThrough(id planningunit.Id, inflowRemovals variable.PlanningUnitValueMap) float64 {
+	ratio := n.DeliveryRatio(id)
+	for downstream, hasDownstream := n.Downstream(id); hasDownstream; downstream, hasDownstream = n.Downstream(downstream) {
+		ratio *= 1 - inflowRemovals[downstream]
+	}
+	return ratio
+}
+
+// DeliverThrough returns how much of a load generated in the supplied subcatchment reaches the end of catchment,
+// given the inflow removals of the subcatchments it is routed through.
+func (n *Network) DeliverThrough(id planningunit.Id, localLoad float64, inflowRemovals variable.PlanningUnitValueMap) float64 {
+	return localLoad * n.DeliveryRatioThrough(id, inflowRemovals)
+}
+
+// UpstreamOf returns every subcatchment whose load is routed through the supplied subcatchment, nearest first.
+func (n *Network) UpstreamOf(id planningunit.Id) planningunit.Ids {
+	upstreamOf := make(planningunit.Ids, 0)
+	for toVisit := n.Upstream(id); len(toVisit) > 0; toVisit = toVisit[1:] {
+		upstreamOf = append(upstreamOf, toVisit[0])
+		toVisit = append(toVisit, n.Upstream(toVisit[0])...)
+	}
+	return upstreamOf
+}
+
+// UpstreamLoadsWithRemoval takes the per-subcatchment loads generated locally, and the inflow removals of
+// subcatchments, returning the delivered loads of every subcatchment upstream of the one supplied, were its inflow
+// removal changed to that given.
+func (n *Network) UpstreamLoadsWithRemoval(id planningunit.Id, inflowRemoval float64,
+	localLoads, inflowRemovals variable.PlanningUnitValueMap) variable.PlanningUnitValueMap {
+	changedRemovals := make(variable.PlanningUnitValueMap, len(inflowRemovals)+1)
+	for removingId, removal := range inflowRemovals {
+		changedRemovals[removingId] = removal
+	}
+	changedRemovals[id] = inflowRemoval
+
+	upstreamLoads := make(variable.PlanningUnitValueMap)
+	for _, upstreamId := range n.UpstreamOf(id) {
+		upstreamLoads[upstreamId] = n.DeliverThrough(upstreamId, localLoads[upstreamId], changedRemovals)
+	}
+	return upstreamLoads
+}
+
+// AccumulatedLoads takes the per-subcatchment loads generated locally, and the inflow removals of subcatchments,
+// returning for each subcatchment the load leaving it, being its own load plus all loads routed to it from upstream,
+// less the proportion of those routed loads it removes.
+func (n *Network) AccumulatedLoads(localLoads, inflowRemovals variable.PlanningUnitValueMap) variable.PlanningUnitValueMap {
+	accumulatedLoads := make(variable.PlanningUnitValueMap, len(n.nodes))
+	for _, id := range n.headwatersFirst {
+		subCatchment := n.nodes[id]
+
+		inflow := float64(0)
+		for _, upstreamId := range subCatchment.upstream {
+			inflow += accumulatedLoads[upstreamId]
+		}
+		accumulatedLoads[id] = (localLoads[id] + inflow*(1-inflowRemovals[id])) * subCatchment.reachDeliveryRatio
+	}
+	return accumulatedLoads
+}
+
+// EndOfCatchmentLoad returns the sum of the accumulated loads leaving the network's outlets.
+func (n *Network) EndOfCatchmentLoad(localLoads, inflowRemovals variable.PlanningUnitValueMap) float64 {
+	accumulatedLoads := n.AccumulatedLoads(localLoads, inflowRemovals)
+
+	endOfCatchmentLoad := float64(0)
+	for _, id := range n.outlets {
+		endOfCatchmentLoad += accumulatedLoads[id]
+	}
+	return endOfCatchmentLoad
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	. "github.com/onsi/gomega"
+)
+
+const equalTo = "=="
+
+// Topology under test:
+//
+//	1 --> 3 --> 4 --> (end of catchment)
+//	2 --/
+//	5 -------------> (end of catchment)
+func buildTestTable(ratios ...float64) tables.CsvTable {
+	ids := []float64{1, 2, 3, 4, 5}
+	downstreamIds := []float64{3, 3, 4, 99, 98}
+
+	header := dataset.TableHeader{"Subcatchment", "DownstreamId"}
+	if len(ratios) > 0 {
+		header = append(header, ReachDeliveryRatioHeading)
+	}
+
+	newTable := new(tables.CsvTableImpl)
+	newTable.SetHeader(header)
+	newTable.SetColumnAndRowSize(uint(len(header)), uint(len(ids)))
+
+	for row := range ids {
+		newTable.SetCell(0, uint(row), ids[row])
+		newTable.SetCell(1, uint(row), downstreamIds[row])
+		if len(ratios) > 0 {
+			newTable.SetCell(2, uint(row), ratios[row])
+		}
+	}
+
+	return newTable
+}
+
+func TestNetwork_Initialise_BuildsTree(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	params := new(parameters.Parameters).Initialise()
+	networkUnderTest := new(Network).Initialise(buildTestTable(), *params)
+
+	g.Expect(networkUnderTest.Outlets()).To(ConsistOf(planningunit.Id(4), planningunit.Id(5)))
+	g.Expect(networkUnderTest.Upstream(3)).To(ConsistOf(planningunit.Id(1), planningunit.Id(2)))
+
+	downstream, hasDownstream := networkUnderTest.Downstream(1)
+	g.Expect(hasDownstream).To(BeTrue())
+	g.Expect(downstream).To(BeNumerically(equalTo, 3))
+
+	_, hasDownstream = networkUnderTest.Downstream(4)
+	g.Expect(hasDownstream).To(BeFalse())
+
+	g.Expect(networkUnderTest.DeliveryRatio(1)).To(BeNumerically(equalTo, 1))
+}
+
+func TestNetwork_Neighbours_UpstreamAndDownstream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	params := new(parameters.Parameters).Initialise()
+	networkUnderTest := new(Network).Initialise(buildTestTable(), *params)
+
+	g.Expect(networkUnderTest.Neighbours(3)).To(ConsistOf(planningunit.Id(1), planningunit.Id(2), planningunit.Id(4)))
+	g.Expect(networkUnderTest.Neighbours(1)).To(ConsistOf(planningunit.Id(3)))
+	g.Expect(networkUnderTest.Neighbours(5)).To(BeEmpty())
+}
+
+func TestNetwork_DeliveryRatio_CompoundsDownstream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	params := new(parameters.Parameters).Initialise()
+	networkUnderTest := new(Network).Initialise(buildTestTable(0.5, 1, 0.8, 0.9, 1), *params)
+
+	g.Expect(networkUnderTest.DeliveryRatio(1)).To(BeNumerically("~", 0.5*0.8*0.9))
+	g.Expect(networkUnderTest.DeliveryRatio(2)).To(BeNumerically("~", 0.8*0.9))
+	g.Expect(networkUnderTest.DeliveryRatio(4)).To(BeNumerically("~", 0.9))
+	g.Expect(networkUnderTest.DeliveryRatio(5)).To(BeNumerically(equalTo, 1))
+	g.Expect(networkUnderTest.DeliveryRatio(42)).To(BeNumerically(equalTo, 1))
+
+	g.Expect(networkUnderTest.Deliver(1, 10)).To(BeNumerically("~", 3.6))
+}
+
+func TestNetwork_AccumulatedLoads(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	params := new(parameters.Parameters).Initialise()
+	networkUnderTest := new(Network).Initialise(buildTestTable(0.5, 1, 0.8, 0.9, 1), *params)
+
+	localLoads := variable.PlanningUnitValueMap{1: 10, 2: 20, 3: 30, 4: 40, 5: 50}
+	deliveredLoads := make(variable.PlanningUnitValueMap, len(localLoads))
+	for id, load := range localLoads {
+		deliveredLoads[id] = networkUnderTest.Deliver(id, load)
+	}
+
+	accumulatedLoads := networkUnderTest.AccumulatedLoads(localLoads, nil)
+
+	expectedAtThree := (10*0.5 + 20 + 30) * 0.8
+	expectedAtFour := (expectedAtThree + 40) * 0.9
+
+	g.Expect(accumulatedLoads[1]).To(BeNumerically("~", 5))
+	g.Expect(accumulatedLoads[3]).To(BeNumerically("~", expectedAtThree))
+	g.Expect(accumulatedLoads[4]).To(BeNumerically("~", expectedAtFour))
+	g.Expect(accumulatedLoads[5]).To(BeNumerically("~", 50))
+
+	g.Expect(networkUnderTest.EndOfCatchmentLoad(localLoads, nil)).To(BeNumerically("~", expectedAtFour+50))
+
+	endOfCatchmentLoad := float64(0)
+	for _, deliveredLoad := range deliveredLoads {
+		endOfCatchmentLoad += deliveredLoad
+	}
+	g.Expect(endOfCatchmentLoad).To(BeNumerically("~", expectedAtFour+50))
+}
+
+func TestNetwork_InflowRemovals_TrapLoadsRoutedFromUpstream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	params := new(parameters.Parameters).Initialise()
+	networkUnderTest := new(Network).Initialise(buildTestTable(0.5, 1, 0.8, 0.9, 1), *params)
+
+	localLoads := variable.PlanningUnitValueMap{1: 10, 2: 20, 3: 30, 4: 40, 5: 50}
+	inflowRemovals := variable.PlanningUnitValueMap{3: 0.25}
+
+	// when
+	accumulatedLoads := networkUnderTest.AccumulatedLoads(localLoads, inflowRemovals)
+
+	// then
+	expectedAtThree := ((10*0.5+20)*0.75 + 30) * 0.8
+	g.Expect(accumulatedLoads[3]).To(BeNumerically("~", expectedAtThree))
+	g.Expect(accumulatedLoads[4]).To(BeNumerically("~", (expectedAtThree+40)*0.9))
+
+	g.Expect(networkUnderTest.DeliveryRatioThrough(1, inflowRemovals)).To(BeNumerically("~", 0.5*0.75*0.8*0.9))
+	g.Expect(networkUnderTest.DeliveryRatioThrough(3, inflowRemovals)).To(BeNumerically("~", 0.8*0.9))
+	g.Expect(networkUnderTest.UpstreamOf(4)).To(Equal(planningunit.Ids{3, 1, 2}))
+
+	// when
+	upstreamLoads := networkUnderTest.UpstreamLoadsWithRemoval(3, 1, localLoads, inflowRemovals)
+
+	// then
+	g.Expect(upstreamLoads).To(HaveLen(2))
+	g.Expect(upstreamLoads[1]).To(BeNumerically(equalTo, 0))
+
+	// when
+	upstreamLoads = networkUnderTest.UpstreamLoadsWithRemoval(3, 0.5, localLoads, inflowRemovals)
+
+	// then
+	g.Expect(upstreamLoads).To(HaveLen(2))
+	g.Expect(upstreamLoads[1]).To(BeNumerically("~", 10*0.5*0.5*0.8*0.9))
+	g.Expect(upstreamLoads[2]).To(BeNumerically("~", 20*0.5*0.8*0.9))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package network
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/math"
+)
+
+// UpstreamChange holds the values that a change in a subcatchment's inflow removal gives the planning units upstream
+// of it, with the values they replace, so that the change can be done and undone on a decision variable.
+type UpstreamChange struct {
+	doneValues   variable.PlanningUnitValueMap
+	undoneValues variable.PlanningUnitValueMap
+}
+
+// NewUpstreamChange returns the change of the decision variable supplied to the planning unit values given, rounded to
+// the variable's precision.
+func NewUpstreamChange(target variable.PlanningUnitDecisionVariable, values variable.PlanningUnitValueMap) UpstreamChange {
+	doneValues := make(variable.PlanningUnitValueMap, len(values))
+	undoneValues := make(variable.PlanningUnitValueMap, len(values))
+	for planningUnit, value := range values {
+		doneValues[planningUnit] = math.RoundFloat(value, int(target.Precision()))
+		undoneValues[planningUnit] = target.ValuesPerPlanningUnit()[planningUnit]
+	}
+	return UpstreamChange{doneValues: doneValues, undoneValues: undoneValues}
+}
+
+// Do sets the upstream planning unit values of the decision variable supplied to their changed values.
+func (c UpstreamChange) Do(target variable.PlanningUnitDecisionVariable) {
+	for planningUnit, value := range c.doneValues {
+		target.SetPlanningUnitValue(planningUnit, value)
+	}
+}
+
+// Undo returns the upstream planning unit values of the decision variable supplied to the values they had before.
+func (c UpstreamChange) Undo(target variable.PlanningUnitDecisionVariable) {
+	for planningUnit, value := range c.undoneValues {
+		target.SetPlanningUnitValue(planningUnit, value)
+	}
+}
+
+// Change returns the total change the upstream change makes to the decision variable.
+func (c UpstreamChange) Change() float64 {
+	change := float64(0)
+	for planningUnit, value := range c.doneValues {
+		change += value - c.undoneValues[planningUnit]
+	}
+	return change
+}
@@ -34,12 +34,26 @@ const (
 	GullySedimentReductionTarget             string = "GullySedimentReductionTarget"
 
 	HillSlopeDeliveryRatio string = "HillSlopeDeliveryRatio"
+	ReachDeliveryRatio     string = "ReachDeliveryRatio"
 
 	MaximumSedimentProduction            = "MaximumSedimentProduction"
 	MaximumImplementationCost            = "MaximumImplementationCost"
 	MaximumOpportunityCost               = "MaximumOpportunityCost"
 	MaximumParticulateNitrogenProduction = "MaximumParticulateNitrogenProduction"
 	MaximumDissolvedNitrogenProduction   = "MaximumDissolvedNitrogenProduction"
+
+	SwapMoveProbability             = "SwapMoveProbability"
+	KFlipMoveProbability            = "KFlipMoveProbability"
+	KFlipMoveSize                   = "KFlipMoveSize"
+	CostAwareMoveProbability        = "CostAwareMoveProbability"
+	IntensityMoveProbability        = "IntensityMoveProbability"
+	RescheduleMoveProbability       = "RescheduleMoveProbability"
+	MoveStatisticsReportingInterval = "MoveStatisticsReportingInterval"
+
+	PlanningHorizon                 = "PlanningHorizon"
+	EstablishmentPeriod             = "EstablishmentPeriod"
+	DiscountRate                    = "DiscountRate"
+	MaximumYearlyImplementationCost = "MaximumYearlyImplementationCost"
 )
 
 func ParameterSpecifications() *Specifications {
@@ -110,6 +124,12 @@ func ParameterSpecifications() *Specifications {
 			Validator:    IsDecimalBetweenZeroAndOne,
 			DefaultValue: float64(0.05),
 		},
+	).Add(
+		Specification{
+			Key:          ReachDeliveryRatio,
+			Validator:    validateIsReachDeliveryRatio,
+			DefaultValue: float64(1),
+		},
 	).Add(
 		Specification{
 			Key:        MaximumSedimentProduction,
@@ -140,6 +160,72 @@ func ParameterSpecifications() *Specifications {
 			Validator:  IsNonNegativeDecimal,
 			IsOptional: true,
 		},
+	).Add(
+		Specification{
+			Key:          SwapMoveProbability,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          KFlipMoveProbability,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          KFlipMoveSize,
+			Validator:    validateIsKFlipMoveSize,
+			DefaultValue: int64(2),
+		},
+	).Add(
+		Specification{
+			Key:          CostAwareMoveProbability,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          IntensityMoveProbability,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          RescheduleMoveProbability,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          PlanningHorizon,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0),
+		},
+	).Add(
+		Specification{
+			Key:          EstablishmentPeriod,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0),
+		},
+	).Add(
+		Specification{
+			Key:          DiscountRate,
+			Validator:    IsDecimalBetweenZeroAndOne,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:        MaximumYearlyImplementationCost,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
+	).Add(
+		Specification{
+			Key:          MoveStatisticsReportingInterval,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0),
+		},
 	)
 
 	return specs
@@ -150,3 +236,11 @@ func validateIsBankErosionFudgeFactor(key string, value interface{}) error {
 	maxValue := 5 * math.Pow(10, -4)
 	return IsDecimalWithInclusiveBounds(key, value, minValue, maxValue)
 }
+
+func validateIsReachDeliveryRatio(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, math.SmallestNonzeroFloat64, 1)
+}
+
+func validateIsKFlipMoveSize(key string, value interface{}) error {
+	return IsIntegerWithInclusiveBounds(key, value, 2, math.MaxInt64)
+}
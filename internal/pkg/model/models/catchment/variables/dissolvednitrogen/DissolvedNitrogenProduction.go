@@ -6,6 +6,8 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	catchmentActions "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
@@ -17,9 +19,6 @@ import (
 const (
 	VariableName = "DissolvedNitrogen"
 
-	planningUnitIndex           = 0
-	proportionOfVegetationIndex = 8
-
 	ProportionOfRiparianVegetation             = "ProportionOfRiparianVegetation"
 	RiparianDissolvedNitrogenRemovalEfficiency = "RiparianDissolvedNitrogenRemovalEfficiency"
 	WetlandsDissolvedNitrogenRemovalEfficiency = "WetlandsDissolvedNitrogenRemovalEfficiency"
@@ -29,6 +28,7 @@ const (
 )
 
 var _ variable.UndoableDecisionVariable = new(DissolvedNitrogenProduction)
+var _ variable.AccumulatingDecisionVariable = new(DissolvedNitrogenProduction)
 
 type DissolvedNitrogenProduction struct {
 	variable.PerPlanningUnitDecisionVariable
@@ -40,6 +40,9 @@ type DissolvedNitrogenProduction struct {
 
 	actionObserved action.ManagementAction
 
+	network     *network.Network
+	localValues variable.PlanningUnitValueMap
+
 	numberOfSubCatchments uint
 
 	subCatchmentAttributes map[planningunit.Id]attributes.Attributes
@@ -75,6 +78,11 @@ func (dn *DissolvedNitrogenProduction) WithObservers(observers ...variable.Obser
 	return dn
 }
 
+func (dn *DissolvedNitrogenProduction) WithNetwork(network *network.Network) *DissolvedNitrogenProduction {
+	dn.network = network
+	return dn
+}
+
 func (dn *DissolvedNitrogenProduction) deriveInitialState(subCatchmentsTable tables.CsvTable, parameters catchmentParameters.Parameters) {
 	dn.deriveNumberOfSubCatchments(subCatchmentsTable)
 	dn.initialiseSubCatchmentAttributes()
@@ -88,6 +96,7 @@ func (dn *DissolvedNitrogenProduction) deriveNumberOfSubCatchments(subCatchments
 
 func (dn *DissolvedNitrogenProduction) initialiseSubCatchmentAttributes() {
 	dn.subCatchmentAttributes = make(map[planningunit.Id]attributes.Attributes, dn.numberOfSubCatchments)
+	dn.localValues = make(variable.PlanningUnitValueMap, dn.numberOfSubCatchments)
 	for index, _ := range dn.subCatchmentAttributes {
 		newAttributes := make(attributes.Attributes, 0)
 		dn.subCatchmentAttributes[index] = newAttributes
@@ -101,11 +110,12 @@ func (dn *DissolvedNitrogenProduction) deriveInitialNitrogen(subCatchmentsTable
 }
 
 func (dn *DissolvedNitrogenProduction) buildDefaultSubCatchmentAttributes(subCatchmentsTable tables.CsvTable) {
+	columns := dataset.ColumnsOf(subCatchmentsTable)
 	for row := uint(0); row < dn.numberOfSubCatchments; row++ {
-		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(planningUnitIndex, row)
+		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(columns.Index(dataset.SubcatchmentHeading), row)
 		subCatchment := Float64ToSubCatchmentId(subCatchmentFloat64)
 
-		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(proportionOfVegetationIndex, row)
+		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(columns.Index(dataset.ProportionOfRiparianVegetationHeading), row)
 
 		dn.subCatchmentAttributes[subCatchment] =
 			dn.subCatchmentAttributes[subCatchment].
@@ -191,7 +201,8 @@ func (dn *DissolvedNitrogenProduction) updateDissolvedNitrogenFor(subCatchment p
 	}
 
 	nitrogenProduced := dn.calculateNitrogenProduction(context)
-	dn.SetPlanningUnitValue(subCatchment, nitrogenProduced)
+	dn.localValues[subCatchment] = nitrogenProduced
+	dn.SetPlanningUnitValue(subCatchment, dn.network.Deliver(subCatchment, nitrogenProduced))
 }
 
 func (dn *DissolvedNitrogenProduction) calculateNitrogenProduction(context nitrogenContext) float64 {
@@ -235,22 +246,11 @@ func (dn *DissolvedNitrogenProduction) observeAction(action action.ManagementAct
 }
 
 func (dn *DissolvedNitrogenProduction) handleRiverBankRestorationAction() {
-	var asIsNitrogen, asIsBufferVegetation, toBeNitrogen, toBeBufferVegetation float64
-
-	switch dn.actionObserved.IsActive() {
-	case true:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-		asIsBufferVegetation = dn.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
-
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-		toBeBufferVegetation = dn.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
-	case false:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-		asIsBufferVegetation = dn.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
+	asIsNitrogen := dn.valueAtPreviousIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
+	asIsBufferVegetation := dn.valueAtPreviousIntensity(catchmentActions.OriginalBufferVegetation, catchmentActions.ActionedBufferVegetation)
 
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-		toBeBufferVegetation = dn.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
-	}
+	toBeNitrogen := dn.valueAtIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
+	toBeBufferVegetation := dn.valueAtIntensity(catchmentActions.OriginalBufferVegetation, catchmentActions.ActionedBufferVegetation)
 
 	actionSubCatchment := dn.actionObserved.PlanningUnit()
 	attributes := dn.subCatchmentAttributes[actionSubCatchment]
@@ -279,25 +279,19 @@ func (dn *DissolvedNitrogenProduction) handleRiverBankRestorationAction() {
 
 	finalisedToBeNitrogen := dn.calculateNitrogenProduction(toBeContext)
 
-	dn.command = new(RiverBankRestorationCommand).
+	command := new(RiverBankRestorationCommand).
 		ForVariable(dn).
 		InPlanningUnit(actionSubCatchment).
 		WithVegetationProportion(toBeBufferVegetation).
 		WithNitrogenContribution(toBeNitrogen).
-		WithChange(finalisedToBeNitrogen - finalisedAsIsNitrogen)
+		WithChange(dn.deliveredChange(finalisedToBeNitrogen - finalisedAsIsNitrogen))
+	command.SetLocalChange(dn.localValues, finalisedToBeNitrogen-finalisedAsIsNitrogen)
+	dn.command = command
 }
 
 func (dn *DissolvedNitrogenProduction) handleGullyRestorationAction() {
-	var asIsNitrogen, toBeNitrogen float64
-
-	switch dn.actionObserved.IsActive() {
-	case true:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-	case false:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-	}
+	asIsNitrogen := dn.valueAtPreviousIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
+	toBeNitrogen := dn.valueAtIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
 
 	actionSubCatchment := dn.actionObserved.PlanningUnit()
 	attributes := dn.subCatchmentAttributes[actionSubCatchment]
@@ -326,24 +320,18 @@ func (dn *DissolvedNitrogenProduction) handleGullyRestorationAction() {
 
 	finalisedToBeNitrogen := dn.calculateNitrogenProduction(toBeContext)
 
-	dn.command = new(GullyRestorationCommand).
+	command := new(GullyRestorationCommand).
 		ForVariable(dn).
 		InPlanningUnit(actionSubCatchment).
 		WithNitrogenContribution(toBeNitrogen).
-		WithChange(finalisedToBeNitrogen - finalisedAsIsNitrogen)
+		WithChange(dn.deliveredChange(finalisedToBeNitrogen - finalisedAsIsNitrogen))
+	command.SetLocalChange(dn.localValues, finalisedToBeNitrogen-finalisedAsIsNitrogen)
+	dn.command = command
 }
 
 func (dn *DissolvedNitrogenProduction) handleHillSlopeRestorationAction() {
-	var asIsNitrogen, toBeNitrogen float64
-
-	switch dn.actionObserved.IsActive() {
-	case true:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-	case false:
-		asIsNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenActionedAttribute)
-		toBeNitrogen = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenOriginalAttribute)
-	}
+	asIsNitrogen := dn.valueAtPreviousIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
+	toBeNitrogen := dn.valueAtIntensity(catchmentActions.DissolvedNitrogenOriginalAttribute, catchmentActions.DissolvedNitrogenActionedAttribute)
 
 	actionSubCatchment := dn.actionObserved.PlanningUnit()
 	attributes := dn.subCatchmentAttributes[actionSubCatchment]
@@ -372,24 +360,19 @@ func (dn *DissolvedNitrogenProduction) handleHillSlopeRestorationAction() {
 
 	finalisedToBeNitrogen := dn.calculateNitrogenProduction(toBeContext)
 
-	dn.command = new(HillSlopeRevegetationCommand).
+	command := new(HillSlopeRevegetationCommand).
 		ForVariable(dn).
 		InPlanningUnit(actionSubCatchment).
 		WithNitrogenContribution(toBeNitrogen).
-		WithChange(finalisedToBeNitrogen - finalisedAsIsNitrogen)
+		WithChange(dn.deliveredChange(finalisedToBeNitrogen - finalisedAsIsNitrogen))
+	command.SetLocalChange(dn.localValues, finalisedToBeNitrogen-finalisedAsIsNitrogen)
+	dn.command = command
 }
 
 func (dn *DissolvedNitrogenProduction) handleWetlandsEstablishmentAction() {
-	var asIsRemovalEfficiency, toBeRemovalEfficiency float64
-
-	switch dn.actionObserved.IsActive() {
-	case true:
-		asIsRemovalEfficiency = 0
-		toBeRemovalEfficiency = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenRemovalEfficiency)
-	case false:
-		asIsRemovalEfficiency = dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenRemovalEfficiency)
-		toBeRemovalEfficiency = 0
-	}
+	removalEfficiency := dn.actionObserved.ModelVariableValue(catchmentActions.DissolvedNitrogenRemovalEfficiency)
+	asIsRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.PreviousEffectiveIntensity(dn.actionObserved))
+	toBeRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.EffectiveIntensity(dn.actionObserved))
 
 	actionSubCatchment := dn.actionObserved.PlanningUnit()
 	attributes := dn.subCatchmentAttributes[actionSubCatchment]
@@ -418,11 +401,14 @@ func (dn *DissolvedNitrogenProduction) handleWetlandsEstablishmentAction() {
 
 	finalisedToBeNitrogen := dn.calculateNitrogenProduction(toBeContext)
 
-	dn.command = new(WetlandsEstablishmentCommand).
+	command := new(WetlandsEstablishmentCommand).
 		ForVariable(dn).
 		InPlanningUnit(dn.actionObserved.PlanningUnit()).
 		WithRemovalEfficiency(toBeRemovalEfficiency).
-		WithChange(finalisedToBeNitrogen - finalisedAsIsNitrogen)
+		WithChange(dn.deliveredChange(finalisedToBeNitrogen - finalisedAsIsNitrogen)).
+		WithUpstreamValues(dn.upstreamValuesWithInflowRemoval(toBeRemovalEfficiency))
+	command.SetLocalChange(dn.localValues, finalisedToBeNitrogen-finalisedAsIsNitrogen)
+	dn.command = command
 }
 
 // NotifyObservers allows structs embedding a BaseInductiveDecisionVariable to trigger a notification of change
@@ -433,6 +419,57 @@ func (dn *DissolvedNitrogenProduction) NotifyObservers() {
 	}
 }
 
+// valueAtIntensity returns the observed action's value for the variable at the action's effective intensity,
+// interpolating between its original and actioned variable values.
+func (dn *DissolvedNitrogenProduction) valueAtIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return dn.interpolatedValue(originalName, actionedName, action.EffectiveIntensity(dn.actionObserved))
+}
+
+// valueAtPreviousIntensity returns the observed action's value for the variable at the action's effective
+// intensity before its latest change.
+func (dn *DissolvedNitrogenProduction) valueAtPreviousIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return dn.interpolatedValue(originalName, actionedName, action.PreviousEffectiveIntensity(dn.actionObserved))
+}
+
+func (dn *DissolvedNitrogenProduction) interpolatedValue(originalName, actionedName action.ModelVariableName, intensity float64) float64 {
+	return action.Interpolate(
+		dn.actionObserved.ModelVariableValue(originalName),
+		dn.actionObserved.ModelVariableValue(actionedName),
+		intensity,
+	)
+}
+
+func (dn *DissolvedNitrogenProduction) deliveredChange(localChange float64) float64 {
+	return dn.network.DeliverThrough(dn.actionObserved.PlanningUnit(), localChange, dn.inflowRemovals())
+}
+
+// upstreamValuesWithInflowRemoval returns the values of the planning units upstream of the observed wetland action,
+// were the wetland to remove the proportion of the dissolved nitrogen routed through it given.
+func (dn *DissolvedNitrogenProduction) upstreamValuesWithInflowRemoval(removalEfficiency float64) variable.PlanningUnitValueMap {
+	return dn.network.UpstreamLoadsWithRemoval(
+		dn.actionObserved.PlanningUnit(), removalEfficiency, dn.localValues, dn.inflowRemovals(),
+	)
+}
+
+// inflowRemovals returns, for each planning unit with a wetland established, the proportion of dissolved nitrogen routed
+// into it from upstream that the wetland removes.
+func (dn *DissolvedNitrogenProduction) inflowRemovals() variable.PlanningUnitValueMap {
+	inflowRemovals := make(variable.PlanningUnitValueMap)
+	for subCatchment, subCatchmentAttributes := range dn.subCatchmentAttributes {
+		removalEfficiency := subCatchmentAttributes.Value(WetlandsDissolvedNitrogenRemovalEfficiency).(float64)
+		if removalEfficiency > 0 {
+			inflowRemovals[subCatchment] = removalEfficiency
+		}
+	}
+	return inflowRemovals
+}
+
+// AccumulatedValuesPerPlanningUnit returns, for each planning unit, the dissolved nitrogen leaving it, being its own
+// dissolved nitrogen plus that routed to it from upstream, less any its wetland removes.
+func (dn *DissolvedNitrogenProduction) AccumulatedValuesPerPlanningUnit() variable.PlanningUnitValueMap {
+	return dn.network.AccumulatedLoads(dn.localValues, dn.inflowRemovals())
+}
+
 func (dn *DissolvedNitrogenProduction) UndoableValue() float64 {
 	return dn.Value() + dn.command.Value()
 }
@@ -5,6 +5,7 @@ package opportunitycost
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/schedule"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/math"
@@ -20,6 +21,7 @@ type OpportunityCost struct {
 	variable.Bounds
 
 	actionObserved action.ManagementAction
+	horizon        schedule.Horizon
 
 	command variable.ChangeCommand
 }
@@ -37,6 +39,12 @@ func (ic *OpportunityCost) Initialise() *OpportunityCost {
 	return ic
 }
 
+// WithHorizon discounts the cost of each action to its start year over the planning horizon supplied.
+func (ic *OpportunityCost) WithHorizon(horizon schedule.Horizon) *OpportunityCost {
+	ic.horizon = horizon
+	return ic
+}
+
 func (ic *OpportunityCost) WithObservers(observers ...variable.Observer) *OpportunityCost {
 	ic.Subscribe(observers...)
 	return ic
@@ -74,13 +82,9 @@ func (ic *OpportunityCost) observeAction(action action.ManagementAction) {
 func (ic *OpportunityCost) handleActionForModelVariable(name action.ModelVariableName) {
 	actionCost := ic.actionObserved.ModelVariableValue(name)
 
-	var newValue float64
-	switch ic.actionObserved.IsActive() {
-	case true:
-		newValue = actionCost
-	case false:
-		newValue = -1 * actionCost
-	}
+	discountedIntensity := ic.actionObserved.Intensity() * ic.horizon.DiscountFactor(ic.actionObserved.StartYear())
+	previousDiscountedIntensity := ic.actionObserved.PreviousIntensity() * ic.horizon.DiscountFactor(ic.actionObserved.PreviousStartYear())
+	newValue := actionCost * (discountedIntensity - previousDiscountedIntensity)
 
 	newValue = math.RoundFloat(newValue, int(ic.Precision()))
 
@@ -3,13 +3,14 @@
 package particulatenitrogen
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/command"
 )
 
 type HillSlopeRevegetationCommand struct {
-	variable.ChangePerPlanningUnitDecisionVariableCommand
+	network.LocalChangeCommand
 
 	undoneHillSlopeContribution float64
 	doneHillSlopeContribution   float64
@@ -44,7 +45,7 @@ func (c *HillSlopeRevegetationCommand) Do() command.CommandStatus {
 	if c.BaseCommand.Do() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.LocalChangeCommand.DoUnguarded()
 	c.setHillSlopeNitrogenContribution(c.doneHillSlopeContribution)
 	return command.Done
 }
@@ -53,7 +54,7 @@ func (c *HillSlopeRevegetationCommand) Undo() command.CommandStatus {
 	if c.BaseCommand.Undo() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.LocalChangeCommand.UndoUnguarded()
 	c.setHillSlopeNitrogenContribution(c.undoneHillSlopeContribution)
 	return command.UnDone
 }
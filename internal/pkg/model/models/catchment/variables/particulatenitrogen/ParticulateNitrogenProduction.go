@@ -6,6 +6,8 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	catchmentActions "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/sedimentproduction"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
@@ -18,9 +20,6 @@ import (
 const (
 	VariableName = "ParticulateNitrogen"
 
-	planningUnitIndex           = 0
-	proportionOfVegetationIndex = 8
-
 	RiverbankVegetationProportion = "RiverbankVegetationProportion"
 	RiparianFineSediment          = "RiparianFineSediment"
 
@@ -34,6 +33,7 @@ const (
 )
 
 var _ variable.UndoableDecisionVariable = new(ParticulateNitrogenProduction)
+var _ variable.AccumulatingDecisionVariable = new(ParticulateNitrogenProduction)
 
 type ParticulateNitrogenProduction struct {
 	variable.PerPlanningUnitDecisionVariable
@@ -45,6 +45,9 @@ type ParticulateNitrogenProduction struct {
 
 	actionObserved action.ManagementAction
 
+	network     *network.Network
+	localValues variable.PlanningUnitValueMap
+
 	sedimentProductionVariable *sedimentproduction.SedimentProduction
 
 	numberOfSubCatchments uint
@@ -90,6 +93,11 @@ func (np *ParticulateNitrogenProduction) WithObservers(observers ...variable.Obs
 	return np
 }
 
+func (np *ParticulateNitrogenProduction) WithNetwork(network *network.Network) *ParticulateNitrogenProduction {
+	np.network = network
+	return np
+}
+
 func (np *ParticulateNitrogenProduction) WithSedimentProductionVariable(variable *sedimentproduction.SedimentProduction) *ParticulateNitrogenProduction {
 	np.sedimentProductionVariable = variable
 	return np
@@ -108,6 +116,7 @@ func (np *ParticulateNitrogenProduction) deriveNumberOfSubCatchments(subCatchmen
 
 func (np *ParticulateNitrogenProduction) initialiseSubCatchmentAttributes() {
 	np.subCatchmentAttributes = make(map[planningunit.Id]attributes.Attributes, np.numberOfSubCatchments)
+	np.localValues = make(variable.PlanningUnitValueMap, np.numberOfSubCatchments)
 	for index, _ := range np.subCatchmentAttributes {
 		newAttributes := make(attributes.Attributes, 0)
 		np.subCatchmentAttributes[index] = newAttributes
@@ -121,11 +130,12 @@ func (np *ParticulateNitrogenProduction) deriveInitialNitrogen(subCatchmentsTabl
 }
 
 func (np *ParticulateNitrogenProduction) buildDefaultSubCatchmentAttributes(subCatchmentsTable tables.CsvTable) {
+	columns := dataset.ColumnsOf(subCatchmentsTable)
 	for row := uint(0); row < np.numberOfSubCatchments; row++ {
-		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(planningUnitIndex, row)
+		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(columns.Index(dataset.SubcatchmentHeading), row)
 		subCatchment := Float64ToSubCatchmentId(subCatchmentFloat64)
 
-		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(proportionOfVegetationIndex, row)
+		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(columns.Index(dataset.ProportionOfRiparianVegetationHeading), row)
 
 		np.subCatchmentAttributes[subCatchment] =
 			np.subCatchmentAttributes[subCatchment].
@@ -232,7 +242,8 @@ func (np *ParticulateNitrogenProduction) updateParticulateNitrogenFor(subCatchme
 	}
 
 	nitrogenProduced := np.calculateNitrogenProduction(context)
-	np.SetPlanningUnitValue(subCatchment, nitrogenProduced)
+	np.localValues[subCatchment] = nitrogenProduced
+	np.SetPlanningUnitValue(subCatchment, np.network.Deliver(subCatchment, nitrogenProduced))
 }
 
 func (np *ParticulateNitrogenProduction) calculateNitrogenProduction(context nitrogenContext) float64 {
@@ -292,27 +303,13 @@ func (np *ParticulateNitrogenProduction) observeAction(action action.ManagementA
 }
 
 func (np *ParticulateNitrogenProduction) handleRiverBankRestorationAction() {
-	var asIsVegetation, asIsRiparianSediment, asIsFineSediment,
-		toBeVegetation, toBeRiparianSediment, toBeFineSediment float64
-
-	switch np.actionObserved.IsActive() {
-	case true:
-		asIsRiparianSediment = np.actionObserved.ModelVariableValue(catchmentActions.OriginalRiparianSedimentProduction)
-		asIsFineSediment = np.actionObserved.ModelVariableValue(catchmentActions.FineSedimentOriginalAttribute)
-		asIsVegetation = np.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
-
-		toBeRiparianSediment = np.actionObserved.ModelVariableValue(catchmentActions.ActionedRiparianSedimentProduction)
-		toBeFineSediment = np.actionObserved.ModelVariableValue(catchmentActions.FineSedimentActionedAttribute)
-		toBeVegetation = np.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
-	case false:
-		asIsRiparianSediment = np.actionObserved.ModelVariableValue(catchmentActions.ActionedRiparianSedimentProduction)
-		asIsFineSediment = np.actionObserved.ModelVariableValue(catchmentActions.FineSedimentActionedAttribute)
-		asIsVegetation = np.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
-
-		toBeRiparianSediment = np.actionObserved.ModelVariableValue(catchmentActions.OriginalRiparianSedimentProduction)
-		toBeFineSediment = np.actionObserved.ModelVariableValue(catchmentActions.FineSedimentOriginalAttribute)
-		toBeVegetation = np.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
-	}
+	asIsRiparianSediment := np.valueAtPreviousIntensity(catchmentActions.OriginalRiparianSedimentProduction, catchmentActions.ActionedRiparianSedimentProduction)
+	asIsFineSediment := np.valueAtPreviousIntensity(catchmentActions.FineSedimentOriginalAttribute, catchmentActions.FineSedimentActionedAttribute)
+	asIsVegetation := np.valueAtPreviousIntensity(catchmentActions.OriginalBufferVegetation, catchmentActions.ActionedBufferVegetation)
+
+	toBeRiparianSediment := np.valueAtIntensity(catchmentActions.OriginalRiparianSedimentProduction, catchmentActions.ActionedRiparianSedimentProduction)
+	toBeFineSediment := np.valueAtIntensity(catchmentActions.FineSedimentOriginalAttribute, catchmentActions.FineSedimentActionedAttribute)
+	toBeVegetation := np.valueAtIntensity(catchmentActions.OriginalBufferVegetation, catchmentActions.ActionedBufferVegetation)
 
 	actionSubCatchment := np.actionObserved.PlanningUnit()
 	attributes := np.subCatchmentAttributes[actionSubCatchment]
@@ -343,25 +340,19 @@ func (np *ParticulateNitrogenProduction) handleRiverBankRestorationAction() {
 
 	toBeNitrogen := np.calculateNitrogenProduction(toBeContext)
 
-	np.command = new(RiverBankRestorationCommand).
+	command := new(RiverBankRestorationCommand).
 		ForVariable(np).
 		InPlanningUnit(actionSubCatchment).
 		WithVegetationProportion(toBeVegetation).
 		WithRiverBankNitrogenContribution(toBeRiparianNitrogen).
-		WithChange(toBeNitrogen - asIsNitrogen)
+		WithChange(np.deliveredChange(toBeNitrogen - asIsNitrogen))
+	command.SetLocalChange(np.localValues, toBeNitrogen-asIsNitrogen)
+	np.command = command
 }
 
 func (np *ParticulateNitrogenProduction) handleGullyRestorationAction() {
-	var asIsGullyNitrogen, toBeGullyNitrogen float64
-
-	switch np.actionObserved.IsActive() {
-	case true:
-		asIsGullyNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenOriginalAttribute)
-		toBeGullyNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenActionedAttribute)
-	case false:
-		asIsGullyNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenActionedAttribute)
-		toBeGullyNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenOriginalAttribute)
-	}
+	asIsGullyNitrogen := np.valueAtPreviousIntensity(catchmentActions.ParticulateNitrogenOriginalAttribute, catchmentActions.ParticulateNitrogenActionedAttribute)
+	toBeGullyNitrogen := np.valueAtIntensity(catchmentActions.ParticulateNitrogenOriginalAttribute, catchmentActions.ParticulateNitrogenActionedAttribute)
 
 	actionSubCatchment := np.actionObserved.PlanningUnit()
 	attributes := np.subCatchmentAttributes[actionSubCatchment]
@@ -388,24 +379,18 @@ func (np *ParticulateNitrogenProduction) handleGullyRestorationAction() {
 
 	toBeNitrogen := np.calculateNitrogenProduction(toBeContext)
 
-	np.command = new(GullyRestorationCommand).
+	command := new(GullyRestorationCommand).
 		ForVariable(np).
 		InPlanningUnit(actionSubCatchment).
 		WithNitrogenContribution(toBeGullyNitrogen).
-		WithChange(toBeNitrogen - asIsNitrogen)
+		WithChange(np.deliveredChange(toBeNitrogen - asIsNitrogen))
+	command.SetLocalChange(np.localValues, toBeNitrogen-asIsNitrogen)
+	np.command = command
 }
 
 func (np *ParticulateNitrogenProduction) handleHillSlopeRestorationAction() {
-	var asIsHillSlopeNitrogen, toBeHillSlopeNitrogen float64
-
-	switch np.actionObserved.IsActive() {
-	case true:
-		asIsHillSlopeNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenOriginalAttribute)
-		toBeHillSlopeNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenActionedAttribute)
-	case false:
-		asIsHillSlopeNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenActionedAttribute)
-		toBeHillSlopeNitrogen = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenOriginalAttribute)
-	}
+	asIsHillSlopeNitrogen := np.valueAtPreviousIntensity(catchmentActions.ParticulateNitrogenOriginalAttribute, catchmentActions.ParticulateNitrogenActionedAttribute)
+	toBeHillSlopeNitrogen := np.valueAtIntensity(catchmentActions.ParticulateNitrogenOriginalAttribute, catchmentActions.ParticulateNitrogenActionedAttribute)
 
 	actionSubCatchment := np.actionObserved.PlanningUnit()
 	attributes := np.subCatchmentAttributes[actionSubCatchment]
@@ -432,24 +417,19 @@ func (np *ParticulateNitrogenProduction) handleHillSlopeRestorationAction() {
 
 	toBeNitrogen := np.calculateNitrogenProduction(toBeContext)
 
-	np.command = new(HillSlopeRevegetationCommand).
+	command := new(HillSlopeRevegetationCommand).
 		ForVariable(np).
 		InPlanningUnit(actionSubCatchment).
 		WithFilteredNitrogenContribution(toBeHillSlopeNitrogen).
-		WithChange(toBeNitrogen - asIsNitrogen)
+		WithChange(np.deliveredChange(toBeNitrogen - asIsNitrogen))
+	command.SetLocalChange(np.localValues, toBeNitrogen-asIsNitrogen)
+	np.command = command
 }
 
 func (np *ParticulateNitrogenProduction) handleWetlandsEstablishmentAction() {
-	var asIsRemovalEfficiency, toBeRemovalEfficiency float64
-
-	switch np.actionObserved.IsActive() {
-	case true:
-		asIsRemovalEfficiency = 0
-		toBeRemovalEfficiency = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenRemovalEfficiency)
-	case false:
-		asIsRemovalEfficiency = np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenRemovalEfficiency)
-		toBeRemovalEfficiency = 0
-	}
+	removalEfficiency := np.actionObserved.ModelVariableValue(catchmentActions.ParticulateNitrogenRemovalEfficiency)
+	asIsRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.PreviousEffectiveIntensity(np.actionObserved))
+	toBeRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.EffectiveIntensity(np.actionObserved))
 
 	actionSubCatchment := np.actionObserved.PlanningUnit()
 	attributes := np.subCatchmentAttributes[actionSubCatchment]
@@ -476,11 +456,14 @@ func (np *ParticulateNitrogenProduction) handleWetlandsEstablishmentAction() {
 
 	toBeNitrogen := np.calculateNitrogenProduction(toBeContext)
 
-	np.command = new(WetlandsEstablishmentCommand).
+	command := new(WetlandsEstablishmentCommand).
 		ForVariable(np).
 		InPlanningUnit(actionSubCatchment).
 		WithRemovalEfficiency(toBeRemovalEfficiency).
-		WithChange(toBeNitrogen - asIsNitrogen)
+		WithChange(np.deliveredChange(toBeNitrogen - asIsNitrogen)).
+		WithUpstreamValues(np.upstreamValuesWithInflowRemoval(toBeRemovalEfficiency))
+	command.SetLocalChange(np.localValues, toBeNitrogen-asIsNitrogen)
+	np.command = command
 }
 
 // NotifyObservers allows structs embedding a BaseInductiveDecisionVariable to trigger a notification of change
@@ -491,6 +474,56 @@ func (np *ParticulateNitrogenProduction) NotifyObservers() {
 	}
 }
 
+// valueAtIntensity returns the observed action's value for the variable at the action's effective intensity,
+// interpolating between its original and actioned variable values.
+func (np *ParticulateNitrogenProduction) valueAtIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return np.interpolatedValue(originalName, actionedName, action.EffectiveIntensity(np.actionObserved))
+}
+
+// valueAtPreviousIntensity returns the observed action's value for the variable at the action's effective
+// intensity before its latest change.
+func (np *ParticulateNitrogenProduction) valueAtPreviousIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return np.interpolatedValue(originalName, actionedName, action.PreviousEffectiveIntensity(np.actionObserved))
+}
+
+func (np *ParticulateNitrogenProduction) interpolatedValue(originalName, actionedName action.ModelVariableName, intensity float64) float64 {
+	return action.Interpolate(
+		np.actionObserved.ModelVariableValue(originalName),
+		np.actionObserved.ModelVariableValue(actionedName),
+		intensity,
+	)
+}
+
+func (np *ParticulateNitrogenProduction) deliveredChange(localChange float64) float64 {
+	return np.network.DeliverThrough(np.actionObserved.PlanningUnit(), localChange, np.inflowRemovals())
+}
+
+// upstreamValuesWithInflowRemoval returns the values of the planning units upstream of the observed wetland action,
+// were the wetland to remove the proportion of the particulate nitrogen routed through it given.
+func (np *ParticulateNitrogenProduction) upstreamValuesWithInflowRemoval(removalEfficiency float64) variable.PlanningUnitValueMap {
+	return np.network.UpstreamLoadsWithRemoval(
+		np.actionObserved.PlanningUnit(), removalEfficiency, np.localValues, np.inflowRemovals(),
+	)
+}
+
+// inflowRemovals returns, for each planning unit with a wetland established, the proportion of particulate nitrogen
+// routed into it from upstream that the wetland removes.
+func (np *ParticulateNitrogenProduction) inflowRemovals() variable.PlanningUnitValueMap {
+	inflowRemovals := make(variable.PlanningUnitValueMap)
+	for subCatchment, subCatchmentAttributes := range np.subCatchmentAttributes {
+		if removalEfficiency := subCatchmentAttributes.Value(WetlandRemovalEfficiency).(float64); removalEfficiency > 0 {
+			inflowRemovals[subCatchment] = removalEfficiency
+		}
+	}
+	return inflowRemovals
+}
+
+// AccumulatedValuesPerPlanningUnit returns, for each planning unit, the particulate nitrogen leaving it, being its
+// own particulate nitrogen plus that routed to it from upstream, less any its wetland removes.
+func (np *ParticulateNitrogenProduction) AccumulatedValuesPerPlanningUnit() variable.PlanningUnitValueMap {
+	return np.network.AccumulatedLoads(np.localValues, np.inflowRemovals())
+}
+
 func (np *ParticulateNitrogenProduction) UndoableValue() float64 {
 	return np.Value() + np.command.Value()
 }
@@ -3,13 +3,14 @@
 package particulatenitrogen
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/command"
 )
 
 type RiverBankRestorationCommand struct {
-	variable.ChangePerPlanningUnitDecisionVariableCommand
+	network.LocalChangeCommand
 
 	doneRiparianVegetationProportion   float64
 	undoneRiparianVegetationProportion float64
@@ -54,7 +55,7 @@ func (c *RiverBankRestorationCommand) Do() command.CommandStatus {
 	if c.BaseCommand.Do() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.LocalChangeCommand.DoUnguarded()
 	c.setRiparianVegetationProportion(c.doneRiparianVegetationProportion)
 	c.setRiparianNitrogenContribution(c.doneRiparianContribution)
 	return command.Done
@@ -64,7 +65,7 @@ func (c *RiverBankRestorationCommand) Undo() command.CommandStatus {
 	if c.BaseCommand.Undo() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.LocalChangeCommand.UndoUnguarded()
 	c.setRiparianVegetationProportion(c.undoneRiparianVegetationProportion)
 	c.setRiparianNitrogenContribution(c.undoneRiparianContribution)
 	return command.UnDone
@@ -3,16 +3,19 @@
 package particulatenitrogen
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/command"
 )
 
 type WetlandsEstablishmentCommand struct {
-	variable.ChangePerPlanningUnitDecisionVariableCommand
+	network.LocalChangeCommand
 
 	undoneRemovalEfficiency float64
 	doneRemovalEfficiency   float64
+
+	upstream network.UpstreamChange
 }
 
 func (c *WetlandsEstablishmentCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *WetlandsEstablishmentCommand {
@@ -36,6 +39,18 @@ func (c *WetlandsEstablishmentCommand) WithChange(changeValue float64) *Wetlands
 	return c
 }
 
+// WithUpstreamValues supplies the values that planning units upstream take on, as the wetland removes a different
+// proportion of the load routed through it from them.
+func (c *WetlandsEstablishmentCommand) WithUpstreamValues(values variable.PlanningUnitValueMap) *WetlandsEstablishmentCommand {
+	c.upstream = network.NewUpstreamChange(c.Variable(), values)
+	return c
+}
+
+// Change returns the change in the variable's value, both in the wetland's planning unit and those upstream of it.
+func (c *WetlandsEstablishmentCommand) Change() float64 {
+	return c.ChangePerPlanningUnitDecisionVariableCommand.Change() + c.upstream.Change()
+}
+
 func (c *WetlandsEstablishmentCommand) variable() *ParticulateNitrogenProduction {
 	return c.Target().(*ParticulateNitrogenProduction)
 }
@@ -44,7 +59,8 @@ func (c *WetlandsEstablishmentCommand) Do() command.CommandStatus {
 	if c.BaseCommand.Do() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.LocalChangeCommand.DoUnguarded()
+	c.upstream.Do(c.Variable())
 	c.setRemovalEfficiency(c.doneRemovalEfficiency)
 	return command.Done
 }
@@ -53,7 +69,8 @@ func (c *WetlandsEstablishmentCommand) Undo() command.CommandStatus {
 	if c.BaseCommand.Undo() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.LocalChangeCommand.UndoUnguarded()
+	c.upstream.Undo(c.Variable())
 	c.setRemovalEfficiency(c.undoneRemovalEfficiency)
 	return command.UnDone
 }
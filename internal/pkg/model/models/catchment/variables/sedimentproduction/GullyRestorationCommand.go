@@ -3,6 +3,7 @@
 package sedimentproduction
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/command"
@@ -11,7 +12,7 @@ import (
 var _ variable.ChangeCommand = new(GullyRestorationCommand)
 
 type GullyRestorationCommand struct {
-	variable.ChangePerPlanningUnitDecisionVariableCommand
+	network.LocalChangeCommand
 
 	undoneGullyContribution float64
 	doneGullyContribution   float64
@@ -27,12 +28,14 @@ func (c *GullyRestorationCommand) InPlanningUnit(planningUnit planningunit.Id) *
 	return c
 }
 
-func (c *GullyRestorationCommand) WithChange(changeValue float64) *GullyRestorationCommand {
-	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
-
+func (c *GullyRestorationCommand) WithGullyContribution(contribution float64) *GullyRestorationCommand {
 	c.undoneGullyContribution = c.gullySedimentContribution()
-	c.doneGullyContribution = c.undoneGullyContribution + changeValue
+	c.doneGullyContribution = contribution
+	return c
+}
 
+func (c *GullyRestorationCommand) WithChange(changeValue float64) *GullyRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
 	return c
 }
 
@@ -44,7 +47,7 @@ func (c *GullyRestorationCommand) Do() command.CommandStatus {
 	if c.BaseCommand.Do() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.LocalChangeCommand.DoUnguarded()
 	c.setGullySedimentContribution(c.doneGullyContribution)
 	return command.Done
 }
@@ -53,7 +56,7 @@ func (c *GullyRestorationCommand) Undo() command.CommandStatus {
 	if c.BaseCommand.Undo() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.LocalChangeCommand.UndoUnguarded()
 	c.setGullySedimentContribution(c.undoneGullyContribution)
 	return command.UnDone
 }
@@ -3,13 +3,14 @@
 package sedimentproduction
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/command"
 )
 
 type RiverBankRestorationCommand struct {
-	variable.ChangePerPlanningUnitDecisionVariableCommand
+	network.LocalChangeCommand
 
 	doneRiparianVegetationProportion   float64
 	undoneRiparianVegetationProportion float64
@@ -55,7 +56,7 @@ func (c *RiverBankRestorationCommand) Do() command.CommandStatus {
 	if c.BaseCommand.Do() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.LocalChangeCommand.DoUnguarded()
 	c.setRiparianVegetation(c.doneRiparianVegetationProportion)
 	c.setRiverbankSedimentContribution(c.doneRiverbankContribution)
 	return command.Done
@@ -65,7 +66,7 @@ func (c *RiverBankRestorationCommand) Undo() command.CommandStatus {
 	if c.BaseCommand.Undo() == command.NoChange {
 		return command.NoChange
 	}
-	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.LocalChangeCommand.UndoUnguarded()
 	c.setRiparianVegetation(c.undoneRiparianVegetationProportion)
 	c.setRiverbankSedimentContribution(c.undoneRiverbankContribution)
 	return command.UnDone
@@ -7,6 +7,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/network"
 	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
@@ -27,9 +28,7 @@ const (
 	HillSlopeSedimentContribution = "HillSlopeSedimentContribution"
 )
 
-var _ variable.DecisionVariable = new(SedimentProduction)
-
-const planningUnitIndex = 0
+var _ variable.AccumulatingDecisionVariable = new(SedimentProduction)
 
 type SedimentProduction struct {
 	variable.PerPlanningUnitDecisionVariable
@@ -43,6 +42,9 @@ type SedimentProduction struct {
 	gullySedimentContribution     actions.GullySedimentContribution
 	hillSlopeSedimentContribution actions.HillSlopeSedimentContribution
 
+	network     *network.Network
+	localValues variable.PlanningUnitValueMap
+
 	numberOfPlanningUnits      uint
 	cachedPlanningUnitSediment float64
 	hillSlopeDeliveryRatio     float64
@@ -80,6 +82,7 @@ func (sl *SedimentProduction) deriveInitialState(dataSet *dataset.DataSetImpl, p
 
 func (sl *SedimentProduction) initialisePlanningUnitAttributes() {
 	sl.planningUnitAttributes = make(map[planningunit.Id]attributes.Attributes, sl.numberOfPlanningUnits)
+	sl.localValues = make(variable.PlanningUnitValueMap, sl.numberOfPlanningUnits)
 	for index, _ := range sl.planningUnitAttributes {
 		newAttributes := make(attributes.Attributes, 0)
 		sl.planningUnitAttributes[index] = newAttributes
@@ -100,9 +103,15 @@ func (sl *SedimentProduction) WithObservers(observers ...variable.Observer) *Sed
 	return sl
 }
 
+func (sl *SedimentProduction) WithNetwork(network *network.Network) *SedimentProduction {
+	sl.network = network
+	return sl
+}
+
 func (sl *SedimentProduction) deriveInitialSedimentProduction(planningUnitTable tables.CsvTable) {
+	columns := dataset.ColumnsOf(planningUnitTable)
 	for row := uint(0); row < sl.numberOfPlanningUnits; row++ {
-		planningUnitFloat64 := planningUnitTable.CellFloat64(planningUnitIndex, row)
+		planningUnitFloat64 := planningUnitTable.CellFloat64(columns.Index(dataset.SubcatchmentHeading), row)
 		planningUnit := Float64ToPlanningUnitId(planningUnitFloat64)
 
 		riverbankSedimentContribution := sl.bankSedimentContribution.OriginalPlanningUnitSedimentContribution(planningUnit)
@@ -122,7 +131,8 @@ func (sl *SedimentProduction) deriveInitialSedimentProduction(planningUnitTable
 		sedimentProduced := riverbankSedimentContribution + gullySedimentContribution + hillSlopeSedimentContribution
 		roundedSedimentProduced := math.RoundFloat(sedimentProduced, int(sl.Precision()))
 
-		sl.SetPlanningUnitValue(planningUnit, roundedSedimentProduced)
+		sl.localValues[planningUnit] = roundedSedimentProduced
+		sl.SetPlanningUnitValue(planningUnit, sl.network.Deliver(planningUnit, roundedSedimentProduced))
 	}
 }
 
@@ -170,22 +180,11 @@ func (sl *SedimentProduction) observeAction(action action.ManagementAction) {
 }
 
 func (sl *SedimentProduction) handleRiverBankRestorationAction() {
-	var toBeRiverBankSediment, asIsRiverBankSediment, toBeVegetation, asIsVegetation float64
-
-	switch sl.actionObserved.IsActive() {
-	case true:
-		asIsVegetation = sl.actionObserved.ModelVariableValue(actions.OriginalBufferVegetation)
-		asIsRiverBankSediment = sl.actionObserved.ModelVariableValue(actions.OriginalRiparianSedimentProduction)
+	asIsVegetation := sl.valueAtPreviousIntensity(actions.OriginalBufferVegetation, actions.ActionedBufferVegetation)
+	asIsRiverBankSediment := sl.valueAtPreviousIntensity(actions.OriginalRiparianSedimentProduction, actions.ActionedRiparianSedimentProduction)
 
-		toBeVegetation = sl.actionObserved.ModelVariableValue(actions.ActionedBufferVegetation)
-		toBeRiverBankSediment = sl.actionObserved.ModelVariableValue(actions.ActionedRiparianSedimentProduction)
-	case false:
-		asIsVegetation = sl.actionObserved.ModelVariableValue(actions.ActionedBufferVegetation)
-		asIsRiverBankSediment = sl.actionObserved.ModelVariableValue(actions.ActionedRiparianSedimentProduction)
-
-		toBeVegetation = sl.actionObserved.ModelVariableValue(actions.OriginalBufferVegetation)
-		toBeRiverBankSediment = sl.actionObserved.ModelVariableValue(actions.OriginalRiparianSedimentProduction)
-	}
+	toBeVegetation := sl.valueAtIntensity(actions.OriginalBufferVegetation, actions.ActionedBufferVegetation)
+	toBeRiverBankSediment := sl.valueAtIntensity(actions.OriginalRiparianSedimentProduction, actions.ActionedRiparianSedimentProduction)
 
 	attributes := sl.planningUnitAttributes[sl.actionObserved.PlanningUnit()]
 
@@ -209,12 +208,14 @@ func (sl *SedimentProduction) handleRiverBankRestorationAction() {
 
 	toBeSediment := sl.calculateSedimentProduction(toBeContext)
 
-	sl.command = new(RiverBankRestorationCommand).
+	command := new(RiverBankRestorationCommand).
 		ForVariable(sl).
 		InPlanningUnit(sl.actionObserved.PlanningUnit()).
 		WithVegetationProportion(toBeVegetation).
 		WithRiverBankContribution(toBeRiverBankSediment).
-		WithChange(toBeSediment - asIsSediment)
+		WithChange(sl.deliveredChange(toBeSediment - asIsSediment))
+	command.SetLocalChange(sl.localValues, toBeSediment-asIsSediment)
+	sl.command = command
 }
 
 func (sl *SedimentProduction) planningUnitSediment(riparianVegetationBufferName action.ModelVariableName) float64 {
@@ -240,16 +241,8 @@ func (sl *SedimentProduction) hillSlopeSediment(planningUnit planningunit.Id) fl
 }
 
 func (sl *SedimentProduction) handleGullyRestorationAction() {
-	var toBeGullySediment, asIsGullySediment float64
-
-	switch sl.actionObserved.IsActive() {
-	case true:
-		asIsGullySediment = sl.actionObserved.ModelVariableValue(actions.OriginalGullySediment)
-		toBeGullySediment = sl.actionObserved.ModelVariableValue(actions.ActionedGullySediment)
-	case false:
-		asIsGullySediment = sl.actionObserved.ModelVariableValue(actions.ActionedGullySediment)
-		toBeGullySediment = sl.actionObserved.ModelVariableValue(actions.OriginalGullySediment)
-	}
+	asIsGullySediment := sl.valueAtPreviousIntensity(actions.OriginalGullySediment, actions.ActionedGullySediment)
+	toBeGullySediment := sl.valueAtIntensity(actions.OriginalGullySediment, actions.ActionedGullySediment)
 
 	attributes := sl.planningUnitAttributes[sl.actionObserved.PlanningUnit()]
 
@@ -273,23 +266,18 @@ func (sl *SedimentProduction) handleGullyRestorationAction() {
 
 	toBeSediment := sl.calculateSedimentProduction(toBeContext)
 
-	sl.command = new(GullyRestorationCommand).
+	command := new(GullyRestorationCommand).
 		ForVariable(sl).
 		InPlanningUnit(sl.actionObserved.PlanningUnit()).
-		WithChange(toBeSediment - asIsSediment)
+		WithGullyContribution(toBeGullySediment).
+		WithChange(sl.deliveredChange(toBeSediment - asIsSediment))
+	command.SetLocalChange(sl.localValues, toBeSediment-asIsSediment)
+	sl.command = command
 }
 
 func (sl *SedimentProduction) handleHillSlopeRestorationAction() {
-	var toBeHillSlopeSediment, asIsHillSlopeSediment float64
-
-	switch sl.actionObserved.IsActive() {
-	case true:
-		asIsHillSlopeSediment = sl.actionObserved.ModelVariableValue(actions.HillSlopeErosionOriginalAttribute)
-		toBeHillSlopeSediment = sl.actionObserved.ModelVariableValue(actions.HillSlopeErosionActionedAttribute)
-	case false:
-		asIsHillSlopeSediment = sl.actionObserved.ModelVariableValue(actions.HillSlopeErosionActionedAttribute)
-		toBeHillSlopeSediment = sl.actionObserved.ModelVariableValue(actions.HillSlopeErosionOriginalAttribute)
-	}
+	asIsHillSlopeSediment := sl.valueAtPreviousIntensity(actions.HillSlopeErosionOriginalAttribute, actions.HillSlopeErosionActionedAttribute)
+	toBeHillSlopeSediment := sl.valueAtIntensity(actions.HillSlopeErosionOriginalAttribute, actions.HillSlopeErosionActionedAttribute)
 
 	attributes := sl.planningUnitAttributes[sl.actionObserved.PlanningUnit()]
 
@@ -313,11 +301,13 @@ func (sl *SedimentProduction) handleHillSlopeRestorationAction() {
 
 	toBeSediment := sl.calculateSedimentProduction(toBeContext)
 
-	sl.command = new(HillSlopeRevegetationCommand).
+	command := new(HillSlopeRevegetationCommand).
 		ForVariable(sl).
 		InPlanningUnit(sl.actionObserved.PlanningUnit()).
 		WithSedimentContribution(toBeHillSlopeSediment).
-		WithChange(toBeSediment - asIsSediment)
+		WithChange(sl.deliveredChange(toBeSediment - asIsSediment))
+	command.SetLocalChange(sl.localValues, toBeSediment-asIsSediment)
+	sl.command = command
 }
 
 func (sl *SedimentProduction) filteredHillSlopeSediment(planningUnit planningunit.Id, hillSlopeVegetation float64) float64 {
@@ -331,16 +321,9 @@ func (sl *SedimentProduction) filteredHillSlopeSediment(planningUnit planninguni
 }
 
 func (sl *SedimentProduction) handleWetlandsEstablishmentAction() {
-	var asIsRemovalEfficiency, toBeRemovalEfficiency float64
-
-	switch sl.actionObserved.IsActive() {
-	case true:
-		asIsRemovalEfficiency = 0
-		toBeRemovalEfficiency = sl.actionObserved.ModelVariableValue(actions.SedimentRemovalEfficiency)
-	case false:
-		asIsRemovalEfficiency = sl.actionObserved.ModelVariableValue(actions.SedimentRemovalEfficiency)
-		toBeRemovalEfficiency = 0
-	}
+	removalEfficiency := sl.actionObserved.ModelVariableValue(actions.SedimentRemovalEfficiency)
+	asIsRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.PreviousEffectiveIntensity(sl.actionObserved))
+	toBeRemovalEfficiency := action.Interpolate(0, removalEfficiency, action.EffectiveIntensity(sl.actionObserved))
 
 	attributes := sl.planningUnitAttributes[sl.actionObserved.PlanningUnit()]
 
@@ -364,11 +347,64 @@ func (sl *SedimentProduction) handleWetlandsEstablishmentAction() {
 
 	toBeSediment := sl.calculateSedimentProduction(toBeContext)
 
-	sl.command = new(WetlandsEstablishmentCommand).
+	command := new(WetlandsEstablishmentCommand).
 		ForVariable(sl).
 		InPlanningUnit(sl.actionObserved.PlanningUnit()).
 		WithRemovalEfficiency(toBeRemovalEfficiency).
-		WithChange(toBeSediment - asIsSediment)
+		WithChange(sl.deliveredChange(toBeSediment - asIsSediment)).
+		WithUpstreamValues(sl.upstreamValuesWithInflowRemoval(toBeRemovalEfficiency))
+	command.SetLocalChange(sl.localValues, toBeSediment-asIsSediment)
+	sl.command = command
+}
+
+// valueAtIntensity returns the observed action's value for the variable at the action's effective intensity,
+// interpolating between its original and actioned variable values.
+func (sl *SedimentProduction) valueAtIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return sl.interpolatedValue(originalName, actionedName, action.EffectiveIntensity(sl.actionObserved))
+}
+
+// valueAtPreviousIntensity returns the observed action's value for the variable at the action's effective
+// intensity before its latest change.
+func (sl *SedimentProduction) valueAtPreviousIntensity(originalName, actionedName action.ModelVariableName) float64 {
+	return sl.interpolatedValue(originalName, actionedName, action.PreviousEffectiveIntensity(sl.actionObserved))
+}
+
+func (sl *SedimentProduction) interpolatedValue(originalName, actionedName action.ModelVariableName, intensity float64) float64 {
+	return action.Interpolate(
+		sl.actionObserved.ModelVariableValue(originalName),
+		sl.actionObserved.ModelVariableValue(actionedName),
+		intensity,
+	)
+}
+
+func (sl *SedimentProduction) deliveredChange(localChange float64) float64 {
+	return sl.network.DeliverThrough(sl.actionObserved.PlanningUnit(), localChange, sl.inflowRemovals())
+}
+
+// upstreamValuesWithInflowRemoval returns the values of the planning units upstream of the observed wetland action,
+// were the wetland to remove the proportion of the sediment routed through it given.
+func (sl *SedimentProduction) upstreamValuesWithInflowRemoval(removalEfficiency float64) variable.PlanningUnitValueMap {
+	return sl.network.UpstreamLoadsWithRemoval(
+		sl.actionObserved.PlanningUnit(), removalEfficiency, sl.localValues, sl.inflowRemovals(),
+	)
+}
+
+// inflowRemovals returns, for each planning unit with a wetland established, the proportion of sediment routed into
+// it from upstream that the wetland removes.
+func (sl *SedimentProduction) inflowRemovals() variable.PlanningUnitValueMap {
+	inflowRemovals := make(variable.PlanningUnitValueMap)
+	for planningUnit, planningUnitAttributes := range sl.planningUnitAttributes {
+		if removalEfficiency := planningUnitAttributes.Value(WetlandRemovalEfficiency).(float64); removalEfficiency > 0 {
+			inflowRemovals[planningUnit] = removalEfficiency
+		}
+	}
+	return inflowRemovals
+}
+
+// AccumulatedValuesPerPlanningUnit returns, for each planning unit, the sediment leaving it, being its own sediment plus
+// that routed to it from upstream, less any its wetland removes.
+func (sl *SedimentProduction) AccumulatedValuesPerPlanningUnit() variable.PlanningUnitValueMap {
+	return sl.network.AccumulatedLoads(sl.localValues, sl.inflowRemovals())
 }
 
 func (sl *SedimentProduction) UndoableValue() float64 {
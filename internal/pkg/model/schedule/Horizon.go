@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package schedule offers the timing of management actions over a multi-year planning horizon, covering how their
+// effects ramp in once started, how their costs are discounted, and yearly schedules of model values.
+package schedule
+
+import "math"
+
+// Horizon is a planning horizon of a number of years, counted from year 0, over which management actions are
+// scheduled to start. A horizon of no years leaves actions unscheduled, taking effect immediately and in full, with
+// undiscounted costs.
+type Horizon struct {
+	// Years is the number of years in the planning horizon.
+	Years uint
+
+	// EstablishmentYears is the number of years an action takes to realise its full effect once started, ramping in
+	// evenly over those years.  An establishment of no years has actions take full effect in their start year.
+	EstablishmentYears uint
+
+	// DiscountRate is the yearly rate, as a proportion, at which costs in later years are discounted.
+	DiscountRate float64
+}
+
+// IsSet reports whether the horizon schedules actions at all.
+func (h Horizon) IsSet() bool {
+	return h.Years > 0
+}
+
+// RampProportion returns the proportion of its full effect that an action started in startYear realises in the
+// year given.
+func (h Horizon) RampProportion(startYear uint, year uint) float64 {
+	if year < startYear {
+		return 0
+	}
+	if h.EstablishmentYears == 0 {
+		return 1
+	}
+
+	yearsEstablishing := year - startYear + 1
+	if yearsEstablishing >= h.EstablishmentYears {
+		return 1
+	}
+	return float64(yearsEstablishing) / float64(h.EstablishmentYears)
+}
+
+// Realisation returns the proportion of its full effect that an action started in startYear realises, on average,
+// over each year of the horizon.  Actions are fully realised when the horizon isn't set.
+func (h Horizon) Realisation(startYear uint) float64 {
+	if !h.IsSet() {
+		return 1
+	}
+
+	totalProportion := float64(0)
+	for year := uint(0); year < h.Years; year++ {
+		totalProportion += h.RampProportion(startYear, year)
+	}
+	return totalProportion / float64(h.Years)
+}
+
+// DiscountFactor returns the factor that costs incurred in the year given are discounted by, to their present value.
+func (h Horizon) DiscountFactor(year uint) float64 {
+	if !h.IsSet() || h.DiscountRate == 0 || year == 0 {
+		return 1
+	}
+	return math.Pow(1+h.DiscountRate, -float64(year))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedule
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHorizon_NotSet_ActionsFullyRealisedAndUndiscounted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	horizonUnderTest := Horizon{EstablishmentYears: 3, DiscountRate: 0.1}
+
+	// then
+	g.Expect(horizonUnderTest.IsSet()).To(BeFalse())
+	g.Expect(horizonUnderTest.Realisation(2)).To(BeNumerically("==", 1))
+	g.Expect(horizonUnderTest.DiscountFactor(2)).To(BeNumerically("==", 1))
+}
+
+func TestHorizon_RampProportion_RampsInOverEstablishment(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	horizonUnderTest := Horizon{Years: 10, EstablishmentYears: 4}
+
+	// then
+	g.Expect(horizonUnderTest.RampProportion(2, 1)).To(BeNumerically("==", 0))
+	g.Expect(horizonUnderTest.RampProportion(2, 2)).To(BeNumerically("==", 0.25))
+	g.Expect(horizonUnderTest.RampProportion(2, 4)).To(BeNumerically("==", 0.75))
+	g.Expect(horizonUnderTest.RampProportion(2, 5)).To(BeNumerically("==", 1))
+	g.Expect(horizonUnderTest.RampProportion(2, 9)).To(BeNumerically("==", 1))
+}
+
+func TestHorizon_Realisation_AveragedOverHorizon(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	immediateHorizon := Horizon{Years: 4}
+	establishingHorizon := Horizon{Years: 4, EstablishmentYears: 2}
+
+	// then
+	g.Expect(immediateHorizon.Realisation(0)).To(BeNumerically("==", 1))
+	g.Expect(immediateHorizon.Realisation(1)).To(BeNumerically("==", 0.75))
+	g.Expect(establishingHorizon.Realisation(0)).To(BeNumerically("==", 0.875))
+	g.Expect(establishingHorizon.Realisation(3)).To(BeNumerically("==", 0.125))
+}
+
+func TestHorizon_DiscountFactor_CompoundsYearly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	horizonUnderTest := Horizon{Years: 5, DiscountRate: 0.1}
+
+	// then
+	g.Expect(horizonUnderTest.DiscountFactor(0)).To(BeNumerically("==", 1))
+	g.Expect(horizonUnderTest.DiscountFactor(1)).To(BeNumerically("~", 1/1.1, 1e-12))
+	g.Expect(horizonUnderTest.DiscountFactor(3)).To(BeNumerically("~", 1/(1.1*1.1*1.1), 1e-12))
+}
+
+func TestSchedule_Value_ByYearAndName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	scheduleUnderTest := New("Cost", "Load")
+	scheduleUnderTest.AddYear(0, 100, 5)
+	scheduleUnderTest.AddYear(1, 50, 4)
+
+	// then
+	g.Expect(scheduleUnderTest.Value(1, "Cost")).To(BeNumerically("==", 50))
+	g.Expect(scheduleUnderTest.Value(0, "Load")).To(BeNumerically("==", 5))
+	g.Expect(scheduleUnderTest.Value(2, "Cost")).To(BeZero())
+	g.Expect(scheduleUnderTest.Value(0, "Unknown")).To(BeZero())
+}
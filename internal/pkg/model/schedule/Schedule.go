@@ -0,0 +1,41 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package schedule
+
+// Schedule reports named model values, such as the costs incurred and the loads produced, for each year of a planning
+// horizon.
+type Schedule struct {
+	ValueNames []string
+	Years      []YearlyValues
+}
+
+// YearlyValues holds the values of a schedule for a single year, in the order of the schedule's value names.
+type YearlyValues struct {
+	Year   uint
+	Values []float64
+}
+
+// New returns an empty schedule of the values named.
+func New(valueNames ...string) *Schedule {
+	return &Schedule{ValueNames: valueNames, Years: make([]YearlyValues, 0)}
+}
+
+// AddYear appends the values supplied for the year given, in the order of the schedule's value names.
+func (s *Schedule) AddYear(year uint, values ...float64) {
+	s.Years = append(s.Years, YearlyValues{Year: year, Values: values})
+}
+
+// Value returns the named value for the year given, or 0 if the schedule has no such year or value.
+func (s *Schedule) Value(year uint, valueName string) float64 {
+	for nameIndex, name := range s.ValueNames {
+		if name != valueName {
+			continue
+		}
+		for _, yearlyValues := range s.Years {
+			if yearlyValues.Year == year {
+				return yearlyValues.Values[nameIndex]
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSampleSize is the number of sampled inputs each solution is evaluated over, unless otherwise supplied.
+	DefaultSampleSize = 100
+)
+
+// DefaultPercentiles are the percentiles reported of each decision variable, unless otherwise supplied.
+var DefaultPercentiles = []float64{5, 50, 95}
+
+// Solution identifies a fixed solution to re-evaluate, by its encoding of management action state (as produced by
+// archive.CompressedModelState.Encoding).
+type Solution struct {
+	Id       string
+	Encoding string
+}
+
+// Analysis re-evaluates fixed solutions of a model over many sampled versions of its uncertain inputs.
+type Analysis struct {
+	model       model.Sampleable
+	inputs      *Inputs
+	sampleSize  uint
+	randomSeed  int64
+	percentiles []float64
+	targets     map[string]float64
+
+	compressor archive.ModelCompressor
+}
+
+func New() *Analysis {
+	return new(Analysis).Initialise()
+}
+
+func (a *Analysis) Initialise() *Analysis {
+	a.inputs = NewInputs()
+	a.sampleSize = DefaultSampleSize
+	a.randomSeed = rand.TimeSeed()
+	a.percentiles = DefaultPercentiles
+	a.targets = make(map[string]float64)
+	return a
+}
+
+func (a *Analysis) WithModel(model model.Sampleable) *Analysis {
+	a.model = model
+	return a
+}
+
+func (a *Analysis) WithInputs(inputs *Inputs) *Analysis {
+	a.inputs = inputs
+	return a
+}
+
+func (a *Analysis) WithSampleSize(sampleSize uint) *Analysis {
+	a.sampleSize = sampleSize
+	return a
+}
+
+// WithRandomSeed has the analysis draw the same samples each time it is run with the seed supplied. A zero seed keeps
+// the default, time-derived seed.
+func (a *Analysis) WithRandomSeed(seed int64) *Analysis {
+	if seed != 0 {
+		a.randomSeed = seed
+	}
+	return a
+}
+
+func (a *Analysis) WithPercentiles(percentiles ...float64) *Analysis {
+	a.percentiles = percentiles
+	return a
+}
+
+// WithTarget has the analysis report the probability of the named decision variable meeting the target supplied,
+// being no worse than the target in the variable's optimisation direction.
+func (a *Analysis) WithTarget(variableName string, target float64) *Analysis {
+	a.targets[variableName] = target
+	return a
+}
+
+// Evaluate re-evaluates each of the solutions supplied over the analysis's sample of inputs, reporting the spread of
+// each solution's decision variables.
+func (a *Analysis) Evaluate(solutions ...Solution) (*Report, error) {
+	if a.model == nil {
+		return nil, errors.New("uncertainty analysis has no model to evaluate solutions with")
+	}
+
+	baseParameters := a.model.SourceParameters()
+	baseDataSet := a.model.SourceDataSet()
+	if validationError := a.validate(baseParameters, baseDataSet); validationError != nil {
+		return nil, validationError
+	}
+
+	baselineModel, baselineError := a.model.Resampled(baseParameters, CopyDataSet(baseDataSet))
+	if baselineError != nil {
+		return nil, errors.Wrap(baselineError, "building baseline model")
+	}
+	if targetError := a.validateTargets(baselineModel); targetError != nil {
+		return nil, targetError
+	}
+
+	report := newReport(a, baselineModel, solutions)
+	if evaluationError := a.evaluateSolutionsOn(baselineModel, solutions, report.recordBaseline); evaluationError != nil {
+		return nil, evaluationError
+	}
+	baselineModel.TearDown()
+
+	generator := rand.NewSeeded(a.randomSeed)
+	for sample := uint(0); sample < a.sampleSize; sample++ {
+		sampledParameters := a.inputs.SampleParameters(baseParameters, generator)
+		sampledDataSet := a.inputs.SampleDataSet(baseDataSet, generator)
+
+		sampledModel, sampleError := a.model.Resampled(sampledParameters, sampledDataSet)
+		if sampleError != nil {
+			return nil, errors.Wrapf(sampleError, "building model for sample [%d]", sample+1)
+		}
+		if evaluationError := a.evaluateSolutionsOn(sampledModel, solutions, report.recordSample); evaluationError != nil {
+			return nil, evaluationError
+		}
+		sampledModel.TearDown()
+	}
+
+	report.summarise()
+	return report, nil
+}
+
+func (a *Analysis) validate(baseParameters parameters.Map, baseDataSet dataset.DataSet) error {
+	validationErrors := compositeErrors.New("Uncertainty analysis")
+
+	if a.sampleSize == 0 {
+		validationErrors.AddMessage("sample size must be at least [1]")
+	}
+	for _, percentile := range a.percentiles {
+		if percentile < 0 || percentile > 100 {
+			validationErrors.AddMessage(fmt.Sprintf("percentile [%g] must be between [0] and [100]", percentile))
+		}
+	}
+	if inputsError := a.inputs.Validate(baseParameters, baseDataSet, a.model.ValidateParameter); inputsError != nil {
+		validationErrors.Add(inputsError)
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func (a *Analysis) validateTargets(model model.Model) error {
+	validationErrors := compositeErrors.New("Uncertainty analysis targets")
+	for variableName := range a.targets {
+		if _, isKnown := (*model.DecisionVariables())[variableName]; !isKnown {
+			validationErrors.AddMessage(fmt.Sprintf("target [%s] is not a decision variable of the model", variableName))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+type valueRecorder func(solutionIndex int, model model.Model)
+
+func (a *Analysis) evaluateSolutionsOn(model model.Model, solutions []Solution, record valueRecorder) error {
+	for index, solution := range solutions {
+		solutionState := a.compressor.Compress(model)
+		if decodingError := solutionState.Decode(solution.Encoding); decodingError != nil {
+			return errors.Wrapf(decodingError, "decoding solution [%s]", solution.Id)
+		}
+		a.compressor.Decompress(solutionState, model)
+		record(index, model)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	sedimentProduction  = "SedimentProduction"
+	dissolvedNitrogen   = "DissolvedNitrogen"
+	analysisSampleSize  = 20
+	analysisRandomSeed  = 1234
+	removalEfficiency   = "DNRemovalEfficiency"
+	actionsTable        = "Actions"
+	bankErosionFudge    = "BankErosionFudgeFactor"
+	asIsSolutionId      = "As-Is"
+	allActiveSolutionId = "All-Active"
+
+	minimumFudge = 1e-4
+	maximumFudge = 5e-4
+)
+
+func TestAnalysis_NoUncertainInputs_MatchesBaseline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	analysisUnderTest := New().WithModel(modelUnderTest).WithSampleSize(analysisSampleSize)
+
+	// when
+	report, evaluationError := analysisUnderTest.Evaluate(buildTestSolutions(g)...)
+
+	// then
+	g.Expect(evaluationError).To(BeNil())
+	g.Expect(report.Solutions).To(HaveLen(2))
+
+	for _, solutionReport := range report.Solutions {
+		for _, variableReport := range solutionReport.Variables {
+			g.Expect(variableReport.Mean).To(BeNumerically("~", variableReport.Baseline, 1e-9))
+			g.Expect(variableReport.StandardDeviation).To(BeNumerically("~", 0, 1e-9))
+			g.Expect(variableReport.PercentileValues).To(HaveLen(len(DefaultPercentiles)))
+			for _, percentileValue := range variableReport.PercentileValues {
+				g.Expect(percentileValue).To(BeNumerically("~", variableReport.Baseline, 1e-9))
+			}
+		}
+	}
+}
+
+func TestAnalysis_UncertainInputs_SpreadsVariablesReproducibly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	solutions := buildTestSolutions(g)
+
+	buildAnalysis := func() *Analysis {
+		inputs := NewInputs().
+			WithParameter(bankErosionFudge, NewDistribution(Normal, 0.2).WithMinimum(minimumFudge).WithMaximum(maximumFudge)).
+			WithColumn(ColumnDistribution{
+				Table:        actionsTable,
+				Column:       removalEfficiency,
+				Distribution: NewDistribution(Uniform, 0.5).WithMaximum(1),
+				PerRow:       true,
+			})
+		return New().
+			WithModel(modelUnderTest).
+			WithInputs(inputs).
+			WithSampleSize(analysisSampleSize).
+			WithRandomSeed(analysisRandomSeed)
+	}
+
+	// when
+	firstReport, firstError := buildAnalysis().Evaluate(solutions...)
+	secondReport, secondError := buildAnalysis().Evaluate(solutions...)
+
+	// then
+	g.Expect(firstError).To(BeNil())
+	g.Expect(secondError).To(BeNil())
+	g.Expect(firstReport).To(Equal(secondReport))
+
+	asIsSediment := firstReport.Solutions[0].Variable(sedimentProduction)
+	g.Expect(asIsSediment.StandardDeviation).To(BeNumerically(">", 0))
+	g.Expect(asIsSediment.PercentileValues[0]).To(BeNumerically("<=", asIsSediment.PercentileValues[1]))
+	g.Expect(asIsSediment.PercentileValues[1]).To(BeNumerically("<=", asIsSediment.PercentileValues[2]))
+
+	allActiveNitrogen := firstReport.Solutions[1].Variable(dissolvedNitrogen)
+	g.Expect(allActiveNitrogen.StandardDeviation).To(BeNumerically(">", 0))
+}
+
+func TestAnalysis_Targets_ReportProbabilityOfMeetingThem(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	solutions := buildTestSolutions(g)[:1]
+
+	baselineSediment := modelUnderTest.DecisionVariable(sedimentProduction).Value()
+	inputs := NewInputs().WithParameter(bankErosionFudge, NewDistribution(Uniform, 0.2).WithMinimum(minimumFudge).WithMaximum(maximumFudge))
+
+	analysisUnderTest := New().
+		WithModel(modelUnderTest).
+		WithInputs(inputs).
+		WithSampleSize(analysisSampleSize).
+		WithRandomSeed(analysisRandomSeed)
+
+	// when
+	generousReport, generousError := analysisUnderTest.WithTarget(sedimentProduction, 2*baselineSediment).Evaluate(solutions...)
+	impossibleReport, impossibleError := analysisUnderTest.WithTarget(sedimentProduction, 0).Evaluate(solutions...)
+
+	// then
+	g.Expect(generousError).To(BeNil())
+	generousVariable := generousReport.Solutions[0].Variable(sedimentProduction)
+	g.Expect(*generousVariable.Target).To(BeNumerically("==", 2*baselineSediment))
+	g.Expect(*generousVariable.TargetProbability).To(BeNumerically("==", 1))
+	g.Expect(generousReport.Solutions[0].Variable(dissolvedNitrogen).TargetProbability).To(BeNil())
+
+	g.Expect(impossibleError).To(BeNil())
+	g.Expect(*impossibleReport.Solutions[0].Variable(sedimentProduction).TargetProbability).To(BeNumerically("==", 0))
+}
+
+func TestAnalysis_BadInputs_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	inputs := NewInputs().
+		WithParameter("NoSuchParameter", NewDistribution(Normal, 0.1)).
+		WithColumn(ColumnDistribution{Table: actionsTable, Column: "NoSuchColumn", Distribution: NewDistribution(Normal, 0.1)}).
+		WithColumn(ColumnDistribution{Table: "NoSuchTable", Column: removalEfficiency, Distribution: NewDistribution(Normal, 0.1)})
+
+	analysisUnderTest := New().WithModel(modelUnderTest).WithInputs(inputs).WithTarget("NoSuchVariable", 1)
+
+	// when
+	report, evaluationError := analysisUnderTest.Evaluate(buildTestSolutions(g)...)
+
+	// then
+	g.Expect(report).To(BeNil())
+	g.Expect(evaluationError).To(Not(BeNil()))
+	g.Expect(evaluationError.Error()).To(ContainSubstring("[NoSuchParameter]"))
+	g.Expect(evaluationError.Error()).To(ContainSubstring("[NoSuchColumn]"))
+	g.Expect(evaluationError.Error()).To(ContainSubstring("[NoSuchTable]"))
+}
+
+func TestAnalysis_UnboundedParameterDistribution_ErrorsBeforeSampling(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	inputs := NewInputs().
+		WithParameter(bankErosionFudge, NewDistribution(Normal, 0.1)).
+		WithParameter("HillSlopeDeliveryRatio", NewDistribution(Uniform, 0.1).WithMinimum(0).WithMaximum(1))
+
+	analysisUnderTest := New().WithModel(modelUnderTest).WithInputs(inputs)
+
+	// when
+	report, evaluationError := analysisUnderTest.Evaluate(buildTestSolutions(g)...)
+
+	// then
+	g.Expect(report).To(BeNil())
+	g.Expect(evaluationError).To(Not(BeNil()))
+	g.Expect(evaluationError.Error()).To(ContainSubstring("parameter [" + bankErosionFudge + "] may be sampled anywhere"))
+	g.Expect(evaluationError.Error()).To(Not(ContainSubstring("HillSlopeDeliveryRatio")))
+}
+
+func TestInputs_SampleDataSet_LeavesBaseDataSetUnchanged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestModel(g)
+	baseDataSet := modelUnderTest.SourceDataSet()
+	baseTable := tableOf(g, baseDataSet.Tables()[actionsTable])
+	columnIndex, _ := columnIndexOf(baseTable, removalEfficiency)
+
+	const riparianRow = 2
+	baseValue := baseTable.CellFloat64(columnIndex, riparianRow)
+
+	inputs := NewInputs().WithColumn(ColumnDistribution{
+		Table:        actionsTable,
+		Column:       removalEfficiency,
+		Distribution: NewDistribution(Uniform, 0.5).WithMinimum(0.7).WithMaximum(0.7),
+	})
+
+	// when
+	sampledDataSet := inputs.SampleDataSet(baseDataSet, rand.NewSeeded(analysisRandomSeed))
+
+	// then
+	sampledTable := tableOf(g, sampledDataSet.Tables()[actionsTable])
+	g.Expect(sampledTable.CellFloat64(columnIndex, riparianRow)).To(BeNumerically("==", 0.7))
+	g.Expect(baseTable.CellFloat64(columnIndex, riparianRow)).To(BeNumerically("==", baseValue))
+}
+
+func TestSpecification_Analysis_ReportsBadDistributions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	specificationUnderTest := Specification{
+		Parameters: map[string]DistributionSpecification{
+			bankErosionFudge: {Distribution: "Lumpy", Spread: 0.1},
+		},
+		Columns: []ColumnSpecification{
+			{Table: actionsTable, Column: removalEfficiency, DistributionSpecification: DistributionSpecification{Distribution: string(Normal), Spread: -1}},
+		},
+	}
+
+	// when
+	analysis, specificationError := specificationUnderTest.Analysis()
+
+	// then
+	g.Expect(analysis).To(BeNil())
+	g.Expect(specificationError).To(Not(BeNil()))
+	g.Expect(specificationError.Error()).To(ContainSubstring("[Lumpy]"))
+	g.Expect(specificationError.Error()).To(ContainSubstring("[" + removalEfficiency + "]"))
+}
+
+func buildTestModel(g *GomegaWithT) *catchment.Model {
+	newModel := catchment.NewModel().WithParameters(parameters.Map{"DataSourcePath": "testdata/ValidModel.csv"})
+	g.Expect(newModel.ParameterErrors()).To(BeNil())
+
+	newModel.Initialise(model.AsIs)
+	return newModel
+}
+
+func buildTestSolutions(g *GomegaWithT) []Solution {
+	var compressor archive.ModelCompressor
+	asIsEncoding := compressor.Compress(buildTestModel(g)).Encoding()
+
+	allActiveModel := buildTestModel(g)
+	for index := range allActiveModel.ManagementActions() {
+		allActiveModel.SetManagementAction(index, true)
+	}
+	allActiveEncoding := compressor.Compress(allActiveModel).Encoding()
+
+	return []Solution{
+		{Id: asIsSolutionId, Encoding: asIsEncoding},
+		{Id: allActiveSolutionId, Encoding: allActiveEncoding},
+	}
+}
+
+func tableOf(g *GomegaWithT, table dataset.Table) tables.CsvTable {
+	csvTable, isCsvTable := table.(tables.CsvTable)
+	g.Expect(isCsvTable).To(BeTrue())
+	return csvTable
+}
+
+func TestReadSolutionSet_KeepsEncodingsVerbatim(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const solutionSetPath = "testdata/ValidSolutions-Summary.csv"
+
+	// when
+	allSolutions, allError := ReadSolutionSet(solutionSetPath)
+	someSolutions, someError := ReadSolutionSet(solutionSetPath, "Solution-1")
+	missingSolutions, missingError := ReadSolutionSet(solutionSetPath, "Solution-1", "Solution-9")
+
+	// then
+	g.Expect(allError).To(BeNil())
+	g.Expect(allSolutions).To(HaveLen(3))
+
+	g.Expect(someError).To(BeNil())
+	g.Expect(someSolutions).To(Equal([]Solution{{Id: "Solution-1", Encoding: "1e3"}}))
+
+	g.Expect(missingSolutions).To(BeNil())
+	g.Expect(missingError).To(MatchError(ContainSubstring("[Solution-9]")))
+
+	report, evaluationError := New().WithModel(buildTestModel(g)).WithSampleSize(1).Evaluate(allSolutions...)
+	g.Expect(evaluationError).To(BeNil())
+	g.Expect(report.Solutions[2].Variable(sedimentProduction).Baseline).
+		To(BeNumerically("<", report.Solutions[0].Variable(sedimentProduction).Baseline))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	separator = ", "
+	newline   = "\n"
+)
+
+// CsvMarshaler marshals an uncertainty report into CSV text, with a row per decision variable of each solution.
+type CsvMarshaler struct{}
+
+func (cm *CsvMarshaler) Marshal(report *Report) ([]byte, error) {
+	builder := new(strings.Builder)
+
+	headings := []string{"Solution", "Variable", "Baseline", "Mean", "StandardDeviation"}
+	for _, percentile := range report.Percentiles {
+		headings = append(headings, fmt.Sprintf("P%g", percentile))
+	}
+	headings = append(headings, "Target", "TargetProbability")
+	builder.WriteString(strings.Join(headings, separator) + newline)
+
+	for _, solution := range report.Solutions {
+		for _, variable := range solution.Variables {
+			row := []string{
+				solution.Id, variable.Name,
+				formatFloat(variable.Baseline), formatFloat(variable.Mean), formatFloat(variable.StandardDeviation),
+			}
+			for _, value := range variable.PercentileValues {
+				row = append(row, formatFloat(value))
+			}
+			row = append(row, formatOptionalFloat(variable.Target), formatOptionalFloat(variable.TargetProbability))
+			builder.WriteString(strings.Join(row, separator) + newline)
+		}
+	}
+
+	return []byte(builder.String()), nil
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatFloat(*value)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package uncertainty offers Monte Carlo analysis of how uncertain model inputs carry through to the decision
+// variables of fixed solutions. Inputs are perturbed by sampled distributions, each solution re-evaluated over every
+// sample, and the spread of each decision variable reported alongside the probability of it meeting a target.
+package uncertainty
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/pkg/errors"
+)
+
+// Shape names the shape of a distribution of multiplicative factors.
+type Shape string
+
+const (
+	// Normal factors are normally distributed about 1, with Spread as their standard deviation.
+	Normal Shape = "Normal"
+
+	// Uniform factors are evenly spread between 1-Spread and 1+Spread.
+	Uniform Shape = "Uniform"
+
+	// Triangular factors are most likely near 1, tailing off evenly to 1-Spread and 1+Spread.
+	Triangular Shape = "Triangular"
+)
+
+// Distribution describes the uncertainty of an input value as a distribution of factors by which its base value is
+// multiplied, with sampled values held within optional bounds.
+type Distribution struct {
+	Shape  Shape
+	Spread float64
+
+	Minimum float64
+	Maximum float64
+}
+
+// NewDistribution returns an unbounded distribution of the shape and (relative) spread supplied.
+func NewDistribution(shape Shape, spread float64) Distribution {
+	return Distribution{
+		Shape:   shape,
+		Spread:  spread,
+		Minimum: math.Inf(-1),
+		Maximum: math.Inf(1),
+	}
+}
+
+// WithMinimum returns a copy of the distribution whose sampled values are never below the minimum supplied.
+func (d Distribution) WithMinimum(minimum float64) Distribution {
+	d.Minimum = minimum
+	return d
+}
+
+// WithMaximum returns a copy of the distribution whose sampled values are never above the maximum supplied.
+func (d Distribution) WithMaximum(maximum float64) Distribution {
+	d.Maximum = maximum
+	return d
+}
+
+// Validate returns an error if the distribution has an unknown shape, a negative spread, or bounds that cross.
+func (d Distribution) Validate() error {
+	switch d.Shape {
+	case Normal, Uniform, Triangular:
+	default:
+		return errors.Errorf("distribution [%s] unknown. Must be one of [%s], [%s] or [%s]",
+			d.Shape, Normal, Uniform, Triangular)
+	}
+	if d.Spread < 0 {
+		return errors.Errorf("distribution spread [%g] must not be negative", d.Spread)
+	}
+	if d.Minimum > d.Maximum {
+		return errors.Errorf("distribution minimum [%g] must not exceed its maximum [%g]", d.Minimum, d.Maximum)
+	}
+	return nil
+}
+
+// Range returns the lowest and highest values the distribution can sample for the base value supplied. Normal
+// distributions of any spread can sample any value, unless bounded.
+func (d Distribution) Range(baseValue float64) (lowest float64, highest float64) {
+	if baseValue == 0 {
+		return d.Apply(0, 1), d.Apply(0, 1)
+	}
+
+	lowestFactor, highestFactor := 1-d.Spread, 1+d.Spread
+	if d.Shape == Normal && d.Spread > 0 {
+		lowestFactor, highestFactor = math.Inf(-1), math.Inf(1)
+	}
+	if baseValue < 0 {
+		lowestFactor, highestFactor = highestFactor, lowestFactor
+	}
+	return d.Apply(baseValue, lowestFactor), d.Apply(baseValue, highestFactor)
+}
+
+// Factor draws a multiplicative factor from the distribution.
+func (d Distribution) Factor(generator *rand.Rand) float64 {
+	switch d.Shape {
+	case Normal:
+		return 1 + d.Spread*generator.NormFloat64()
+	case Uniform:
+		return 1 + d.Spread*(2*generator.Float64Unitary()-1)
+	case Triangular:
+		return 1 + d.Spread*(generator.Float64Unitary()+generator.Float64Unitary()-1)
+	default:
+		return 1
+	}
+}
+
+// Apply returns the base value supplied scaled by factor, held within the distribution's bounds.
+func (d Distribution) Apply(baseValue float64, factor float64) float64 {
+	return math.Min(math.Max(baseValue*factor, d.Minimum), d.Maximum)
+}
+
+// Sample returns the base value supplied, scaled by a factor freshly drawn from the distribution.
+func (d Distribution) Sample(baseValue float64, generator *rand.Rand) float64 {
+	return d.Apply(baseValue, d.Factor(generator))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+const sampleSize = 1000
+
+func TestDistribution_Validate_ReportsBadDistributions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	unknownShape := NewDistribution("Lumpy", 0.1)
+	negativeSpread := NewDistribution(Normal, -0.1)
+	crossedBounds := NewDistribution(Uniform, 0.1).WithMinimum(2).WithMaximum(1)
+	valid := NewDistribution(Triangular, 0.1).WithMinimum(0).WithMaximum(1)
+
+	// then
+	g.Expect(unknownShape.Validate()).To(MatchError(ContainSubstring("[Lumpy]")))
+	g.Expect(negativeSpread.Validate()).To(MatchError(ContainSubstring("[-0.1]")))
+	g.Expect(crossedBounds.Validate()).To(MatchError(ContainSubstring("minimum [2]")))
+	g.Expect(valid.Validate()).To(BeNil())
+}
+
+func TestDistribution_Sample_StaysWithinSpreadAndBounds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const baseValue = 10.0
+	generator := rand.NewSeeded(42)
+
+	uniform := NewDistribution(Uniform, 0.2)
+	triangular := NewDistribution(Triangular, 0.2)
+	boundedNormal := NewDistribution(Normal, 0.5).WithMinimum(8).WithMaximum(11)
+
+	// when
+	for sample := 0; sample < sampleSize; sample++ {
+		// then
+		g.Expect(uniform.Sample(baseValue, generator)).To(BeNumerically("~", baseValue, 2))
+		g.Expect(triangular.Sample(baseValue, generator)).To(BeNumerically("~", baseValue, 2))
+
+		boundedValue := boundedNormal.Sample(baseValue, generator)
+		g.Expect(boundedValue).To(BeNumerically(">=", 8))
+		g.Expect(boundedValue).To(BeNumerically("<=", 11))
+	}
+}
+
+func TestDistribution_Range_CoversSampledValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	uniform := NewDistribution(Uniform, 0.2)
+	boundedNormal := NewDistribution(Normal, 0.5).WithMinimum(8)
+
+	// when
+	uniformLowest, uniformHighest := uniform.Range(-10)
+	normalLowest, normalHighest := boundedNormal.Range(10)
+
+	// then
+	g.Expect(uniformLowest).To(BeNumerically("~", -12))
+	g.Expect(uniformHighest).To(BeNumerically("~", -8))
+	g.Expect(normalLowest).To(BeNumerically("==", 8))
+	g.Expect(math.IsInf(normalHighest, 1)).To(BeTrue())
+}
+
+func TestDistribution_Sample_ZeroSpread_KeepsBaseValue(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const baseValue = 10.0
+	generator := rand.NewSeeded(42)
+
+	// when
+	for _, shape := range []Shape{Normal, Uniform, Triangular} {
+		sampledValue := NewDistribution(shape, 0).Sample(baseValue, generator)
+
+		// then
+		g.Expect(sampledValue).To(BeNumerically("==", baseValue))
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+// ColumnDistribution describes the uncertainty of the numeric values in a column of a data set table.
+type ColumnDistribution struct {
+	Table  string
+	Column string
+	Distribution
+
+	// PerRow has a factor drawn independently for each row of the column. Otherwise, a single factor drawn per sample
+	// scales every row of the column alike.
+	PerRow bool
+}
+
+// Inputs holds the distributions of a model's uncertain inputs, being named parameters and columns of its data set.
+type Inputs struct {
+	parameters map[string]Distribution
+	columns    []ColumnDistribution
+}
+
+func NewInputs() *Inputs {
+	return new(Inputs).Initialise()
+}
+
+func (i *Inputs) Initialise() *Inputs {
+	i.parameters = make(map[string]Distribution)
+	i.columns = make([]ColumnDistribution, 0)
+	return i
+}
+
+func (i *Inputs) WithParameter(name string, distribution Distribution) *Inputs {
+	i.parameters[name] = distribution
+	return i
+}
+
+func (i *Inputs) WithColumn(column ColumnDistribution) *Inputs {
+	i.columns = append(i.columns, column)
+	return i
+}
+
+// ParameterValidator returns the reason the value supplied is invalid for the named parameter, or nil if valid.
+type ParameterValidator func(name string, value interface{}) error
+
+// Validate checks each distribution, and that each names a decimal parameter of the base parameters supplied, or a
+// column of a table of the base data set supplied, returning all problems found as a single error, or nil if there
+// are none. Parameters that could be sampled beyond the values the validator supplied accepts are also reported, so
+// that bad samples are caught before, rather than part-way through, an analysis.
+func (i *Inputs) Validate(baseParameters parameters.Map, baseDataSet dataset.DataSet, validator ParameterValidator) error {
+	validationErrors := compositeErrors.New("Uncertain inputs")
+
+	for _, name := range i.parameterNames() {
+		distribution := i.parameters[name]
+		if distributionError := distribution.Validate(); distributionError != nil {
+			validationErrors.AddMessage(fmt.Sprintf("parameter [%s] %s", name, distributionError.Error()))
+			continue
+		}
+		baseValue, isDecimal := baseParameters[name].(float64)
+		if !isDecimal {
+			validationErrors.AddMessage(fmt.Sprintf("parameter [%s] is not a decimal parameter of the model", name))
+			continue
+		}
+		if rangeError := validateRange(name, distribution, baseValue, validator); rangeError != nil {
+			validationErrors.AddMessage(rangeError.Error())
+		}
+	}
+
+	for _, column := range i.columns {
+		if distributionError := column.Validate(); distributionError != nil {
+			validationErrors.AddMessage(fmt.Sprintf("table [%s] column [%s] %s",
+				column.Table, column.Column, distributionError.Error()))
+		}
+		table, tableError := baseDataSet.Table(column.Table)
+		csvTable, isCsvTable := table.(tables.CsvTable)
+		if tableError != nil || !isCsvTable {
+			validationErrors.AddMessage(fmt.Sprintf("table [%s] is not a table of the model's data set", column.Table))
+			continue
+		}
+		if _, hasColumn := columnIndexOf(csvTable, column.Column); !hasColumn {
+			validationErrors.AddMessage(fmt.Sprintf("table [%s] has no column [%s]", column.Table, column.Column))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// validateRange checks both extremes of the values the distribution can sample for the named parameter, which
+// suffices as the parameters of models only ever accept a single range of decimal values.
+func validateRange(name string, distribution Distribution, baseValue float64, validator ParameterValidator) error {
+	lowest, highest := distribution.Range(baseValue)
+	for _, extreme := range []float64{lowest, highest} {
+		if validationError := validator(name, extreme); validationError != nil {
+			return fmt.Errorf("parameter [%s] may be sampled anywhere from [%g] to [%g], beyond its valid values (%s). "+
+				"Bound its distribution with a Minimum and Maximum", name, lowest, highest, validationError.Error())
+		}
+	}
+	return nil
+}
+
+func (i *Inputs) parameterNames() []string {
+	names := make([]string, 0, len(i.parameters))
+	for name := range i.parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SampleParameters returns a copy of the (validated) base parameters supplied, with each uncertain parameter sampled.
+func (i *Inputs) SampleParameters(baseParameters parameters.Map, generator *rand.Rand) parameters.Map {
+	sampledParameters := make(parameters.Map, len(baseParameters))
+	for name, value := range baseParameters {
+		sampledParameters[name] = value
+	}
+
+	for _, name := range i.parameterNames() {
+		baseValue := baseParameters[name].(float64)
+		sampledParameters.SetFloat64(name, i.parameters[name].Sample(baseValue, generator))
+	}
+	return sampledParameters
+}
+
+// SampleDataSet returns a copy of the (validated) base data set supplied, with the numeric values of each uncertain
+// column sampled. Tables that aren't CSV tables are shared with the base data set, rather than copied.
+func (i *Inputs) SampleDataSet(baseDataSet dataset.DataSet, generator *rand.Rand) dataset.DataSet {
+	sampledDataSet := CopyDataSet(baseDataSet)
+	for _, column := range i.columns {
+		table, _ := sampledDataSet.Table(column.Table)
+		sampleColumn(table.(tables.CsvTable), column, generator)
+	}
+	return sampledDataSet
+}
+
+func sampleColumn(table tables.CsvTable, column ColumnDistribution, generator *rand.Rand) {
+	columnIndex, _ := columnIndexOf(table, column.Column)
+	columnFactor := column.Factor(generator)
+
+	_, rowCount := table.ColumnAndRowSize()
+	for row := uint(0); row < rowCount; row++ {
+		baseValue, isNumeric := table.Cell(columnIndex, row).(float64)
+		if !isNumeric {
+			continue
+		}
+
+		factor := columnFactor
+		if column.PerRow {
+			factor = column.Factor(generator)
+		}
+		table.SetCell(columnIndex, row, column.Apply(baseValue, factor))
+	}
+}
+
+func columnIndexOf(table tables.CsvTable, heading string) (uint, bool) {
+	for index, tableHeading := range table.Header() {
+		if tableHeading == heading {
+			return uint(index), true
+		}
+	}
+	return 0, false
+}
+
+// CopyDataSet returns a copy of the data set supplied, deep-copying each of its CSV tables so that their cells can be
+// changed without changing those of the original.
+func CopyDataSet(original dataset.DataSet) dataset.DataSet {
+	dataSetCopy := dataset.NewDataSet(original.Name())
+	for name, table := range original.Tables() {
+		if csvTable, isCsvTable := table.(tables.CsvTable); isCsvTable {
+			dataSetCopy.AddTable(name, copyCsvTable(csvTable))
+			continue
+		}
+		dataSetCopy.AddTable(name, table)
+	}
+	return dataSetCopy
+}
+
+func copyCsvTable(original tables.CsvTable) tables.CsvTable {
+	tableCopy := new(tables.CsvTableImpl)
+	tableCopy.SetName(original.Name())
+
+	header := make(dataset.TableHeader, len(original.Header()))
+	copy(header, original.Header())
+	tableCopy.SetHeader(header)
+
+	columnCount, rowCount := original.ColumnAndRowSize()
+	tableCopy.SetColumnAndRowSize(columnCount, rowCount)
+	for row := uint(0); row < rowCount; row++ {
+		for column := uint(0); column < columnCount; column++ {
+			tableCopy.SetCell(column, row, original.Cell(column, row))
+		}
+	}
+	return tableCopy
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+)
+
+// Report summarises how each solution's decision variables spread over the sampled inputs of an uncertainty analysis.
+type Report struct {
+	SampleSize  uint
+	RandomSeed  int64
+	Percentiles []float64
+	Solutions   []SolutionReport
+}
+
+// SolutionReport summarises the spread of a single solution's decision variables.
+type SolutionReport struct {
+	Id        string
+	Variables []VariableReport
+}
+
+// VariableReport summarises the spread of a single decision variable of a solution over the sampled inputs.
+type VariableReport struct {
+	Name string
+
+	// Baseline is the variable's value with the model's inputs taken as exact.
+	Baseline float64
+
+	Mean              float64
+	StandardDeviation float64
+
+	// PercentileValues holds the variable's value at each of the report's percentiles, in the same order.
+	PercentileValues []float64
+
+	// Target is the value the variable is to be no worse than, if one was supplied.
+	Target *float64 `json:",omitempty"`
+
+	// TargetProbability is the proportion of sampled inputs over which the variable meets its target, if it has one.
+	TargetProbability *float64 `json:",omitempty"`
+
+	direction dominance.Direction
+	samples   []float64
+}
+
+// Variable returns the report of the named decision variable, or nil if the solution has no such variable.
+func (sr *SolutionReport) Variable(name string) *VariableReport {
+	for index := range sr.Variables {
+		if sr.Variables[index].Name == name {
+			return &sr.Variables[index]
+		}
+	}
+	return nil
+}
+
+func newReport(analysis *Analysis, model model.Model, solutions []Solution) *Report {
+	report := &Report{
+		SampleSize:  analysis.sampleSize,
+		RandomSeed:  analysis.randomSeed,
+		Percentiles: analysis.percentiles,
+		Solutions:   make([]SolutionReport, len(solutions)),
+	}
+
+	variableNames := model.DecisionVariables().SortedKeys()
+	for solutionIndex, solution := range solutions {
+		solutionReport := SolutionReport{Id: solution.Id, Variables: make([]VariableReport, len(variableNames))}
+		for variableIndex, variableName := range variableNames {
+			variableReport := VariableReport{
+				Name:      variableName,
+				direction: variable.OptimisationDirectionOf(model.DecisionVariable(variableName)),
+				samples:   make([]float64, 0, analysis.sampleSize),
+			}
+			if target, hasTarget := analysis.targets[variableName]; hasTarget {
+				targetCopy := target
+				variableReport.Target = &targetCopy
+			}
+			solutionReport.Variables[variableIndex] = variableReport
+		}
+		report.Solutions[solutionIndex] = solutionReport
+	}
+	return report
+}
+
+func (r *Report) recordBaseline(solutionIndex int, model model.Model) {
+	variables := r.Solutions[solutionIndex].Variables
+	for index := range variables {
+		variables[index].Baseline = model.DecisionVariable(variables[index].Name).Value()
+	}
+}
+
+func (r *Report) recordSample(solutionIndex int, model model.Model) {
+	variables := r.Solutions[solutionIndex].Variables
+	for index := range variables {
+		variables[index].samples = append(variables[index].samples, model.DecisionVariable(variables[index].Name).Value())
+	}
+}
+
+func (r *Report) summarise() {
+	for solutionIndex := range r.Solutions {
+		variables := r.Solutions[solutionIndex].Variables
+		for index := range variables {
+			variables[index].summarise(r.Percentiles)
+		}
+	}
+}
+
+func (vr *VariableReport) summarise(percentiles []float64) {
+	sortedSamples := make([]float64, len(vr.samples))
+	copy(sortedSamples, vr.samples)
+	sort.Float64s(sortedSamples)
+
+	vr.Mean = meanOf(sortedSamples)
+	vr.StandardDeviation = standardDeviationOf(sortedSamples, vr.Mean)
+
+	vr.PercentileValues = make([]float64, len(percentiles))
+	for index, percentile := range percentiles {
+		vr.PercentileValues[index] = percentileOf(sortedSamples, percentile)
+	}
+
+	if vr.Target != nil {
+		probability := proportionMeeting(sortedSamples, *vr.Target, vr.direction)
+		vr.TargetProbability = &probability
+	}
+}
+
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := float64(0)
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func standardDeviationOf(values []float64, mean float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	sumOfSquares := float64(0)
+	for _, value := range values {
+		sumOfSquares += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sumOfSquares / float64(len(values)-1))
+}
+
+// percentileOf returns the value at the percentile supplied of the (sorted) values, interpolating linearly between
+// the values either side of it.
+func percentileOf(sortedValues []float64, percentile float64) float64 {
+	if len(sortedValues) == 0 {
+		return 0
+	}
+
+	rank := percentile / 100 * float64(len(sortedValues)-1)
+	lowerIndex := int(math.Floor(rank))
+	upperIndex := int(math.Ceil(rank))
+	proportion := rank - float64(lowerIndex)
+
+	return sortedValues[lowerIndex] + proportion*(sortedValues[upperIndex]-sortedValues[lowerIndex])
+}
+
+func proportionMeeting(values []float64, target float64, direction dominance.Direction) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	meetingTarget := 0
+	for _, value := range values {
+		if (direction == dominance.Maximising && value >= target) || (direction != dominance.Maximising && value <= target) {
+			meetingTarget++
+		}
+	}
+	return float64(meetingTarget) / float64(len(values))
+}
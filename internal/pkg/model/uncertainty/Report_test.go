@@ -0,0 +1,67 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func TestVariableReport_Summarise_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	target := 3.0
+	reportUnderTest := VariableReport{
+		Name:      sedimentProduction,
+		Target:    &target,
+		direction: dominance.Minimising,
+		samples:   []float64{5, 1, 4, 2, 3},
+	}
+
+	// when
+	reportUnderTest.summarise([]float64{0, 25, 50, 90, 100})
+
+	// then
+	g.Expect(reportUnderTest.Mean).To(BeNumerically("==", 3))
+	g.Expect(reportUnderTest.StandardDeviation).To(BeNumerically("~", 1.5811, 1e-4))
+	g.Expect(reportUnderTest.PercentileValues).To(Equal([]float64{1, 2, 3, 4.6, 5}))
+	g.Expect(*reportUnderTest.TargetProbability).To(BeNumerically("==", 0.6))
+}
+
+func TestCsvMarshaler_Marshal_RowPerSolutionVariable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	target := 3.0
+	probability := 0.6
+	reportUnderTest := &Report{
+		Percentiles: []float64{5, 95},
+		Solutions: []SolutionReport{
+			{
+				Id: asIsSolutionId,
+				Variables: []VariableReport{
+					{Name: sedimentProduction, Baseline: 3, Mean: 3, StandardDeviation: 1.5, PercentileValues: []float64{1.2, 4.8},
+						Target: &target, TargetProbability: &probability},
+					{Name: dissolvedNitrogen, Baseline: 2, Mean: 2, PercentileValues: []float64{2, 2}},
+				},
+			},
+		},
+	}
+
+	// when
+	marshaled, marshalError := new(CsvMarshaler).Marshal(reportUnderTest)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	lines := strings.Split(strings.TrimSuffix(string(marshaled), newline), newline)
+	g.Expect(lines).To(Equal([]string{
+		"Solution, Variable, Baseline, Mean, StandardDeviation, P5, P95, Target, TargetProbability",
+		"As-Is, SedimentProduction, 3, 3, 1.5, 1.2, 4.8, 3, 0.6",
+		"As-Is, DissolvedNitrogen, 2, 2, 0, 2, 2, , ",
+	}))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	solutionHeading = "Solution"
+	actionsHeading  = "Actions"
+)
+
+// ReadSolutionSet returns the solutions listed in the CSV solution set summary file supplied, as saved by the
+// explorer, limited to those with the ids supplied (or every solution if no ids are supplied).
+func ReadSolutionSet(filePath string, ids ...string) ([]Solution, error) {
+	file, openError := os.Open(filePath)
+	if openError != nil {
+		return nil, errors.Wrap(openError, "opening solution set")
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	records, readError := reader.ReadAll()
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading solution set")
+	}
+
+	return solutionsFrom(records, ids)
+}
+
+func solutionsFrom(records [][]string, ids []string) ([]Solution, error) {
+	if len(records) == 0 || records[0][0] != solutionHeading {
+		return nil, errors.New("solution set misses mandatory [" + solutionHeading + "] heading")
+	}
+
+	actionsIndex := -1
+	for index, heading := range records[0] {
+		if heading == actionsHeading {
+			actionsIndex = index
+		}
+	}
+	if actionsIndex < 0 {
+		return nil, errors.New("solution set misses mandatory [" + actionsHeading + "] heading")
+	}
+
+	solutions := make([]Solution, 0, len(records)-1)
+	for _, record := range records[1:] {
+		solution := Solution{Id: strings.TrimSpace(record[0]), Encoding: strings.TrimSpace(record[actionsIndex])}
+		if len(ids) == 0 || contains(ids, solution.Id) {
+			solutions = append(solutions, solution)
+		}
+	}
+
+	missingErrors := compositeErrors.New("Solution set")
+	for _, id := range ids {
+		if !hasSolution(solutions, id) {
+			missingErrors.AddMessage("solution set has no solution [" + id + "]")
+		}
+	}
+	if missingErrors.Size() > 0 {
+		return nil, missingErrors
+	}
+	return solutions, nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSolution(solutions []Solution, id string) bool {
+	for _, solution := range solutions {
+		if solution.Id == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+// Specification describes an uncertainty analysis as supplied by users, via configuration files or API requests.
+// Unset sample sizes and percentiles take their defaults, and a zero random seed is replaced with a time-derived one.
+type Specification struct {
+	SampleSize  uint
+	RandomSeed  int64
+	Percentiles []float64
+	Targets     map[string]float64
+
+	Parameters map[string]DistributionSpecification
+	Columns    []ColumnSpecification
+}
+
+// DistributionSpecification describes the distribution of an uncertain input. Unset bounds leave sampled values
+// unbounded.
+type DistributionSpecification struct {
+	Distribution string
+	Spread       float64
+	Minimum      *float64
+	Maximum      *float64
+}
+
+// ColumnSpecification describes the distribution of the values of an uncertain data set column.
+type ColumnSpecification struct {
+	Table  string
+	Column string
+	DistributionSpecification
+	PerRow bool
+}
+
+func (ds DistributionSpecification) distribution() Distribution {
+	distribution := NewDistribution(Shape(ds.Distribution), ds.Spread)
+	if ds.Minimum != nil {
+		distribution = distribution.WithMinimum(*ds.Minimum)
+	}
+	if ds.Maximum != nil {
+		distribution = distribution.WithMaximum(*ds.Maximum)
+	}
+	return distribution
+}
+
+// Analysis returns a new analysis (needing only a model) as specified, or an error describing every distribution
+// that is specified badly.
+func (s Specification) Analysis() (*Analysis, error) {
+	specificationErrors := compositeErrors.New("Uncertainty specification")
+
+	inputs := NewInputs()
+	for name, parameterSpecification := range s.Parameters {
+		distribution := parameterSpecification.distribution()
+		if distributionError := distribution.Validate(); distributionError != nil {
+			specificationErrors.AddMessage(fmt.Sprintf("parameter [%s] %s", name, distributionError.Error()))
+		}
+		inputs.WithParameter(name, distribution)
+	}
+
+	for _, columnSpecification := range s.Columns {
+		column := ColumnDistribution{
+			Table:        columnSpecification.Table,
+			Column:       columnSpecification.Column,
+			Distribution: columnSpecification.distribution(),
+			PerRow:       columnSpecification.PerRow,
+		}
+		if distributionError := column.Validate(); distributionError != nil {
+			specificationErrors.AddMessage(fmt.Sprintf("table [%s] column [%s] %s",
+				column.Table, column.Column, distributionError.Error()))
+		}
+		inputs.WithColumn(column)
+	}
+
+	if specificationErrors.Size() > 0 {
+		return nil, specificationErrors
+	}
+
+	analysis := New().WithInputs(inputs).WithRandomSeed(s.RandomSeed)
+	if s.SampleSize > 0 {
+		analysis.WithSampleSize(s.SampleSize)
+	}
+	if len(s.Percentiles) > 0 {
+		analysis.WithPercentiles(s.Percentiles...)
+	}
+	for variableName, target := range s.Targets {
+		analysis.WithTarget(variableName, target)
+	}
+	return analysis, nil
+}
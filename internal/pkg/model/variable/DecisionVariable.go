@@ -6,6 +6,7 @@
 package variable
 
 import (
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/name"
 )
 
@@ -25,6 +26,21 @@ type DecisionVariable interface {
 	Precision() Precision
 }
 
+// Directed describes a DecisionVariable that knows whether its value should be minimised or maximised when optimising
+// its model. Decision variables that aren't Directed are minimised.
+type Directed interface {
+	OptimisationDirection() dominance.Direction
+	SetOptimisationDirection(direction dominance.Direction)
+}
+
+// OptimisationDirectionOf returns the direction in which the decision variable supplied should be optimised.
+func OptimisationDirectionOf(variable DecisionVariable) dominance.Direction {
+	if directedVariable, isDirected := variable.(Directed); isDirected {
+		return directedVariable.OptimisationDirection()
+	}
+	return dominance.Minimising
+}
+
 type UnitOfMeasure string
 
 func (uom UnitOfMeasure) String() string { return string(uom) }
@@ -42,6 +58,7 @@ type Precision int
 const defaultPrecision = 3
 
 var _ DecisionVariable = NewSimpleDecisionVariable("test")
+var _ Directed = NewSimpleDecisionVariable("test")
 
 func NewSimpleDecisionVariable(name string) *SimpleDecisionVariable {
 	return &SimpleDecisionVariable{
@@ -58,6 +75,8 @@ type SimpleDecisionVariable struct {
 
 	unitOfMeasure UnitOfMeasure
 	precision     Precision
+
+	optimisationDirection dominance.Direction
 }
 
 func (v *SimpleDecisionVariable) Name() string                           { return v.name }
@@ -68,3 +87,11 @@ func (v *SimpleDecisionVariable) UnitOfMeasure() UnitOfMeasure           { retur
 func (v *SimpleDecisionVariable) SetUnitOfMeasure(measure UnitOfMeasure) { v.unitOfMeasure = measure }
 func (v *SimpleDecisionVariable) Precision() Precision                   { return v.precision }
 func (v *SimpleDecisionVariable) SetPrecision(precision Precision)       { v.precision = precision }
+
+func (v *SimpleDecisionVariable) OptimisationDirection() dominance.Direction {
+	return v.optimisationDirection
+}
+
+func (v *SimpleDecisionVariable) SetOptimisationDirection(direction dominance.Direction) {
+	v.optimisationDirection = direction
+}
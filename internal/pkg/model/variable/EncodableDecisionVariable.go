@@ -17,12 +17,18 @@ var defaultConverter = strings.NewConverter().Localised().WithFloatingPointPreci
 
 type EncodeableDecisionVariables []EncodeableDecisionVariable
 
+// AccumulatedSuffix is appended to the name of a decision variable to name its accumulated values, where they are
+// encoded as a decision variable of their own.
+const AccumulatedSuffix = "Accumulated"
+
 const (
 	nameKey                 = "Name"
 	measureKey              = "Measure"
 	valueKey                = "Value"
 	valuePerPlanningUnitKey = "ValuePerPlanningUnit"
 
+	accumulatedValuePerPlanningUnitKey = "AccumulatedValuePerPlanningUnit"
+
 	comma      = ","
 	openBrace  = "{"
 	closeBrace = "}"
@@ -40,6 +46,19 @@ func (v EncodeableDecisionVariables) Less(i, j int) bool {
 	return v[i].Name < v[j].Name
 }
 
+// WithAccumulated returns the decision variables, each followed by its accumulated values as a decision variable of
+// their own, for those variables that have them.
+func (v EncodeableDecisionVariables) WithAccumulated() EncodeableDecisionVariables {
+	variables := make(EncodeableDecisionVariables, 0, len(v))
+	for _, variable := range v {
+		variables = append(variables, variable)
+		if accumulated, hasAccumulated := variable.Accumulated(); hasAccumulated {
+			variables = append(variables, accumulated)
+		}
+	}
+	return variables
+}
+
 type PlanningUnitValue struct {
 	PlanningUnit planningunit.Id
 	Value        float64
@@ -60,19 +79,35 @@ func (v PlanningUnitValues) Less(i, j int) bool {
 }
 
 type EncodeableDecisionVariable struct {
-	Name                 string
-	Value                float64
-	Measure              UnitOfMeasure      `json:"UnitOfMeasure"`
-	ValuePerPlanningUnit PlanningUnitValues `json:",omitempty"`
+	Name                            string
+	Value                           float64
+	Measure                         UnitOfMeasure      `json:"UnitOfMeasure"`
+	ValuePerPlanningUnit            PlanningUnitValues `json:",omitempty"`
+	AccumulatedValuePerPlanningUnit PlanningUnitValues `json:",omitempty"`
 }
 
 func MakeEncodeable(variable DecisionVariable) EncodeableDecisionVariable {
 	return EncodeableDecisionVariable{
-		Name:                 variable.Name(),
-		Value:                math.RoundFloat(variable.Value(), int(variable.Precision())),
-		Measure:              variable.UnitOfMeasure(),
-		ValuePerPlanningUnit: encodeValuesPerPlanningUnit(variable),
+		Name:                            variable.Name(),
+		Value:                           math.RoundFloat(variable.Value(), int(variable.Precision())),
+		Measure:                         variable.UnitOfMeasure(),
+		ValuePerPlanningUnit:            encodeValuesPerPlanningUnit(variable),
+		AccumulatedValuePerPlanningUnit: encodeAccumulatedValuesPerPlanningUnit(variable),
+	}
+}
+
+// Accumulated returns the variable's accumulated values as a decision variable of their own, named for the variable
+// with AccumulatedSuffix appended, and false if the variable has no accumulated values.
+func (v EncodeableDecisionVariable) Accumulated() (EncodeableDecisionVariable, bool) {
+	if v.AccumulatedValuePerPlanningUnit == nil {
+		return EncodeableDecisionVariable{}, false
 	}
+	return EncodeableDecisionVariable{
+		Name:                 v.Name + AccumulatedSuffix,
+		Value:                v.Value,
+		Measure:              v.Measure,
+		ValuePerPlanningUnit: v.AccumulatedValuePerPlanningUnit,
+	}, true
 }
 
 func encodeValuesPerPlanningUnit(variable DecisionVariable) PlanningUnitValues {
@@ -80,12 +115,21 @@ func encodeValuesPerPlanningUnit(variable DecisionVariable) PlanningUnitValues {
 	if !isVariablePerPlanningUnit {
 		return nil
 	}
+	return encodePlanningUnitValues(variablePerPlanningUnit.ValuesPerPlanningUnit(), variable.Precision())
+}
 
-	rawValues := variablePerPlanningUnit.ValuesPerPlanningUnit()
+func encodeAccumulatedValuesPerPlanningUnit(variable DecisionVariable) PlanningUnitValues {
+	accumulatingVariable, isAccumulatingVariable := variable.(AccumulatingDecisionVariable)
+	if !isAccumulatingVariable {
+		return nil
+	}
+	return encodePlanningUnitValues(accumulatingVariable.AccumulatedValuesPerPlanningUnit(), variable.Precision())
+}
 
+func encodePlanningUnitValues(rawValues PlanningUnitValueMap, precision Precision) PlanningUnitValues {
 	values := make(PlanningUnitValues, 0)
 	for planningUnitId, planningUnitValue := range rawValues {
-		roundedValue := math.RoundFloat(planningUnitValue, int(variable.Precision()))
+		roundedValue := math.RoundFloat(planningUnitValue, int(precision))
 		if roundedValue == 0 {
 			continue
 		}
@@ -102,7 +146,8 @@ func encodeValuesPerPlanningUnit(variable DecisionVariable) PlanningUnitValues {
 }
 
 func (v *EncodeableDecisionVariable) MarshalJSON() ([]byte, error) {
-	planningUnitValues := v.deriveFormattedPerPlanningUnitValues()
+	planningUnitValues := v.deriveFormattedPerPlanningUnitValues(v.ValuePerPlanningUnit)
+	accumulatedPlanningUnitValues := v.deriveFormattedPerPlanningUnitValues(v.AccumulatedValuePerPlanningUnit)
 
 	perAttributeJson := new(strings.FluentBuilder).
 		Add(openBrace).
@@ -110,15 +155,17 @@ func (v *EncodeableDecisionVariable) MarshalJSON() ([]byte, error) {
 		Add(formatKeyValuePair(measureKey, v.Measure.String())).Add(comma).
 		Add(formatKeyValuePair(valueKey, v.formatMeasureValue(v.Value))).
 		AddIf(v.hasValuesPerPlanningUnit(), comma, formatKeyArrayPair(valuePerPlanningUnitKey, planningUnitValues)).
+		AddIf(v.hasAccumulatedValuesPerPlanningUnit(), comma,
+			formatKeyArrayPair(accumulatedValuePerPlanningUnitKey, accumulatedPlanningUnitValues)).
 		Add(closeBrace).
 		String()
 
 	return []byte(perAttributeJson), nil
 }
 
-func (v *EncodeableDecisionVariable) deriveFormattedPerPlanningUnitValues() []string {
+func (v *EncodeableDecisionVariable) deriveFormattedPerPlanningUnitValues(values PlanningUnitValues) []string {
 	perPlanningUnitValues := make([]string, 0)
-	for _, planningUnitValue := range v.ValuePerPlanningUnit {
+	for _, planningUnitValue := range values {
 		formattedValue := v.formatPlanningUnitValue(planningUnitValue)
 		perPlanningUnitValues = append(perPlanningUnitValues, formattedValue)
 	}
@@ -135,6 +182,10 @@ func (v *EncodeableDecisionVariable) hasValuesPerPlanningUnit() bool {
 	return len(v.ValuePerPlanningUnit) > 0
 }
 
+func (v *EncodeableDecisionVariable) hasAccumulatedValuesPerPlanningUnit() bool {
+	return len(v.AccumulatedValuePerPlanningUnit) > 0
+}
+
 func (v *EncodeableDecisionVariable) formatMeasureValue(value float64) string {
 	switch v.Measure {
 	case Dollars:
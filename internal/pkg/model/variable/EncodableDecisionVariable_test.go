@@ -84,3 +84,57 @@ func TestPerPlanningUnitDecisionVariable_MarshalJson_AsExpected(t *testing.T) {
 	g.Expect(entry1Map["PlanningUnit"]).To(Equal("19"))
 	g.Expect(entry1Map["Value"]).To(Equal("41.410"))
 }
+
+func TestAccumulatingDecisionVariable_MarshalJson_AccumulatedValuesIncluded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	variableUnderTest := EncodeableDecisionVariable{
+		Name:    "AccumulatingEncodeableDecisionVariable",
+		Measure: TonnesPerYear,
+		Value:   84.84,
+		ValuePerPlanningUnit: PlanningUnitValues{
+			PlanningUnitValue{PlanningUnit: 18, Value: 43.43},
+			PlanningUnitValue{PlanningUnit: 19, Value: 41.41},
+		},
+		AccumulatedValuePerPlanningUnit: PlanningUnitValues{
+			PlanningUnitValue{PlanningUnit: 18, Value: 43.43},
+			PlanningUnitValue{PlanningUnit: 19, Value: 84.84},
+		},
+	}
+
+	jsonOfVariableUnderTest, marshalError := variableUnderTest.MarshalJSON()
+
+	g.Expect(marshalError).To(BeNil())
+
+	var derivedData map[string]interface{}
+	unmnarshalError := json.Unmarshal(jsonOfVariableUnderTest, &derivedData)
+
+	g.Expect(unmnarshalError).To(BeNil())
+
+	accumulatedValues, isArray := derivedData["AccumulatedValuePerPlanningUnit"].([]interface{})
+	g.Expect(isArray).To(BeTrue())
+	g.Expect(accumulatedValues).To(HaveLen(2))
+	g.Expect(accumulatedValues[1].(map[string]interface{})["Value"]).To(Equal("84.840"))
+}
+
+func TestEncodeableDecisionVariables_WithAccumulated_AccumulatedFollowVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	accumulatedValues := PlanningUnitValues{PlanningUnitValue{PlanningUnit: 19, Value: 84.84}}
+	variablesUnderTest := EncodeableDecisionVariables{
+		{Name: "Accumulating", Value: 84.84, Measure: TonnesPerYear, AccumulatedValuePerPlanningUnit: accumulatedValues},
+		{Name: "Simple", Value: 42.42, Measure: Dollars},
+	}
+
+	// when
+	expandedVariables := variablesUnderTest.WithAccumulated()
+
+	// then
+	g.Expect(expandedVariables).To(HaveLen(3))
+	g.Expect(expandedVariables[1].Name).To(Equal("Accumulating" + AccumulatedSuffix))
+	g.Expect(expandedVariables[1].Value).To(Equal(84.84))
+	g.Expect(expandedVariables[1].ValuePerPlanningUnit).To(Equal(accumulatedValues))
+	g.Expect(expandedVariables[1].AccumulatedValuePerPlanningUnit).To(BeNil())
+	g.Expect(expandedVariables[2].Name).To(Equal("Simple"))
+}
@@ -15,6 +15,13 @@ type PlanningUnitDecisionVariable interface {
 	SetPlanningUnitValue(planningUnit planningunit.Id, newValue float64)
 }
 
+// AccumulatingDecisionVariable is a decision variable whose per-planning-unit values are loads delivered downstream to
+// the end of catchment, and which can report the load accumulated at each planning unit along the way.
+type AccumulatingDecisionVariable interface {
+	PlanningUnitDecisionVariable
+	AccumulatedValuesPerPlanningUnit() PlanningUnitValueMap
+}
+
 func NewPerPlanningUnitDecisionVariable() *PerPlanningUnitDecisionVariable {
 	return new(PerPlanningUnitDecisionVariable).Initialise()
 }
@@ -102,6 +102,25 @@ func (p *Parameters) validateParam(key string, value interface{}) bool {
 	return validationError.IsValid()
 }
 
+// Validate returns the reason the value supplied is invalid for the parameter keyed, or nil if it is valid, without
+// recording a validation error or assigning the value.
+func (p *Parameters) Validate(key string, value interface{}) error {
+	validationError := p.specifications.Validate(key, value).(specification.ValidationError)
+	if validationError.IsValid() {
+		return nil
+	}
+	return validationError
+}
+
+// Values returns a copy of every parameter value held, keyed by parameter name.
+func (p *Parameters) Values() Map {
+	values := make(Map, len(p.paramMap))
+	for key, value := range p.paramMap {
+		values[key] = value
+	}
+	return values
+}
+
 func (p *Parameters) GetInt64(key string) int64 {
 	return p.paramMap[key].(int64)
 }
@@ -113,3 +132,20 @@ func (p *Parameters) GetFloat64(key string) float64 {
 func (p *Parameters) GetString(key string) string {
 	return p.paramMap[key].(string)
 }
+
+// GetMap returns the table of values held against key, as supplied either as a Map or as a decoded TOML table.
+func (p *Parameters) GetMap(key string) Map {
+	return AsMap(p.paramMap[key])
+}
+
+// AsMap returns value as a Map if it is a Map or a decoded TOML table, or nil otherwise.
+func AsMap(value interface{}) Map {
+	switch typedValue := value.(type) {
+	case Map:
+		return typedValue
+	case map[string]interface{}:
+		return typedValue
+	default:
+		return nil
+	}
+}
@@ -31,21 +31,99 @@ func (g *RandContainer) SetRandomNumberGenerator(generator *Rand) {
 	g.rand = *generator
 }
 
+// Seeded defines an interface for entities whose pseudo-random behaviour is reproducible from a single seed.
+type Seeded interface {
+	RandomSeed() int64
+	SetRandomSeed(seed int64)
+}
+
+// SeedContainer offers a struct implementing the Seeded interface. If no seed has been explicitly set, a seed
+// derived from the system-time is adopted on first request, and reported from then on.
+type SeedContainer struct {
+	seed    int64
+	seedSet bool
+}
+
+func (c *SeedContainer) RandomSeed() int64 {
+	if !c.seedSet {
+		c.SetRandomSeed(TimeSeed())
+	}
+	return c.seed
+}
+
+func (c *SeedContainer) SetRandomSeed(seed int64) {
+	c.seed = seed
+	c.seedSet = true
+}
+
 // Rand is a source of project-specific random numbers
 type Rand struct {
 	officialRand rand.Rand
+	source       *countingSource
+	seed         int64
+}
+
+// State captures how far a seeded Rand has progressed through its sequence of random values, allowing an equivalent
+// Rand to be recreated later via NewFromState.
+type State struct {
+	Seed  int64
+	Draws uint64
 }
 
 // New returns a new Rand that uses random values from src to generate other random values.
 func New(src rand.Source) *Rand {
-	unsafeRand := rand.New(src)
-	return &Rand{officialRand: *unsafeRand}
+	source := &countingSource{source: src}
+	unsafeRand := rand.New(source)
+	return &Rand{officialRand: *unsafeRand, source: source}
 }
 
-// New returns a new Rand that uses random values seeded from a source of the system-time to generate
+// NewTimeSeeded returns a new Rand that uses random values seeded from a source of the system-time to generate
 // other random values.
 func NewTimeSeeded() *Rand {
-	return New(rand.NewSource(time.Now().UnixNano()))
+	return NewSeeded(TimeSeed())
+}
+
+// NewSeeded returns a new Rand whose sequence of random values is entirely determined by the seed supplied.
+func NewSeeded(seed int64) *Rand {
+	newRand := New(rand.NewSource(seed))
+	newRand.seed = seed
+	return newRand
+}
+
+// NewFromState returns a new Rand that continues the sequence of random values of the Rand the state was taken from.
+func NewFromState(state State) *Rand {
+	newRand := NewSeeded(state.Seed)
+	for draw := uint64(0); draw < state.Draws; draw++ {
+		newRand.source.Int63()
+	}
+	return newRand
+}
+
+// State returns how far the Rand has progressed through its sequence of random values. It is only meaningful for
+// a Rand created with a seed (via NewSeeded, NewDerived, NewTimeSeeded or NewFromState).
+func (r *Rand) State() State {
+	return State{Seed: r.seed, Draws: r.source.draws}
+}
+
+// NewDerived returns a new Rand seeded with the seed derived from baseSeed for the given stream (see DeriveSeed).
+func NewDerived(baseSeed int64, stream uint64) *Rand {
+	return NewSeeded(DeriveSeed(baseSeed, stream))
+}
+
+// TimeSeed returns a seed derived from the system-time, for use when no explicit seed has been supplied.
+func TimeSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// DeriveSeed returns a seed for one of many independent streams of random numbers stemming from a single base
+// seed. The same base seed and stream always derive the same seed, and neighbouring streams derive seeds that are
+// well scattered from each other.
+func DeriveSeed(baseSeed int64, stream uint64) int64 {
+	// See: http://xoshiro.di.unimi.it/splitmix64.c
+	mixed := uint64(baseSeed) + (stream+1)*0x9E3779B97F4A7C15
+	mixed = (mixed ^ (mixed >> 30)) * 0xBF58476D1CE4E5B9
+	mixed = (mixed ^ (mixed >> 27)) * 0x94D049BB133111EB
+	return int64(mixed ^ (mixed >> 31))
 }
 
 // Uint64 returns a pseudo-random 64-bit value as a uint64 from the default Source.
@@ -70,3 +148,34 @@ func (r *Rand) Float64Unitary() float64 {
 	distributionRange := int64(math.Pow(2, 53))
 	return float64(r.Int63n(distributionRange)) / float64(distributionRange-1)
 }
+
+// NormFloat64 returns, as a float64, a normally distributed pseudo-random number with a mean of 0 and a standard
+// deviation of 1.
+func (r *Rand) NormFloat64() float64 {
+	return r.officialRand.NormFloat64()
+}
+
+// countingSource wraps a source of random values, counting the number of values drawn from it, so that the state
+// of a seeded source can be recorded and replayed.
+type countingSource struct {
+	source rand.Source
+	draws  uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.source.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	if source64, isSource64 := s.source.(rand.Source64); isSource64 {
+		s.draws++
+		return source64.Uint64()
+	}
+	return uint64(s.Int63())>>31 | uint64(s.Int63())<<32
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.draws = 0
+	s.source.Seed(seed)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package rand
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const sampleSize = 100
+
+func TestNewSeeded_SameSeed_SameSequence(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	firstRand := NewSeeded(42)
+	secondRand := NewSeeded(42)
+
+	for sample := 0; sample < sampleSize; sample++ {
+		g.Expect(firstRand.Int63n(1_000_000)).To(Equal(secondRand.Int63n(1_000_000)))
+	}
+}
+
+func TestDeriveSeed_Repeatable_AndDistinctPerStream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const baseSeed = int64(42)
+
+	g.Expect(DeriveSeed(baseSeed, 1)).To(Equal(DeriveSeed(baseSeed, 1)))
+
+	derivedSeeds := make(map[int64]bool, sampleSize)
+	for stream := uint64(0); stream < sampleSize; stream++ {
+		derivedSeeds[DeriveSeed(baseSeed, stream)] = true
+	}
+	g.Expect(derivedSeeds).To(HaveLen(sampleSize))
+
+	g.Expect(DeriveSeed(baseSeed, 1)).ToNot(Equal(DeriveSeed(baseSeed+1, 1)))
+}
+
+func TestSeedContainer_UnsetSeed_AdoptedOnce(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	containerUnderTest := new(SeedContainer)
+	adoptedSeed := containerUnderTest.RandomSeed()
+
+	g.Expect(containerUnderTest.RandomSeed()).To(Equal(adoptedSeed))
+
+	containerUnderTest.SetRandomSeed(7)
+	g.Expect(containerUnderTest.RandomSeed()).To(BeNumerically("==", 7))
+}
+
+func TestNewFromState_ContinuesSequence(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	originalRand := NewSeeded(42)
+	for sample := 0; sample < sampleSize; sample++ {
+		originalRand.Intn(sample + 1)
+		originalRand.Uint64()
+		originalRand.Float64Unitary()
+	}
+
+	restoredRand := NewFromState(originalRand.State())
+	g.Expect(restoredRand.State()).To(Equal(originalRand.State()))
+
+	for sample := 0; sample < sampleSize; sample++ {
+		g.Expect(restoredRand.Int63n(1_000_000)).To(Equal(originalRand.Int63n(1_000_000)))
+	}
+}
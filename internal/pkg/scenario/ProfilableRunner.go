@@ -3,6 +3,8 @@
 package scenario
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/scenario/profiling"
 	"github.com/LindsayBradford/crem/pkg/excel"
@@ -32,6 +34,10 @@ func (runner *ProfilingRunner) LogHandler() logging.Logger {
 	return runner.base.LogHandler()
 }
 
+func (runner *ProfilingRunner) SetContext(ctx context.Context) {
+	setContextOf(runner.base, ctx)
+}
+
 func (runner *ProfilingRunner) Run() error {
 	runner.LogHandler().Info("About to collect cpu profiling data to file [" + runner.profilePath + "]")
 	defer runner.LogHandler().Info("Collection of cpu profiling data to file [" + runner.profilePath + "] complete.")
@@ -56,6 +62,10 @@ func (runner *SpreadsheetSafeScenarioRunner) LogHandler() logging.Logger {
 	return runner.base.LogHandler()
 }
 
+func (runner *SpreadsheetSafeScenarioRunner) SetContext(ctx context.Context) {
+	setContextOf(runner.base, ctx)
+}
+
 func (runner *SpreadsheetSafeScenarioRunner) Run() error {
 	runner.LogHandler().Debug("Making scenario runner spreadsheet interaction safe")
 
@@ -68,3 +78,10 @@ func (runner *SpreadsheetSafeScenarioRunner) Run() error {
 
 	return runner.base.Run()
 }
+
+// setContextOf passes the context supplied to the runner, if it can be cancelled.
+func setContextOf(runner CallableRunner, ctx context.Context) {
+	if cancellableRunner, runnerIsCancellable := runner.(Cancellable); runnerIsCancellable {
+		cancellableRunner.SetContext(ctx)
+	}
+}
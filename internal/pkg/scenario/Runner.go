@@ -3,12 +3,15 @@
 package scenario
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	. "time"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/logging"
 )
 
@@ -19,6 +22,14 @@ type CallableRunner interface {
 	Run() error
 }
 
+// Cancellable is implemented by scenarios and runners that can be cancelled part-way through their runs, via the
+// context supplied.
+type Cancellable interface {
+	SetContext(ctx context.Context)
+}
+
+var _ Cancellable = new(Runner)
+
 type Runner struct {
 	annealer   annealing.Annealer
 	logHandler logging.Logger
@@ -28,8 +39,14 @@ type Runner struct {
 	operationType     string
 	runNumber         uint64
 	maxConcurrentRuns uint64
+	randomSeed        int64
 	tearDown          func()
 
+	checkpointer      *checkpoint.Checkpointer
+	resumeCheckpoints string
+
+	context context.Context
+
 	startTime  Time
 	finishTime Time
 }
@@ -41,8 +58,10 @@ func NewRunner() *Runner {
 func (runner *Runner) initialise() *Runner {
 	runner.runNumber = 1
 	runner.maxConcurrentRuns = 1 // Sequential by default
+	runner.randomSeed = rand.TimeSeed()
 	runner.name = "Default Scenario"
 	runner.tearDown = defaultTearDown
+	runner.context = context.Background()
 	return runner
 }
 
@@ -71,6 +90,30 @@ func (runner *Runner) WithRunNumber(runNumber uint64) *Runner {
 	return runner
 }
 
+// WithRandomSeed fixes the seed from which every run's random numbers are derived. A seed of 0 leaves the runner
+// seeded from the system-time.
+func (runner *Runner) WithRandomSeed(randomSeed int64) *Runner {
+	if randomSeed != 0 {
+		runner.randomSeed = randomSeed
+	}
+	return runner
+}
+
+// WithCheckpointing has each run save a checkpoint of its progress to the supplied directory every interval
+// iterations. An interval of 0 leaves checkpointing off.
+func (runner *Runner) WithCheckpointing(directory string, interval uint64) *Runner {
+	if interval > 0 {
+		runner.checkpointer = checkpoint.NewCheckpointer(directory, interval)
+	}
+	return runner
+}
+
+// ResumingFrom has each run resume from its latest checkpoint in the supplied directory, if one exists.
+func (runner *Runner) ResumingFrom(directory string) *Runner {
+	runner.resumeCheckpoints = directory
+	return runner
+}
+
 func (runner *Runner) WithTearDownFunction(tearDown func()) *Runner {
 	if tearDown != nil {
 		runner.tearDown = tearDown
@@ -96,11 +139,18 @@ func (runner *Runner) SetAnnealer(annealer annealing.Annealer) {
 	annealer.AddObserver(runner.saver)
 }
 
+// SetContext has the runner stop starting new runs once the supplied context is done, and each run in progress stop
+// at the end of its current iteration, saving what it has found so far as partial results.
+func (runner *Runner) SetContext(ctx context.Context) {
+	runner.context = ctx
+}
+
 func (runner *Runner) Run() error {
 	runner.logScenarioStartMessage()
 	runner.startTime = Now()
 
 	runError := runner.runScenario()
+	runner.saveMergedSolutionSet()
 
 	runner.finishTime = Now()
 	runner.logHandler.Info("Finished running scenario [" + runner.name + "]")
@@ -126,6 +176,9 @@ func (runner *Runner) logScenarioStartMessage() {
 
 	message := fmt.Sprintf("Scenario [%s]: configured for %d run(s), %s", runner.name, runner.runNumber, runTypeText)
 	runner.logHandler.Info(message)
+
+	seedMessage := fmt.Sprintf("Scenario [%s]: random seed [%d]", runner.name, runner.randomSeed)
+	runner.logHandler.Info(seedMessage)
 }
 
 func (runner *Runner) generateElapsedTimeString() string {
@@ -147,22 +200,66 @@ func (runner *Runner) runScenario() error {
 		runWaitGroup.Done()
 	}
 
-	runWaitGroup.Add(int(runner.runNumber))
-
 	for runNumber := uint64(1); runNumber <= runner.runNumber; runNumber++ {
 		concurrentRunGuard <- struct{}{}
+		if runner.isCancelled() {
+			runner.logHandler.Warn(fmt.Sprintf("Scenario [%s]: cancelled, skipping runs [%d] onwards", runner.name, runNumber))
+			runner.mergeSkippedRuns(runNumber)
+			break
+		}
+		runWaitGroup.Add(1)
 		go doRun(runNumber)
 	}
 
 	runWaitGroup.Wait()
 
+	if runner.isCancelled() {
+		runner.logHandler.Warn(fmt.Sprintf("Scenario [%s]: cancelled, partial results saved", runner.name))
+	}
+
 	return nil
 }
 
+// mergeSkippedRuns includes the checkpointed solution sets of the runs skipped from the given run number onwards in the
+// merged solution set, warning of those runs without a checkpoint to resume from, which it will miss.
+func (runner *Runner) mergeSkippedRuns(firstSkippedRun uint64) {
+	if runner.runNumber < 2 {
+		return
+	}
+	for runNumber := firstSkippedRun; runNumber <= runner.runNumber; runNumber++ {
+		runId := runner.generateCloneId(runNumber)
+		if runCheckpoint := runner.loadResumeCheckpoint(runId); runCheckpoint != nil {
+			runner.mergeCheckpointedRun(runCheckpoint)
+			continue
+		}
+		runner.logHandler.Warn(runId + ": skipped before finding any solutions, missing from the merged solution set")
+	}
+}
+
+func (runner *Runner) isCancelled() bool {
+	return runner.context.Err() != nil
+}
+
+func (runner *Runner) saveMergedSolutionSet() {
+	if runner.runNumber < 2 {
+		return
+	}
+	if merger, saverMerges := runner.saver.(SolutionSetMerger); saverMerges {
+		merger.SaveMergedSolutionSet()
+	}
+}
+
 func (runner *Runner) run(runNumber uint64) {
 	annealerCopy := runner.annealer.DeepClone()
 
 	runner.assignNewRunId(runNumber, annealerCopy)
+	runner.assignRunSeed(runNumber, annealerCopy)
+	runner.assignCancellation(annealerCopy)
+	if finishedCheckpoint := runner.assignCheckpointing(annealerCopy); finishedCheckpoint != nil {
+		runner.logHandler.Info(annealerCopy.Id() + ": run already finished as of its checkpoint, skipping")
+		runner.mergeCheckpointedRun(finishedCheckpoint)
+		return
+	}
 	runner.wireObservers(annealerCopy)
 
 	annealerCopy.Anneal()
@@ -177,6 +274,82 @@ func (runner *Runner) assignNewRunId(runNumber uint64, annealerCopy annealing.An
 	runner.logRunStartMessage(runNumber)
 }
 
+func (runner *Runner) assignRunSeed(runNumber uint64, annealerCopy annealing.Annealer) {
+	if seededExplorer, explorerIsSeeded := annealerCopy.SolutionExplorer().(rand.Seeded); explorerIsSeeded {
+		seededExplorer.SetRandomSeed(runner.deriveRunSeed(runNumber))
+	}
+}
+
+func (runner *Runner) assignCancellation(annealerCopy annealing.Annealer) {
+	if cancellableAnnealer, annealerIsCancellable := annealerCopy.(annealing.Cancellable); annealerIsCancellable {
+		cancellableAnnealer.SetContext(runner.context)
+	}
+}
+
+// deriveRunSeed returns the random seed for the given run. A lone run uses the scenario's seed as-is, so that the seed
+// recorded against any run's solutions can be supplied as the scenario seed of a single run to reproduce it.
+func (runner *Runner) deriveRunSeed(runNumber uint64) int64 {
+	if runner.runNumber == 1 {
+		return runner.randomSeed
+	}
+	return rand.DeriveSeed(runner.randomSeed, runNumber)
+}
+
+// assignCheckpointing wires the runner's checkpointing and resumption into the annealer, returning the checkpoint it
+// would be resumed from if the annealer's run had already finished as of that checkpoint, or nil otherwise.
+func (runner *Runner) assignCheckpointing(annealerCopy annealing.Annealer) *checkpoint.Checkpoint {
+	resumableAnnealer, annealerIsResumable := annealerCopy.(annealing.Resumable)
+	if !annealerIsResumable {
+		return nil
+	}
+
+	if runner.checkpointer != nil {
+		resumableAnnealer.SetCheckpointer(runner.checkpointer)
+	}
+
+	if runner.resumeCheckpoints == "" {
+		return nil
+	}
+
+	runCheckpoint := runner.loadResumeCheckpoint(annealerCopy.Id())
+	if runCheckpoint == nil {
+		runner.logHandler.Warn(annealerCopy.Id() + ": no usable checkpoint in [" + runner.resumeCheckpoints + "], starting afresh")
+		return nil
+	}
+
+	if runCheckpoint.Finished {
+		return runCheckpoint
+	}
+
+	resumableAnnealer.ResumeFrom(runCheckpoint)
+	return nil
+}
+
+// loadResumeCheckpoint returns the checkpoint to resume the identified run from, or nil if there is no usable one.
+func (runner *Runner) loadResumeCheckpoint(runId string) *checkpoint.Checkpoint {
+	if runner.resumeCheckpoints == "" {
+		return nil
+	}
+
+	checkpointPath := checkpoint.FilePath(runner.resumeCheckpoints, runId)
+	runCheckpoint, loadError := checkpoint.Load(checkpointPath)
+	if loadError != nil {
+		return nil
+	}
+	return runCheckpoint
+}
+
+// mergeCheckpointedRun hands a run's checkpoint to the saver, for merging the solution set archived in it with the
+// solution sets of the runs annealed.
+func (runner *Runner) mergeCheckpointedRun(runCheckpoint *checkpoint.Checkpoint) {
+	if runner.runNumber < 2 {
+		return
+	}
+	if merger, saverMerges := runner.saver.(SolutionSetMerger); saverMerges {
+		merger.MergeCheckpointedSolutionSet(runCheckpoint)
+	}
+}
+
 func (runner *Runner) wireObservers(annealer annealing.Annealer) {
 	if observingAnnealer, annealerIsObserver := annealer.(observer.Observer); annealerIsObserver {
 		explorer := annealer.SolutionExplorer()
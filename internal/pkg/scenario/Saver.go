@@ -8,31 +8,52 @@ import (
 	encoding2 "github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/exact"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/geojson"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	"github.com/pkg/errors"
 )
 
 const (
-	CompressedModel    = "CompressedModel"
-	ModelArchive       = "ModelArchive"
+	CompressedModel = "CompressedModel"
+	ModelArchive    = "ModelArchive"
+	CostCurve       = "MarginalCostCurve"
+	RandomSeed      = "RandomSeed"
+	Partial         = "Partial"
+
+	CurrentIteration = "CurrentIteration"
+
 	defaultOutputPath  = "solutions"
 	defaultOutputLevel = "Summary"
 
 	asIsSolutionNote = "As-is state; zero active management actions"
+	mergedIdSuffix   = " Merged"
+	exactIdSuffix    = " Exact"
+	runDetail        = "Run"
 	topSummaryEntry  = uint64(0)
 )
 
 var membershipMatcher = regexp.MustCompile("\\((\\d+)/(\\d+)\\)")
 
+var (
+	calibrationDetails = []string{explorer.CalibratedStartingTemperature, explorer.CalibratedCoolingFactor}
+	qualityDetails     = []string{explorer.Hypervolume, explorer.Spread, explorer.Spacing, explorer.GenerationalDistance}
+)
+
 type OutputLevel string
 
 type CallableSaver interface {
@@ -41,14 +62,40 @@ type CallableSaver interface {
 	SetDecompressionModel(model model.Model)
 }
 
+// SolutionSetMerger is implemented by savers able to combine the solution sets of every run of a scenario, once all
+// of the scenario's runs have finished.
+type SolutionSetMerger interface {
+	// MergeCheckpointedSolutionSet includes the solution set archived in the checkpoint of a run not annealed by the
+	// scenario this time around (such as one finished as of its checkpoint) in the merge.
+	MergeCheckpointedSolutionSet(runCheckpoint *checkpoint.Checkpoint)
+	SaveMergedSolutionSet()
+}
+
+var _ SolutionSetMerger = new(Saver)
+
+type runSolutionSet struct {
+	solutionSet archive.NonDominanceModelArchive
+	randomSeed  int64
+}
+
 type Saver struct {
 	loggers.ContainedLogger
 	decompressionModel model.Model
 	outputType         encoding.OutputType
 	outputLevel        OutputLevel
 	outputPath         string
+	boundaries         *geojson.Boundaries
 
 	decompressionMutex sync.Mutex
+
+	runSolutionSets  []runSolutionSet
+	solutionSetMutex sync.Mutex
+
+	exactComparison bool
+	exactFront      *archive.NonDominanceModelArchive
+	exactOptima     map[string]*archive.CompressedModelState
+	exactError      error
+	exactMutex      sync.Mutex
 }
 
 func NewSaver() *Saver {
@@ -79,6 +126,21 @@ func (s *Saver) WithOutputLevel(outputLevel OutputLevel) *Saver {
 	return s
 }
 
+// WithExactComparison has the saver compare the solutions of each run with those found by exhaustively solving the
+// model (see exact.Solver), for models offering few enough management actions to do so. The exact Pareto front is saved
+// as a solution set of its own, and each run's gap to it (or to the exact optimum of its objective) is saved in its own
+// summary columns.
+func (s *Saver) WithExactComparison(compare bool) *Saver {
+	s.exactComparison = compare
+	return s
+}
+
+// WithBoundaries supplies the planning unit boundaries that GeoJSON output joins solutions to.
+func (s *Saver) WithBoundaries(boundaries *geojson.Boundaries) *Saver {
+	s.boundaries = boundaries
+	return s
+}
+
 func (s *Saver) WithLogHandler(logHandler logging.Logger) *Saver {
 	s.SetLogHandler(logHandler)
 	return s
@@ -116,56 +178,213 @@ func (s *Saver) ObserveEvent(event observer.Event) {
 	if event.EventType != observer.FinishedAnnealing {
 		return
 	}
+	randomSeed := deriveRandomSeedFrom(event)
+	runNote := derivePartialNoteFrom(event)
+	runDetails := deriveDetailsFrom(event, calibrationDetails...)
 	if event.HasAttribute(CompressedModel) {
 		s.LogHandler().Info("Saving annealing optimised solution")
 		compressedModel := event.Attribute(CompressedModel).(archive.CompressedModelState)
-		s.saveOptimisedModel(&compressedModel)
+		optimisedDetails := append(runDetails, s.deriveExactOptimumDetailsFor(event, &compressedModel)...)
+		s.saveOptimisedModel(&compressedModel, randomSeed, runNote, optimisedDetails...)
 	}
 	if event.HasAttribute(ModelArchive) {
 		s.LogHandler().Info("Saving annealing solution set")
 		modelArchive := event.Attribute(ModelArchive).(archive.NonDominanceModelArchive)
-		s.saveSolutionSet(modelArchive)
+		setDetails := append(runDetails, deriveDetailsFrom(event, qualityDetails...)...)
+		setDetails = append(setDetails, s.deriveExactFrontDetailsFor(modelArchive)...)
+		s.saveSolutionSet(modelArchive, randomSeed, runNote, setDetails...)
+		s.rememberSolutionSet(modelArchive, randomSeed)
+	}
+	if event.HasAttribute(CostCurve) {
+		s.LogHandler().Info("Saving marginal cost curve solution set")
+		costCurve := event.Attribute(CostCurve).(archive.NonDominanceModelArchive)
+		s.saveCostCurve(costCurve, randomSeed, runNote)
 	}
 }
 
-func (s *Saver) saveOptimisedModel(optimisedModel *archive.CompressedModelState) {
+func deriveRandomSeedFrom(event observer.Event) int64 {
+	if randomSeed, isSeed := event.Attribute(RandomSeed).(int64); isSeed {
+		return randomSeed
+	}
+	return 0
+}
+
+// deriveDetailsFrom returns those of the named attributes of the event holding 64-bit floating point values (such as
+// calibrated coolant settings, or the quality metrics of a run's solution set), as details to report in their own
+// summary columns against the solutions of the run.
+func deriveDetailsFrom(event observer.Event, attributeNames ...string) []solution.VariableSummary {
+	details := make([]solution.VariableSummary, 0)
+	for _, name := range attributeNames {
+		if value, isFloat := event.Attribute(name).(float64); isFloat {
+			details = append(details, solution.VariableSummary{Name: name, Value: value})
+		}
+	}
+	return details
+}
+
+// derivePartialNoteFrom returns a note marking the solutions found by a run as partial, if the run was cancelled
+// before annealing finished.
+func derivePartialNoteFrom(event observer.Event) string {
+	if isPartial, _ := event.Attribute(Partial).(bool); isPartial {
+		return fmt.Sprintf("; partial -- annealing cancelled at iteration [%v]", event.Attribute(CurrentIteration))
+	}
+	return ""
+}
+
+// deriveExactFrontDetailsFor returns the measures of the gap between the solution set and the model's exact Pareto
+// front, solving for (and saving) that front when first asked. None are returned if exact comparison wasn't asked for,
+// or the model cannot be solved exactly.
+func (s *Saver) deriveExactFrontDetailsFor(solutionSet archive.NonDominanceModelArchive) []solution.VariableSummary {
+	if !s.exactComparison || solutionSet.IsEmpty() {
+		return nil
+	}
+
+	exactFront := s.exactFrontFor(solutionSet)
+	if exactFront == nil {
+		return nil
+	}
+
+	report := exact.Compare(&solutionSet, exactFront)
+	s.LogHandler().Info(solutionSet.Id() + ": compared with exact Pareto front. " + report.String())
+
+	return []solution.VariableSummary{
+		{Name: exact.ExactSolutionsFound, Value: float64(report.ExactSolutionsFound)},
+		{Name: exact.ExactGenerationalDistance, Value: report.GenerationalDistance},
+		{Name: exact.ExactInvertedGenerationalDistance, Value: report.InvertedGenerationalDistance},
+		{Name: exact.ExactHypervolumeRatio, Value: report.HypervolumeRatio},
+	}
+}
+
+func (s *Saver) exactFrontFor(solutionSet archive.NonDominanceModelArchive) *archive.NonDominanceModelArchive {
+	s.exactMutex.Lock()
+	defer s.exactMutex.Unlock()
+
+	if s.exactFront != nil || s.exactError != nil {
+		return s.exactFront
+	}
+
+	solver := s.buildExactSolver().WithDirections(solutionSet.Archive()[0].Directions)
+	if s.exactFront, s.exactError = solver.ParetoFront(); s.exactError != nil {
+		s.LogHandler().Warn("Exact comparison skipped: " + s.exactError.Error())
+		return nil
+	}
+
+	s.LogHandler().Info("Saving exact Pareto front")
+	s.exactFront.SetId(scenarioNameOf(solutionSet.Id()) + exactIdSuffix)
 	s.ensureOutputPathIsUsable()
-	s.encodeOptimisedModel(optimisedModel)
+	s.encodeSolutionSetNoting(*s.exactFront, 0, "Exact Pareto front member %d of %d", "")
+	return s.exactFront
 }
 
-func (s *Saver) encodeOptimisedModel(optimisedModel *archive.CompressedModelState) {
+// deriveExactOptimumDetailsFor returns the exact optimum of the objective of the run the event reports on, and the
+// optimised model's gap to it. None are returned if exact comparison wasn't asked for, the run reports no objective,
+// or the model cannot be solved exactly.
+func (s *Saver) deriveExactOptimumDetailsFor(event observer.Event, optimisedModel *archive.CompressedModelState) []solution.VariableSummary {
+	variableName, hasObjective := event.Attribute(explorer.ObjectiveVariable).(string)
+	if !s.exactComparison || !hasObjective {
+		return nil
+	}
+
+	directionName, _ := event.Attribute(explorer.OptimisationDirection).(string)
+	direction, _ := dominance.ParseDirection(directionName)
+
+	optimum := s.exactOptimumOf(variableName, direction)
+	variableIndex := s.variableIndexOf(variableName)
+	if optimum == nil || variableIndex < 0 {
+		return nil
+	}
+
+	optimumValue := optimum.Variables[variableIndex]
+	return []solution.VariableSummary{
+		{Name: exact.ExactOptimum, Value: optimumValue},
+		{Name: exact.ExactOptimalityGap, Value: exact.OptimalityGap(optimisedModel.Variables[variableIndex], optimumValue, direction)},
+	}
+}
+
+func (s *Saver) exactOptimumOf(variableName string, direction dominance.Direction) *archive.CompressedModelState {
+	s.exactMutex.Lock()
+	defer s.exactMutex.Unlock()
+
+	optimumKey := variableName + " " + direction.String()
+	if optimum, isSolved := s.exactOptima[optimumKey]; isSolved || s.exactError != nil {
+		return optimum
+	}
+
+	var optimum *archive.CompressedModelState
+	if optimum, s.exactError = s.buildExactSolver().Optimum(variableName, direction); s.exactError != nil {
+		s.LogHandler().Warn("Exact comparison skipped: " + s.exactError.Error())
+		return nil
+	}
+
+	if s.exactOptima == nil {
+		s.exactOptima = make(map[string]*archive.CompressedModelState)
+	}
+	s.exactOptima[optimumKey] = optimum
+	return optimum
+}
+
+// buildExactSolver returns an exact solver over an as-is clone of the decompression model, leaving the decompression
+// model free for saving solutions while the solver visits every state of its clone.
+func (s *Saver) buildExactSolver() *exact.Solver {
+	s.decompressionMutex.Lock()
+	defer s.decompressionMutex.Unlock()
+
+	solvedModel := s.decompressionModel.DeepClone()
+	solvedModel.Initialise(model.AsIs)
+	return exact.New().WithModel(solvedModel)
+}
+
+func (s *Saver) variableIndexOf(variableName string) int {
+	s.decompressionMutex.Lock()
+	defer s.decompressionMutex.Unlock()
+
+	for index, name := range s.decompressionModel.DecisionVariables().SortedKeys() {
+		if name == variableName {
+			return index
+		}
+	}
+	return -1
+}
+
+func (s *Saver) saveOptimisedModel(optimisedModel *archive.CompressedModelState, randomSeed int64, runNote string, runDetails ...solution.VariableSummary) {
+	s.ensureOutputPathIsUsable()
+	s.encodeOptimisedModel(optimisedModel, randomSeed, runNote, runDetails...)
+}
+
+func (s *Saver) encodeOptimisedModel(optimisedModel *archive.CompressedModelState, randomSeed int64, runNote string, runDetails ...solution.VariableSummary) {
 	summary := make(solutionset.Summary, 0)
-	s.encodeAndSummariseAsIsSolution(optimisedModel, summary)
-	s.encodeAndSummariseOptimisedSolution(optimisedModel, summary)
+	s.encodeAndSummariseAsIsSolution(optimisedModel, randomSeed, summary)
+	s.encodeAndSummariseOptimisedSolution(optimisedModel, randomSeed, runNote, summary, runDetails...)
 	s.encodeSummary(&summary)
 }
 
-func (s *Saver) encodeAndSummariseAsIsSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary) {
-	asIsSolution := s.deriveASsIsSolutionForOptimised(optimisedModel.Id())
+func (s *Saver) encodeAndSummariseAsIsSolution(optimisedModel *archive.CompressedModelState, randomSeed int64, summary solutionset.Summary) {
+	asIsSolution := s.deriveASsIsSolutionForOptimised(optimisedModel.Id(), randomSeed)
 	s.encodeSolutionDetail(*asIsSolution)
 	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
 }
 
-func (s *Saver) encodeAndSummariseOptimisedSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary) {
-	optimisedSolution := s.deriveSolutionFromCompressedModel(optimisedModel, optimisedModel.Id()+" Solution (1/1)")
+func (s *Saver) encodeAndSummariseOptimisedSolution(optimisedModel *archive.CompressedModelState, randomSeed int64, runNote string, summary solutionset.Summary, runDetails ...solution.VariableSummary) {
+	optimisedSolution := s.deriveSolutionFromCompressedModel(optimisedModel, optimisedModel.Id()+" Solution (1/1)", randomSeed)
 	s.encodeSolutionDetail(*optimisedSolution)
-	s.summarise(&summary, optimisedSolution, "Computationally optimised solution", topSummaryEntry+1)
+	s.summarise(&summary, optimisedSolution, "Computationally optimised solution"+runNote, topSummaryEntry+1, runDetails...)
 }
 
-func (s *Saver) deriveSolutionFromCompressedModel(compressedModel *archive.CompressedModelState, solutionId string) *solution.Solution {
+func (s *Saver) deriveSolutionFromCompressedModel(compressedModel *archive.CompressedModelState, solutionId string, randomSeed int64) *solution.Solution {
 	s.decompressionMutex.Lock()
 	defer s.decompressionMutex.Unlock()
 
 	new(archive.ModelCompressor).Decompress(compressedModel, s.decompressionModel)
 	decompressedModelSolution := new(solution.SolutionBuilder).
 		WithId(solutionId).
+		WithRandomSeed(randomSeed).
 		ForModel(s.decompressionModel).
 		Build()
 
 	return decompressedModelSolution
 }
 
-func (s *Saver) deriveASsIsSolutionForOptimised(solutionId string) *solution.Solution {
+func (s *Saver) deriveASsIsSolutionForOptimised(solutionId string, randomSeed int64) *solution.Solution {
 	s.decompressionMutex.Lock()
 	defer s.decompressionMutex.Unlock()
 
@@ -174,6 +393,7 @@ func (s *Saver) deriveASsIsSolutionForOptimised(solutionId string) *solution.Sol
 
 	decompressedModelSolution := new(solution.SolutionBuilder).
 		WithId(asIsSolutionId).
+		WithRandomSeed(randomSeed).
 		ForModel(s.decompressionModel).
 		Build()
 
@@ -192,6 +412,7 @@ func (s *Saver) encodeSolutionDetail(modelSolution solution.Solution) {
 	encoder := new(encoding.Builder).
 		ForOutputType(s.outputType).
 		WithOutputPath(s.outputPath).
+		WithBoundaries(s.boundaries).
 		WithLogHandler(s.LogHandler()).
 		Build()
 
@@ -200,30 +421,42 @@ func (s *Saver) encodeSolutionDetail(modelSolution solution.Solution) {
 	}
 }
 
-func (s *Saver) saveSolutionSet(solutionSet archive.NonDominanceModelArchive) {
+func (s *Saver) saveSolutionSet(solutionSet archive.NonDominanceModelArchive, randomSeed int64, runNote string, runDetails ...solution.VariableSummary) {
 	s.ensureOutputPathIsUsable()
-	s.encodeSolutionSet(solutionSet)
+	s.encodeSolutionSet(solutionSet, randomSeed, runNote, runDetails...)
 }
 
-func (s *Saver) encodeSolutionSet(solutionSet archive.NonDominanceModelArchive) {
+func (s *Saver) encodeSolutionSet(solutionSet archive.NonDominanceModelArchive, randomSeed int64, runNote string, runDetails ...solution.VariableSummary) {
+	s.encodeSolutionSetNoting(solutionSet, randomSeed, "Pareto front member %d of %d", runNote, runDetails...)
+}
+
+// saveCostCurve saves the steps of a marginal cost curve as a solution set, in the order the steps were taken.
+func (s *Saver) saveCostCurve(costCurve archive.NonDominanceModelArchive, randomSeed int64, runNote string) {
+	s.ensureOutputPathIsUsable()
+	s.encodeSolutionSetNoting(costCurve, randomSeed, "Marginal cost curve step %d of %d", runNote)
+}
+
+// encodeSolutionSetNoting encodes the solution set, noting each solution with noteFormat (formatted with the solution's
+// position in the set and the set's size), followed by noteSuffix, and reporting the details supplied of each.
+func (s *Saver) encodeSolutionSetNoting(solutionSet archive.NonDominanceModelArchive, randomSeed int64, noteFormat string, noteSuffix string, details ...solution.VariableSummary) {
 	summary := make(solutionset.Summary, 0)
 
-	asIsSolution := s.deriveASsIsSolution(solutionSet)
+	asIsSolution := s.deriveASsIsSolution(solutionSet, randomSeed)
 	s.encodeSolutionDetail(*asIsSolution)
 
 	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
 
 	numberOfSolutions := len(solutionSet.Archive())
 	for solutionIndex, compressedModel := range solutionSet.Archive() {
-		currentSolution := s.deriveModelSolution(solutionSet, solutionIndex, compressedModel)
+		currentSolution := s.deriveModelSolution(solutionSet, solutionIndex, compressedModel, randomSeed)
 		s.encodeSolutionDetail(*currentSolution)
-		formattedNote := fmt.Sprintf("Pareto front member %d of %d", solutionIndex+1, numberOfSolutions)
-		s.summarise(&summary, currentSolution, formattedNote, topSummaryEntry+uint64(1+solutionIndex))
+		formattedNote := fmt.Sprintf(noteFormat, solutionIndex+1, numberOfSolutions) + noteSuffix
+		s.summarise(&summary, currentSolution, formattedNote, topSummaryEntry+uint64(1+solutionIndex), details...)
 	}
 	s.encodeSummary(&summary)
 }
 
-func (s *Saver) deriveASsIsSolution(solutionSet archive.NonDominanceModelArchive) *solution.Solution {
+func (s *Saver) deriveASsIsSolution(solutionSet archive.NonDominanceModelArchive, randomSeed int64) *solution.Solution {
 	s.decompressionMutex.Lock()
 	defer s.decompressionMutex.Unlock()
 
@@ -232,6 +465,7 @@ func (s *Saver) deriveASsIsSolution(solutionSet archive.NonDominanceModelArchive
 
 	decompressedModelSolution := new(solution.SolutionBuilder).
 		WithId(asIsSolutionId).
+		WithRandomSeed(randomSeed).
 		ForModel(s.decompressionModel).
 		Build()
 
@@ -242,12 +476,12 @@ func (s *Saver) deriveAsIsSolutionId(solutionSet archive.NonDominanceModelArchiv
 	return solutionSet.Id() + " As-Is"
 }
 
-func (s *Saver) deriveModelSolution(solutionSet archive.NonDominanceModelArchive, solutionIndex int, compressedModel *archive.CompressedModelState) *solution.Solution {
+func (s *Saver) deriveModelSolution(solutionSet archive.NonDominanceModelArchive, solutionIndex int, compressedModel *archive.CompressedModelState, randomSeed int64) *solution.Solution {
 	solutionId := s.deriveSolutionId(solutionSet, solutionIndex+1)
-	return s.deriveSolutionFrom(compressedModel, solutionId)
+	return s.deriveSolutionFrom(compressedModel, solutionId, randomSeed)
 }
 
-func (s *Saver) deriveSolutionFrom(compressedModel *archive.CompressedModelState, solutionId string) *solution.Solution {
+func (s *Saver) deriveSolutionFrom(compressedModel *archive.CompressedModelState, solutionId string, randomSeed int64) *solution.Solution {
 	s.decompressionMutex.Lock()
 	defer s.decompressionMutex.Unlock()
 
@@ -255,6 +489,7 @@ func (s *Saver) deriveSolutionFrom(compressedModel *archive.CompressedModelState
 
 	decompressedModelSolution := new(solution.SolutionBuilder).
 		WithId(solutionId).
+		WithRandomSeed(randomSeed).
 		ForModel(s.decompressionModel).
 		Build()
 
@@ -267,11 +502,14 @@ func (s *Saver) deriveSolutionId(solutionSet archive.NonDominanceModelArchive, c
 	return solutionId
 }
 
-func (s *Saver) summarise(summary *solutionset.Summary, solution *solution.Solution, note string, sortOrder uint64) {
+func (s *Saver) summarise(summary *solutionset.Summary, solution *solution.Solution, note string, sortOrder uint64, details ...solution.VariableSummary) {
 	baseMap := *summary
 	summaryId := deriveSummaryIdFromSolution(solution)
-	baseMap[solution.Id] =
-		*solution.Summarise().WithId(summaryId).Noting(note).WithSortOrder(sortOrder)
+	solutionSummary := solution.Summarise().WithId(summaryId).Noting(note).WithSortOrder(sortOrder)
+	for _, detail := range details {
+		solutionSummary.WithDetail(detail.Name, detail.Value)
+	}
+	baseMap[solution.Id] = *solutionSummary
 }
 func deriveSummaryIdFromSolution(solution *solution.Solution) string {
 	if strings.Contains(solution.Id, "(1/1)") {
@@ -297,6 +535,7 @@ func (s *Saver) encodeSummary(summary *solutionset.Summary) {
 	encoder := new(encoding2.Builder).
 		ForOutputType(s.outputType).
 		WithOutputPath(s.outputPath).
+		WithBoundaries(s.boundaries).
 		WithLogHandler(s.LogHandler()).
 		Build()
 
@@ -304,3 +543,103 @@ func (s *Saver) encodeSummary(summary *solutionset.Summary) {
 		s.LogHandler().Error(encodingError)
 	}
 }
+
+func (s *Saver) rememberSolutionSet(solutionSet archive.NonDominanceModelArchive, randomSeed int64) {
+	s.solutionSetMutex.Lock()
+	defer s.solutionSetMutex.Unlock()
+
+	s.runSolutionSets = append(s.runSolutionSets, runSolutionSet{solutionSet: solutionSet, randomSeed: randomSeed})
+}
+
+// MergeCheckpointedSolutionSet includes the solution set archived in the checkpoint supplied in the merged solution
+// set, without saving it as a run's own.
+func (s *Saver) MergeCheckpointedSolutionSet(runCheckpoint *checkpoint.Checkpoint) {
+	if len(runCheckpoint.Explorer.Archive) == 0 {
+		return
+	}
+
+	s.decompressionMutex.Lock()
+	archivedStates, decodeError := runCheckpoint.Explorer.ArchivedModelStates(s.decompressionModel)
+	s.decompressionMutex.Unlock()
+
+	if decodeError != nil {
+		s.LogHandler().Error(errors.Wrap(decodeError, runCheckpoint.RunId+": checkpointed solution set not merged"))
+		return
+	}
+
+	solutionSet := archive.New()
+	solutionSet.SetId(runCheckpoint.RunId)
+	solutionSet.Restore(archivedStates)
+	s.rememberSolutionSet(*solutionSet, runCheckpoint.RandomSeed)
+}
+
+// SaveMergedSolutionSet saves a single solution set, being the non-dominated union of the solution sets of every run
+// saved or merged so far, with each solution's Run column holding the number of the run that found it. Nothing is
+// saved for fewer than two runs.
+func (s *Saver) SaveMergedSolutionSet() {
+	s.solutionSetMutex.Lock()
+	defer s.solutionSetMutex.Unlock()
+
+	if len(s.runSolutionSets) < 2 {
+		return
+	}
+
+	s.LogHandler().Info("Saving merged solution set of all runs")
+	sort.Slice(s.runSolutionSets, func(i, j int) bool {
+		return runNumberOf(s.runSolutionSets[i].solutionSet.Id()) < runNumberOf(s.runSolutionSets[j].solutionSet.Id())
+	})
+
+	s.ensureOutputPathIsUsable()
+	mergedSet, origins := s.mergeRunSolutionSets()
+	s.encodeMergedSolutionSet(mergedSet, origins)
+}
+
+func (s *Saver) mergeRunSolutionSets() (*archive.NonDominanceModelArchive, map[*archive.CompressedModelState]runSolutionSet) {
+	origins := make(map[*archive.CompressedModelState]runSolutionSet)
+	solutionSets := make([]*archive.NonDominanceModelArchive, len(s.runSolutionSets))
+
+	for index := range s.runSolutionSets {
+		runSet := s.runSolutionSets[index]
+		solutionSets[index] = &runSet.solutionSet
+		for _, compressedModel := range runSet.solutionSet.Archive() {
+			if _, alreadyFound := origins[compressedModel]; !alreadyFound {
+				origins[compressedModel] = runSet
+			}
+		}
+	}
+
+	mergedId := scenarioNameOf(s.runSolutionSets[0].solutionSet.Id()) + mergedIdSuffix
+	return archive.Merge(mergedId, solutionSets...), origins
+}
+
+func (s *Saver) encodeMergedSolutionSet(mergedSet *archive.NonDominanceModelArchive, origins map[*archive.CompressedModelState]runSolutionSet) {
+	summary := make(solutionset.Summary, 0)
+
+	asIsSolution := s.deriveASsIsSolutionForOptimised(mergedSet.Id(), 0)
+	s.encodeSolutionDetail(*asIsSolution)
+	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
+
+	numberOfSolutions := mergedSet.Len()
+	for solutionIndex, compressedModel := range mergedSet.Archive() {
+		origin := origins[compressedModel]
+		currentSolution := s.deriveModelSolution(*mergedSet, solutionIndex, compressedModel, origin.randomSeed)
+		s.encodeSolutionDetail(*currentSolution)
+		formattedNote := fmt.Sprintf("Merged Pareto front member %d of %d", solutionIndex+1, numberOfSolutions)
+		runNumber := solution.VariableSummary{Name: runDetail, Value: float64(runNumberOf(origin.solutionSet.Id()))}
+		s.summarise(&summary, currentSolution, formattedNote, topSummaryEntry+uint64(1+solutionIndex), runNumber)
+	}
+	s.encodeSummary(&summary)
+}
+
+func runNumberOf(runId string) int {
+	membership := membershipMatcher.FindStringSubmatch(runId)
+	if membership == nil {
+		return 0
+	}
+	runNumber, _ := strconv.Atoi(membership[1])
+	return runNumber
+}
+
+func scenarioNameOf(runId string) string {
+	return strings.TrimSpace(membershipMatcher.ReplaceAllString(runId, ""))
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"encoding/csv"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/checkpoint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/exact"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	modumbParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/modumb/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	baseParameters "github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+func TestSaver_SaveMergedSolutionSet_MergesObservedAndCheckpointedRuns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	observedRun := buildTestSolutionSet(testModel, "Test (1/2)", 0)
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(ModelArchive, *observedRun).
+			WithAttribute(RandomSeed, int64(11)),
+	)
+
+	checkpointedRun := checkpoint.New("Test (2/2)")
+	checkpointedRun.Finished = true
+	checkpointedRun.RandomSeed = 22
+	for _, state := range buildTestSolutionSet(testModel, "Test (2/2)", 1).Archive() {
+		checkpointedRun.Explorer.Archive = append(checkpointedRun.Explorer.Archive, checkpoint.NewModelState(state))
+	}
+
+	// when
+	saverUnderTest.MergeCheckpointedSolutionSet(checkpointedRun)
+	saverUnderTest.SaveMergedSolutionSet()
+
+	// then
+	rows := readCsvRows(t, path.Join(outputPath, "TestMerged-Summary.csv"))
+	header := rows[0]
+	g.Expect(header[len(header)-2:]).To(Equal([]string{"RandomSeed", "Run"}))
+
+	g.Expect(rows).To(HaveLen(4))
+	g.Expect(rows[1][len(header)-1]).To(Equal(""))
+	g.Expect(rows[2][len(header)-2:]).To(Equal([]string{"11", "1"}))
+	g.Expect(rows[3][len(header)-2:]).To(Equal([]string{"22", "2"}))
+}
+
+func TestSaver_SaveMergedSolutionSet_SingleRun_NothingSaved(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	checkpointedRun := checkpoint.New("Test (1/2)")
+	for _, state := range buildTestSolutionSet(testModel, "Test (1/2)", 0).Archive() {
+		checkpointedRun.Explorer.Archive = append(checkpointedRun.Explorer.Archive, checkpoint.NewModelState(state))
+	}
+	saverUnderTest.MergeCheckpointedSolutionSet(checkpointedRun)
+
+	// when
+	saverUnderTest.SaveMergedSolutionSet()
+
+	// then
+	_, statError := os.Stat(path.Join(outputPath, "TestMerged-Summary.csv"))
+	g.Expect(os.IsNotExist(statError)).To(BeTrue())
+}
+
+func TestSaver_ObserveEvent_CalibratedRun_CalibrationInOwnColumns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	// when
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(ModelArchive, *buildTestSolutionSet(testModel, "Test", 0)).
+			WithAttribute(explorer.CalibratedStartingTemperature, 12.5).
+			WithAttribute(explorer.CalibratedCoolingFactor, 0.995),
+	)
+
+	// then
+	rows := readCsvRows(t, path.Join(outputPath, "Test-Summary.csv"))
+	header := rows[0]
+	g.Expect(header[len(header)-2:]).To(Equal([]string{explorer.CalibratedStartingTemperature, explorer.CalibratedCoolingFactor}))
+
+	g.Expect(rows).To(HaveLen(3))
+	g.Expect(rows[1][len(header)-2:]).To(Equal([]string{"", ""}))
+	g.Expect(rows[2][len(header)-2:]).To(Equal([]string{"12.5", "0.995"}))
+	g.Expect(rows[2][len(header)-4]).To(Equal("Pareto front member 1 of 1"))
+}
+
+func TestSaver_ObserveEvent_MeasuredRun_QualityInOwnColumns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	// when
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(ModelArchive, *buildTestSolutionSet(testModel, "Test", 0)).
+			JoiningAttributes(explorer.QualityAttributes(&archive.QualityMetrics{Hypervolume: 0.75, Spread: 0.5})),
+	)
+
+	// then
+	rows := readCsvRows(t, path.Join(outputPath, "Test-Summary.csv"))
+	header := rows[0]
+	g.Expect(header[len(header)-4:]).To(Equal([]string{
+		explorer.Hypervolume, explorer.Spread, explorer.Spacing, explorer.GenerationalDistance,
+	}))
+	g.Expect(rows[2][len(header)-4:]).To(Equal([]string{"0.75", "0.5", "0", "0"}))
+	g.Expect(rows[2][len(header)-6]).To(Equal("Pareto front member 1 of 1"))
+}
+
+func TestSaver_ObserveEvent_CostCurve_RandomSeedSaved(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	// when
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(CostCurve, *buildTestSolutionSet(testModel, "Test", 0)).
+			WithAttribute(RandomSeed, int64(33)),
+	)
+
+	// then
+	rows := readCsvRows(t, path.Join(outputPath, "Test-Summary.csv"))
+	header := rows[0]
+	g.Expect(header[len(header)-1]).To(Equal("RandomSeed"))
+	g.Expect(rows[2][len(header)-1]).To(Equal("33"))
+	g.Expect(rows[2][len(header)-2]).To(Equal("Marginal cost curve step 1 of 1"))
+}
+
+func TestSaver_ObserveEvent_ExactComparison_ExactFrontAndGapSaved(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := modumb.NewModel().
+		WithId("Test").
+		WithParameters(baseParameters.Map{modumbParameters.NumberOfPlanningUnits: int64(2)})
+	testModel.Initialise(model.AsIs)
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithExactComparison(true).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	// when
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(ModelArchive, *buildTestSolutionSet(testModel, "Test", 0)),
+	)
+
+	// then
+	exactRows := readCsvRows(t, path.Join(outputPath, "TestExact-Summary.csv"))
+	g.Expect(len(exactRows)).To(BeNumerically(">", 2))
+
+	rows := readCsvRows(t, path.Join(outputPath, "Test-Summary.csv"))
+	header := rows[0]
+	g.Expect(header[len(header)-4:]).To(Equal([]string{
+		exact.ExactSolutionsFound, exact.ExactGenerationalDistance,
+		exact.ExactInvertedGenerationalDistance, exact.ExactHypervolumeRatio,
+	}))
+	g.Expect(rows[2][len(header)-4]).To(Equal("0"))
+}
+
+func TestSaver_ObserveEvent_ExactComparisonOfUnsolvableModel_NoGapSaved(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	testModel := buildTestModel()
+
+	saverUnderTest := NewSaver().
+		WithOutputType(encoding.CsvOutput).
+		WithOutputPath(outputPath).
+		WithExactComparison(true).
+		WithLogHandler(loggers.NewNullLogger())
+	saverUnderTest.SetDecompressionModel(testModel)
+
+	// when
+	saverUnderTest.ObserveEvent(
+		*observer.NewEvent(observer.FinishedAnnealing).
+			WithAttribute(ModelArchive, *buildTestSolutionSet(testModel, "Test", 0)),
+	)
+
+	// then
+	rows := readCsvRows(t, path.Join(outputPath, "Test-Summary.csv"))
+	g.Expect(rows[0]).To(Not(ContainElement(exact.ExactSolutionsFound)))
+
+	_, statError := os.Stat(path.Join(outputPath, "TestExact-Summary.csv"))
+	g.Expect(os.IsNotExist(statError)).To(BeTrue())
+}
+
+func buildTestModel() *modumb.Model {
+	testModel := modumb.NewModel().WithId("Test")
+	testModel.Initialise(model.AsIs)
+	return testModel
+}
+
+func buildTestSolutionSet(testModel *modumb.Model, id string, activeAction int) *archive.NonDominanceModelArchive {
+	solutionModel := testModel.DeepClone()
+	solutionModel.Initialise(model.AsIs)
+	solutionModel.SetManagementAction(activeAction, true)
+
+	solutionSet := archive.New()
+	solutionSet.SetId(id)
+	solutionSet.AttemptToArchive(solutionModel)
+	return solutionSet
+}
+
+func readCsvRows(t *testing.T, filePath string) [][]string {
+	file, openError := os.Open(filePath)
+	if openError != nil {
+		t.Fatal(openError)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	rows, readError := reader.ReadAll()
+	if readError != nil {
+		t.Fatal(readError)
+	}
+	return rows
+}
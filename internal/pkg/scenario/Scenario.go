@@ -1,6 +1,8 @@
 package scenario
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
@@ -19,6 +21,7 @@ func NewBaseScenario() *BaseScenario {
 }
 
 var _ Scenario = new(BaseScenario)
+var _ Cancellable = new(BaseScenario)
 
 type BaseScenario struct {
 	annealer annealing.Annealer
@@ -51,6 +54,11 @@ func (s *BaseScenario) LogHandler() logging.Logger {
 	return s.runner.LogHandler()
 }
 
+func (s *BaseScenario) SetContext(ctx context.Context) {
+	assert.That(s.runner != nil)
+	setContextOf(s.runner, ctx)
+}
+
 func (s *BaseScenario) Run() error {
 	assert.That(s.annealer != nil)
 	return s.runner.Run()
@@ -0,0 +1,137 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/uncertainty"
+	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+const uncertaintyReportSuffix = "-Uncertainty"
+
+func NewUncertaintyScenario() *UncertaintyScenario {
+	return new(UncertaintyScenario)
+}
+
+var _ Scenario = new(UncertaintyScenario)
+
+// UncertaintyScenario re-evaluates the solutions of an existing solution set over sampled model inputs, saving a report
+// of how their decision variables spread, instead of annealing for new solutions.
+type UncertaintyScenario struct {
+	name            string
+	analysis        *uncertainty.Analysis
+	solutionSetPath string
+	solutionIds     []string
+	outputPath      string
+	jsonOutput      bool
+	logHandler      logging.Logger
+
+	model model.Model
+}
+
+func (s *UncertaintyScenario) WithName(name string) *UncertaintyScenario {
+	s.name = name
+	return s
+}
+
+func (s *UncertaintyScenario) WithAnalysis(analysis *uncertainty.Analysis) *UncertaintyScenario {
+	s.analysis = analysis
+	return s
+}
+
+// WithSolutionSet has the scenario re-evaluate the solutions with the ids supplied (or all solutions, if none are
+// supplied) of the CSV solution set summary file at the path supplied.
+func (s *UncertaintyScenario) WithSolutionSet(solutionSetPath string, solutionIds ...string) *UncertaintyScenario {
+	s.solutionSetPath = solutionSetPath
+	s.solutionIds = solutionIds
+	return s
+}
+
+func (s *UncertaintyScenario) WithOutputPath(outputPath string) *UncertaintyScenario {
+	s.outputPath = outputPath
+	return s
+}
+
+// WithJsonOutput has the scenario save its report as JSON, rather than CSV.
+func (s *UncertaintyScenario) WithJsonOutput(jsonOutput bool) *UncertaintyScenario {
+	s.jsonOutput = jsonOutput
+	return s
+}
+
+func (s *UncertaintyScenario) WithLogHandler(logHandler logging.Logger) *UncertaintyScenario {
+	s.logHandler = logHandler
+	return s
+}
+
+func (s *UncertaintyScenario) LogHandler() logging.Logger {
+	return s.logHandler
+}
+
+// SetAnnealer takes the model of the annealer supplied as the model to re-evaluate solutions with. The annealer itself
+// is never run.
+func (s *UncertaintyScenario) SetAnnealer(annealer annealing.Annealer) {
+	s.model = annealer.Model()
+}
+
+func (s *UncertaintyScenario) Run() error {
+	assert.That(s.model != nil && s.analysis != nil)
+
+	s.model.Initialise(model.AsIs)
+	sampleableModel, isSampleable := s.model.(model.Sampleable)
+	if !isSampleable {
+		return errors.New("scenario [" + s.name + "] model does not support uncertainty analysis")
+	}
+
+	solutions, readError := uncertainty.ReadSolutionSet(s.solutionSetPath, s.solutionIds...)
+	if readError != nil {
+		return errors.Wrap(readError, "uncertainty analysis of scenario ["+s.name+"]")
+	}
+
+	s.logHandler.Info(fmt.Sprintf("Scenario [%s]: Evaluating [%d] solutions of [%s] over sampled inputs",
+		s.name, len(solutions), s.solutionSetPath))
+
+	report, evaluationError := s.analysis.WithModel(sampleableModel).Evaluate(solutions...)
+	if evaluationError != nil {
+		return errors.Wrap(evaluationError, "uncertainty analysis of scenario ["+s.name+"]")
+	}
+
+	return s.save(report)
+}
+
+func (s *UncertaintyScenario) save(report *uncertainty.Report) error {
+	var marshaled []byte
+	var marshalError error
+	fileExtension := ".csv"
+
+	if s.jsonOutput {
+		marshaled, marshalError = json.MarshalIndent(report, "", "  ")
+		fileExtension = ".json"
+	} else {
+		marshaled, marshalError = new(uncertainty.CsvMarshaler).Marshal(report)
+	}
+	if marshalError != nil {
+		return errors.Wrap(marshalError, "marshaling uncertainty report")
+	}
+
+	if mkDirError := os.MkdirAll(s.outputPath, os.ModePerm); mkDirError != nil {
+		return errors.Wrap(mkDirError, "creating output path for uncertainty report")
+	}
+
+	reportPath := path.Join(s.outputPath, s.name+uncertaintyReportSuffix+fileExtension)
+	s.logHandler.Info("Scenario [" + s.name + "]: Saving uncertainty report to [" + reportPath + "]")
+
+	if writeError := ioutil.WriteFile(reportPath, marshaled, 0666); writeError != nil {
+		return errors.Wrap(writeError, "writing uncertainty report")
+	}
+	return nil
+}
@@ -32,6 +32,9 @@ func (s *RestServer) WithApiMux(apiMux rest.Mux) *RestServer {
 	s.adminMux = new(admin.Mux).Initialise()
 	s.apiMux = apiMux
 	s.apiMux.AddHandler("^/$", s.adminMux.StatusHandler)
+	if engineApiMux, isEngineApiMux := s.apiMux.(*engineApi.Mux); isEngineApiMux {
+		s.adminMux.AddHandler("/sessions", engineApiMux.SessionsHandler)
+	}
 	return s
 }
 
@@ -3,6 +3,8 @@
 package job
 
 import (
+	"sync"
+
 	"github.com/LindsayBradford/crem/internal/pkg/server/job/uuid"
 	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
 )
@@ -19,6 +21,7 @@ type AttributeKey string
 const (
 	Unspecified Status = "UNSPECIFIED"
 	Created     Status = "CREATED"
+	Running     Status = "RUNNING"
 	Completed   Status = "COMPLETED"
 	Errored     Status = "ERRORED"
 	Invalid     Status = "INVALID"
@@ -28,6 +31,8 @@ type Job struct {
 	Id               Id
 	Attributes       map[AttributeKey]interface{}
 	HiddenAttributes map[AttributeKey]interface{} `json:"-"`
+
+	mutex sync.RWMutex
 }
 
 func (j *Job) Initialise() *Job {
@@ -52,17 +57,61 @@ func (j *Job) recordCreationAttributes() {
 }
 
 func (j *Job) SetStatus(status Status) {
-	j.Attributes[statusKey] = status
+	j.SetAttribute(statusKey, status)
 }
 
 func (j *Job) Status() Status {
-	status, ok := j.Attributes[statusKey].(Status)
+	status, ok := j.Attribute(statusKey).(Status)
 	if ok {
 		return status
 	}
 	return Unspecified
 }
 
+// SetAttribute, and the other attribute accessors, are safe to call while the job is being processed by a Queue.
+func (j *Job) SetAttribute(key AttributeKey, value interface{}) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.Attributes[key] = value
+}
+
+func (j *Job) Attribute(key AttributeKey) interface{} {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.Attributes[key]
+}
+
+func (j *Job) SetHiddenAttribute(key AttributeKey, value interface{}) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.HiddenAttributes[key] = value
+}
+
+func (j *Job) HiddenAttribute(key AttributeKey) interface{} {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.HiddenAttributes[key]
+}
+
+// Snapshot returns a copy of the job as it currently stands, safe to encode while the job continues to be processed.
+func (j *Job) Snapshot() *Job {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	snapshot := &Job{
+		Id:               j.Id,
+		Attributes:       make(map[AttributeKey]interface{}, len(j.Attributes)),
+		HiddenAttributes: make(map[AttributeKey]interface{}, len(j.HiddenAttributes)),
+	}
+	for key, value := range j.Attributes {
+		snapshot.Attributes[key] = value
+	}
+	for key, value := range j.HiddenAttributes {
+		snapshot.HiddenAttributes[key] = value
+	}
+	return snapshot
+}
+
 func (j *Job) IsProcessed() bool {
 	if j.Status() == Completed || j.Status() == Invalid {
 		return true
@@ -79,5 +128,5 @@ func (j *Job) RecordCompletionTime() {
 }
 
 func (j *Job) RecordTimeForAttribute(key AttributeKey) {
-	j.Attributes[key] = rest.FormattedTimestamp()
+	j.SetAttribute(key, rest.FormattedTimestamp())
 }
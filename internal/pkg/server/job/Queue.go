@@ -5,13 +5,31 @@ package job
 const defaultQueueLength = 1
 const unspecifiedQueueLength = 0
 
+const defaultWorkerNumber = 1
+const unspecifiedWorkerNumber = 0
+
 type Queue struct {
 	Jobs        chan *Job      `json:"-"`
 	JobFunction func(job *Job) `json:"-"`
+
+	workerNumber uint64
 }
 
 func (jq *Queue) Initialise() *Queue {
 	jq.Jobs = make(chan *Job, defaultQueueLength)
+	jq.workerNumber = defaultWorkerNumber
+	return jq
+}
+
+func (jq *Queue) WithWorkerNumber(workerNumber uint64) *Queue {
+	if workerNumber > unspecifiedWorkerNumber {
+		jq.workerNumber = workerNumber
+	}
+	return jq
+}
+
+func (jq *Queue) WithJobFunction(jobFunction func(job *Job)) *Queue {
+	jq.JobFunction = jobFunction
 	return jq
 }
 
@@ -36,9 +54,16 @@ func (jq *Queue) Enqueue(newJob *Job) EnqueuedStatus {
 	}
 }
 
+// Start launches the queue's pool of workers, each applying the queue's JobFunction to the next enqueued job, one
+// job at a time. Start returns immediately, leaving the workers running.
 func (jq *Queue) Start() {
-	for {
-		job := <-jq.Jobs
+	for worker := uint64(0); worker < jq.workerNumber; worker++ {
+		go jq.work()
+	}
+}
+
+func (jq *Queue) work() {
+	for job := range jq.Jobs {
 		jq.JobFunction(job)
 	}
 }
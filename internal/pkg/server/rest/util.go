@@ -5,10 +5,12 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const ContentTypeHeaderKey = "Content-Type"
+const AcceptHeaderKey = "Accept"
 const CacheControlHeaderKey = "Cache-Control"
 const UrlPathSeparator = "/"
 
@@ -25,6 +27,19 @@ func FormattedTimestamp() string {
 	return fmt.Sprintf("%v", time.Now().Format(time.RFC3339Nano))
 }
 
+// RequestAccepts reports whether the request's Accept header explicitly names the mime type supplied.
+func RequestAccepts(r *http.Request, mimeType string) bool {
+	for _, acceptHeader := range r.Header.Values(AcceptHeaderKey) {
+		for _, acceptedType := range strings.Split(acceptHeader, ",") {
+			mediaRange := strings.TrimSpace(strings.Split(acceptedType, ";")[0])
+			if strings.EqualFold(mediaRange, mimeType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Below useful for quick debugging.
 func SendTextOnResponseBody(text string, w http.ResponseWriter) {
 	fmt.Fprintf(w, text)
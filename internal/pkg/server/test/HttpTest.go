@@ -18,6 +18,7 @@ type HttpTestRequestContext struct {
 	TargetUrl   string
 	RequestBody string
 	ContentType string
+	Accept      string
 	Handler     http.HandlerFunc
 }
 
@@ -53,6 +54,10 @@ func (context *HttpTestRequestContext) newRequest() *http.Request {
 	if context.ContentType != "" {
 		request.Header.Add(rest.ContentTypeHeaderKey, context.ContentType)
 	}
+
+	if context.Accept != "" {
+		request.Header.Add(rest.AcceptHeaderKey, context.Accept)
+	}
 	return request
 }
 
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dominance
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Direction identifies whether smaller or larger values of a vector entry are preferred when deciding dominance.
+type Direction int
+
+const (
+	Minimising Direction = iota
+	Maximising
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Maximising:
+		return "Maximising"
+	default:
+		return "Minimising"
+	}
+}
+
+// ParseDirection returns the Direction named by value, or an error if value names no Direction.
+func ParseDirection(value string) (Direction, error) {
+	directions := []Direction{Minimising, Maximising}
+
+	for _, direction := range directions {
+		if value == direction.String() {
+			return direction, nil
+		}
+	}
+
+	errorMsg := fmt.Sprintf("Value [%s] is not a valid optimisation direction, should be one of %v", value, directions)
+	return Minimising, errors.New(errorMsg)
+}
+
+// Directions holds the Direction of each entry of a vector. Entries with no Direction supplied are minimised.
+type Directions []Direction
+
+func (d Directions) Of(index int) Direction {
+	if index < len(d) {
+		return d[index]
+	}
+	return Minimising
+}
+
+func (d Directions) prefers(index int, value float64, otherValue float64) bool {
+	if d.Of(index) == Maximising {
+		return value > otherValue
+	}
+	return value < otherValue
+}
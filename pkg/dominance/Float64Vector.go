@@ -41,16 +41,23 @@ func vectorLengthsMatch(firstVector *Float64Vector, secondVector *Float64Vector)
 	return len(*firstVector) == len(*secondVector)
 }
 
+// Dominates reports whether every value of the vector is less than its counterpart in otherCandidate.
 func (v *Float64Vector) Dominates(otherCandidate Candidate) bool {
-	return v.allLessThanValuesIn(otherCandidate)
+	return v.DominatesTowards(otherCandidate, nil)
 }
 
-func (v *Float64Vector) allLessThanValuesIn(otherCandidate Candidate) bool {
+// DominatesTowards reports whether every value of the vector is preferred, in the direction supplied for it, to
+// its counterpart in otherCandidate.
+func (v *Float64Vector) DominatesTowards(otherCandidate Candidate, directions Directions) bool {
+	return v.allPreferredToValuesIn(otherCandidate, directions)
+}
+
+func (v *Float64Vector) allPreferredToValuesIn(otherCandidate Candidate, directions Directions) bool {
 	otherCandidateAsVector := *asFloat64Vector(otherCandidate)
 	thisCandidateAsVector := *v
 
 	for index := range thisCandidateAsVector {
-		if thisCandidateAsVector[index] >= otherCandidateAsVector[index] {
+		if !directions.prefers(index, thisCandidateAsVector[index], otherCandidateAsVector[index]) {
 			return false
 		}
 	}
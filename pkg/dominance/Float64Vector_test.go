@@ -245,3 +245,30 @@ func TestFloat64Vector_Dominates_ValidlyIdentifiesIsDominatedBy(t *testing.T) {
 	t.Logf("Expecting vectorUnderTest %v to %s otherCandidateVector: %v", vectorUnderTest, beDominatedBy, otherCandidateVector)
 	g.Expect(vectorUnderTest.IsDominatedBy(otherCandidateVector)).To(BeTrue())
 }
+
+func TestFloat64Vector_DominatesTowards_RespectsDirections(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	directions := Directions{Minimising, Maximising}
+
+	vectorUnderTest := &Float64Vector{1, 10}
+	otherCandidateVector := &Float64Vector{2, 5}
+
+	// then
+	t.Logf("Expecting vectorUnderTest %v to %s otherCandidateVector: %v", vectorUnderTest, dominate, otherCandidateVector)
+	g.Expect(vectorUnderTest.DominatesTowards(otherCandidateVector, directions)).To(BeTrue())
+	g.Expect(otherCandidateVector.DominatesTowards(vectorUnderTest, directions)).To(BeFalse())
+	g.Expect(vectorUnderTest.Dominates(otherCandidateVector)).To(BeFalse())
+}
+
+func TestParseDirection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	maximising, maximisingError := ParseDirection("Maximising")
+	g.Expect(maximisingError).To(BeNil())
+	g.Expect(maximising).To(Equal(Maximising))
+
+	_, invalidError := ParseDirection("Sideways")
+	g.Expect(invalidError).To(Not(BeNil()))
+}
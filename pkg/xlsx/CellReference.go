@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const alphabetSize = 26
+
+// cellReference returns the A1-style reference of the cell at the 1-based row and column supplied.
+func cellReference(row uint, column uint) string {
+	return columnName(column) + strconv.FormatUint(uint64(row), 10)
+}
+
+func columnName(column uint) string {
+	name := ""
+	for remaining := column; remaining > 0; remaining = (remaining - 1) / alphabetSize {
+		name = string(rune('A'+(remaining-1)%alphabetSize)) + name
+	}
+	return name
+}
+
+// parseCellReference returns the 1-based row and column of the A1-style cell reference supplied.
+func parseCellReference(reference string) (row uint, column uint, err error) {
+	index := 0
+	for ; index < len(reference) && reference[index] >= 'A' && reference[index] <= 'Z'; index++ {
+		column = column*alphabetSize + uint(reference[index]-'A'+1)
+	}
+
+	parsedRow, parseError := strconv.ParseUint(reference[index:], 10, 32)
+	if index == 0 || parseError != nil || parsedRow == 0 {
+		return 0, 0, errors.New("invalid cell reference [" + reference + "]")
+	}
+	return uint(parsedRow), column, nil
+}
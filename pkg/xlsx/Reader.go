@@ -0,0 +1,247 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	workbookPath              = "xl/workbook.xml"
+	workbookRelationshipsPath = "xl/_rels/workbook.xml.rels"
+	sharedStringsPath         = "xl/sharedStrings.xml"
+
+	sharedStringCell = "s"
+	inlineStringCell = "inlineStr"
+	formulaTextCell  = "str"
+	booleanCell      = "b"
+	errorCell        = "e"
+)
+
+type xmlWorkbook struct {
+	Sheets []struct {
+		Name           string `xml:"name,attr"`
+		RelationshipId string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xmlRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xmlText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xmlText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var builder strings.Builder
+	for _, run := range t.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+type xmlSharedStrings struct {
+	Items []xmlText `xml:"si"`
+}
+
+type xmlWorksheet struct {
+	Rows []struct {
+		Reference string    `xml:"r,attr"`
+		Cells     []xmlCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xmlCell struct {
+	Reference    string   `xml:"r,attr"`
+	Type         string   `xml:"t,attr,omitempty"`
+	Value        *string  `xml:"v,omitempty"`
+	InlineString *xmlText `xml:"is,omitempty"`
+}
+
+type reader struct {
+	files         map[string]*zip.File
+	sharedStrings []string
+}
+
+// Read reads a workbook from the xlsx content supplied.
+func Read(content io.ReaderAt, size int64) (*Workbook, error) {
+	archive, zipError := zip.NewReader(content, size)
+	if zipError != nil {
+		return nil, errors.Wrap(zipError, "xlsx content is not a zip archive")
+	}
+
+	workbookReader := &reader{files: make(map[string]*zip.File, len(archive.File))}
+	for _, file := range archive.File {
+		workbookReader.files[file.Name] = file
+	}
+
+	return workbookReader.read()
+}
+
+func (r *reader) read() (*Workbook, error) {
+	var workbookXml xmlWorkbook
+	if readError := r.unmarshal(workbookPath, &workbookXml); readError != nil {
+		return nil, readError
+	}
+
+	var relationships xmlRelationships
+	if readError := r.unmarshal(workbookRelationshipsPath, &relationships); readError != nil {
+		return nil, readError
+	}
+
+	if readError := r.readSharedStrings(); readError != nil {
+		return nil, readError
+	}
+
+	targets := make(map[string]string, len(relationships.Relationships))
+	for _, relationship := range relationships.Relationships {
+		targets[relationship.Id] = worksheetPathOf(relationship.Target)
+	}
+
+	workbook := NewWorkbook()
+	for _, sheet := range workbookXml.Sheets {
+		worksheet := workbook.AddWorksheet(sheet.Name)
+		if readError := r.readWorksheet(targets[sheet.RelationshipId], worksheet); readError != nil {
+			return nil, errors.Wrap(readError, "worksheet ["+sheet.Name+"]")
+		}
+	}
+	return workbook, nil
+}
+
+// worksheetPathOf returns the archive path of a worksheet relationship's target, given relative to the workbook, or
+// absolute from the archive root.
+func worksheetPathOf(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(workbookPath), target)
+}
+
+func (r *reader) unmarshal(filePath string, content interface{}) error {
+	file, isPresent := r.files[filePath]
+	if !isPresent {
+		return errors.New("xlsx content missing [" + filePath + "]")
+	}
+
+	fileReader, openError := file.Open()
+	if openError != nil {
+		return errors.Wrap(openError, "opening ["+filePath+"]")
+	}
+	defer fileReader.Close()
+
+	if decodeError := xml.NewDecoder(fileReader).Decode(content); decodeError != nil {
+		return errors.Wrap(decodeError, "decoding ["+filePath+"]")
+	}
+	return nil
+}
+
+func (r *reader) readSharedStrings() error {
+	if _, isPresent := r.files[sharedStringsPath]; !isPresent {
+		return nil
+	}
+
+	var sharedStrings xmlSharedStrings
+	if readError := r.unmarshal(sharedStringsPath, &sharedStrings); readError != nil {
+		return readError
+	}
+
+	r.sharedStrings = make([]string, len(sharedStrings.Items))
+	for index, item := range sharedStrings.Items {
+		r.sharedStrings[index] = item.String()
+	}
+	return nil
+}
+
+func (r *reader) readWorksheet(worksheetPath string, worksheet *Worksheet) error {
+	var worksheetXml xmlWorksheet
+	if readError := r.unmarshal(worksheetPath, &worksheetXml); readError != nil {
+		return readError
+	}
+
+	rowIndex := uint(0)
+	for _, row := range worksheetXml.Rows {
+		var rowError error
+		if rowIndex, rowError = positionFollowing(rowIndex, row.Reference); rowError != nil {
+			return rowError
+		}
+
+		columnIndex := uint(0)
+		for _, cell := range row.Cells {
+			if cell.Reference == "" {
+				columnIndex++
+			} else {
+				cellRow, cellColumn, referenceError := parseCellReference(cell.Reference)
+				if referenceError != nil {
+					return referenceError
+				}
+				rowIndex, columnIndex = cellRow, cellColumn
+			}
+
+			value, valueError := r.valueOf(cell)
+			if valueError != nil {
+				return errors.Wrap(valueError, "cell ["+cellReference(rowIndex, columnIndex)+"]")
+			}
+			worksheet.SetCell(rowIndex, columnIndex, value)
+		}
+	}
+	return nil
+}
+
+// positionFollowing returns the 1-based row index given by the (optional) reference supplied, or, where there is no
+// reference, that of the row following the previous row index supplied.
+func positionFollowing(previousIndex uint, reference string) (uint, error) {
+	if reference == "" {
+		return previousIndex + 1, nil
+	}
+	index, parseError := strconv.ParseUint(reference, 10, 32)
+	if parseError != nil || index == 0 {
+		return 0, errors.New("invalid row reference [" + reference + "]")
+	}
+	return uint(index), nil
+}
+
+func (r *reader) valueOf(cell xmlCell) (interface{}, error) {
+	if cell.Type == inlineStringCell && cell.InlineString != nil {
+		return cell.InlineString.String(), nil
+	}
+	if cell.Value == nil {
+		return nil, nil
+	}
+
+	rawValue := *cell.Value
+	switch cell.Type {
+	case sharedStringCell:
+		index, parseError := strconv.Atoi(rawValue)
+		if parseError != nil || index < 0 || index >= len(r.sharedStrings) {
+			return nil, errors.New("invalid shared string index [" + rawValue + "]")
+		}
+		return r.sharedStrings[index], nil
+	case formulaTextCell, errorCell:
+		return rawValue, nil
+	case booleanCell:
+		return rawValue == "1", nil
+	default:
+		number, parseError := strconv.ParseFloat(rawValue, 64)
+		if parseError != nil {
+			return nil, errors.Wrap(parseError, "invalid number")
+		}
+		return number, nil
+	}
+}
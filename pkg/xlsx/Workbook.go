@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package xlsx reads and writes the cell values of Office Open XML (.xlsx) workbooks directly, needing neither Excel
+// nor Windows. Only values are kept; formulas are read as their last calculated values, and formatting is ignored.
+package xlsx
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+type Workbook struct {
+	worksheets []*Worksheet
+}
+
+func NewWorkbook() *Workbook {
+	return new(Workbook)
+}
+
+// Open reads the workbook at the file path supplied.
+func Open(filePath string) (*Workbook, error) {
+	file, openError := os.Open(filePath)
+	if openError != nil {
+		return nil, errors.Wrap(openError, "opening xlsx workbook")
+	}
+	defer file.Close()
+
+	fileInfo, statError := file.Stat()
+	if statError != nil {
+		return nil, errors.Wrap(statError, "opening xlsx workbook")
+	}
+
+	workbook, readError := Read(file, fileInfo.Size())
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading xlsx workbook ["+filePath+"]")
+	}
+	return workbook, nil
+}
+
+// Worksheets returns the workbook's worksheets, in workbook order.
+func (wb *Workbook) Worksheets() []*Worksheet {
+	return wb.worksheets
+}
+
+// AddWorksheet appends a new, empty worksheet of the name supplied to the workbook.
+func (wb *Workbook) AddWorksheet(name string) *Worksheet {
+	worksheet := newWorksheet(name)
+	wb.worksheets = append(wb.worksheets, worksheet)
+	return worksheet
+}
+
+// SaveAs writes the workbook to the file path supplied, replacing any file already there.
+func (wb *Workbook) SaveAs(filePath string) error {
+	file, createError := os.Create(filePath)
+	if createError != nil {
+		return errors.Wrap(createError, "creating xlsx workbook")
+	}
+
+	if writeError := wb.Write(file); writeError != nil {
+		file.Close()
+		return errors.Wrap(writeError, "writing xlsx workbook ["+filePath+"]")
+	}
+	return file.Close()
+}
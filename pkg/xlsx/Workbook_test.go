@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// testWorkbookPath is the Excel-saved workbook shared with the Excel and xlsx data set tests.
+const testWorkbookPath = "../../internal/pkg/dataset/excel/testdata/testExcelDataSetLoad.xlsx"
+
+func TestOpen_ExcelSavedWorkbook_ValuesRead(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	workbook, openError := Open(testWorkbookPath)
+
+	// then
+	g.Expect(openError).To(BeNil())
+	g.Expect(workbook.Worksheets()).To(HaveLen(2))
+
+	csvSheet := workbook.Worksheets()[0]
+	g.Expect(csvSheet.Name()).To(Equal("testCsvTable"))
+	g.Expect(csvSheet.ColumnCount()).To(BeNumerically("==", 4))
+	g.Expect(csvSheet.RowCount()).To(BeNumerically("==", 6))
+	g.Expect(csvSheet.Cell(1, 1)).To(Equal("IntegerColumn"))
+	g.Expect(csvSheet.Cell(2, 1)).To(Equal(float64(1)))
+	g.Expect(csvSheet.Cell(2, 2)).To(Equal("enrty1"))
+	g.Expect(csvSheet.Cell(2, 3)).To(BeNumerically("~", 1.001))
+	g.Expect(csvSheet.Cell(2, 4)).To(Equal(true))
+	g.Expect(csvSheet.Cell(7, 1)).To(BeNil())
+
+	ascSheet := workbook.Worksheets()[1]
+	g.Expect(ascSheet.Name()).To(Equal("testAscTable"))
+	g.Expect(ascSheet.Cell(6, 1)).To(Equal("NODATA_value"))
+}
+
+func TestOpen_MissingFile_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, openError := Open("testdata/missing.xlsx")
+
+	// then
+	g.Expect(openError).To(Not(BeNil()))
+}
+
+func TestWorkbook_Write_ReadsBackSameValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	workbook := NewWorkbook()
+	first := workbook.AddWorksheet("First")
+	first.SetCell(1, 1, "Name")
+	first.SetCell(1, 2, "Value")
+	first.SetCell(2, 1, "Cost & <Benefit>")
+	first.SetCell(2, 2, 1234.5678)
+	first.SetCell(3, 1, uint64(42))
+	first.SetCell(3, 28, true)
+
+	second := workbook.AddWorksheet("Second")
+	second.SetCell(1, 1, int64(-7))
+
+	// when
+	var content bytes.Buffer
+	writeError := workbook.Write(&content)
+	g.Expect(writeError).To(BeNil())
+
+	readWorkbook, readError := Read(bytes.NewReader(content.Bytes()), int64(content.Len()))
+
+	// then
+	g.Expect(readError).To(BeNil())
+	g.Expect(readWorkbook.Worksheets()).To(HaveLen(2))
+
+	readFirst := readWorkbook.Worksheets()[0]
+	g.Expect(readFirst.Name()).To(Equal("First"))
+	g.Expect(readFirst.Cell(2, 1)).To(Equal("Cost & <Benefit>"))
+	g.Expect(readFirst.Cell(2, 2)).To(Equal(1234.5678))
+	g.Expect(readFirst.Cell(3, 1)).To(Equal(float64(42)))
+	g.Expect(readFirst.Cell(3, 28)).To(Equal(true))
+	g.Expect(readFirst.ColumnCount()).To(BeNumerically("==", 28))
+	g.Expect(readFirst.RowCount()).To(BeNumerically("==", 3))
+
+	g.Expect(readWorkbook.Worksheets()[1].Cell(1, 1)).To(Equal(float64(-7)))
+}
+
+func TestWorkbook_Write_UnsupportedValue_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	workbook := NewWorkbook()
+	workbook.AddWorksheet("Sheet").SetCell(1, 1, struct{}{})
+
+	// when
+	writeError := workbook.Write(new(bytes.Buffer))
+
+	// then
+	g.Expect(writeError).To(Not(BeNil()))
+}
+
+func TestCellReference_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := map[string][2]uint{"A1": {1, 1}, "Z9": {9, 26}, "AA10": {10, 27}, "AZ1": {1, 52}, "XFD1048576": {1048576, 16384}}
+
+	for reference, position := range cases {
+		g.Expect(cellReference(position[0], position[1])).To(Equal(reference))
+
+		row, column, parseError := parseCellReference(reference)
+		g.Expect(parseError).To(BeNil())
+		g.Expect([2]uint{row, column}).To(Equal(position))
+	}
+
+	_, _, parseError := parseCellReference("1A")
+	g.Expect(parseError).To(Not(BeNil()))
+}
+
+func TestRead_CellsWithoutReferences_PositionedInOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const worksheetXml = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+		`<row><c t="inlineStr"><is><t>Name</t></is></c><c t="inlineStr"><is><t>Value</t></is></c></row>` +
+		`<row r="3"><c><v>1</v></c><c r="D3"><v>4</v></c><c><v>5</v></c></row>` +
+		`<row><c><v>6</v></c></row>` +
+		`</sheetData></worksheet>`
+	content := buildMinimalWorkbookContent(g, worksheetXml)
+
+	// when
+	workbook, readError := Read(bytes.NewReader(content), int64(len(content)))
+
+	// then
+	g.Expect(readError).To(BeNil())
+
+	sheet := workbook.Worksheets()[0]
+	g.Expect(sheet.Cell(1, 1)).To(Equal("Name"))
+	g.Expect(sheet.Cell(1, 2)).To(Equal("Value"))
+	g.Expect(sheet.Cell(3, 1)).To(Equal(float64(1)))
+	g.Expect(sheet.Cell(3, 4)).To(Equal(float64(4)))
+	g.Expect(sheet.Cell(3, 5)).To(Equal(float64(5)))
+	g.Expect(sheet.Cell(4, 1)).To(Equal(float64(6)))
+	g.Expect(sheet.RowCount()).To(BeNumerically("==", 4))
+}
+
+func buildMinimalWorkbookContent(g *GomegaWithT, worksheetXml string) []byte {
+	parts := map[string]string{
+		workbookPath: `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		workbookRelationshipsPath: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": worksheetXml,
+	}
+
+	var content bytes.Buffer
+	archive := zip.NewWriter(&content)
+	for partPath, partContent := range parts {
+		partWriter, createError := archive.Create(partPath)
+		g.Expect(createError).To(BeNil())
+		_, writeError := partWriter.Write([]byte(partContent))
+		g.Expect(writeError).To(BeNil())
+	}
+	g.Expect(archive.Close()).To(Succeed())
+
+	return content.Bytes()
+}
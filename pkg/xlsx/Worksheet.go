@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+type cellPosition struct {
+	row    uint
+	column uint
+}
+
+// Worksheet is a named grid of cell values, addressed by 1-based row and column as Excel addresses them. Cell values
+// are float64 for numbers, string for text and bool for booleans, with empty cells being nil.
+type Worksheet struct {
+	name  string
+	cells map[cellPosition]interface{}
+
+	rowCount    uint
+	columnCount uint
+}
+
+func newWorksheet(name string) *Worksheet {
+	return &Worksheet{name: name, cells: make(map[cellPosition]interface{})}
+}
+
+func (ws *Worksheet) Name() string {
+	return ws.name
+}
+
+func (ws *Worksheet) Cell(row uint, column uint) interface{} {
+	return ws.cells[cellPosition{row: row, column: column}]
+}
+
+// SetCell sets the value of the cell at the 1-based row and column supplied. Integer values are kept as float64, as
+// Excel keeps them. A nil value empties the cell.
+func (ws *Worksheet) SetCell(row uint, column uint, value interface{}) {
+	position := cellPosition{row: row, column: column}
+	if value == nil {
+		delete(ws.cells, position)
+		return
+	}
+
+	ws.cells[position] = normalised(value)
+	if row > ws.rowCount {
+		ws.rowCount = row
+	}
+	if column > ws.columnCount {
+		ws.columnCount = column
+	}
+}
+
+func normalised(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case int:
+		return float64(typedValue)
+	case int32:
+		return float64(typedValue)
+	case int64:
+		return float64(typedValue)
+	case uint:
+		return float64(typedValue)
+	case uint32:
+		return float64(typedValue)
+	case uint64:
+		return float64(typedValue)
+	case float32:
+		return float64(typedValue)
+	default:
+		return value
+	}
+}
+
+// RowCount returns the number of rows from the first to the last holding a value.
+func (ws *Worksheet) RowCount() uint {
+	return ws.rowCount
+}
+
+// ColumnCount returns the number of columns from the first to the last holding a value.
+func (ws *Worksheet) ColumnCount() uint {
+	return ws.columnCount
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+	spreadsheetNamespace   = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relationshipsNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+	contentTypes = `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`%s</Types>`
+	worksheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+
+	packageRelationships = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	worksheetRelationshipType = relationshipsNamespace + "/worksheet"
+	stylesRelationshipType    = relationshipsNamespace + "/styles"
+
+	styles = `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs>` +
+		`</styleSheet>`
+)
+
+type xmlWorkbookOut struct {
+	XMLName             xml.Name              `xml:"workbook"`
+	Namespace           string                `xml:"xmlns,attr"`
+	RelationshipsPrefix string                `xml:"xmlns:r,attr"`
+	Sheets              []xmlWorkbookOutSheet `xml:"sheets>sheet"`
+}
+
+type xmlWorkbookOutSheet struct {
+	Name           string `xml:"name,attr"`
+	SheetId        int    `xml:"sheetId,attr"`
+	RelationshipId string `xml:"r:id,attr"`
+}
+
+type xmlRelationshipsOut struct {
+	XMLName       xml.Name             `xml:"Relationships"`
+	Namespace     string               `xml:"xmlns,attr"`
+	Relationships []xmlRelationshipOut `xml:"Relationship"`
+}
+
+type xmlRelationshipOut struct {
+	Id     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+type xmlWorksheetOut struct {
+	XMLName   xml.Name    `xml:"worksheet"`
+	Namespace string      `xml:"xmlns,attr"`
+	Rows      []xmlRowOut `xml:"sheetData>row"`
+}
+
+type xmlRowOut struct {
+	Reference uint      `xml:"r,attr"`
+	Cells     []xmlCell `xml:"c"`
+}
+
+// Write writes the workbook as xlsx content to the writer supplied. Text is written as inline strings, so the
+// content needs no shared string table.
+func (wb *Workbook) Write(writer io.Writer) error {
+	archive := zip.NewWriter(writer)
+
+	if writeError := wb.writeParts(archive); writeError != nil {
+		archive.Close()
+		return writeError
+	}
+	return archive.Close()
+}
+
+func (wb *Workbook) writeParts(archive *zip.Writer) error {
+	worksheetContentTypes := ""
+	for index := range wb.worksheets {
+		worksheetContentTypes += fmt.Sprintf(worksheetContentType, index+1)
+	}
+
+	if writeError := writeText(archive, "[Content_Types].xml", fmt.Sprintf(contentTypes, worksheetContentTypes)); writeError != nil {
+		return writeError
+	}
+	if writeError := writeText(archive, "_rels/.rels", packageRelationships); writeError != nil {
+		return writeError
+	}
+	if writeError := writeText(archive, "xl/styles.xml", styles); writeError != nil {
+		return writeError
+	}
+	if writeError := writeXml(archive, workbookPath, wb.workbookXml()); writeError != nil {
+		return writeError
+	}
+	if writeError := writeXml(archive, workbookRelationshipsPath, wb.relationshipsXml()); writeError != nil {
+		return writeError
+	}
+
+	for index, worksheet := range wb.worksheets {
+		worksheetXml, cellError := worksheet.worksheetXml()
+		if cellError != nil {
+			return errors.Wrap(cellError, "worksheet ["+worksheet.Name()+"]")
+		}
+		if writeError := writeXml(archive, worksheetPartOf(index), worksheetXml); writeError != nil {
+			return writeError
+		}
+	}
+	return nil
+}
+
+func worksheetPartOf(index int) string {
+	return fmt.Sprintf("xl/worksheets/sheet%d.xml", index+1)
+}
+
+func worksheetRelationshipIdOf(index int) string {
+	return fmt.Sprintf("rId%d", index+1)
+}
+
+func (wb *Workbook) workbookXml() xmlWorkbookOut {
+	workbookXml := xmlWorkbookOut{Namespace: spreadsheetNamespace, RelationshipsPrefix: relationshipsNamespace}
+	for index, worksheet := range wb.worksheets {
+		workbookXml.Sheets = append(workbookXml.Sheets, xmlWorkbookOutSheet{
+			Name:           worksheet.Name(),
+			SheetId:        index + 1,
+			RelationshipId: worksheetRelationshipIdOf(index),
+		})
+	}
+	return workbookXml
+}
+
+func (wb *Workbook) relationshipsXml() xmlRelationshipsOut {
+	relationshipsXml := xmlRelationshipsOut{Namespace: "http://schemas.openxmlformats.org/package/2006/relationships"}
+	for index := range wb.worksheets {
+		relationshipsXml.Relationships = append(relationshipsXml.Relationships, xmlRelationshipOut{
+			Id:     worksheetRelationshipIdOf(index),
+			Type:   worksheetRelationshipType,
+			Target: fmt.Sprintf("worksheets/sheet%d.xml", index+1),
+		})
+	}
+	relationshipsXml.Relationships = append(relationshipsXml.Relationships, xmlRelationshipOut{
+		Id:     worksheetRelationshipIdOf(len(wb.worksheets)),
+		Type:   stylesRelationshipType,
+		Target: "styles.xml",
+	})
+	return relationshipsXml
+}
+
+func (ws *Worksheet) worksheetXml() (xmlWorksheetOut, error) {
+	positions := make([]cellPosition, 0, len(ws.cells))
+	for position := range ws.cells {
+		positions = append(positions, position)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].row != positions[j].row {
+			return positions[i].row < positions[j].row
+		}
+		return positions[i].column < positions[j].column
+	})
+
+	worksheetXml := xmlWorksheetOut{Namespace: spreadsheetNamespace}
+	for _, position := range positions {
+		cell, cellError := cellXmlOf(position, ws.cells[position])
+		if cellError != nil {
+			return worksheetXml, cellError
+		}
+
+		lastRow := len(worksheetXml.Rows) - 1
+		if lastRow < 0 || worksheetXml.Rows[lastRow].Reference != position.row {
+			worksheetXml.Rows = append(worksheetXml.Rows, xmlRowOut{Reference: position.row})
+			lastRow++
+		}
+		worksheetXml.Rows[lastRow].Cells = append(worksheetXml.Rows[lastRow].Cells, cell)
+	}
+	return worksheetXml, nil
+}
+
+func cellXmlOf(position cellPosition, value interface{}) (xmlCell, error) {
+	cell := xmlCell{Reference: cellReference(position.row, position.column)}
+
+	switch typedValue := value.(type) {
+	case float64:
+		formattedValue := strconv.FormatFloat(typedValue, 'g', -1, 64)
+		cell.Value = &formattedValue
+	case bool:
+		formattedValue := "0"
+		if typedValue {
+			formattedValue = "1"
+		}
+		cell.Type = booleanCell
+		cell.Value = &formattedValue
+	case string:
+		cell.Type = inlineStringCell
+		cell.InlineString = &xmlText{Text: typedValue}
+	case fmt.Stringer:
+		cell.Type = inlineStringCell
+		cell.InlineString = &xmlText{Text: typedValue.String()}
+	default:
+		return cell, errors.New(fmt.Sprintf("unsupported value [%v] of cell [%s]", value, cell.Reference))
+	}
+	return cell, nil
+}
+
+func writeText(archive *zip.Writer, partPath string, content string) error {
+	partWriter, createError := archive.Create(partPath)
+	if createError != nil {
+		return errors.Wrap(createError, "creating ["+partPath+"]")
+	}
+	if _, writeError := io.WriteString(partWriter, xmlHeader+content); writeError != nil {
+		return errors.Wrap(writeError, "writing ["+partPath+"]")
+	}
+	return nil
+}
+
+func writeXml(archive *zip.Writer, partPath string, content interface{}) error {
+	marshaled, marshalError := xml.Marshal(content)
+	if marshalError != nil {
+		return errors.Wrap(marshalError, "marshaling ["+partPath+"]")
+	}
+	return writeText(archive, partPath, string(marshaled))
+}